@@ -71,6 +71,12 @@
     
             // $left && $right
             {'and': {'left': expression, 'right': expression}},
+
+            // $left || $right
+            {'or': {'left': expression, 'right': expression}},
+
+            // $left + $right
+            {'plus': {'left': expression, 'right': expression}},
             
             // ! ...
             {'not': expression},
@@ -200,6 +206,10 @@
             // }
             'function': {
                 'documentation?': String, // commented per-line
+                // if present, the function is a method, e.g.
+                //     func ($name $type) $name(...) ...
+                // where the receiver's name is optional
+                'receiver?': {'name?': String, 'type': String},
                 'name': String,
                 'parameters': [{'name?': String, 'type': String}, ...etc],
                 'results': [{'name?': String, 'type': String}, ...etc],
@@ -220,6 +230,23 @@
                 }
             }},
 
+            // type $name $definition
+            // e.g.
+            //     type FooBar struct{ baz int }
+            {'type': {
+                'documentation?': String, // commented per-line
+                'name': String,
+                'definition': String
+            }},
+
+            // var $name $type = $value
+            {'variable': {
+                'documentation?': String, // commented per-line
+                'name': String,
+                'type?': String,
+                'value?': expression
+            }},
+
             // Included in the output source verbatim. This is used for
             // predetermined snippets of Go code that do not depend on the
             // input, such as utility functions and types.
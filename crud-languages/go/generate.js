@@ -1,7 +1,9 @@
 // This module provides a function, `generate`, that takes:
 // - an object as produced by some SQL dialect's `types2crud` function,
-// - an array of Okra types, and
-// - an object of proto file options (by file)
+// - an array of Okra types,
+// - an object of proto file options (by file),
+// - optionally, an object classifying database error codes, and
+// - optionally, an object of statements for polling the outbox
 //
 // and returns a string containing Go source code for a package that implements
 // the CRUD operations.
@@ -19,8 +21,9 @@ define([
 // - "Generate" contains only the `generate` function, which is the function
 //   provided by this module.
 // - "CRUD Operations" contains one function for each of the CRUD operations
-//   create/read/update/delete. Each function produces the abstract syntax tree
-//   (AST) of a function that does the indicated operation for some message type.
+//   create/read/update/delete, and for the other operations (e.g. list). Each
+//   function produces the abstract syntax tree (AST) of a function that does
+//   the indicated operation for some message type.
 // - "CRUD Instructions" contains one function for each of the CRUD
 //   instructions that are combined to create the bodies of CRUD operations. An
 //   instruction is something like "execute this SQL query." Each function in
@@ -43,13 +46,25 @@ define([
 // - `crud`: an object as produced by some SQL dialect's `types2crud` function
 // - `types`: an array of Okra types
 // - `options`: an object of proto file options (by file)
-function generate({crud, types, options}) {
-    return renderFile(generateUnrendered({crud, types, options}));
+// - `errors`: an object as produced by some SQL dialect's `errors` module
+//   (optional)
+// - `outbox`: an object as produced by some SQL dialect's `outboxPoller`
+//   function (required if, and only if, `crud` publishes changes to the
+//   outbox)
+function generate({crud, types, options, errors, outbox}) {
+    return renderFile(
+        generateUnrendered({crud, types, options, errors, outbox}));
 }
 
 // See `generate` for documentation. This is the implementation except for the
 // rendering at the end (AST -> code).
-function generateUnrendered({crud, types, options}) {
+function generateUnrendered({
+    crud,
+    types,
+    options,
+    errors = noErrors,
+    outbox
+}) {
     // Verify that the arguments have the expected shape.
     // - `crud`
     schemas.crud.enforce(crud);
@@ -61,9 +76,27 @@ function generateUnrendered({crud, types, options}) {
         [Any]: Object,
         ...etc
     })).enforce(options);
+    // - `errors`
+    schemas.errors.enforce(errors);
+    // - `outbox`
+    const publishes = Object.values(crud).some(
+        operations => operations.outbox !== undefined);
+    if (publishes !== (outbox !== undefined)) {
+        throw Error(publishes
+            ? 'The CRUD operations publish changes to the outbox, but no ' +
+              'outbox poller statements were specified.'
+            : 'Outbox poller statements were specified, but the CRUD ' +
+              'operations do not publish changes to the outbox.');
+    }
+    if (outbox !== undefined) {
+        schemas.outbox.enforce(outbox);
+    }
 
     const {protoImports, typePackageAlias} = typeImports({types, options});
-    const messages = types.filter(type => type.kind === 'message');
+    // Message types without CRUD operations (those that appear only as the
+    // types of fields of other messages) don't get funcs of their own.
+    const messages = types.filter(
+        type => type.kind === 'message' && type.name in crud);
 
     // Make `types` an object by-name rather than just an array.
     types = Object.fromEntries(types.map(type => [type.name, type]));
@@ -92,17 +125,83 @@ This file is generated code. Please do not modify it by hand.`;
                     // e.g. crud[message.name].create, or .read
                     instructions: crud[message.name][operation],
                     types, 
-                    typePackageAlias
+                    typePackageAlias,
+                    // whether operations that change the message record the
+                    // change in the message's history
+                    history: history !== undefined,
+                    // whether operations that change the message insert the
+                    // change into the outbox
+                    outbox: publish !== undefined
                 };
             }
 
+            const history = crud[message.name].history;
+            const publish = crud[message.name].outbox;
+
             return [
                 funcCreate(argumentsFor('create')),
                 funcRead(argumentsFor('read')),
                 funcUpdate(argumentsFor('update')),
-                funcDelete(argumentsFor('delete'))
+                funcDelete(argumentsFor('delete')),
+                // a soft-deleted message can also be undeleted and purged
+                ...(crud[message.name].undelete === undefined ? [] : [
+                    funcUndelete(argumentsFor('undelete')),
+                    funcPurge(argumentsFor('purge'))
+                ]),
+                funcUpsert(argumentsFor('upsert')),
+                funcList(argumentsFor('list')),
+                funcReadMany(argumentsFor('read-many')),
+                funcCreateMany({
+                    ...argumentsFor('create-many'),
+                    // the instructions that record and publish the creation
+                    // of many messages at once, if applicable
+                    recordMany: history && history.recordMany,
+                    publishMany: publish && publish.publishMany
+                }),
+                funcExists(argumentsFor('exists')),
+                funcCount({
+                    typeName: message.name,
+                    count: crud[message.name].count
+                }),
+                // a message having a history can be read as of a time, and
+                // the changes to it are recorded by a helper func
+                ...(history === undefined ? [] : [
+                    funcReadAsOf({
+                        ...argumentsFor('history'),
+                        instructions: [history.asOf]
+                    }),
+                    funcRecordHistory({
+                        ...argumentsFor('history'),
+                        instructions: [history.record]
+                    })
+                ]),
+                // the changes to a message can be inserted into the outbox by
+                // a helper func
+                ...(publish === undefined ? [] : [
+                    funcPublishChange({
+                        ...argumentsFor('outbox'),
+                        instructions: [publish.publish]
+                    })
+                ]),
+                // one func for each indexed field, e.g. ReadFooBarsByColor
+                ...Object.entries(crud[message.name].lookups || {})
+                    .map(([fieldName, instructions]) => funcLookup({
+                        ...argumentsFor('lookups'),
+                        instructions,
+                        fieldName
+                    })),
+                // e.g. FooBarQuery(), and the types and vars that it uses
+                ...queryDeclarations({
+                    typeName: message.name,
+                    query: crud[message.name].query,
+                    types,
+                    typePackageAlias
+                })
             ];
-        }).flat()
+        }).flat().concat(
+            [varErrorClasses(errors)],
+            // e.g. ClaimOutboxEvents and AcknowledgeOutboxEvent
+            outbox === undefined ? [] : outboxDeclarations(outbox))
     };
 
     // Calls to `typePackageAlias` have been helping decide which
@@ -125,8 +224,9 @@ This file is generated code. Please do not modify it by hand.`;
 // CRUD Operations
 // ===============
 // This section contains one function for each of the CRUD operations
-// create/read/update/delete. Each function produces AST of a function that
-// does the indicated operation for some message type.
+// create/read/update/delete, and for the other operations (e.g. list). Each
+// function produces AST of a function that does the indicated operation for
+// some message type.
 
 // Return a Go AST node representing a func that creates a new instance of a
 // message of the specified `typeName` in the database using the specified CRUD
@@ -134,14 +234,21 @@ This file is generated code. Please do not modify it by hand.`;
 // inspect the message type and any enum types that it might depend upon. Use
 // the specified `typePackageAlias` function to look up which package aliases
 // (e.g. "pb", "p2") a given message/enum type belongs to.
-function funcCreate({typeName, instructions, types, typePackageAlias}) {
+function funcCreate({
+    typeName,
+    instructions,
+    types,
+    typePackageAlias,
+    history,
+    outbox
+}) {
     // Here's what we're going for:
     //
     //     ... documentation ...
-    //     func CreateFooBar(ctx context.Context, db *sql.DB, message *pb.FooBar) (err error) {
+    //     func CreateFooBar(ctx context.Context, db Database, message *pb.FooBar) (err error) {
     //         ... vars ...
     //
-    //         transaction, err = db.BeginTx(ctx, nil)
+    //         transaction, err = beginTransaction(ctx, db)
     //         if err != nil {
     //             return
     //         }
@@ -159,7 +266,7 @@ specified cancellation context ctx. Return nil on success, or return a
 non-nil value if an error occurs.`;
     const parameters = [
         {name: 'ctx', type: 'context.Context'},
-        {name: 'db', type: '*sql.DB'},
+        {name: 'db', type: 'Database'},
         {name: 'message',
          type: `*${typePackageAlias(typeName)}.${messageOrEnum2go(typeName)}`}
     ];
@@ -197,18 +304,36 @@ non-nil value if an error occurs.`;
     }
 
     // `transaction` is a variable assumed to be in scope, so add that first.
-    variable({name: 'transaction', goType: '*sql.Tx'});
+    variable({name: 'transaction', goType: 'transactor'});
 
     // Generate statements that implement each instruction, and append the
     // statements to the body of the func.
     statements.push(...performInstructions({
         instructions,
         typeByField,
+        types,
         variable,
         included,
         typePackageAlias
     }));
 
+    if (history) {
+        statements.push(...recordHistory({
+            typeName,
+            id: {dot: ['message', field2go(types[typeName].idFieldName)]},
+            operation: 'create'
+        }), {spacer: 1});
+    }
+
+    if (outbox) {
+        statements.push(...publishChange({
+            typeName,
+            id: {dot: ['message', field2go(types[typeName].idFieldName)]},
+            operation: 'create',
+            fieldMask: null
+        }), {spacer: 1});
+    }
+
     statements.push(...commitTransactionAndReturn);
 
     return {function: func};
@@ -224,10 +349,19 @@ function funcRead({typeName, instructions, types, typePackageAlias}) {
     // Here's what we're going for:
     //
     //      // ... documentation ...
-    //      func ReadFooBar(ctx context.Context, db *sql.DB, message *pb.FooBar) (err error) {
+    //      func ReadFooBar(ctx context.Context, db Database, message *pb.FooBar, fieldMask []string) (err error) {
     //         ... vars ...
     //
-    //         transaction, err = db.BeginTx(ctx, nil)
+    //         if len(fieldMask) == 0 {
+    //             fieldMask = []string{... all field names ...}
+    //         }
+    //
+    //         included = make(map[string]bool, len(fieldMask))
+    //         for _, field := range fieldMask {
+    //             included[field] = true
+    //         }
+    //
+    //         transaction, err = beginTransaction(ctx, db)
     //         if err != nil {
     //             return
     //         }
@@ -249,15 +383,19 @@ function funcRead({typeName, instructions, types, typePackageAlias}) {
 
     const documentation =
 `${funcName} reads from the specified db into the specified message, where
-the ID of the message must be pre-populated by the caller. On success, the
-error returned will be nil. On error, the error returned will not be nil.
-The specified cancellation context ctx is forwarded wherever appropriate.`;
+the ID of the message must be pre-populated by the caller. Each element of
+the specified fieldMask is the name of a field in message whose value is to
+be read. Fields not in fieldMask are not modified. If fieldMask is empty,
+then all fields are read. On success, the error returned will be nil. On
+error, the error returned will not be nil. The specified cancellation
+context ctx is forwarded wherever appropriate.`;
 
     const parameters = [
         {name: 'ctx', type: 'context.Context'},
-        {name: 'db', type: '*sql.DB'},
+        {name: 'db', type: 'Database'},
         // `message` is a pointer to a protobuf message (of the correct type)
-        {name: 'message', type: `*${messageType}`}
+        {name: 'message', type: `*${messageType}`},
+        {name: 'fieldMask', type: '[]string'}
     ];
     const results = [
         {name: 'err', type: 'error'}
@@ -282,19 +420,38 @@ The specified cancellation context ctx is forwarded wherever appropriate.`;
     // it hasn't been added already, and returns the name of the variable.
     const variable = variableAdder(variables);
 
-    // In a "read" func, all fields are included (I haven't implemented partial
-    // record fetching -- no need), so this always returns `true`.
-    function included(fieldName /*ignored*/) {
-        return true;
+    // A variable `included` of map[string]bool type will be in scope. The
+    // generated code checks whether a field is included by looking up the
+    // field name in the map. The ID is always included, since it's how the
+    // message is found.
+    //
+    // If `included` is never referenced by the generated code, then it doesn't
+    // need to be defined, so also keep track of whether `included` has ever
+    // been called.
+    let defineInclusionBoilerplate = false;
+    const idFieldName = types[typeName].idFieldName;
+
+    function included(fieldName) {
+        if (fieldName === idFieldName) {
+            return true;
+        }
+
+        defineInclusionBoilerplate = true;
+
+        return {
+            index: {
+                object: 'included',
+                index: fieldName // the literal string, quoted
+            }
+        };
     }
 
     // `transaction` is a variable assumed to be in scope, so add that first.
-    variable({name: 'transaction', goType: '*sql.Tx'});
+    variable({name: 'transaction', goType: 'transactor'});
 
-    // Begin by assigning a default value to `message` (the return
-    // value). Then start a transaction.
+    // Start a transaction.
     statements.push(
-        // transaction, err = db.BeginTx(ctx, nil)
+        // transaction, err = beginTransaction(ctx, db)
         // if err != nil {
         //     return
         // }
@@ -306,13 +463,43 @@ The specified cancellation context ctx is forwarded wherever appropriate.`;
     statements.push(...performInstructions({
         instructions,
         typeByField,
+        types,
         variable,
         included,
-        typePackageAlias
+        typePackageAlias,
+        messages: {messageType, idFieldName}
     }));
 
     statements.push(...commitTransactionAndReturn);
 
+    // If `performInstructions`, above, made any calls to `included`, then we
+    // need to emit statements that set up the lookup map of field names that
+    // are included in the read. An empty field mask means all fields. Prepend
+    // those statements to `statements`.
+    if (defineInclusionBoilerplate) {
+        statements.splice(0, 0,
+            // if len(fieldMask) == 0 {
+            //     fieldMask = []string{... all field names ...}
+            // }
+            {if: {
+                condition: {equal: {
+                    left: {call: {function: 'len', arguments: [{symbol: 'fieldMask'}]}},
+                    right: 0
+                }},
+                body: [{assign: {
+                    left: ['fieldMask'],
+                    right: [{sequenceLiteral: {
+                        type: '[]string',
+                        elements: Object.keys(typeByField)
+                    }}]
+                }}]
+            }},
+
+            {spacer: 1},
+
+            ...inclusionBoilerplate(variable, types[typeName]));
+    }
+
     return {function: func};
 }
 
@@ -322,7 +509,14 @@ The specified cancellation context ctx is forwarded wherever appropriate.`;
 // to inspect the message type and any enum types that it might depend upon.
 // Use the specified `typePackageAlias` function to look up which package
 // aliases (e.g. "pb", "p2") a given message/enum type belongs to.
-function funcUpdate({typeName, instructions, types, typePackageAlias}) {
+function funcUpdate({
+    typeName,
+    instructions,
+    types,
+    typePackageAlias,
+    history,
+    outbox
+}) {
     // Here's what we're going for:
     //
     // // UpdateFooBar updates within the specified db the fields of the specified
@@ -330,10 +524,18 @@ function funcUpdate({typeName, instructions, types, typePackageAlias}) {
     // // specified cancellation context ctx. Each element of fieldMask is the
     // // name of a field in message whose value is to be used in the database
     // // update. Return nil on success, or a non-nil error if an error occurs.
-    // func UpdateFooBar(ctx context.Context, db *sql.DB, message pb.FooBar, fieldMask []string) (err error) {
+    // func UpdateFooBar(ctx context.Context, db Database, message pb.FooBar, fieldMask []string) (err error) {
     //     ... other vars ...
     //     var included map[string]bool
     //
+    //     if len(fieldMask) == 0 {
+    //         return
+    //     }
+    //     err = checkFieldMask(fieldMask, ... names of the fields ...)
+    //     if err != nil {
+    //         return
+    //     }
+    //
     //     included = make(map[string]bool, len(fieldMask))
     //     for _, field := range fieldMask {
     //         included[field] = true
@@ -351,16 +553,30 @@ function funcUpdate({typeName, instructions, types, typePackageAlias}) {
         (byName, {name, type}) => Object.assign(byName, {[name]: type}),
         {});
 
+    const versionFieldName = types[typeName].versionFieldName;
+    const versionField = versionFieldName && field2go(versionFieldName);
+
     const documentation =
 `${funcName} updates within the specified db the fields of the specified
 message that are indicated by the specified fieldMask, subject to
 specified cancellation context ctx. Each element of fieldMask is the
 name of a field in message whose value is to be used in the database
-update. Return nil on success, or a non-nil error if an error occurs.`;
+update. Return nil on success, or a non-nil error if an error occurs. If
+fieldMask is empty, then there is nothing to update, and nil is returned
+without consulting db. If fieldMask contains anything other than the name of
+a field in message, then the error returned is of class ErrInvalidArgument.` +
+        (versionField === undefined ? '' : `
+
+Whichever fields are updated, the update applies only if message.${versionField}
+is the version of the message in the database. If it isn't, then the error
+returned is a VersionConflict. On success, the version in the database and
+message.${versionField} are incremented. If db is a transaction of the
+caller's, then message.${versionField} is incremented even if the caller
+later rolls back the transaction.`);
 
     const parameters = [
         {name: 'ctx', type: 'context.Context'},
-        {name: 'db', type: '*sql.DB'},
+        {name: 'db', type: 'Database'},
         {name: 'message', type: `*${messageType}`},
         {name: 'fieldMask', type: '[]string'}
     ];
@@ -408,7 +624,7 @@ update. Return nil on success, or a non-nil error if an error occurs.`;
     }
 
     // `transaction` is a variable assumed to be in scope, so add that first.
-    variable({name: 'transaction', goType: '*sql.Tx'});
+    variable({name: 'transaction', goType: 'transactor'});
 
     statements.push(...beginTransaction);
 
@@ -417,12 +633,56 @@ update. Return nil on success, or a non-nil error if an error occurs.`;
     statements.push(...performInstructions({
         instructions,
         typeByField,
+        types,
         variable,
         included,
         typePackageAlias
     }));
 
-    statements.push(...commitTransactionAndReturn);
+    // An update having an empty fieldMask returned before getting here (see
+    // below), so the update changed some fields, and is recorded.
+    const recorded = [
+        ...(history ? recordHistory({
+            typeName,
+            id: {dot: ['message', field2go(types[typeName].idFieldName)]},
+            operation: 'update'
+        }) : []),
+        ...(outbox ? publishChange({
+            typeName,
+            id: {dot: ['message', field2go(types[typeName].idFieldName)]},
+            operation: 'update',
+            fieldMask: {symbol: 'fieldMask'}
+        }) : [])
+    ];
+    if (recorded.length !== 0) {
+        statements.push(...recorded, {spacer: 1});
+    }
+
+    if (versionField === undefined) {
+        statements.push(...commitTransactionAndReturn);
+    }
+    else {
+        // The database incremented the version, so follow suit once the
+        // update is committed:
+        //
+        //     err = transaction.Commit()
+        //     if err == nil {
+        //         message.Version = message.Version + 1
+        //     }
+        //     return
+        const [commit, ret] = commitTransactionAndReturn;
+        const version = {dot: ['message', versionField]};
+        statements.push(
+            commit,
+            {if: {
+                condition: {equal: {left: {symbol: 'err'}, right: null}},
+                body: [{assign: {
+                    left: [version],
+                    right: [{plus: {left: version, right: 1}}]
+                }}]
+            }},
+            ret);
+    }
 
     // If `performInstructions`, above, made any calls to `included`, then we
     // need to emit statements that set up the lookup map of field names that
@@ -432,9 +692,43 @@ update. Return nil on success, or a non-nil error if an error occurs.`;
     // message type consists of only an ID field. Unlikely, but possible, and
     // if we have unused variables in the generated Go code, it won't compile.
     if (defineInclusionBoilerplate) {
-        statements.splice(0, 0, ...inclusionBoilerplate(variable));
+        statements.splice(0, 0,
+            ...inclusionBoilerplate(variable, types[typeName]));
     }
 
+    // Before anything else, check fieldMask. An empty fieldMask updates
+    // nothing, and so does a name that isn't a field (or oneof) of the
+    // message, though that's more likely a mistake:
+    //
+    //     if len(fieldMask) == 0 {
+    //         return
+    //     }
+    //     err = checkFieldMask(fieldMask, "foo", "bar", ...)
+    //     if err != nil {
+    //         return
+    //     }
+    const fieldNames = [
+        ...types[typeName].fields.map(({name}) => name),
+        ...Object.keys(oneofMembers(types[typeName]))
+    ];
+    statements.splice(0, 0,
+        {if: {
+            condition: {equal: {
+                left: {call: {function: 'len', arguments: [{symbol: 'fieldMask'}]}},
+                right: 0
+            }},
+            body: [{return: []}]
+        }},
+        {assign: {
+            left: ['err'],
+            right: [{call: {
+                function: 'checkFieldMask',
+                arguments: [{symbol: 'fieldMask'}, ...fieldNames]
+            }}]
+        }},
+        ifErrReturn,
+        {spacer: 1});
+
     return {function: func};
 }
 
@@ -444,7 +738,137 @@ update. Return nil on success, or a non-nil error if an error occurs.`;
 // to inspect the message type and any enum types that it might depend upon.
 // Use the specified `typePackageAlias` function to look up which package
 // aliases (e.g. "pb", "p2") a given message/enum type belongs to.
-function funcDelete({typeName, instructions, types, typePackageAlias}) {
+//
+// If the message type is soft-deleted, then the func only marks the instance
+// as deleted (see `funcUndelete` and `funcPurge`).
+function funcDelete({
+    typeName,
+    instructions,
+    types,
+    typePackageAlias,
+    history,
+    outbox
+}) {
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = `Delete${goTypeName}`;
+    const documentation = types[typeName].softDelete
+        ?
+`${funcName} marks the message having the specified id in the specified db
+as deleted, subject to the specified cancellation context ctx. A deleted
+message is not read, listed, looked up, queried, or counted, but it remains
+in the database until it is purged (see Purge${goTypeName}), and until then
+it can be restored (see Undelete${goTypeName}). On success, the error
+returned will be nil. On error, the error returned will not be nil. It is
+not considered an error if there is no message having the specified id in
+the database, or if it is already deleted; i.e. deletions are idempotent.`
+        :
+`${funcName} deletes the message having the specified id from the specified
+db, subject to the specified cancellation context ctx. On success, the error
+returned will be nil. On error, the error returned will not be nil. It is
+not considered an error if there is no message having the specified id in
+the database; i.e. deletions are idempotent.`;
+
+    return funcByID({
+        typeName,
+        instructions,
+        types,
+        typePackageAlias,
+        funcName,
+        documentation,
+        history,
+        outbox,
+        operation: 'delete'
+    });
+}
+
+// Return a Go AST node representing a func that restores an instance of a
+// soft-deleted message of the specified `typeName` that was deleted, using
+// the specified CRUD `instructions`. Use the specified `types` and
+// `typePackageAlias` as in `funcDelete`.
+function funcUndelete({
+    typeName,
+    instructions,
+    types,
+    typePackageAlias,
+    history,
+    outbox
+}) {
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = `Undelete${goTypeName}`;
+    const documentation =
+`${funcName} restores the message having the specified id in the specified
+db that was deleted by Delete${goTypeName}, subject to the specified
+cancellation context ctx. On success, the error returned will be nil. On
+error, the error returned will not be nil. It is not considered an error if
+there is no message having the specified id in the database, or if it is
+not deleted.`;
+
+    return funcByID({
+        typeName,
+        instructions,
+        types,
+        typePackageAlias,
+        funcName,
+        documentation,
+        history,
+        outbox,
+        operation: 'undelete'
+    });
+}
+
+// Return a Go AST node representing a func that permanently removes an
+// instance of a soft-deleted message of the specified `typeName` from the
+// database, using the specified CRUD `instructions`. Use the specified
+// `types` and `typePackageAlias` as in `funcDelete`.
+function funcPurge({
+    typeName,
+    instructions,
+    types,
+    typePackageAlias,
+    history,
+    outbox
+}) {
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = `Purge${goTypeName}`;
+    const documentation =
+`${funcName} removes the message having the specified id from the specified
+db, whether or not it was deleted by Delete${goTypeName}, subject to the
+specified cancellation context ctx. A purged message cannot be restored. On
+success, the error returned will be nil. On error, the error returned will
+not be nil. It is not considered an error if there is no message having the
+specified id in the database; i.e. purges are idempotent.`;
+
+    return funcByID({
+        typeName,
+        instructions,
+        types,
+        typePackageAlias,
+        funcName,
+        documentation,
+        history,
+        outbox,
+        operation: 'purge'
+    });
+}
+
+// Return a Go AST node representing a func having the specified `funcName`
+// and `documentation` that does something to an instance of a message of the
+// specified `typeName` given only its ID, e.g. deletes it, using the specified
+// CRUD `instructions`. Use the specified `types` and `typePackageAlias` as in
+// `funcDelete`. If `history` is true, then record the change in the message's
+// history as the specified `operation`, e.g. "delete". If `outbox` is true,
+// then also insert the change into the outbox.
+function funcByID({
+    typeName,
+    instructions,
+    types,
+    typePackageAlias,
+    funcName,
+    documentation,
+    history,
+    outbox,
+    operation
+}) {
     // Here's what we're going for:
     //
     // // DeleteFooBar deletes the message having the specified id from the specified
@@ -452,7 +876,7 @@ function funcDelete({typeName, instructions, types, typePackageAlias}) {
     // // returned will be nil. On error, the error returned will not be nil. It is
     // // not considered an error if there is no message having the specified id in
     // // the database; i.e. deletions are idempotent.`;
-    // func DeleteFooBar(ctx context.Context, db *sql.DB, id int64) error {
+    // func DeleteFooBar(ctx context.Context, db Database, id int64) error {
     //     ... other vars ...
     //
     //     var message pb.FooBar
@@ -466,17 +890,10 @@ function funcDelete({typeName, instructions, types, typePackageAlias}) {
         (byName, {name, type}) => Object.assign(byName, {[name]: type}),
         {});
 
-    const funcName = `Delete${messageOrEnum2go(typeName)}`;
-    const documentation =
-`${funcName} deletes the message having the specified id from the specified
-db, subject to the specified cancellation context ctx. On success, the error
-returned will be nil. On error, the error returned will not be nil. It is
-not considered an error if there is no message having the specified id in
-the database; i.e. deletions are idempotent.`;
     const idFieldName = types[typeName].idFieldName;
     const parameters = [
         {name: 'ctx', type: 'context.Context'},
-        {name: 'db', type: '*sql.DB'},
+        {name: 'db', type: 'Database'},
         // `id` has whatever Go type corresponds to the designated ID field of
         // the message type.
         {name: 'id',
@@ -520,319 +937,4124 @@ the database; i.e. deletions are idempotent.`;
         }
     };
 
-    // In a "delete" func, no fields are referenced, so it's an error if
-    // `included` is called.
+    // In a "delete" func (or the like), no fields are referenced, so it's an
+    // error if `included` is called.
     function included(fieldName) {
-        throw Error('funcDelete processed an instruction that queried ' +
-            'whether a field is included, but instructions in a deletion ' +
-            'operation should not have to reference any fields. fieldName: ' +
-            fieldName);
+        throw Error(`${funcName} processed an instruction that queried ` +
+            'whether a field is included, but instructions in an operation ' +
+            'given only an ID should not have to reference any fields. ' +
+            `fieldName: ${fieldName}`);
     }
 
     // `transaction` is a variable assumed to be in scope, so add that first.
-    variable({name: 'transaction', goType: '*sql.Tx'});
+    variable({name: 'transaction', goType: 'transactor'});
 
     // Generate statements that implement each instruction, and append the
     // statements to the body of the func.
     statements.push(...performInstructions({
         instructions,
         typeByField,
+        types,
         variable,
         included,
         typePackageAlias
     }));
 
+    // If the operation's statement says whether it changed anything (see
+    // "onNoRows" in `crud.tisch.js`), then record and publish the change only
+    // if it did:
+    //
+    //     if affected != 0 {
+    //         ... record the change ...
+    //     }
+    const changed = instructions.some(({onNoRows}) => onNoRows === 'unchanged')
+        ? {notEqual: {left: {symbol: 'affected'}, right: 0}}
+        : undefined;
+
+    const recorded = [
+        ...(history ? recordHistory({
+            typeName,
+            id: {symbol: 'id'},
+            operation
+        }) : []),
+        ...(outbox ? publishChange({
+            typeName,
+            id: {symbol: 'id'},
+            operation,
+            fieldMask: null
+        }) : [])
+    ];
+    if (recorded.length !== 0) {
+        statements.push(...onlyIf(changed, recorded), {spacer: 1});
+    }
+
     statements.push(...commitTransactionAndReturn);
 
     return {function: func}
 }
 
-// CRUD Instructions
-// =================
-// This section contains one function for each of the CRUD instructions that
-// are combined to create the bodies of CRUD operations. An instruction is
-// something like "execute this SQL query." Each function in this section
-// returns an array of statements that can be included as part of the AST
-// returned by one of the functions in the "CRUD Operations" section.
-//
-// All of these functions assume that the following Go variables are in scope:
-// - `message` is the protobuf message struct being read from or written to.
-//   It might be a pointer to a message or the message itself, depending on
-//   the operation.
-// - `transaction` is the `*sql.Tx` object for the current database transaction.
-// - `ctx` is the `context.Context` object describing the current cancellation
-//   context.
-// - `err` is the `error` variable to assign to before returning due to an
-//   error.
-//
-// Additionally, the functions might depend on any of the following variables,
-// but will say so by invoking their `variable` parameter first (i.e. these are
-// not assumed to be in scope, but will be if indicated by a call to
-// `variable`):
-// - `parameters` is a `[]interface{}` used when specifying a variable number
-//   of parameters to a SQL command (such as the "exec-with-tuples"
-//   instruction).
-// - `rows` is a `*sql.Rows` used when iterating through SQL query results.
-// - `ok` is `bool` used to capture the success or failure of `rows.Next()`.
-// - `included` is a `map[string]bool` for looking up whether a particular
-//   message field is included in the current operation.
-//
-// If an instruction encounters an error, it will assign to `err` and then
-// return using a `return` statement without any arguments. Thus the function
-// in which the instruction is expanded must use named return values.
-
-// Return an array of statements that perform the specified CRUD "query"
-// `instruction` in the context implied by the other specified arguments.
-function performQuery({
-    // the "query" CRUD instruction
-    instruction,
-
-    // object that maps a message field name to an okra type
-    typeByField,
-
-    // function that registers a specified `variable({name, goType})` and returns `name`
-    // Note that that variables that are _assumed_ to be in scope, such as
-    // `ctx`, `message`, `transaction`, and `err`, don't need to use this
-    // function. It's for variables like `rows` and `ok`.
-    variable,
-
-    // function that returns an expression for whether a field is included in the CRUD operation
-    included
+// Return a Go AST node representing a func that creates or replaces an
+// instance of a message of the specified `typeName` in the database using the
+// specified CRUD `instructions`. Use the specified `types` object of okra types
+// by name to inspect the message type and any enum types that it might depend
+// upon. Use the specified `typePackageAlias` function to look up which package
+// aliases (e.g. "pb", "p2") a given message/enum type belongs to.
+function funcUpsert({
+    typeName,
+    instructions,
+    types,
+    typePackageAlias,
+    history,
+    outbox
 }) {
-    // Reminder of the shape of a "query" instruction:
-    //
-    //    {
-    //        'instruction': 'query',
-    //        'sql': String,
-    //        'parameters': [inputParameter, ...etc]
-    //    }
-    
     // Here's what we're going for:
     //
-    //     rows, err = transaction.QueryContext(ctx, $query, $parameters ...)
-    //     if err != nil {
+    //     ... documentation ...
+    //     func UpsertFooBar(ctx context.Context, db Database, message *pb.FooBar) (err error) {
+    //         ... vars ...
+    //
+    //         transaction, err = beginTransaction(ctx, db)
+    //         if err != nil {
+    //             return
+    //         }
+    //
+    //         ... all the instructions ...
+    //
+    //         err = transaction.Commit()
     //         return
     //     }
-    //     ok = rows.Next()
 
-    const parameters = inputParameters2expressions({
-        parameters: instruction.parameters,
-        typeByField,
-        included
-    });
+    const funcName = `Upsert${messageOrEnum2go(typeName)}`;
+    const versionFieldName = types[typeName].versionFieldName;
+    const documentation =
+`${funcName} adds the specified message to the specified db, or replaces the
+message in the db that has the same ID, subject to the specified cancellation
+context ctx. Return nil on success, or return a non-nil value if an error
+occurs.` +
+        (versionFieldName === undefined ? '' : `
+
+If the message is added, then its version in the db is message.${field2go(versionFieldName)}.
+If it replaces a message, then the version in the db is instead incremented,
+regardless of message.${field2go(versionFieldName)}, which is not modified.`);
+    const parameters = [
+        {name: 'ctx', type: 'context.Context'},
+        {name: 'db', type: 'Database'},
+        {name: 'message',
+         type: `*${typePackageAlias(typeName)}.${messageOrEnum2go(typeName)}`}
+    ];
+    const results = [{name: 'err', type: 'error'}];
+    const variables = [];
+    // Begin by starting a transaction. We'll fill out the rest later.
+    const statements = [...beginTransaction];
+    const func = {
+        documentation,
+        name: funcName,
+        parameters,
+        results,
+        body: {
+            variables,
+            statements
+        }
+    };
 
-    // The following code references these variables.
-    variable({name: 'rows', goType: '*sql.Rows'})
-    variable({name: 'ok', goType: 'bool'})
+    // Define the arguments needed by the instruction handlers.
 
-    return [
-        // rows, err = transaction.QueryContext(ctx, $query, $parameters)
-        {assign: {
-            left: ['rows', 'err'],
-            right: [{
-                call: {
-                    function: {dot: ['transaction', 'QueryContext']},
-                    arguments: [
-                        {symbol: 'ctx'},
-                        instruction.sql,
-                        ...parameters
-                    ]
-                }
-            }]
-        }},
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
 
-        // if err != nil {
-        //     return
-        // }
-        ifErrReturn,
+    // variable({name, goType}) adds the variable with the specified name and
+    // having the specified type to the func's variable declarations section if
+    // it hasn't been added already, and returns the name of the variable.
+    const variable = variableAdder(variables);
+
+    // In an "upsert" func, all fields are "included," so this always returns
+    // `true`.
+    function included(fieldName /*ignored*/) {
+        return true;
+    }
+
+    // `transaction` is a variable assumed to be in scope, so add that first.
+    variable({name: 'transaction', goType: 'transactor'});
+
+    // Generate statements that implement each instruction, and append the
+    // statements to the body of the func.
+    statements.push(...performInstructions({
+        instructions,
+        typeByField,
+        types,
+        variable,
+        included,
+        typePackageAlias
+    }));
+
+    if (history) {
+        statements.push(...recordHistory({
+            typeName,
+            id: {dot: ['message', field2go(types[typeName].idFieldName)]},
+            operation: 'upsert'
+        }), {spacer: 1});
+    }
+
+    if (outbox) {
+        statements.push(...publishChange({
+            typeName,
+            id: {dot: ['message', field2go(types[typeName].idFieldName)]},
+            operation: 'upsert',
+            fieldMask: null
+        }), {spacer: 1});
+    }
+
+    statements.push(...commitTransactionAndReturn);
+
+    return {function: func};
+}
+
+// Return a Go AST node representing a func that reads a page of instances of
+// a message of the specified `typeName` from the database using the specified
+// CRUD `instructions`. Use the specified `types` object of okra types by name
+// to inspect the message type and any enum types that it might depend upon.
+// Use the specified `typePackageAlias` function to look up which package
+// aliases (e.g. "pb", "p2") a given message/enum type belongs to.
+function funcList({typeName, instructions, types, typePackageAlias}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func ListFooBars(ctx context.Context, db Database, pageSize int, pageToken string) (messages []*pb.FooBar, nextPageToken string, err error) {
+    //     ... vars ...
+    //
+    //     if pageSize < 1 {
+    //         err = fmt.Errorf(...)
+    //         return
+    //     }
+    //
+    //     if pageToken != "" {
+    //         err = decodePageToken(pageToken, &after)
+    //         if err != nil {
+    //             return
+    //         }
+    //     }
+    //
+    //     transaction, err = beginTransaction(ctx, db)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     ... instructions up to and including "read-rows" ...
+    //
+    //     if len(messages) == 0 {
+    //         err = transaction.Commit()
+    //         return
+    //     }
+    //
+    //     ... set up `byID` and `last` ...
+    //
+    //     ... the remaining instructions ...
+    //
+    //     if len(messages) == pageSize {
+    //         nextPageToken, err = encodePageToken(messages[len(messages)-1].Id)
+    //         if err != nil {
+    //             return
+    //         }
+    //     }
+    //
+    //     err = transaction.Commit()
+    //     return
+    // }
+
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = `List${pluralize(goTypeName)}`;
+    const messageType = `${typePackageAlias(typeName)}.${goTypeName}`;
+
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+
+    const idFieldName = types[typeName].idFieldName;
+    const idType = typeByField[idFieldName];
+    const idGoType = idMapKeyType({funcName, typeName, types, typePackageAlias});
+    const lastID = {raw: `messages[len(messages)-1].${field2go(idFieldName)}`};
+
+    const documentation =
+`${funcName} reads from the specified db at most the specified pageSize
+messages, in order of their IDs, subject to the specified cancellation
+context ctx. If the specified pageToken is empty, then the page begins with
+the first message. Otherwise, pageToken must be a nextPageToken returned by
+a previous call to ${funcName}, and the page begins with the message
+after the last message of that call's page. On success, return the messages
+and a nextPageToken for the next page, and a nil error. If there are no more
+messages after the returned page, then nextPageToken is empty. Note that the
+last page might be empty. On error, the error returned will not be nil.`;
+
+    const parameters = [
+        {name: 'ctx', type: 'context.Context'},
+        {name: 'db', type: 'Database'},
+        {name: 'pageSize', type: 'int'},
+        {name: 'pageToken', type: 'string'}
+    ];
+    const results = [
+        {name: 'messages', type: `[]*${messageType}`},
+        {name: 'nextPageToken', type: 'string'},
+        {name: 'err', type: 'error'}
+    ];
+    const variables = [];
+    const statements = [];
+    const func = {
+        documentation,
+        name: funcName,
+        parameters,
+        results,
+        body: {
+            variables,
+            statements
+        }
+    };
+
+    // Define the arguments needed by the instruction handlers.
+
+    // variable({name, goType}) adds the variable with the specified name and
+    // having the specified type to the func's variable declarations section if
+    // it hasn't been added already, and returns the name of the variable.
+    const variable = variableAdder(variables);
+
+    // In a "list" func, all fields are included, so this always returns
+    // `true`.
+    function included(fieldName /*ignored*/) {
+        return true;
+    }
+
+    // The bounds of the page are local variables (or expressions involving
+    // the parameters). `last` is assigned only once the messages have been
+    // read, so keep track of whether it's needed.
+    let referencesLast = false;
+    function page(bound) {
+        switch (bound) {
+        case 'first':
+            // pageToken == ""
+            return {equal: {left: {symbol: 'pageToken'}, right: ''}};
+        case 'after':
+            return inputExpression({
+                okraType: idType,
+                expression: {symbol: 'after'}
+            });
+        case 'last':
+            referencesLast = true;
+            variable({name: 'last', goType: idGoType});
+            return inputExpression({
+                okraType: idType,
+                expression: {symbol: 'last'}
+            });
+        default:
+            // 'size'
+            return {symbol: 'pageSize'};
+        }
+    }
+
+    const messages = {messageType, idFieldName};
+
+    // `transaction` is a variable assumed to be in scope, so add that first.
+    variable({name: 'transaction', goType: 'transactor'});
+    variable({name: 'after', goType: idGoType});
+
+    statements.push(
+        // if pageSize < 1 {
+        //     err = invalidArgument(...)
+        //     return
+        // }
+        {if: {
+            condition: {raw: 'pageSize < 1'},
+            body: [
+                {assign: {
+                    left: ['err'],
+                    right: [{call: {
+                        function: 'invalidArgument',
+                        arguments: [
+                            `${funcName} requires a positive pageSize, but %d was specified`,
+                            {symbol: 'pageSize'}
+                        ]
+                    }}]
+                }},
+                {return: []}
+            ]
+        }},
+
+        {spacer: 1},
+
+        // if pageToken != "" {
+        //     err = decodePageToken(pageToken, &after)
+        //     if err != nil {
+        //         return
+        //     }
+        // }
+        {if: {
+            condition: {notEqual: {left: {symbol: 'pageToken'}, right: ''}},
+            body: [
+                {assign: {
+                    left: ['err'],
+                    right: [{call: {
+                        function: 'decodePageToken',
+                        arguments: [
+                            {symbol: 'pageToken'},
+                            {address: {symbol: 'after'}}
+                        ]
+                    }}]
+                }},
+                ifErrReturn
+            ]
+        }},
+
+        {spacer: 1},
+
+        ...beginTransaction);
+
+    // The instructions are divided into two parts: those that read the
+    // messages (ending with "read-rows"), and those that depend upon there
+    // being messages (e.g. reading the array fields of the messages).
+    const split = instructions.findIndex(
+        ({instruction}) => instruction === 'read-rows') + 1;
+    const [head, tail] = [instructions.slice(0, split), instructions.slice(split)];
+
+    const instructionArguments = {
+        typeByField,
+        types,
+        variable,
+        included,
+        typePackageAlias,
+        page,
+        messages
+    };
+
+    statements.push(
+        ...performInstructions({instructions: head, ...instructionArguments}),
+
+        // if len(messages) == 0 {
+        //     err = transaction.Commit()
+        //     return
+        // }
+        {if: {
+            condition: {equal: {
+                left: {call: {function: 'len', arguments: [{symbol: 'messages'}]}},
+                right: 0
+            }},
+            body: [...commitTransactionAndReturn]
+        }},
+
+        {spacer: 1});
+
+    const tailStatements = performInstructions({
+        instructions: tail,
+        ...instructionArguments
+    });
+
+    // If any "read-keyed-array" or "read-keyed-child-rows" instructions were
+    // performed, then they'll need to look up messages by ID.
+    //
+    //     byID = make(map[$idGoType]*pb.FooBar, len(messages))
+    //     for _, message := range messages {
+    //         byID[message.Id] = message
+    //     }
+    if (tail.some(({instruction}) =>
+        ['read-keyed-array', 'read-keyed-child-rows'].includes(instruction))) {
+        statements.push(
+            {assign: {
+                left: ['byID'],
+                right: [{call: {
+                    function: 'make',
+                    arguments: [
+                        {symbol: `map[${idGoType}]*${messageType}`},
+                        {call: {function: 'len', arguments: [{symbol: 'messages'}]}}
+                    ]
+                }}]
+            }},
+            mapMessagesByID(idFieldName));
+    }
+
+    // last = messages[len(messages)-1].Id
+    if (referencesLast) {
+        statements.push({assign: {left: ['last'], right: [lastID]}});
+    }
+
+    statements.push(
+        {spacer: 1},
+
+        ...tailStatements,
+
+        // if len(messages) == pageSize {
+        //     nextPageToken, err = encodePageToken(messages[len(messages)-1].Id)
+        //     if err != nil {
+        //         return
+        //     }
+        // }
+        {if: {
+            condition: {equal: {
+                left: {call: {function: 'len', arguments: [{symbol: 'messages'}]}},
+                right: {symbol: 'pageSize'}
+            }},
+            body: [
+                {assign: {
+                    left: ['nextPageToken', 'err'],
+                    right: [{call: {
+                        function: 'encodePageToken',
+                        arguments: [lastID]
+                    }}]
+                }},
+                ifErrReturn
+            ]
+        }},
+
+        {spacer: 1},
+
+        ...commitTransactionAndReturn);
+
+    return {function: func};
+}
+
+// Return a Go AST node representing a func that reads the instances of a
+// message of the specified `typeName` having any of a slice of IDs from the
+// database using the specified CRUD `instructions`. Use the specified `types`
+// object of okra types by name to inspect the message type and any enum types
+// that it might depend upon. Use the specified `typePackageAlias` function to
+// look up which package aliases (e.g. "pb", "p2") a given message/enum type
+// belongs to.
+function funcReadMany({typeName, instructions, types, typePackageAlias}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func ReadFooBars(ctx context.Context, db Database, ids []string) (byID map[string]*pb.FooBar, err error) {
+    //     ... vars ...
+    //
+    //     byID = make(map[string]*pb.FooBar, len(ids))
+    //     if len(ids) == 0 {
+    //         return
+    //     }
+    //
+    //     transaction, err = beginTransaction(ctx, db)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     remaining = ids
+    //     for len(remaining) != 0 {
+    //         ids = remaining
+    //         if len(ids) > maxStatementParameters {
+    //             ids = ids[:maxStatementParameters]
+    //         }
+    //         remaining = remaining[len(ids):]
+    //         messages = nil
+    //
+    //         ... instructions up to and including "read-rows" ...
+    //
+    //         for _, message := range messages {
+    //             byID[message.Id] = message
+    //         }
+    //
+    //         ... the remaining instructions ...
+    //     }
+    //
+    //     err = transaction.Commit()
+    //     return
+    // }
+
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = `Read${pluralize(goTypeName)}`;
+    const messageType = `${typePackageAlias(typeName)}.${goTypeName}`;
+
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+
+    const idFieldName = types[typeName].idFieldName;
+    const idType = typeByField[idFieldName];
+    const idGoType = idMapKeyType({funcName, typeName, types, typePackageAlias});
+    const mapType = `map[${idGoType}]*${messageType}`;
+
+    const documentation =
+`${funcName} reads from the specified db the messages having any of the
+specified ids, subject to the specified cancellation context ctx. Each table
+is queried once for all of the ids, or once per chunk of the ids if there are
+more than a statement can have parameters. On success, return a map from ID to
+message, and a nil error. IDs for which there is no message are absent from
+the map. On error, the error returned will not be nil.`;
+
+    const parameters = [
+        {name: 'ctx', type: 'context.Context'},
+        {name: 'db', type: 'Database'},
+        {name: 'ids', type: `[]${idGoType}`}
+    ];
+    const results = [
+        {name: 'byID', type: mapType},
+        {name: 'err', type: 'error'}
+    ];
+    const variables = [];
+    const statements = [];
+    const func = {
+        documentation,
+        name: funcName,
+        parameters,
+        results,
+        body: {
+            variables,
+            statements
+        }
+    };
+
+    // Define the arguments needed by the instruction handlers.
+
+    // variable({name, goType}) adds the variable with the specified name and
+    // having the specified type to the func's variable declarations section if
+    // it hasn't been added already, and returns the name of the variable.
+    // `byID` is a named result, and so is already in scope.
+    const variable = variableAdder(variables, ['byID']);
+
+    // In a "read-many" func, all fields are included, so this always returns
+    // `true`.
+    function included(fieldName /*ignored*/) {
+        return true;
+    }
+
+    // Each "query-with-tuples" instruction loops over `ids`, naming each
+    // element `id`.
+    function batch(what /* always "id" */) {
+        return inputExpression({
+            okraType: idType,
+            expression: {symbol: 'id'}
+        });
+    }
+
+    const messages = {messageType, idFieldName};
+
+    // `transaction` is a variable assumed to be in scope, so add that first.
+    variable({name: 'transaction', goType: 'transactor'});
+    variable({name: 'messages', goType: `[]*${messageType}`});
+
+    statements.push(
+        // byID = make(map[$idGoType]*pb.FooBar, len(ids))
+        {assign: {
+            left: ['byID'],
+            right: [{call: {
+                function: 'make',
+                arguments: [
+                    {symbol: mapType},
+                    {call: {function: 'len', arguments: [{symbol: 'ids'}]}}
+                ]
+            }}]
+        }},
+
+        // if len(ids) == 0 {
+        //     return
+        // }
+        {if: {
+            condition: {equal: {
+                left: {call: {function: 'len', arguments: [{symbol: 'ids'}]}},
+                right: 0
+            }},
+            body: [{return: []}]
+        }},
+
+        {spacer: 1},
+
+        ...beginTransaction);
+
+    // The instructions are divided into two parts: those that read the
+    // messages (ending with "read-rows"), and those that look up the messages
+    // by ID (e.g. reading the array fields of the messages).
+    const split = instructions.findIndex(
+        ({instruction}) => instruction === 'read-rows') + 1;
+    const [head, tail] = [instructions.slice(0, split), instructions.slice(split)];
+
+    const instructionArguments = {
+        typeByField,
+        types,
+        variable,
+        included,
+        typePackageAlias,
+        batch,
+        messages
+    };
+
+    // Each "query-with-tuples" instruction has one tuple of parameters per ID,
+    // and a statement can have at most `maxStatementParameters` parameters, so
+    // the IDs are read in chunks no larger than that allows.
+    const parametersPerID = Math.max(...instructions
+        .filter(({instruction}) => instruction === 'query-with-tuples')
+        .map(({parameters}) => parameters.length));
+    const chunkSize = parametersPerID > 1
+        ? `maxStatementParameters/${parametersPerID}`
+        : 'maxStatementParameters';
+
+    variable({name: 'remaining', goType: `[]${idGoType}`});
+
+    statements.push(
+        // remaining = ids
+        {assign: {
+            left: ['remaining'],
+            right: [{symbol: 'ids'}]
+        }},
+
+        // for len(remaining) != 0 {
+        //     ids = remaining
+        //     if len(ids) > $chunkSize {
+        //         ids = ids[:$chunkSize]
+        //     }
+        //     remaining = remaining[len(ids):]
+        //     messages = nil
+        //
+        //     ... instructions ...
+        // }
+        {conditionFor: {
+            condition: {notEqual: {
+                left: {call: {function: 'len', arguments: [{symbol: 'remaining'}]}},
+                right: 0
+            }},
+            body: [
+                {assign: {
+                    left: ['ids'],
+                    right: [{symbol: 'remaining'}]
+                }},
+                {if: {
+                    condition: {raw: `len(ids) > ${chunkSize}`},
+                    body: [{assign: {
+                        left: ['ids'],
+                        right: [{raw: `ids[:${chunkSize}]`}]
+                    }}]
+                }},
+                {assign: {
+                    left: ['remaining'],
+                    right: [{raw: 'remaining[len(ids):]'}]
+                }},
+                {assign: {
+                    left: ['messages'],
+                    right: [null]
+                }},
+
+                {spacer: 1},
+
+                ...performInstructions({instructions: head, ...instructionArguments}),
+
+                // for _, message := range messages {
+                //     byID[message.Id] = message
+                // }
+                mapMessagesByID(idFieldName),
+
+                {spacer: 1},
+
+                ...performInstructions({instructions: tail, ...instructionArguments})
+            ]
+        }},
+
+        {spacer: 1},
+
+        ...commitTransactionAndReturn);
+
+    return {function: func};
+}
+
+// Return a Go AST node representing a func that creates many new instances of
+// a message of the specified `typeName` in the database using the specified
+// CRUD `instructions`. Use the specified `types` object of okra types by name
+// to inspect the message type and any enum types that it might depend upon.
+// Use the specified `typePackageAlias` function to look up which package
+// aliases (e.g. "pb", "p2") a given message/enum type belongs to.
+function funcCreateMany({
+    typeName,
+    instructions,
+    types,
+    typePackageAlias,
+    recordMany,
+    publishMany
+}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func CreateFooBars(ctx context.Context, db Database, messages []*pb.FooBar) (err error) {
+    //     ... vars ...
+    //
+    //     if len(messages) == 0 {
+    //         return
+    //     }
+    //
+    //     transaction, err = beginTransaction(ctx, db)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     ... all the instructions ...
+    //
+    //     err = transaction.Commit()
+    //     return
+    // }
+
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = `Create${pluralize(goTypeName)}`;
+    const messageType = `${typePackageAlias(typeName)}.${goTypeName}`;
+
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+
+    const documentation =
+`${funcName} adds the specified messages to the specified db, subject to the
+specified cancellation context ctx. Each table is inserted into using as few
+statements as fit within MaxStatementBytes. Either all of the messages are
+added, or none of them are. Return nil on success, or return a non-nil value
+if an error occurs.` + (recordMany || publishMany ? `
+
+Any history records or outbox events of the creations contain the messages
+as specified, rather than as read back from db, so they lack any values that
+db fills in, such as timestamps.` : '');
+
+    const parameters = [
+        {name: 'ctx', type: 'context.Context'},
+        {name: 'db', type: 'Database'},
+        {name: 'messages', type: `[]*${messageType}`}
+    ];
+    const results = [
+        {name: 'err', type: 'error'}
+    ];
+    const variables = [];
+    const statements = [];
+    const func = {
+        documentation,
+        name: funcName,
+        parameters,
+        results,
+        body: {
+            variables,
+            statements
+        }
+    };
+
+    // Define the arguments needed by the instruction handlers.
+
+    // variable({name, goType}) adds the variable with the specified name and
+    // having the specified type to the func's variable declarations section if
+    // it hasn't been added already, and returns the name of the variable.
+    const variable = variableAdder(variables);
+
+    // In a "create-many" func, all fields are included, so this always
+    // returns `true`.
+    function included(fieldName /*ignored*/) {
+        return true;
+    }
+
+    // `transaction` is a variable assumed to be in scope, so add that first.
+    variable({name: 'transaction', goType: 'transactor'});
+
+    statements.push(
+        // if len(messages) == 0 {
+        //     return
+        // }
+        {if: {
+            condition: {equal: {
+                left: {call: {function: 'len', arguments: [{symbol: 'messages'}]}},
+                right: 0
+            }},
+            body: [{return: []}]
+        }},
+
+        {spacer: 1},
+
+        // transaction, err = beginTransaction(ctx, db)
+        // if err != nil {
+        //     return
+        // }
+        ...beginTransaction,
+
+        ...performInstructions({
+            instructions,
+            typeByField,
+            types,
+            variable,
+            included,
+            typePackageAlias
+        }));
+
+    if (recordMany || publishMany) {
+        statements.push(
+            ...recordAndPublishMany({
+                typeName,
+                typeByField,
+                types,
+                variable,
+                included,
+                recordMany,
+                publishMany
+            }),
+            {spacer: 1});
+    }
+
+    statements.push(...commitTransactionAndReturn);
+
+    return {function: func};
+}
+
+// Return an array of Go statements that, at the end of a "create-many" func
+// for a message of the specified `typeName`, record the creation of each of
+// the `messages` in scope in their history using the specified `recordMany`
+// instruction, and publish it to the outbox using the specified `publishMany`
+// instruction. Either instruction may be `undefined`, in which case that part
+// is omitted. Unlike `recordHistory` and `publishChange`, the messages are
+// not read back from the database, so each takes one round trip per batch of
+// messages rather than two per message.
+function recordAndPublishMany({
+    typeName,
+    typeByField,
+    types,
+    variable,
+    included,
+    recordMany,
+    publishMany
+}) {
+    // Here's what we're going for:
+    //
+    //     historyBatch = newTupleBatch(transaction, $sql, $tuple)
+    //     outboxBatch = newTupleBatch(transaction, $sql, $tuple)
+    //     for _, message := range messages {
+    //         serialized, err = marshalMessage(message)
+    //         if err != nil {
+    //             return
+    //         }
+    //
+    //         err = historyBatch.add(ctx, $parameters...)
+    //         if err != nil {
+    //             return
+    //         }
+    //
+    //         encodedID, err = encodeOutboxID(message.Id)
+    //         if err != nil {
+    //             return
+    //         }
+    //         err = outboxBatch.add(ctx, $parameters...)
+    //         if err != nil {
+    //             return
+    //         }
+    //     }
+    //     err = historyBatch.flush(ctx)
+    //     if err != nil {
+    //         return
+    //     }
+    //     err = outboxBatch.flush(ctx)
+    //     if err != nil {
+    //         return
+    //     }
+
+    const idFieldName = types[typeName].idFieldName;
+    const id = {dot: ['message', field2go(idFieldName)]};
+    variable({name: 'serialized', goType: '[]byte'});
+
+    // The values of `{history: ...}` parameters, as in `funcRecordHistory`.
+    function history(what) {
+        return {
+            id: () => inputExpression({
+                okraType: typeByField[idFieldName],
+                expression: id
+            }),
+            operation: () => 'create', // the literal string, quoted
+            actor: () => ({call: {
+                function: 'actorOf',
+                arguments: [{symbol: 'ctx'}]
+            }}),
+            message: () => ({call: {
+                function: 'fromOptionalBytes',
+                arguments: [{symbol: 'serialized'}]
+            }})
+        }[what]();
+    }
+
+    // The values of `{outbox: ...}` parameters, as in `funcPublishChange`.
+    function outbox(what) {
+        return {
+            type: () => typeName, // the literal string, quoted
+            id: () => ({symbol: 'encodedID'}),
+            operation: () => 'create',
+            // a creation changes the whole message, so there's no field mask
+            fieldMask: () => null,
+            message: () => ({call: {
+                function: 'fromOptionalBytes',
+                arguments: [{symbol: 'serialized'}]
+            }})
+        }[what]();
+    }
+
+    // Return the statements that create the specified `batch` for the
+    // specified "exec-many-with-tuples" `instruction`, add a tuple to it, and
+    // flush it, respectively.
+    function batchStatements({batch, instruction}) {
+        variable({name: batch, goType: '*tupleBatch'});
+        return {
+            // $batch = newTupleBatch(transaction, $sql, $tuple)
+            create: {assign: {
+                left: [batch],
+                right: [{call: {
+                    function: 'newTupleBatch',
+                    arguments: [
+                        {symbol: 'transaction'},
+                        instruction.sql,
+                        instruction.tuple
+                    ]
+                }}]
+            }},
+            // err = $batch.add(ctx, $parameters...)
+            // if err != nil {
+            //     return
+            // }
+            add: [
+                {assign: {
+                    left: ['err'],
+                    right: [{call: {
+                        function: {dot: [batch, 'add']},
+                        arguments: [
+                            {symbol: 'ctx'},
+                            ...instruction.parameters.map(parameter =>
+                                inputParameter2expression({
+                                    parameter,
+                                    typeByField,
+                                    included,
+                                    history,
+                                    outbox
+                                }))
+                        ]
+                    }}]
+                }},
+                ifErrReturn
+            ],
+            // err = $batch.flush(ctx)
+            // if err != nil {
+            //     return
+            // }
+            flush: [
+                {assign: {
+                    left: ['err'],
+                    right: [{call: {
+                        function: {dot: [batch, 'flush']},
+                        arguments: [{symbol: 'ctx'}]
+                    }}]
+                }},
+                ifErrReturn
+            ]
+        };
+    }
+
+    const batches = [];
+    const loopBody = [
+        // serialized, err = marshalMessage(message)
+        {assign: {
+            left: ['serialized', 'err'],
+            right: [{call: {
+                function: 'marshalMessage',
+                arguments: [{symbol: 'message'}]
+            }}]
+        }},
+        ifErrReturn
+    ];
+
+    if (recordMany) {
+        const historyBatch =
+            batchStatements({batch: 'historyBatch', instruction: recordMany});
+        batches.push(historyBatch);
+        loopBody.push({spacer: 1}, ...historyBatch.add);
+    }
+
+    if (publishMany) {
+        variable({name: 'encodedID', goType: 'string'});
+        const outboxBatch =
+            batchStatements({batch: 'outboxBatch', instruction: publishMany});
+        batches.push(outboxBatch);
+        loopBody.push(
+            {spacer: 1},
+
+            // encodedID, err = encodeOutboxID(message.Id)
+            {assign: {
+                left: ['encodedID', 'err'],
+                right: [{call: {
+                    function: 'encodeOutboxID',
+                    arguments: [id]
+                }}]
+            }},
+            ifErrReturn,
+
+            ...outboxBatch.add);
+    }
+
+    return [
+        ...batches.map(batch => batch.create),
+
+        // for _, message := range messages {
+        //     ...
+        // }
+        {rangeFor: {
+            variables: ['_', 'message'],
+            sequence: {symbol: 'messages'},
+            body: loopBody
+        }},
+
+        ...batches.map(batch => batch.flush).flat()
+    ];
+}
+
+// Return a Go AST node representing a func that checks whether there is an
+// instance of a message of the specified `typeName` having a particular ID in
+// the database, using the specified CRUD `instructions`. Use the specified
+// `types` object of okra types by name to inspect the message type. Use the
+// specified `typePackageAlias` function to look up which package aliases
+// (e.g. "pb", "p2") a given message/enum type belongs to.
+function funcExists({typeName, instructions, types, typePackageAlias}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func ExistsFooBar(ctx context.Context, db Database, id int64) (exists bool, err error) {
+    //     ... other vars ...
+    //
+    //     var message pb.FooBar
+    //     message.Id = id
+    //
+    //     transaction, err = beginTransaction(ctx, db)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     ... instructions ...
+    //
+    //     err = transaction.Commit()
+    //     return
+    // }
+
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+
+    const funcName = `Exists${messageOrEnum2go(typeName)}`;
+    const documentation =
+`${funcName} returns whether there is a message having the specified id in
+the specified db, subject to the specified cancellation context ctx. The
+message itself is not read. On error, the error returned will not be nil.`;
+    const idFieldName = types[typeName].idFieldName;
+    const parameters = [
+        {name: 'ctx', type: 'context.Context'},
+        {name: 'db', type: 'Database'},
+        {name: 'id',
+         type: type2go({
+            okraType: typeByField[idFieldName],
+            typePackageAlias
+        })}
+    ];
+    const results = [
+        {name: 'exists', type: 'bool'},
+        {name: 'err', type: 'error'}
+    ];
+    const variables = [];
+
+    // variable({name, goType}) adds the variable with the specified name and
+    // having the specified type to the func's variable declarations section if
+    // it hasn't been added already, and returns the name of the variable.
+    const variable = variableAdder(variables);
+
+    // As in `funcDelete`, the instructions refer to the ID field of a message,
+    // so have a message that contains just the ID value.
+    const messageType =
+        `${typePackageAlias(typeName)}.${messageOrEnum2go(typeName)}`;
+    variable({name: 'message', goType: messageType});
+
+    const statements = [
+        // message.Id = id
+        {assign: {
+            left: [{dot: ['message', field2go(idFieldName)]}],
+            right: [{symbol: 'id'}]
+        }},
+
+        ...beginTransaction
+    ];
+    const func = {
+        documentation,
+        name: funcName,
+        parameters,
+        results,
+        body: {
+            variables,
+            statements
+        }
+    };
+
+    // In an "exists" func, no fields other than the ID are referenced, so
+    // it's an error if `included` is called.
+    function included(fieldName) {
+        throw Error('funcExists processed an instruction that queried ' +
+            'whether a field is included, but instructions in an existence ' +
+            'check should not have to reference any fields. fieldName: ' +
+            fieldName);
+    }
+
+    // `transaction` is a variable assumed to be in scope, so add that first.
+    variable({name: 'transaction', goType: 'transactor'});
+
+    statements.push(...performInstructions({
+        instructions,
+        typeByField,
+        types,
+        variable,
+        included,
+        typePackageAlias
+    }));
+
+    statements.push(...commitTransactionAndReturn);
+
+    return {function: func};
+}
+
+// Return a Go AST node representing a func that counts the instances of a
+// message of the specified `typeName` in the database that satisfy
+// conditions built by the message's query builder (see
+// `queryDeclarations`), using the specified `count` description (see
+// `crud.tisch.js`).
+function funcCount({typeName, count}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func CountFooBars(ctx context.Context, db Database, conditions ...FooBarCondition) (count int64, err error) {
+    //     ... vars ...
+    //
+    //     for _, where := range conditions {
+    //         all = append(all, where.condition)
+    //     }
+    //     sqlText, parameters, err = buildCount("select count(*) from foobar", all)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     transaction, err = beginTransaction(ctx, db)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     rows, err = transaction.QueryContext(ctx, sqlText, parameters...)
+    //     if err != nil {
+    //         return
+    //     }
+    //     ok = rows.Next()
+    //
+    //     if ok {
+    //         err = rows.Scan(&count)
+    //         ...
+    //     }
+    //
+    //     err = transaction.Commit()
+    //     return
+    // }
+
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = `Count${pluralize(goTypeName)}`;
+    const conditionType = `${goTypeName}Condition`;
+
+    const documentation =
+`${funcName} returns the number of messages in the specified db that satisfy
+all of the specified conditions, subject to the specified cancellation
+context ctx. If no conditions are specified, then all of the messages are
+counted. On error, the error returned will not be nil.` +
+        // A soft-deleted message type has a condition of its own (see
+        // `crud.tisch.js`), which excludes deleted messages.
+        (count.where === undefined ? '' : `
+
+Messages deleted by Delete${goTypeName} are not counted.`);
+
+    const variables = [];
+    const variable = variableAdder(variables);
+    variable({name: 'transaction', goType: 'transactor'});
+    variable({name: 'all', goType: '[]condition'});
+    variable({name: 'sqlText', goType: 'string'});
+    variable({name: 'parameters', goType: '[]interface{}'});
+
+    const statements = [
+        // all = append(all, condition{sql: $where})
+        ...(count.where === undefined ? [] : [{assign: {
+            left: ['all'],
+            right: [{call: {
+                function: 'append',
+                arguments: [{symbol: 'all'}, conditionLiteral(count.where)]
+            }}]
+        }}]),
+
+        // for _, where := range conditions {
+        //     all = append(all, where.condition)
+        // }
+        {rangeFor: {
+            variables: ['_', 'where'],
+            sequence: {symbol: 'conditions'},
+            body: [{assign: {
+                left: ['all'],
+                right: [{call: {
+                    function: 'append',
+                    arguments: [{symbol: 'all'}, {dot: ['where', 'condition']}]
+                }}]
+            }}]
+        }},
+
+        // sqlText, parameters, err = buildCount($sql, all)
+        {assign: {
+            left: ['sqlText', 'parameters', 'err'],
+            right: [{call: {
+                function: 'buildCount',
+                arguments: [count.sql, {symbol: 'all'}]
+            }}]
+        }},
+        ifErrReturn,
+
+        {spacer: 1},
+
+        ...beginTransaction,
+
+        // rows, err = transaction.QueryContext(ctx, sqlText, parameters...)
+        {assign: {
+            left: ['rows', 'err'],
+            right: [{call: {
+                function: {dot: ['transaction', 'QueryContext']},
+                arguments: [{symbol: 'ctx'}, {symbol: 'sqlText'}],
+                rest: {symbol: 'parameters'}
+            }}]
+        }},
+        ifErrReturn,
+
+        // ok = rows.Next()
+        {assign: {
+            left: ['ok'],
+            right: [{call: {function: {dot: ['rows', 'Next']}, arguments: []}}]
+        }},
+
+        {spacer: 1},
+
+        ...performReadResult({
+            instruction: {instruction: 'read-result', destination: 'count'},
+            variable
+        }),
+
+        {spacer: 1},
+
+        ...commitTransactionAndReturn
+    ];
+
+    return {function: {
+        documentation,
+        name: funcName,
+        parameters: [
+            {name: 'ctx', type: 'context.Context'},
+            {name: 'db', type: 'Database'},
+            {name: 'conditions', type: `...${conditionType}`}
+        ],
+        results: [
+            {name: 'count', type: 'int64'},
+            {name: 'err', type: 'error'}
+        ],
+        body: {variables, statements}
+    }};
+}
+
+// Return a Go AST node representing a func that reads an instance of a
+// message of the specified `typeName` as it was at a particular time, from the
+// message's history table, using the specified CRUD `instructions` (the "as
+// of" query of the message's history). Use the specified `types` object of
+// okra types by name to inspect the message type. Use the specified
+// `typePackageAlias` function to look up which package aliases (e.g. "pb",
+// "p2") a given message/enum type belongs to.
+function funcReadAsOf({typeName, instructions, types, typePackageAlias}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func ReadFooBarAsOf(ctx context.Context, db Database, id int64, asOf *timestamp.Timestamp) (message *pb.FooBar, err error) {
+    //     ... vars ...
+    //
+    //     transaction, err = beginTransaction(ctx, db)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     ... the instruction ...
+    //
+    //     if !ok {
+    //         err = noRow()
+    //         return
+    //     }
+    //     err = rows.Scan(&operation, intoBytes(&serialized))
+    //     if err != nil {
+    //         return
+    //     }
+    //     if operation == "delete" || operation == "purge" {
+    //         err = noRow()
+    //         return
+    //     }
+    //     err = unmarshalMessage(serialized, &found)
+    //     if err != nil {
+    //         return
+    //     }
+    //     message = &found
+    //
+    //     err = transaction.Commit()
+    //     return
+    // }
+
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = `Read${goTypeName}AsOf`;
+    const messageType = `${typePackageAlias(typeName)}.${goTypeName}`;
+
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+
+    const documentation =
+`${funcName} returns the message having the specified id in the specified
+db as it was at the specified time asOf, according to the message's
+history, subject to the specified cancellation context ctx. If the message
+did not exist at that time, or had been deleted, then the error returned is
+a NoRow. On error, the error returned will not be nil.`;
+
+    const idFieldName = types[typeName].idFieldName;
+    const idType = typeByField[idFieldName];
+    const parameters = [
+        {name: 'ctx', type: 'context.Context'},
+        {name: 'db', type: 'Database'},
+        {name: 'id', type: type2go({okraType: idType, typePackageAlias})},
+        {name: 'asOf',
+         type: type2go({
+             okraType: {builtin: '.google.protobuf.Timestamp'},
+             typePackageAlias
+         })}
+    ];
+    const results = [
+        {name: 'message', type: `*${messageType}`},
+        {name: 'err', type: 'error'}
+    ];
+    const variables = [];
+
+    // variable({name, goType}) adds the variable with the specified name and
+    // having the specified type to the func's variable declarations section if
+    // it hasn't been added already, and returns the name of the variable.
+    const variable = variableAdder(variables);
+
+    // `transaction` is a variable assumed to be in scope, so add that first.
+    variable({name: 'transaction', goType: 'transactor'});
+    variable({name: 'operation', goType: 'string'});
+    variable({name: 'serialized', goType: '[]byte'});
+    variable({name: 'found', goType: messageType});
+
+    // The history instructions don't refer to message fields.
+    function included(fieldName) {
+        throw Error(`${funcName} processed an instruction that queried ` +
+            'whether a field is included, but the history of a message ' +
+            `does not involve its fields. fieldName: ${fieldName}`);
+    }
+
+    // The "as of" query refers to the ID of the message and to the time.
+    function history(what) {
+        return {
+            id: () => inputExpression({
+                okraType: idType,
+                expression: {symbol: 'id'}
+            }),
+            time: () => ({call: {
+                function: 'fromTimestamp',
+                arguments: [{symbol: 'asOf'}]
+            }})
+        }[what]();
+    }
+
+    // err = noRow()
+    // return
+    const returnNoRow = [
+        {assign: {
+            left: ['err'],
+            right: [{call: {function: 'noRow', arguments: []}}]
+        }},
+        {return: []}
+    ];
+
+    const statements = [
+        ...beginTransaction,
+
+        ...performInstructions({
+            instructions,
+            typeByField,
+            types,
+            variable,
+            included,
+            typePackageAlias,
+            history
+        }),
+
+        // if !ok {
+        //     err = noRow()
+        //     return
+        // }
+        {if: {
+            condition: {not: {symbol: 'ok'}},
+            body: returnNoRow
+        }},
+
+        // err = rows.Scan(&operation, intoBytes(&serialized))
+        {assign: {
+            left: ['err'],
+            right: [{call: {
+                function: {dot: ['rows', 'Scan']},
+                arguments: [
+                    {address: {symbol: 'operation'}},
+                    {call: {
+                        function: 'intoBytes',
+                        arguments: [{address: {symbol: 'serialized'}}]
+                    }}
+                ]
+            }}]
+        }},
+        ifErrReturn,
+
+        // if operation == "delete" || operation == "purge" {
+        //     err = noRow()
+        //     return
+        // }
+        {if: {
+            condition: {or: {
+                left: {equal: {left: {symbol: 'operation'}, right: 'delete'}},
+                right: {equal: {left: {symbol: 'operation'}, right: 'purge'}}
+            }},
+            body: returnNoRow
+        }},
+
+        // err = unmarshalMessage(serialized, &found)
+        {assign: {
+            left: ['err'],
+            right: [{call: {
+                function: 'unmarshalMessage',
+                arguments: [
+                    {symbol: 'serialized'},
+                    {address: {symbol: 'found'}}
+                ]
+            }}]
+        }},
+        ifErrReturn,
+
+        // message = &found
+        {assign: {
+            left: ['message'],
+            right: [{address: {symbol: 'found'}}]
+        }},
+
+        {spacer: 1},
+
+        ...commitTransactionAndReturn
+    ];
+
+    return {function: {
+        documentation,
+        name: funcName,
+        parameters,
+        results,
+        body: {variables, statements}
+    }};
+}
+
+// Return a Go AST node representing an unexported func that appends a record
+// of a change to an instance of a message of the specified `typeName` to the
+// message's history table, using the specified CRUD `instructions` (the
+// "record" instruction of the message's history). The operations that change
+// a message call the func before committing (see `recordHistory`). Use the
+// specified `types` object of okra types by name to inspect the message type.
+// Use the specified `typePackageAlias` function to look up which package
+// aliases (e.g. "pb", "p2") a given message/enum type belongs to.
+function funcRecordHistory({typeName, instructions, types, typePackageAlias}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func recordFooBarHistory(ctx context.Context, transaction transactor, id int64, operation string) (err error) {
+    //     var message pb.FooBar
+    //     var serialized []byte
+    //
+    //     if operation != "delete" && operation != "purge" {
+    //         message.Id = id
+    //         err = ReadFooBar(ctx, transaction, &message, nil)
+    //         if errors.Is(err, ErrNotFound) {
+    //             err = nil
+    //             return
+    //         }
+    //         if err != nil {
+    //             return
+    //         }
+    //         serialized, err = marshalMessage(&message)
+    //         if err != nil {
+    //             return
+    //         }
+    //     }
+    //
+    //     ... the instruction ...
+    //
+    //     return
+    // }
+
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = recordHistoryFuncName(typeName);
+    const messageType = `${typePackageAlias(typeName)}.${goTypeName}`;
+
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+
+    const documentation =
+`${funcName} appends a record to the history of the message
+having the specified id, using the specified transaction, subject to the
+specified cancellation context ctx. The record says that the specified
+operation was performed on the message by the actor of ctx (see WithActor).
+Unless the operation deleted the message, the record includes the message as
+read after the operation; if there is no such message, e.g. because it is
+deleted, then nothing is recorded.`;
+
+    const idFieldName = types[typeName].idFieldName;
+    const idType = typeByField[idFieldName];
+    const parameters = [
+        {name: 'ctx', type: 'context.Context'},
+        {name: 'transaction', type: 'transactor'},
+        {name: 'id', type: type2go({okraType: idType, typePackageAlias})},
+        {name: 'operation', type: 'string'}
+    ];
+    const results = [{name: 'err', type: 'error'}];
+    const variables = [];
+
+    // The transaction belongs to the caller, so it's in scope without being
+    // declared (or rolled back) here.
+    const variable = variableAdder(variables, ['transaction']);
+    variable({name: 'message', goType: messageType});
+    variable({name: 'serialized', goType: '[]byte'});
+
+    // The history instructions don't refer to message fields.
+    function included(fieldName) {
+        throw Error(`${funcName} processed an instruction that queried ` +
+            'whether a field is included, but the history of a message ' +
+            `does not involve its fields. fieldName: ${fieldName}`);
+    }
+
+    // The "record" statement refers to everything that goes into a record.
+    function history(what) {
+        return {
+            id: () => inputExpression({
+                okraType: idType,
+                expression: {symbol: 'id'}
+            }),
+            operation: () => ({symbol: 'operation'}),
+            actor: () => ({call: {
+                function: 'actorOf',
+                arguments: [{symbol: 'ctx'}]
+            }}),
+            message: () => ({call: {
+                function: 'fromOptionalBytes',
+                arguments: [{symbol: 'serialized'}]
+            }})
+        }[what]();
+    }
+
+    const statements = [
+        readChangedMessage({typeName, idFieldName}),
+
+        {spacer: 1},
+
+        ...performInstructions({
+            instructions,
+            typeByField,
+            types,
+            variable,
+            included,
+            typePackageAlias,
+            history
+        }),
+
+        {return: []}
+    ];
+
+    return {function: {
+        documentation,
+        name: funcName,
+        parameters,
+        results,
+        body: {variables, statements}
+    }};
+}
+
+// Return a Go AST node for a statement that, unless the `operation` variable
+// in scope is "delete" or "purge", reads the instance of a message of the
+// specified `typeName` whose ID field, having the specified `idFieldName`, is
+// the `id` variable in scope, and then serializes it into the `serialized`
+// variable in scope. If there is no such message, the statement returns with
+// no error. This is common to `funcRecordHistory` and `funcPublishChange`.
+function readChangedMessage({typeName, idFieldName}) {
+    // if operation != "delete" && operation != "purge" {
+    //     ...
+    // }
+    return {if: {
+        condition: {and: {
+            left: {notEqual: {left: {symbol: 'operation'}, right: 'delete'}},
+            right: {notEqual: {left: {symbol: 'operation'}, right: 'purge'}}
+        }},
+        body: [
+            // message.Id = id
+            {assign: {
+                left: [{dot: ['message', field2go(idFieldName)]}],
+                right: [{symbol: 'id'}]
+            }},
+
+            // err = ReadFooBar(ctx, transaction, &message, nil)
+            {assign: {
+                left: ['err'],
+                right: [{call: {
+                    function: `Read${messageOrEnum2go(typeName)}`,
+                    arguments: [
+                        {symbol: 'ctx'},
+                        {symbol: 'transaction'},
+                        {address: {symbol: 'message'}},
+                        null
+                    ]
+                }}]
+            }},
+
+            // if errors.Is(err, ErrNotFound) {
+            //     err = nil
+            //     return
+            // }
+            {if: {
+                condition: {call: {
+                    function: {dot: ['errors', 'Is']},
+                    arguments: [{symbol: 'err'}, {symbol: 'ErrNotFound'}]
+                }},
+                body: [
+                    {assign: {left: ['err'], right: [null]}},
+                    {return: []}
+                ]
+            }},
+            ifErrReturn,
+
+            // serialized, err = marshalMessage(&message)
+            {assign: {
+                left: ['serialized', 'err'],
+                right: [{call: {
+                    function: 'marshalMessage',
+                    arguments: [{address: {symbol: 'message'}}]
+                }}]
+            }},
+            ifErrReturn
+        ]
+    }};
+}
+
+// Return the name of the func that records a change to an instance of a
+// message of the specified `typeName` in the message's history, e.g.
+// "recordFooBarHistory" (see `funcRecordHistory`).
+function recordHistoryFuncName(typeName) {
+    return `record${messageOrEnum2go(typeName)}History`;
+}
+
+// Return an array of Go statements that record, as the specified `operation`
+// (e.g. "update"), a change to the instance of a message of the specified
+// `typeName` having the ID that is the value of the specified `id`
+// expression, within the current transaction (see `funcRecordHistory`).
+//
+//     err = recordFooBarHistory(ctx, transaction, $id, "$operation")
+//     if err != nil {
+//         return
+//     }
+function recordHistory({typeName, id, operation}) {
+    return [
+        {assign: {
+            left: ['err'],
+            right: [{call: {
+                function: recordHistoryFuncName(typeName),
+                arguments: [
+                    {symbol: 'ctx'},
+                    {symbol: 'transaction'},
+                    id,
+                    operation // the literal string, quoted
+                ]
+            }}]
+        }},
+        ifErrReturn
+    ];
+}
+
+// Return an array containing a Go `if` statement that performs the specified
+// `statements` if the specified `condition` is true, or return `statements`
+// if `condition` is `undefined`.
+function onlyIf(condition, statements) {
+    if (condition === undefined) {
+        return statements;
+    }
+    return [{if: {condition, body: statements}}];
+}
+
+// Return a Go AST node representing an unexported func that inserts a change
+// to an instance of a message of the specified `typeName` into the outbox,
+// using the specified CRUD `instructions` (the "publish" instruction of the
+// message's outbox). The operations that change a message call the func
+// before committing (see `publishChange`). Use the specified `types` object of
+// okra types by name to inspect the message type. Use the specified
+// `typePackageAlias` function to look up which package aliases (e.g. "pb",
+// "p2") a given message/enum type belongs to.
+function funcPublishChange({typeName, instructions, types, typePackageAlias}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func publishFooBarChange(ctx context.Context, transaction transactor, id int64, operation string, fieldMask []string) (err error) {
+    //     var message pb.FooBar
+    //     var serialized []byte
+    //     var encodedID string
+    //
+    //     if operation != "delete" && operation != "purge" {
+    //         ... read and serialize the message, as in recordFooBarHistory ...
+    //     }
+    //
+    //     encodedID, err = encodeOutboxID(id)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     ... the instruction ...
+    //
+    //     return
+    // }
+
+    const funcName = publishChangeFuncName(typeName);
+    const messageType =
+        `${typePackageAlias(typeName)}.${messageOrEnum2go(typeName)}`;
+
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+
+    const documentation =
+`${funcName} inserts into the outbox an event saying that the
+specified operation was performed on the message having the specified id,
+using the specified transaction, subject to the specified cancellation
+context ctx. The specified fieldMask names the fields that the operation
+changed, or is empty if it changed the whole message. Unless the operation
+deleted the message, the event includes the message as read after the
+operation; if there is no such message, e.g. because it is deleted, then
+nothing is inserted.`;
+
+    const idFieldName = types[typeName].idFieldName;
+    const idType = typeByField[idFieldName];
+    const parameters = [
+        {name: 'ctx', type: 'context.Context'},
+        {name: 'transaction', type: 'transactor'},
+        {name: 'id', type: type2go({okraType: idType, typePackageAlias})},
+        {name: 'operation', type: 'string'},
+        {name: 'fieldMask', type: '[]string'}
+    ];
+    const results = [{name: 'err', type: 'error'}];
+    const variables = [];
+
+    // The transaction belongs to the caller, so it's in scope without being
+    // declared (or rolled back) here.
+    const variable = variableAdder(variables, ['transaction']);
+    variable({name: 'message', goType: messageType});
+    variable({name: 'serialized', goType: '[]byte'});
+    variable({name: 'encodedID', goType: 'string'});
+
+    // The outbox instructions don't refer to message fields.
+    function included(fieldName) {
+        throw Error(`${funcName} processed an instruction that queried ` +
+            'whether a field is included, but publishing a change to a ' +
+            `message does not involve its fields. fieldName: ${fieldName}`);
+    }
+
+    // The "publish" statement refers to everything that goes into an event.
+    function outbox(what) {
+        return {
+            type: () => typeName, // the literal string, quoted
+            id: () => ({symbol: 'encodedID'}),
+            operation: () => ({symbol: 'operation'}),
+            fieldMask: () => ({call: {
+                function: 'joinFieldMask',
+                arguments: [{symbol: 'fieldMask'}]
+            }}),
+            message: () => ({call: {
+                function: 'fromOptionalBytes',
+                arguments: [{symbol: 'serialized'}]
+            }})
+        }[what]();
+    }
+
+    const statements = [
+        readChangedMessage({typeName, idFieldName}),
+
+        {spacer: 1},
+
+        // encodedID, err = encodeOutboxID(id)
+        {assign: {
+            left: ['encodedID', 'err'],
+            right: [{call: {
+                function: 'encodeOutboxID',
+                arguments: [{symbol: 'id'}]
+            }}]
+        }},
+        ifErrReturn,
+
+        {spacer: 1},
+
+        ...performInstructions({
+            instructions,
+            typeByField,
+            types,
+            variable,
+            included,
+            typePackageAlias,
+            outbox
+        }),
+
+        {return: []}
+    ];
+
+    return {function: {
+        documentation,
+        name: funcName,
+        parameters,
+        results,
+        body: {variables, statements}
+    }};
+}
+
+// Return the name of the func that inserts a change to an instance of a
+// message of the specified `typeName` into the outbox, e.g.
+// "publishFooBarChange" (see `funcPublishChange`).
+function publishChangeFuncName(typeName) {
+    return `publish${messageOrEnum2go(typeName)}Change`;
+}
+
+// Return an array of Go statements that insert into the outbox, as the
+// specified `operation` (e.g. "update"), a change to the instance of a message
+// of the specified `typeName` having the ID that is the value of the specified
+// `id` expression, within the current transaction (see `funcPublishChange`).
+// The specified `fieldMask` is an expression for the names of the changed
+// fields, or is `null` if the operation changed the whole message.
+//
+//     err = publishFooBarChange(ctx, transaction, $id, "$operation", $fieldMask)
+//     if err != nil {
+//         return
+//     }
+function publishChange({typeName, id, operation, fieldMask}) {
+    return [
+        {assign: {
+            left: ['err'],
+            right: [{call: {
+                function: publishChangeFuncName(typeName),
+                arguments: [
+                    {symbol: 'ctx'},
+                    {symbol: 'transaction'},
+                    id,
+                    operation, // the literal string, quoted
+                    fieldMask
+                ]
+            }}]
+        }},
+        ifErrReturn
+    ];
+}
+
+// Return an array of Go AST nodes representing the exported funcs with which
+// clients poll the outbox, using the specified `outbox` statements (as
+// produced by some SQL dialect's `outboxPoller` function). The work is done
+// by prerendered funcs, to which the generated funcs pass the SQL.
+function outboxDeclarations(outbox) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func ClaimOutboxEvents(ctx context.Context, db Database, limit int, lease time.Duration) (events []OutboxEvent, err error) {
+    //     return claimOutboxEvents(ctx, db, limit, lease, $claim, $read)
+    // }
+    //
+    // // ... documentation ...
+    // func AcknowledgeOutboxEvent(ctx context.Context, db Database, event OutboxEvent) (err error) {
+    //     return acknowledgeOutboxEvent(ctx, db, event, $acknowledge)
+    // }
+
+    const claim = {function: {
+        documentation:
+`ClaimOutboxEvents claims up to the specified limit of the earliest events in
+the outbox of the specified db that are not already claimed, subject to the
+specified cancellation context ctx, and returns them in order. Events are
+inserted into the outbox by the operations that change messages, in the same
+transaction as the change. A claim expires once the specified lease has
+elapsed, after which the event can be claimed again, e.g. by another
+poller. Acknowledge each event once it has been dealt with (see
+AcknowledgeOutboxEvent); an event that is not acknowledged before its claim
+expires might be delivered more than once. Events are delivered in order
+only if there is a single poller, since other pollers can claim later events
+while an earlier event is claimed. Return the events claimed and a
+nil error on success, or return a non-nil error if an error occurs.`,
+        name: 'ClaimOutboxEvents',
+        parameters: [
+            {name: 'ctx', type: 'context.Context'},
+            {name: 'db', type: 'Database'},
+            {name: 'limit', type: 'int'},
+            {name: 'lease', type: 'time.Duration'}
+        ],
+        results: [
+            {name: 'events', type: '[]OutboxEvent'},
+            {name: 'err', type: 'error'}
+        ],
+        body: {
+            variables: [],
+            statements: [{return: [{call: {
+                function: 'claimOutboxEvents',
+                arguments: [
+                    {symbol: 'ctx'},
+                    {symbol: 'db'},
+                    {symbol: 'limit'},
+                    {symbol: 'lease'},
+                    outbox.claim,
+                    outbox.read
+                ]
+            }}]}]
+        }
+    }};
+
+    const acknowledge = {function: {
+        documentation:
+`AcknowledgeOutboxEvent deletes the specified event, claimed by
+ClaimOutboxEvents, from the outbox of the specified db, subject to the
+specified cancellation context ctx. It is not considered an error if the
+event is no longer claimed by the caller, e.g. because the claim expired and
+the event was claimed again; i.e. the event is then left for the new
+claimant. Return nil on success, or return a non-nil error if an error
+occurs.`,
+        name: 'AcknowledgeOutboxEvent',
+        parameters: [
+            {name: 'ctx', type: 'context.Context'},
+            {name: 'db', type: 'Database'},
+            {name: 'event', type: 'OutboxEvent'}
+        ],
+        results: [{name: 'err', type: 'error'}],
+        body: {
+            variables: [],
+            statements: [{return: [{call: {
+                function: 'acknowledgeOutboxEvent',
+                arguments: [
+                    {symbol: 'ctx'},
+                    {symbol: 'db'},
+                    {symbol: 'event'},
+                    outbox.acknowledge
+                ]
+            }}]}]
+        }
+    }};
+
+    return [claim, acknowledge];
+}
+
+// Return a Go AST node representing a func that reads the instances of a
+// message of the specified `typeName` whose field having the specified
+// `fieldName` has a particular value, using the specified CRUD
+// `instructions`. Use the specified `types` object of okra types by name to
+// inspect the message type and any enum types that it might depend upon. Use
+// the specified `typePackageAlias` function to look up which package aliases
+// (e.g. "pb", "p2") a given message/enum type belongs to.
+function funcLookup({typeName, fieldName, instructions, types, typePackageAlias}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func ReadFooBarsByColor(ctx context.Context, db Database, value pb.Color) (messages []*pb.FooBar, err error) {
+    //     ... vars ...
+    //
+    //     transaction, err = beginTransaction(ctx, db)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     ... instructions up to and including "read-rows" ...
+    //
+    //     if len(messages) == 0 {
+    //         err = transaction.Commit()
+    //         return
+    //     }
+    //
+    //     ... set up `byID` ...
+    //
+    //     ... the remaining instructions ...
+    //
+    //     err = transaction.Commit()
+    //     return
+    // }
+
+    const goTypeName = messageOrEnum2go(typeName);
+    const funcName = `Read${pluralize(goTypeName)}By${field2go(fieldName)}`;
+    const messageType = `${typePackageAlias(typeName)}.${goTypeName}`;
+
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+
+    const idFieldName = types[typeName].idFieldName;
+    const idGoType = idMapKeyType({funcName, typeName, types, typePackageAlias});
+    const valueType = typeByField[fieldName];
+
+    const documentation =
+`${funcName} reads from the specified db the messages whose ${fieldName}
+is the specified value, in order of their IDs, subject to the specified
+cancellation context ctx. On success, return the messages and a nil error.
+If there are no such messages, then the returned slice is empty. Note that a
+zero value is stored as null, so looking up a zero value finds no messages.
+On error, the error returned will not be nil.`;
+
+    const parameters = [
+        {name: 'ctx', type: 'context.Context'},
+        {name: 'db', type: 'Database'},
+        {name: 'value', type: type2go({okraType: valueType, typePackageAlias})}
+    ];
+    const results = [
+        {name: 'messages', type: `[]*${messageType}`},
+        {name: 'err', type: 'error'}
+    ];
+    const variables = [];
+    const statements = [];
+    const func = {
+        documentation,
+        name: funcName,
+        parameters,
+        results,
+        body: {
+            variables,
+            statements
+        }
+    };
+
+    // Define the arguments needed by the instruction handlers.
+
+    // variable({name, goType}) adds the variable with the specified name and
+    // having the specified type to the func's variable declarations section if
+    // it hasn't been added already, and returns the name of the variable.
+    const variable = variableAdder(variables);
+
+    // In a lookup func, all fields are included, so this always returns
+    // `true`.
+    function included(fieldName /*ignored*/) {
+        return true;
+    }
+
+    // Each query refers to the `value` parameter.
+    function lookup(fieldName /* always the looked up field */) {
+        return inputExpression({
+            okraType: valueType,
+            expression: {symbol: 'value'}
+        });
+    }
+
+    const messages = {messageType, idFieldName};
+
+    // `transaction` is a variable assumed to be in scope, so add that first.
+    variable({name: 'transaction', goType: 'transactor'});
+
+    statements.push(...beginTransaction);
+
+    // The instructions are divided into two parts: those that read the
+    // messages (ending with "read-rows"), and those that depend upon there
+    // being messages (e.g. reading the array fields of the messages).
+    const split = instructions.findIndex(
+        ({instruction}) => instruction === 'read-rows') + 1;
+    const [head, tail] = [instructions.slice(0, split), instructions.slice(split)];
+
+    const instructionArguments = {
+        typeByField,
+        types,
+        variable,
+        included,
+        typePackageAlias,
+        lookup,
+        messages
+    };
+
+    statements.push(
+        ...performInstructions({instructions: head, ...instructionArguments}));
+
+    // If there are instructions after "read-rows," then they read array
+    // fields and message fields, and will need to look up messages by ID.
+    //
+    //     if len(messages) == 0 {
+    //         err = transaction.Commit()
+    //         return
+    //     }
+    //
+    //     byID = make(map[$idGoType]*pb.FooBar, len(messages))
+    //     for _, message := range messages {
+    //         byID[message.Id] = message
+    //     }
+    if (tail.length !== 0) {
+        statements.push(
+            {if: {
+                condition: {equal: {
+                    left: {call: {function: 'len', arguments: [{symbol: 'messages'}]}},
+                    right: 0
+                }},
+                body: [...commitTransactionAndReturn]
+            }},
+
+            {spacer: 1},
+
+            {assign: {
+                left: ['byID'],
+                right: [{call: {
+                    function: 'make',
+                    arguments: [
+                        {symbol: `map[${idGoType}]*${messageType}`},
+                        {call: {function: 'len', arguments: [{symbol: 'messages'}]}}
+                    ]
+                }}]
+            }},
+            mapMessagesByID(idFieldName),
+
+            {spacer: 1},
+
+            ...performInstructions({instructions: tail, ...instructionArguments}));
+    }
+
+    statements.push(...commitTransactionAndReturn);
+
+    return {function: func};
+}
+
+// Return a Go AST expression of a `condition` (see `prerendered.js`) whose
+// SQL is the specified `sql` and that has no parameters, e.g.
+//
+//     condition{sql: "deleted_at is null"}
+function conditionLiteral(sql) {
+    return {raw: `condition{sql: ${JSON.stringify(sql)}}`};
+}
+
+// Return an array of Go AST declarations for a query builder of instances of
+// a message of the specified `typeName`, e.g. `BoyScoutQuery()`, as described
+// by the specified `query` (see `crud.tisch.js`). Use the specified `types`
+// object of okra types by name to inspect the message type and any enum types
+// that it might depend upon. Use the specified `typePackageAlias` function to
+// look up which package aliases (e.g. "pb", "p2") a given message/enum type
+// belongs to.
+function queryDeclarations({typeName, query, types, typePackageAlias}) {
+    // Here's what we're going for:
+    //
+    // type FooBarQueryBuilder struct{ query queryBuilder }
+    // func FooBarQuery() *FooBarQueryBuilder
+    // func (builder *FooBarQueryBuilder) Where(where FooBarCondition) *FooBarQueryBuilder
+    // func (builder *FooBarQueryBuilder) And(where FooBarCondition) *FooBarQueryBuilder
+    // func (builder *FooBarQueryBuilder) OrderBy(orderings ...FooBarOrdering) *FooBarQueryBuilder
+    // func (builder *FooBarQueryBuilder) Limit(limit int) *FooBarQueryBuilder
+    // func (builder *FooBarQueryBuilder) Read(ctx context.Context, db Database) (messages []*pb.FooBar, err error)
+    //
+    // type FooBarCondition struct{ condition }
+    // func (left FooBarCondition) Or(right FooBarCondition) FooBarCondition
+    //
+    // type FooBarOrdering struct{ sql string }
+    //
+    // and then for each field that a query can compare, e.g. "color":
+    //
+    // type FooBarColorColumn struct{}
+    // var FooBarColor FooBarColorColumn
+    // func (FooBarColorColumn) Eq(value pb.Color) FooBarCondition
+    // ... Ne, Lt, Le, Gt, Ge, Before, and After, as applicable ...
+    // func (FooBarColorColumn) IsNull() FooBarCondition
+    // func (FooBarColorColumn) IsNotNull() FooBarCondition
+    // func (FooBarColorColumn) Asc() FooBarOrdering
+    // func (FooBarColorColumn) Desc() FooBarOrdering
+
+    const goTypeName = messageOrEnum2go(typeName);
+    const messageType = `${typePackageAlias(typeName)}.${goTypeName}`;
+    const builderType = `${goTypeName}QueryBuilder`;
+    const conditionType = `${goTypeName}Condition`;
+    const orderingType = `${goTypeName}Ordering`;
+    const readManyName = `Read${pluralize(goTypeName)}`;
+
+    // {<fieldName>: <okra type>}
+    const typeByField = types[typeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+
+    // The names of the column variables are the message name followed by the
+    // field name, so they might collide with the other names declared here.
+    const fields = Object.entries(query.fields).map(([fieldName, sql]) =>
+        ({fieldName, sql, columnName: `${goTypeName}${field2go(fieldName)}`}));
+    const reserved = [
+        `${goTypeName}Query`, builderType, conditionType, orderingType];
+    fields.forEach(({fieldName, columnName}) => {
+        if (reserved.includes(columnName) ||
+            reserved.includes(`${columnName}Column`)) {
+            throw Error(`The query column for the field ${typeName}.` +
+                `${fieldName} would be named ${columnName}, which conflicts ` +
+                `with the query builder of ${typeName}.`);
+        }
+    });
+
+    // Return a Go AST declaration of a method of the specified `receiver`
+    // type. The method has no local variables, except for those specified.
+    function method({
+        documentation,
+        receiver,
+        name,
+        parameters = [],
+        results,
+        variables = [],
+        statements
+    }) {
+        return {function: {
+            documentation,
+            receiver,
+            name,
+            parameters,
+            results,
+            body: {variables, statements}
+        }};
+    }
+
+    // Return the statements of a method of the query builder that appends the
+    // specified `value` to the specified `member` of the builder's state,
+    // and then returns the builder.
+    //
+    //     builder.query.$member = append(builder.query.$member, $value)
+    //     return builder
+    function appendAndReturn({member, value}) {
+        const target = {dot: ['builder', 'query', member]};
+        return [
+            {assign: {
+                left: [target],
+                right: [{call: {function: 'append', arguments: [target, value]}}]
+            }},
+            {return: [{symbol: 'builder'}]}
+        ];
+    }
+
+    const builder = {name: 'builder', type: `*${builderType}`};
+    const returnsBuilder = [{type: `*${builderType}`}];
+
+    const declarations = [
+        {type: {
+            documentation:
+`${builderType} builds a query that reads ${goTypeName} messages. Use
+${goTypeName}Query to create one.`,
+            name: builderType,
+            definition: 'struct{ query queryBuilder }'
+        }},
+
+        {function: {
+            documentation:
+`${goTypeName}Query returns a new query builder that, until conditions are
+added to it, reads all of the ${goTypeName} messages, in order of their IDs.
+For example:
+
+    messages, err := ${goTypeName}Query().Where(condition).Limit(10).Read(ctx, db)` +
+                (query.where === undefined ? '' : `
+
+Messages deleted by Delete${goTypeName} are never read.`),
+            name: `${goTypeName}Query`,
+            parameters: [],
+            results: returnsBuilder,
+            body: {
+                variables: [],
+                // A soft-deleted message type has a condition of its own
+                // (see `crud.tisch.js`), which the builder begins with.
+                //
+                //     return &FooBarQueryBuilder{queryBuilder{conditions: []condition{...}}}
+                statements: [{return: [{address: {sequenceLiteral: {
+                    type: builderType,
+                    elements: query.where === undefined ? [] : [
+                        {raw: 'queryBuilder{conditions: []condition{' +
+                            `${conditionLiteral(query.where).raw}}}`}
+                    ]
+                }}}]}]
+            }
+        }},
+
+        method({
+            documentation:
+`Where adds the specified condition to the query, and returns the builder.
+A message is read only if all of the conditions added are true of it.`,
+            receiver: builder,
+            name: 'Where',
+            parameters: [{name: 'where', type: conditionType}],
+            results: returnsBuilder,
+            statements: appendAndReturn({
+                member: 'conditions',
+                value: {dot: ['where', 'condition']}
+            })
+        }),
+
+        method({
+            documentation: `And is a synonym for Where.`,
+            receiver: builder,
+            name: 'And',
+            parameters: [{name: 'where', type: conditionType}],
+            results: returnsBuilder,
+            statements: appendAndReturn({
+                member: 'conditions',
+                value: {dot: ['where', 'condition']}
+            })
+        }),
+
+        method({
+            documentation:
+`OrderBy orders the messages read by the specified orderings, and returns
+the builder. Messages that are equal according to all of the orderings are
+ordered by their IDs.`,
+            receiver: builder,
+            name: 'OrderBy',
+            parameters: [{name: 'orderings', type: `...${orderingType}`}],
+            results: returnsBuilder,
+            statements: [
+                // for _, ordering := range orderings {
+                //     builder.query.orderings = append(builder.query.orderings, ordering.sql)
+                // }
+                {rangeFor: {
+                    variables: ['_', 'ordering'],
+                    sequence: {symbol: 'orderings'},
+                    body: appendAndReturn({
+                        member: 'orderings',
+                        value: {dot: ['ordering', 'sql']}
+                    }).slice(0, 1)
+                }},
+                {return: [{symbol: 'builder'}]}
+            ]
+        }),
+
+        method({
+            documentation:
+`Limit reads at most the specified limit of messages, and returns the
+builder. A limit that is not positive means no limit.`,
+            receiver: builder,
+            name: 'Limit',
+            parameters: [{name: 'limit', type: 'int'}],
+            results: returnsBuilder,
+            statements: [
+                {assign: {
+                    left: [{dot: ['builder', 'query', 'limit']}],
+                    right: [{symbol: 'limit'}]
+                }},
+                {return: [{symbol: 'builder'}]}
+            ]
+        }),
+
+        queryRead({
+            typeName,
+            query,
+            types,
+            typePackageAlias,
+            builderType,
+            messageType,
+            readManyName
+        }),
+
+        {type: {
+            documentation:
+`${conditionType} is a condition on ${goTypeName} messages, for use with
+${builderType}. Conditions are returned by the methods of column
+variables, e.g. ${fields[0].columnName}.Eq.`,
+            name: conditionType,
+            definition: 'struct{ condition }'
+        }},
+
+        method({
+            documentation:
+`Or returns a condition that is true when either the left or the specified
+right condition is true.`,
+            receiver: {name: 'left', type: conditionType},
+            name: 'Or',
+            parameters: [{name: 'right', type: conditionType}],
+            results: [{type: conditionType}],
+            statements: [{return: [{sequenceLiteral: {
+                type: conditionType,
+                elements: [{call: {
+                    function: 'orConditions',
+                    arguments: [
+                        {dot: ['left', 'condition']},
+                        {dot: ['right', 'condition']}
+                    ]
+                }}]
+            }}]}]
+        }),
+
+        {type: {
+            documentation:
+`${orderingType} is an order in which ${builderType} reads messages.
+Orderings are returned by the methods of column variables, e.g.
+${fields[0].columnName}.Desc.`,
+            name: orderingType,
+            definition: 'struct{ sql string }'
+        }}
+    ];
+
+    // Each field that a query can compare has a "column" type, and a variable
+    // of that type whose methods return conditions and orderings.
+    fields.forEach(({fieldName, sql: {column, parameter}, columnName}) => {
+        const okraType = typeByField[fieldName];
+        const {goType, expression, operators, zeroIsNull} =
+            queryValue({okraType, typePackageAlias});
+        const receiver = {type: `${columnName}Column`};
+
+        // Return a Go AST expression of a condition whose SQL is produced by
+        // calling the specified `function` with the specified `arguments`.
+        function condition(func, ...args) {
+            return {sequenceLiteral: {
+                type: conditionType,
+                elements: [{call: {function: func, arguments: [column, ...args]}}]
+            }};
+        }
+
+        declarations.push(
+            {type: {
+                documentation:
+`${columnName}Column is the type of ${columnName}, which refers to the
+${fieldName} field of ${goTypeName} messages in queries.` + (zeroIsNull ? `
+The zero value of the field is stored as null, so Eq of the zero value
+checks that the field is null.` : ''),
+                name: `${columnName}Column`,
+                definition: 'struct{}'
+            }},
+
+            {variable: {
+                documentation:
+`${columnName} refers to the ${fieldName} field of ${goTypeName} messages
+in queries (see ${goTypeName}Query).`,
+                name: columnName,
+                type: `${columnName}Column`
+            }},
+
+            ...operators.map(([name, operator, description]) => method({
+                documentation:
+`${name} returns a condition that is true when the ${fieldName} field
+${description} the specified value.`,
+                receiver,
+                name,
+                parameters: [{name: 'value', type: goType}],
+                results: [{type: conditionType}],
+                statements: [{return: [
+                    condition('compareColumn', operator, parameter, expression)
+                ]}]
+            })),
+
+            method({
+                documentation:
+`IsNull returns a condition that is true when the ${fieldName} field is null.`,
+                receiver,
+                name: 'IsNull',
+                results: [{type: conditionType}],
+                statements: [{return: [condition('columnIsNull')]}]
+            }),
+
+            method({
+                documentation:
+`IsNotNull returns a condition that is true when the ${fieldName} field is not
+null.`,
+                receiver,
+                name: 'IsNotNull',
+                results: [{type: conditionType}],
+                statements: [{return: [condition('columnIsNotNull')]}]
+            }),
+
+            ...[['Asc', 'asc', 'ascending'], ['Desc', 'desc', 'descending']]
+                .map(([name, direction, description]) => method({
+                    documentation:
+`${name} returns an ordering by the ${fieldName} field, ${description}.`,
+                    receiver,
+                    name,
+                    results: [{type: orderingType}],
+                    statements: [{return: [{sequenceLiteral: {
+                        type: orderingType,
+                        elements: [`${column} ${direction}`]
+                    }}]}]
+                })));
+    });
+
+    return declarations;
+}
+
+// Return a Go AST node representing the `Read` method of the query builder
+// of the specified `typeName` (see `queryDeclarations`), where the query
+// builder's Go type is the specified `builderType`, the message's Go type is
+// the specified `messageType`, and the func that reads many messages by ID is
+// named the specified `readManyName`. Use the specified `query` description,
+// `types`, and `typePackageAlias` as in `queryDeclarations`.
+function queryRead({
+    typeName,
+    query,
+    types,
+    typePackageAlias,
+    builderType,
+    messageType,
+    readManyName
+}) {
+    // Here's what we're going for:
+    //
+    // // ... documentation ...
+    // func (builder *FooBarQueryBuilder) Read(ctx context.Context, db Database) (messages []*pb.FooBar, err error) {
+    //     ... vars ...
+    //
+    //     sqlText, parameters, err = buildQuery(builder.query, "select id from foobar", "id")
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     transaction, err = beginTransaction(ctx, db)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     rows, err = transaction.QueryContext(ctx, sqlText, parameters...)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     for rows.Next() {
+    //         err = rows.Scan(intoString(&id))
+    //         if err != nil {
+    //             return
+    //         }
+    //         ids = append(ids, id)
+    //     }
+    //
+    //     byID, err = ReadFooBars(ctx, transaction, ids)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    //     for _, key := range ids {
+    //         if byID[key] != nil {
+    //             messages = append(messages, byID[key])
+    //         }
+    //     }
+    //
+    //     err = transaction.Commit()
+    //     return
+    // }
+
+    const funcName = `${builderType}.Read`;
+    const {idFieldName, fields} = types[typeName];
+    const idType = fields.find(({name}) => name === idFieldName).type;
+    const idGoType = idMapKeyType({funcName, typeName, types, typePackageAlias});
+
+    const documentation =
+`Read reads from the specified db the messages selected by the query,
+subject to the specified cancellation context ctx. The messages are read
+within one transaction. On success, return the messages and a nil error. On
+error, the error returned will not be nil.`;
+
+    const variables = [];
+    const variable = variableAdder(variables);
+    variable({name: 'transaction', goType: 'transactor'});
+    variable({name: 'sqlText', goType: 'string'});
+    variable({name: 'parameters', goType: '[]interface{}'});
+    variable({name: 'rows', goType: '*sql.Rows'});
+    variable({name: 'id', goType: idGoType});
+    variable({name: 'ids', goType: `[]${idGoType}`});
+    variable({name: 'byID', goType: `map[${idGoType}]*${messageType}`});
+
+    const byKey = {index: {object: 'byID', index: {symbol: 'key'}}};
+
+    const statements = [
+        // sqlText, parameters, err = buildQuery(builder.query, $sql, $key)
+        {assign: {
+            left: ['sqlText', 'parameters', 'err'],
+            right: [{call: {
+                function: 'buildQuery',
+                arguments: [{dot: ['builder', 'query']}, query.sql, query.key]
+            }}]
+        }},
+        ifErrReturn,
+
+        {spacer: 1},
+
+        ...beginTransaction,
+
+        // rows, err = transaction.QueryContext(ctx, sqlText, parameters...)
+        {assign: {
+            left: ['rows', 'err'],
+            right: [{call: {
+                function: {dot: ['transaction', 'QueryContext']},
+                arguments: [{symbol: 'ctx'}, {symbol: 'sqlText'}],
+                rest: {symbol: 'parameters'}
+            }}]
+        }},
+        ifErrReturn,
+
+        {spacer: 1},
+
+        // for rows.Next() {
+        //     err = rows.Scan(intoString(&id))
+        //     if err != nil {
+        //         return
+        //     }
+        //     ids = append(ids, id)
+        // }
+        {conditionFor: {
+            condition: {call: {function: {dot: ['rows', 'Next']}, arguments: []}},
+            body: [
+                {assign: {
+                    left: ['err'],
+                    right: [{call: {
+                        function: {dot: ['rows', 'Scan']},
+                        arguments: [fieldDestinationExpression({
+                            okraType: idType,
+                            target: {symbol: 'id'},
+                            typePackageAlias
+                        })]
+                    }}]
+                }},
+                ifErrReturn,
+                {assign: {
+                    left: ['ids'],
+                    right: [{call: {
+                        function: 'append',
+                        arguments: [{symbol: 'ids'}, {symbol: 'id'}]
+                    }}]
+                }}
+            ]
+        }},
+
+        {spacer: 1},
+
+        // byID, err = ReadFooBars(ctx, transaction, ids)
+        {assign: {
+            left: ['byID', 'err'],
+            right: [{call: {
+                function: readManyName,
+                arguments: [
+                    {symbol: 'ctx'},
+                    {symbol: 'transaction'},
+                    {symbol: 'ids'}
+                ]
+            }}]
+        }},
+        ifErrReturn,
+
+        {spacer: 1},
+
+        // for _, key := range ids {
+        //     if byID[key] != nil {
+        //         messages = append(messages, byID[key])
+        //     }
+        // }
+        {rangeFor: {
+            variables: ['_', 'key'],
+            sequence: {symbol: 'ids'},
+            body: [{if: {
+                condition: {notEqual: {left: byKey, right: null}},
+                body: [{assign: {
+                    left: ['messages'],
+                    right: [{call: {
+                        function: 'append',
+                        arguments: [{symbol: 'messages'}, byKey]
+                    }}]
+                }}]
+            }}]
+        }},
+
+        {spacer: 1},
+
+        ...commitTransactionAndReturn
+    ];
+
+    return {function: {
+        documentation,
+        receiver: {name: 'builder', type: `*${builderType}`},
+        name: 'Read',
+        parameters: [
+            {name: 'ctx', type: 'context.Context'},
+            {name: 'db', type: 'Database'}
+        ],
+        results: [
+            {name: 'messages', type: `[]*${messageType}`},
+            {name: 'err', type: 'error'}
+        ],
+        body: {variables, statements}
+    }};
+}
+
+// CRUD Instructions
+// =================
+// This section contains one function for each of the CRUD instructions that
+// are combined to create the bodies of CRUD operations. An instruction is
+// something like "execute this SQL query." Each function in this section
+// returns an array of statements that can be included as part of the AST
+// returned by one of the functions in the "CRUD Operations" section.
+//
+// All of these functions assume that the following Go variables are in scope:
+// - `message` is the protobuf message struct being read from or written to.
+//   It might be a pointer to a message or the message itself, depending on
+//   the operation.
+// - `transaction` is the `transactor` object for the current database
+//   transaction. It is either a `*sql.Tx` begun by the CRUD operation, or a
+//   wrapper around a transaction supplied by the caller.
+// - `ctx` is the `context.Context` object describing the current cancellation
+//   context.
+// - `err` is the `error` variable to assign to before returning due to an
+//   error.
+//
+// Additionally, the functions might depend on any of the following variables,
+// but will say so by invoking their `variable` parameter first (i.e. these are
+// not assumed to be in scope, but will be if indicated by a call to
+// `variable`):
+// - `parameters` is a `[]interface{}` used when specifying a variable number
+//   of parameters to a SQL command (such as the "exec-with-tuples" and
+//   "query-with-tuples" instructions).
+// - `rows` is a `*sql.Rows` used when iterating through SQL query results.
+// - `ok` is `bool` used to capture the success or failure of `rows.Next()`.
+// - `included` is a `map[string]bool` for looking up whether a particular
+//   message field is included in the current operation.
+//
+// If an instruction encounters an error, it will assign to `err` and then
+// return using a `return` statement without any arguments. Thus the function
+// in which the instruction is expanded must use named return values.
+
+// Return an array of statements that perform the specified CRUD "query"
+// `instruction` in the context implied by the other specified arguments.
+function performQuery({
+    // the "query" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    // Note that that variables that are _assumed_ to be in scope, such as
+    // `ctx`, `message`, `transaction`, and `err`, don't need to use this
+    // function. It's for variables like `rows` and `ok`.
+    variable,
+
+    // function that returns an expression for whether a field is included in the CRUD operation
+    included,
+
+    // function that returns an expression for a bound of the page in a "list" operation
+    page,
+
+    // function that returns an expression for the value looked up by a
+    // lookup operation
+    lookup,
+
+    // function that returns an expression for a value in a history
+    // instruction
+    history
+}) {
+    // Reminder of the shape of a "query" instruction:
+    //
+    //    {
+    //        'instruction': 'query',
+    //        'condition?': {'included': String},
+    //        'sql': String,
+    //        'parameters': [inputParameter, ...etc]
+    //    }
+    
+    // Here's what we're going for:
+    //
+    //     rows, err = transaction.QueryContext(ctx, $query, $parameters ...)
+    //     if err != nil {
+    //         return
+    //     }
+    //     ok = rows.Next()
+    //
+    // or, if there's a "condition," wrap the above in an `if` statement.
+
+    const parameters = inputParameters2expressions({
+        parameters: instruction.parameters,
+        typeByField,
+        included,
+        page,
+        lookup,
+        history
+    });
+
+    // The following code references these variables.
+    variable({name: 'rows', goType: '*sql.Rows'})
+    variable({name: 'ok', goType: 'bool'})
+
+    // If there's a condition, we'll wrap all of this in an `if`.
+    const statements = [
+        // rows, err = transaction.QueryContext(ctx, $query, $parameters)
+        {assign: {
+            left: ['rows', 'err'],
+            right: [{
+                call: {
+                    function: {dot: ['transaction', 'QueryContext']},
+                    arguments: [
+                        {symbol: 'ctx'},
+                        instruction.sql,
+                        ...parameters
+                    ]
+                }
+            }]
+        }},
+
+        // if err != nil {
+        //     return
+        // }
+        ifErrReturn,
+
+        // ok = rows.Next()
+        {assign: {
+            left: ['ok'],
+            right: [{
+                call: {
+                    function: {dot: ['rows', 'Next']},
+                    arguments: []
+                }
+            }]
+        }}
+    ];
+
+    return ifIncluded({
+        condition: instruction.condition,
+        included,
+        statements
+    });
+}
+
+// Return an array of statements that perform the specified CRUD
+// "query-with-tuples" `instruction` in the context implied by the other
+// specified arguments.
+function performQueryWithTuples({
+    // the "query-with-tuples" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    variable,
+
+    // function that returns an expression for whether a field is included in the CRUD operation
+    included,
+
+    // function that returns an expression for an ID in a "read-many" operation
+    batch
+}) {
+    // Reminder of the shape of a "query-with-tuples" instruction:
+    //
+    //    {
+    //        'instruction': 'query-with-tuples',
+    //        'tuple': String,
+    //        'sql': String,
+    //        'suffix': String,
+    //        'parameters': [inputParameter, ...etc]
+    //    }
+
+    // Here's what we're going for:
+    //
+    //     parameters = nil
+    //     for _, id := range ids {
+    //         parameters = append(parameters, $parameters...)
+    //     }
+    //
+    //     rows, err = transaction.QueryContext(
+    //         ctx,
+    //         withTuples($sql, $tuple, len(ids)) + $suffix,
+    //         parameters...)
+    //     if err != nil {
+    //         return
+    //     }
+    //     ok = rows.Next()
+    //
+    // where `ids` is the slice of IDs parameter of the enclosing func, and
+    // `id` is what `batch` refers to. The enclosing func is responsible for
+    // not performing this instruction when `ids` is empty.
+
+    const parameters = inputParameters2expressions({
+        parameters: instruction.parameters,
+        typeByField,
+        included,
+        batch
+    });
+
+    // The following code references these variables.
+    variable({name: 'parameters', goType: '[]interface{}'});
+    variable({name: 'rows', goType: '*sql.Rows'});
+    variable({name: 'ok', goType: 'bool'});
+
+    return [
+        // parameters = nil
+        {assign: {
+            left: ['parameters'],
+            right: [null]
+        }},
+
+        // for _, id := range ids {
+        //     parameters = append(parameters, $parameters...)
+        // }
+        {rangeFor: {
+            variables: ['_', 'id'],
+            sequence: {symbol: 'ids'},
+            body: [{assign: {
+                left: ['parameters'],
+                right: [{call: {
+                    function: 'append',
+                    arguments: [{symbol: 'parameters'}, ...parameters]
+                }}]
+            }}]
+        }},
+
+        // rows, err = transaction.QueryContext(
+        //     ctx,
+        //     withTuples($sql, $tuple, len(ids)) + $suffix,
+        //     parameters...)
+        {assign: {
+            left: ['rows', 'err'],
+            right: [{
+                call: {
+                    function: {dot: ['transaction', 'QueryContext']},
+                    arguments: [
+                        {symbol: 'ctx'},
+                        {plus: {
+                            left: {call: {
+                                function: 'withTuples',
+                                arguments: [
+                                    instruction.sql,
+                                    instruction.tuple,
+                                    {call: {
+                                        function: 'len',
+                                        arguments: [{symbol: 'ids'}]
+                                    }}
+                                ]
+                            }},
+                            right: instruction.suffix
+                        }}
+                    ],
+                    rest: {symbol: 'parameters'}
+                }
+            }]
+        }},
+
+        // if err != nil {
+        //     return
+        // }
+        ifErrReturn,
 
         // ok = rows.Next()
         {assign: {
-            left: ['ok'],
+            left: ['ok'],
+            right: [{
+                call: {
+                    function: {dot: ['rows', 'Next']},
+                    arguments: []
+                }
+            }]
+        }}
+    ];
+}
+
+// Return an array of statements that perform the specified CRUD "read-result"
+// `instruction` in the context implied by the other specified arguments.
+function performReadResult({
+    // the "read-result" CRUD instruction
+    instruction,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    variable
+}) {
+    // Reminder of the shape of a "read-result" instruction:
+    //
+    //    {
+    //        'instruction': 'read-result',
+    //        'destination': or('exists', 'count')
+    //    }
+
+    // Here's what we're going for:
+    //
+    //     if ok {
+    //         err = rows.Scan(&$destination)
+    //         if err != nil {
+    //             return
+    //         }
+    //     }
+    //
+    // where `$destination` is a named result of the enclosing func, e.g.
+    // `exists`.
+
+    // The following code references these variables.
+    variable({name: 'rows', goType: '*sql.Rows'});
+    variable({name: 'ok', goType: 'bool'});
+
+    return [{if: {
+        condition: {symbol: 'ok'},
+        body: [
+            // err = rows.Scan(&$destination)
+            {assign: {
+                left: ['err'],
+                right: [{
+                    call: {
+                        function: {dot: ['rows', 'Scan']},
+                        arguments: [{address: {symbol: instruction.destination}}]
+                    }
+                }]
+            }},
+
+            // if err != nil {
+            //     return
+            // }
+            ifErrReturn
+        ]
+    }}];
+}
+
+// Return an array of statements that perform the specified CRUD "read-row"
+// `instruction` in the context implied by the other specified arguments.
+function performReadRow({
+    // the "read-row" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    // Note that that variables that are _assumed_ to be in scope, such as
+    // `ctx` and `transaction`, don't need to use this function. It's for
+    // variables like `rows` and `ok`.
+    variable,
+
+    // function that returns an expression for whether a field is included in the CRUD operation
+    // Output parameters of excluded fields go to an effective /dev/null.
+    included,
+
+    // function that maps message/enum type name to a Go package alias
+    typePackageAlias,
+
+    // object `{messageType, idFieldName}` describing the Go type of the
+    // message being read (e.g. "pb.FooBar") and the name of the ID field
+    messages
+}) {
+    // Reminder of the shape of a "read-row" instruction:
+    //
+    //    {
+    //        'instruction': 'read-row',
+    //        'destinations': [outputParameter, ...etc],
+    //        'onNoRow?': 'uniqueViolation'
+    //    }
+    
+    // Here's what we're going for:
+    //
+    //     if !ok {
+    //         err = noRow()
+    //         return
+    //     }
+    //     
+    //     $oneofMembers
+    //     err = rows.Scan($destinations)
+    //     if err != nil {
+    //         return
+    //     }
+    //     rows.Next()
+    //
+    // where each destination of a field that might be excluded is wrapped in
+    // `scanIf($included, $destination)`, and where `$oneofMembers` declares
+    // a variable for each member of a oneof (see `scanDestinations`). If
+    // `onNoRow` is "uniqueViolation", then the error is `uniqueViolation()`
+    // instead of `noRow()`.
+    const {declarations, expressions} = scanDestinations({
+        destinations: instruction.destinations,
+        typeByField,
+        typePackageAlias,
+        messages
+    });
+
+    const destinations = expressions.map((expression, i) => {
+        const destination = instruction.destinations[i];
+        if (destination === 'ignore') {
+            return expression;
+        }
+
+        // If inclusion is hard-coded to true, then omit the `scanIf`. The
+        // members of a oneof are included together with the oneof.
+        const condition = included(destination.oneof || destination.field);
+        if (condition === true) {
+            return expression;
+        }
+
+        return {call: {
+            function: 'scanIf',
+            arguments: [condition, expression]
+        }};
+    });
+
+    // The following code references these variables.
+    variable({name: 'rows', goType: '*sql.Rows'})
+    variable({name: 'ok', goType: 'bool'})
+
+    return [
+        // if !ok {
+        //     err = noRow()
+        //     return
+        // }
+        {if: {
+            condition: {not: {symbol: 'ok'}},
+            body: [
+                {assign: {
+                    left: ['err'],
+                    right: [{
+                        call: {
+                            function: instruction.onNoRow === 'uniqueViolation'
+                                ? 'uniqueViolation'
+                                : 'noRow',
+                            arguments: []
+                        }
+                    }]
+                }},
+                {return: []}
+            ]
+        }},
+
+        //
+        {spacer: 1},
+
+        // var oneofFoo = &pb.FooBar_Foo{}
+        // ...
+        ...declarations,
+
+        // err = rows.Scan($destinations)
+        {assign: {
+            left: ['err'],
+            right: [{
+                call: {
+                    function: {dot: ['rows', 'Scan']},
+                    arguments: destinations
+                }
+            }]
+        }},
+
+        // if err != nil {
+        //     return
+        // }
+        ifErrReturn,
+
+        // rows.Next()
+        {call: {
+            function: {dot: ['rows', 'Next']},
+            arguments: []}}
+    ];
+}
+
+// Return an array of statements that perform the specified CRUD "read-array"
+// `instruction` in the context implied by the other specified arguments.
+function performReadArray({
+    // the "read-array" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    // Note that that variables that are _assumed_ to be in scope, such as
+    // `ctx` and `transaction`, don't need to use this function. It's for
+    // variables like `rows` and `ok`.
+    variable,
+
+    // function that returns an expression for whether a field is included in the CRUD operation
+    included,
+
+    // function that maps message/enum type name to a Go package alias
+    typePackageAlias
+}) {
+    // Reminder of the shape of a "read-array" instruction:
+    //
+    //    {
+    //        'instruction': 'read-array',
+    //        'destination': outputParameter
+    //    }
+    
+    // Here's what we're going for:
+    //
+    //     for ; ok; ok = rows.Next() {
+    //         var temp whateverGoType
+    //         err = rows.Scan(&temp) // might use intoDate or intoTimestamp
+    //         if err != nil {
+    //             return
+    //         }
+    //         $destination = append($destination, temp)
+    //     }
+    //
+    // or, if the destination field might be excluded, wrap the above in an
+    // `if` statement.
+    //
+    // If the destination is a map, then it's handled separately.
+    const fieldType = typeByField[instruction.destination.field];
+    if (fieldType.map) {
+        return performReadMap({
+            instruction,
+            typeByField,
+            variable,
+            included,
+            typePackageAlias
+        });
+    }
+
+    // For each row, we scan one array element into a temporary variable
+    // `temp`. The expression that we pass to `rows.Scan` depends on the type
+    // of the destination array. `intoTemp` is that expression.
+    // If the field is an array, then the element type is `.array`. Otherwise,
+    // it's a FieldMask as so the element type is string.
+    const elementType = fieldType.array || {builtin: 'TYPE_STRING'};
+    const intoTemp = fieldDestinationExpression({
+        okraType: elementType,
+        target: {symbol: 'temp'},
+        typePackageAlias
+    });
+
+    // If the destination is an array, then we append to it using the `append`
+    // function. If it's a FieldMask, then we append to it using `appendField`
+    // (which we defined).
+    const appendFunctionName = fieldType.array ? 'append' : 'appendField';
+
+    // the array we're appending to
+    const destinationGoArray = {
+        dot: ['message', field2go(instruction.destination.field)]
+    };
+
+    // The following code references this variable.
+    variable({name: 'rows', goType: '*sql.Rows'});
+
+    const statements = [{
+        // for ; ok; ok = rows.Next() {
+        iterationFor: {
+            condition: {symbol: 'ok'},
+            post: {assign: {
+                left: ['ok'],
+                right: [{
+                    call: {
+                        function: {dot: ['rows', 'Next']},
+                        arguments: []
+                    }
+                }]}},
+            body: [
+                // var temp whateverGoType
+                {variable: {
+                    name: 'temp',
+                    // The destination is an array. We want an instance of
+                    // an _element_ of the array.
+                    type: type2go({okraType: elementType, typePackageAlias})
+                }},
+                
+                // err = rows.Scan($intoTemp)
+                {assign: {
+                    left: ['err'],
+                    right: [{
+                        call: {
+                            function: {dot: ['rows', 'Scan']},
+                            arguments: [intoTemp]
+                        }
+                    }]
+                }},
+
+                // if err != nil {
+                //     return
+                // }
+                ifErrReturn,
+
+                // $destination = append($destination, temp)
+                // or
+                // $destination = appendField($destination, temp)
+                {assign: {
+                    left: [destinationGoArray],
+                    right: [{
+                        call: {
+                            function: appendFunctionName,
+                            arguments: [
+                                destinationGoArray,
+                                {symbol: 'temp'}
+                            ]
+                        }
+                    }]
+                }}
+            ]
+        }
+    }];
+
+    return ifIncluded({
+        condition: {included: instruction.destination.field},
+        included,
+        statements
+    });
+}
+
+// Return an array of statements that perform the specified CRUD "read-array"
+// `instruction`, whose destination is a map-valued field, in the context
+// implied by the other specified arguments.
+function performReadMap({
+    // the "read-array" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    variable,
+
+    // function that returns an expression for whether a field is included in the CRUD operation
+    included,
+
+    // function that maps message/enum type name to a Go package alias
+    typePackageAlias
+}) {
+    // Here's what we're going for:
+    //
+    //     $destination = make(map[whateverKeyType]whateverGoType)
+    //     for ; ok; ok = rows.Next() {
+    //         var mapKey whateverKeyType
+    //         var temp whateverGoType
+    //         err = rows.Scan(&mapKey, &temp)
+    //         if err != nil {
+    //             return
+    //         }
+    //         $destination[mapKey] = temp
+    //     }
+    //
+    // or, if the destination field might be excluded, wrap the above in an
+    // `if` statement.
+    const fieldType = typeByField[instruction.destination.field];
+    const destinationGoMap = {
+        dot: ['message', field2go(instruction.destination.field)]
+    };
+
+    // The following code references this variable.
+    variable({name: 'rows', goType: '*sql.Rows'});
+
+    const statements = [
+        // $destination = make(map[whateverKeyType]whateverGoType)
+        {assign: {
+            left: [destinationGoMap],
+            right: [{call: {
+                function: 'make',
+                arguments: [
+                    {symbol: type2go({okraType: fieldType, typePackageAlias})}
+                ]
+            }}]
+        }},
+
+        // for ; ok; ok = rows.Next() {
+        {iterationFor: {
+            condition: {symbol: 'ok'},
+            post: {assign: {
+                left: ['ok'],
+                right: [{
+                    call: {
+                        function: {dot: ['rows', 'Next']},
+                        arguments: []
+                    }
+                }]}},
+            body: readMapEntryStatements({
+                fieldType,
+                typePackageAlias,
+                destinationGoMap
+            })
+        }}
+    ];
+
+    return ifIncluded({
+        condition: {included: instruction.destination.field},
+        included,
+        statements
+    });
+}
+
+// Return an array of statements that perform the specified CRUD
+// "read-keyed-array" `instruction`, whose destination is a map-valued field,
+// in the context implied by the other specified arguments.
+function performReadKeyedMap({
+    // the "read-keyed-array" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    variable,
+
+    // function that maps message/enum type name to a Go package alias
+    typePackageAlias,
+
+    // object `{messageType, idFieldName}` describing the Go type of the
+    // messages being read (e.g. "pb.FooBar") and the name of the ID field
+    messages
+}) {
+    // Here's what we're going for:
+    //
+    //     for ; ok; ok = rows.Next() {
+    //         var key whateverIDType
+    //         var mapKey whateverKeyType
+    //         var temp whateverGoType
+    //         err = rows.Scan(&key, &mapKey, &temp)
+    //         if err != nil {
+    //             return
+    //         }
+    //         message = byID[key]
+    //         if message != nil {
+    //             if message.$destination == nil {
+    //                 message.$destination = make(map[whateverKeyType]whateverGoType)
+    //             }
+    //             message.$destination[mapKey] = temp
+    //         }
+    //     }
+    //
+    // See the analogous code in `performReadKeyedArray`.
+    const fieldType = typeByField[instruction.destination.field];
+    const idType = typeByField[messages.idFieldName];
+    const intoKey = fieldDestinationExpression({
+        okraType: idType,
+        target: {symbol: 'key'},
+        typePackageAlias
+    });
+    const destinationGoMap = {
+        dot: ['message', field2go(instruction.destination.field)]
+    };
+
+    // The following code references these variables.
+    variable({name: 'rows', goType: '*sql.Rows'});
+    variable({name: 'message', goType: `*${messages.messageType}`});
+    variable({
+        name: 'byID',
+        goType: `map[${type2go({okraType: idType, typePackageAlias})}]*${messages.messageType}`
+    });
+
+    const [declareMapKey, declareTemp, scan, errCheck, assignEntry] =
+        readMapEntryStatements({
+            fieldType,
+            typePackageAlias,
+            destinationGoMap,
+            leadingDestinations: [intoKey]
+        });
+
+    return [{
+        // for ; ok; ok = rows.Next() {
+        iterationFor: {
+            condition: {symbol: 'ok'},
+            post: {assign: {
+                left: ['ok'],
+                right: [{
+                    call: {
+                        function: {dot: ['rows', 'Next']},
+                        arguments: []
+                    }
+                }]}},
+            body: [
+                // var key whateverIDType
+                {variable: {
+                    name: 'key',
+                    type: type2go({okraType: idType, typePackageAlias})
+                }},
+                declareMapKey,
+                declareTemp,
+                scan,
+                errCheck,
+
+                // message = byID[key]
+                {assign: {
+                    left: ['message'],
+                    right: [{index: {object: 'byID', index: {symbol: 'key'}}}]
+                }},
+
+                // As in `performReadKeyedArray`, ignore rows that don't
+                // belong to any of the messages that we read.
+                {if: {
+                    condition: {notEqual: {
+                        left: {symbol: 'message'},
+                        right: null
+                    }},
+                    body: [
+                        // if $destination == nil {
+                        //     $destination = make(...)
+                        // }
+                        {if: {
+                            condition: {equal: {
+                                left: destinationGoMap,
+                                right: null
+                            }},
+                            body: [{assign: {
+                                left: [destinationGoMap],
+                                right: [{call: {
+                                    function: 'make',
+                                    arguments: [{symbol: type2go({
+                                        okraType: fieldType,
+                                        typePackageAlias
+                                    })}]
+                                }}]
+                            }}]
+                        }},
+                        assignEntry
+                    ]
+                }}
+            ]
+        }
+    }];
+}
+
+// Return an array of statements that read one entry of a map having the
+// specified okra `fieldType` from the current row of `rows`, and that assign
+// the entry into the specified `destinationGoMap`. Use the specified
+// `typePackageAlias` to resolve package names for enum and message types.
+// Optionally specify `leadingDestinations` to scan columns that precede the
+// key and value, e.g. the ID of a message. The returned statements are, in
+// order: the declarations of the key and of the value variables, the scan,
+// the error check, and the assignment.
+function readMapEntryStatements({
+    fieldType,
+    typePackageAlias,
+    destinationGoMap,
+    leadingDestinations = []
+}) {
+    const {key: keyType, value: valueType} = fieldType.map;
+
+    return [
+        // var mapKey whateverKeyType
+        {variable: {
+            name: 'mapKey',
+            type: type2go({okraType: keyType, typePackageAlias})
+        }},
+
+        // var temp whateverGoType
+        {variable: {
+            name: 'temp',
+            type: type2go({okraType: valueType, typePackageAlias})
+        }},
+
+        // err = rows.Scan(..., $intoMapKey, $intoTemp)
+        {assign: {
+            left: ['err'],
             right: [{
                 call: {
-                    function: {dot: ['rows', 'Next']},
-                    arguments: []
+                    function: {dot: ['rows', 'Scan']},
+                    arguments: [
+                        ...leadingDestinations,
+                        fieldDestinationExpression({
+                            okraType: keyType,
+                            target: {symbol: 'mapKey'},
+                            typePackageAlias
+                        }),
+                        fieldDestinationExpression({
+                            okraType: valueType,
+                            target: {symbol: 'temp'},
+                            typePackageAlias
+                        })
+                    ]
                 }
             }]
+        }},
+
+        // if err != nil {
+        //     return
+        // }
+        ifErrReturn,
+
+        // $destination[mapKey] = temp
+        {assign: {
+            left: [{index: {object: destinationGoMap, index: {symbol: 'mapKey'}}}],
+            right: [{symbol: 'temp'}]
         }}
-    ]
+    ];
+}
+
+// Return an array of statements that perform the specified CRUD "read-rows"
+// `instruction` in the context implied by the other specified arguments.
+function performReadRows({
+    // the "read-rows" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    variable,
+
+    // function that maps message/enum type name to a Go package alias
+    typePackageAlias,
+
+    // object `{messageType, idFieldName}` describing the Go type of the
+    // messages being read (e.g. "pb.FooBar") and the name of the ID field
+    messages
+}) {
+    // Reminder of the shape of a "read-rows" instruction:
+    //
+    //    {
+    //        'instruction': 'read-rows',
+    //        'destinations': [outputParameter, ...etc]
+    //    }
+    
+    // Here's what we're going for:
+    //
+    //     for ; ok; ok = rows.Next() {
+    //         message = &pb.FooBar{}
+    //         $oneofMembers
+    //         err = rows.Scan($destinations)
+    //         if err != nil {
+    //             return
+    //         }
+    //         messages = append(messages, message)
+    //     }
+    //
+    // where `messages` is the named result of the enclosing func, and where
+    // `$oneofMembers` declares a variable for each member of a oneof (see
+    // `scanDestinations`).
+    const {declarations, expressions: destinations} = scanDestinations({
+        destinations: instruction.destinations,
+        typeByField,
+        typePackageAlias,
+        messages
+    });
+
+    // The following code references these variables.
+    variable({name: 'rows', goType: '*sql.Rows'});
+    variable({name: 'message', goType: `*${messages.messageType}`});
+
+    return [{
+        // for ; ok; ok = rows.Next() {
+        iterationFor: {
+            condition: {symbol: 'ok'},
+            post: {assign: {
+                left: ['ok'],
+                right: [{
+                    call: {
+                        function: {dot: ['rows', 'Next']},
+                        arguments: []
+                    }
+                }]}},
+            body: [
+                // message = &pb.FooBar{}
+                {assign: {
+                    left: ['message'],
+                    right: [{address: {sequenceLiteral: {
+                        type: messages.messageType,
+                        elements: []
+                    }}}]
+                }},
+
+                // var oneofFoo = &pb.FooBar_Foo{}
+                // ...
+                ...declarations,
+
+                // err = rows.Scan($destinations)
+                {assign: {
+                    left: ['err'],
+                    right: [{
+                        call: {
+                            function: {dot: ['rows', 'Scan']},
+                            arguments: destinations
+                        }
+                    }]
+                }},
+
+                // if err != nil {
+                //     return
+                // }
+                ifErrReturn,
+
+                // messages = append(messages, message)
+                {assign: {
+                    left: ['messages'],
+                    right: [{
+                        call: {
+                            function: 'append',
+                            arguments: [
+                                {symbol: 'messages'},
+                                {symbol: 'message'}
+                            ]
+                        }
+                    }]
+                }}
+            ]
+        }
+    }];
 }
 
-// Return an array of statements that perform the specified CRUD "read-row"
-// `instruction` in the context implied by the other specified arguments.
-function performReadRow({
-    // the "read-row" CRUD instruction
+// Return an array of statements that perform the specified CRUD
+// "read-keyed-array" `instruction` in the context implied by the other
+// specified arguments.
+function performReadKeyedArray({
+    // the "read-keyed-array" CRUD instruction
     instruction,
 
     // object that maps a message field name to an okra type
     typeByField,
 
     // function that registers a specified `variable({name, goType})` and returns `name`
-    // Note that that variables that are _assumed_ to be in scope, such as
-    // `ctx` and `transaction`, don't need to use this function. It's for
-    // variables like `rows` and `ok`.
     variable,
 
-    // function that returns an expression for whether a field is included in the CRUD operation
-    // included
-    // NOTE: `included` is not used here. In the future, it could be used to
-    // decide which output parameters refer to message fields (included ones)
-    // and which output parameters go to an effective /dev/null (excluded
-    // ones). However, I don't currently have a need for this. `include` would
-    // be defined as "always true." So, I instead omit it for now.
-
     // function that maps message/enum type name to a Go package alias
-    typePackageAlias
+    typePackageAlias,
+
+    // object `{messageType, idFieldName}` describing the Go type of the
+    // messages being read (e.g. "pb.FooBar") and the name of the ID field
+    messages
 }) {
-    // Reminder of the shape of a "read-row" instruction:
+    // Reminder of the shape of a "read-keyed-array" instruction:
     //
     //    {
-    //        'instruction': 'read-row',
-    //        'destinations': [outputParameter, ...etc]
+    //        'instruction': 'read-keyed-array',
+    //        'destination': outputParameter
     //    }
     
     // Here's what we're going for:
     //
-    //     if !ok {
-    //         err = nowRow()
-    //         return
-    //     }
-    //     
-    //     err = rows.Scan($destinations)
-    //     if err != nil {
-    //         return
+    //     for ; ok; ok = rows.Next() {
+    //         var key whateverIDType
+    //         var temp whateverGoType
+    //         err = rows.Scan(&key, &temp) // might use intoDate or intoTimestamp
+    //         if err != nil {
+    //             return
+    //         }
+    //         message = byID[key]
+    //         if message != nil {
+    //             message.$destination = append(message.$destination, temp)
+    //         }
     //     }
-    //     rows.Next()
     //
-    const destinations = instruction.destinations.map(destination => {
-        if (destination === 'ignore') {
-            return {call: {function: 'ignore', arguments: []}};
-        }
+    // where `byID` maps each ID to the corresponding message previously read
+    // by a "read-rows" instruction. The enclosing func is responsible for
+    // populating `byID`.
 
-        const okraType = typeByField[destination.field];
-        const member = field2go(destination.field); // Go struct field name
-        const target = {dot: ['message', member]};
-        return fieldDestinationExpression({
-            okraType,
-            target,
-            typePackageAlias
+    // See the analogous code in `performReadArray`.
+    const fieldType = typeByField[instruction.destination.field];
+    const elementType = fieldType.array || {builtin: 'TYPE_STRING'};
+    const idType = typeByField[messages.idFieldName];
+    if (fieldType.map) {
+        return performReadKeyedMap({
+            instruction,
+            typeByField,
+            variable,
+            typePackageAlias,
+            messages
         });
+    }
+
+    const intoKey = fieldDestinationExpression({
+        okraType: idType,
+        target: {symbol: 'key'},
+        typePackageAlias
+    });
+    const intoTemp = fieldDestinationExpression({
+        okraType: elementType,
+        target: {symbol: 'temp'},
+        typePackageAlias
     });
+    const appendFunctionName = fieldType.array ? 'append' : 'appendField';
+    const destinationGoArray = {
+        dot: ['message', field2go(instruction.destination.field)]
+    };
 
     // The following code references these variables.
-    variable({name: 'rows', goType: '*sql.Rows'})
-    variable({name: 'ok', goType: 'bool'})
+    variable({name: 'rows', goType: '*sql.Rows'});
+    variable({name: 'message', goType: `*${messages.messageType}`});
+    variable({
+        name: 'byID',
+        goType: `map[${type2go({okraType: idType, typePackageAlias})}]*${messages.messageType}`
+    });
 
-    return [
-        // if !ok {
-        //     err = noRow()
-        //     return
-        // }
-        {if: {
-            condition: {not: {symbol: 'ok'}},
+    return [{
+        // for ; ok; ok = rows.Next() {
+        iterationFor: {
+            condition: {symbol: 'ok'},
+            post: {assign: {
+                left: ['ok'],
+                right: [{
+                    call: {
+                        function: {dot: ['rows', 'Next']},
+                        arguments: []
+                    }
+                }]}},
             body: [
+                // var key whateverIDType
+                {variable: {
+                    name: 'key',
+                    type: type2go({okraType: idType, typePackageAlias})
+                }},
+
+                // var temp whateverGoType
+                {variable: {
+                    name: 'temp',
+                    type: type2go({okraType: elementType, typePackageAlias})
+                }},
+                
+                // err = rows.Scan($intoKey, $intoTemp)
                 {assign: {
                     left: ['err'],
                     right: [{
-                        call: {function: 'noRow', arguments: []}
+                        call: {
+                            function: {dot: ['rows', 'Scan']},
+                            arguments: [intoKey, intoTemp]
+                        }
                     }]
                 }},
-                {return: []}
-            ]
-        }},
-
-        //
-        {spacer: 1},
 
-        // err = rows.Scan($destinations)
-        {assign: {
-            left: ['err'],
-            right: [{
-                call: {
-                    function: {dot: ['rows', 'Scan']},
-                    arguments: destinations
-                }
-            }]
-        }},
+                // if err != nil {
+                //     return
+                // }
+                ifErrReturn,
 
-        // if err != nil {
-        //     return
-        // }
-        ifErrReturn,
+                // message = byID[key]
+                {assign: {
+                    left: ['message'],
+                    right: [{index: {object: 'byID', index: {symbol: 'key'}}}]
+                }},
 
-        // rows.Next()
-        {call: {
-            function: {dot: ['rows', 'Next']},
-            arguments: []}}
-    ];
+                // If the row doesn't belong to any of the messages that we
+                // read, then ignore it. This can happen if the table was
+                // modified concurrently and the transaction isolation level
+                // permits us to see the modification.
+                //
+                // if message != nil {
+                //     $destination = append($destination, temp)
+                // }
+                {if: {
+                    condition: {notEqual: {
+                        left: {symbol: 'message'},
+                        right: null
+                    }},
+                    body: [{assign: {
+                        left: [destinationGoArray],
+                        right: [{
+                            call: {
+                                function: appendFunctionName,
+                                arguments: [
+                                    destinationGoArray,
+                                    {symbol: 'temp'}
+                                ]
+                            }
+                        }]
+                    }}]
+                }}
+            ]
+        }
+    }];
 }
 
-// Return an array of statements that perform the specified CRUD "read-array"
-// `instruction` in the context implied by the other specified arguments.
-function performReadArray({
-    // the "read-array" CRUD instruction
+// Return an array of statements that perform the specified CRUD
+// "read-child-rows" `instruction` in the context implied by the other
+// specified arguments.
+function performReadChildRows({
+    // the "read-child-rows" CRUD instruction
     instruction,
 
     // object that maps a message field name to an okra type
     typeByField,
 
+    // object that maps a type name to an okra type
+    types,
+
     // function that registers a specified `variable({name, goType})` and returns `name`
-    // Note that that variables that are _assumed_ to be in scope, such as
-    // `ctx` and `transaction`, don't need to use this function. It's for
-    // variables like `rows` and `ok`.
     variable,
 
     // function that returns an expression for whether a field is included in the CRUD operation
-    // included
-    // NOTE: `included` is not used here. See the note in `performReadRow`.
+    included,
 
     // function that maps message/enum type name to a Go package alias
     typePackageAlias
 }) {
-    // Reminder of the shape of a "read-array" instruction:
+    // Reminder of the shape of a "read-child-rows" instruction:
     //
     //    {
-    //        'instruction': 'read-array',
-    //        'destination': outputParameter
+    //        'instruction': 'read-child-rows',
+    //        'destination': {'field': String},
+    //        'destinations': [outputParameter, ...etc]
     //    }
-    
+
+    // Here's what we're going for, if the destination field is repeated:
+    //
+    //     for ; ok; ok = rows.Next() {
+    //         child = &pb.Child{}
+    //         err = rows.Scan($destinations)
+    //         if err != nil {
+    //             return
+    //         }
+    //         message.$destination = append(message.$destination, child)
+    //     }
+    //
+    // or, if it isn't repeated:
+    //
+    //     message.$destination = nil
+    //     if ok {
+    //         child = &pb.Child{}
+    //         err = rows.Scan($destinations)
+    //         if err != nil {
+    //             return
+    //         }
+    //         message.$destination = child
+    //         rows.Next()
+    //     }
+    //
+    // where each of the `$destinations` is a field of `child`. Either way, if
+    // the destination field might be excluded, wrap the above in an `if`
+    // statement.
+    const fieldName = instruction.destination.field;
+    const repeated = typeByField[fieldName].array !== undefined;
+    const destinationGoField = {dot: ['message', field2go(fieldName)]};
+
+    // The following code references these variables.
+    variable({name: 'rows', goType: '*sql.Rows'});
+    variable({
+        name: 'child',
+        goType: type2go({
+            okraType: typeByField[fieldName].array || typeByField[fieldName],
+            typePackageAlias
+        })
+    });
+
+    const readChild = readChildStatements({
+        instruction,
+        typeByField,
+        types,
+        typePackageAlias
+    });
+
+    let statements;
+    if (repeated) {
+        statements = [{
+            // for ; ok; ok = rows.Next() {
+            iterationFor: {
+                condition: {symbol: 'ok'},
+                post: {assign: {
+                    left: ['ok'],
+                    right: [{
+                        call: {
+                            function: {dot: ['rows', 'Next']},
+                            arguments: []
+                        }
+                    }]}},
+                body: [
+                    ...readChild,
+
+                    // message.$destination = append(message.$destination, child)
+                    {assign: {
+                        left: [destinationGoField],
+                        right: [{
+                            call: {
+                                function: 'append',
+                                arguments: [
+                                    destinationGoField,
+                                    {symbol: 'child'}
+                                ]
+                            }
+                        }]
+                    }}
+                ]
+            }
+        }];
+    }
+    else {
+        statements = [
+            // message.$destination = nil
+            {assign: {
+                left: [destinationGoField],
+                right: [null]
+            }},
+
+            // if ok {
+            {if: {
+                condition: {symbol: 'ok'},
+                body: [
+                    ...readChild,
+
+                    // message.$destination = child
+                    {assign: {
+                        left: [destinationGoField],
+                        right: [{symbol: 'child'}]
+                    }},
+
+                    // There's at most one row, since the child table's
+                    // primary key is the parent ID. Advance past it, so that
+                    // the rows are exhausted.
+                    //
+                    // rows.Next()
+                    {call: {
+                        function: {dot: ['rows', 'Next']},
+                        arguments: []}}
+                ]
+            }}
+        ];
+    }
+
+    return ifIncluded({
+        condition: {included: fieldName},
+        included,
+        statements
+    });
+}
+
+// Return an array of statements that perform the specified CRUD
+// "read-keyed-child-rows" `instruction` in the context implied by the other
+// specified arguments.
+function performReadKeyedChildRows({
+    // the "read-keyed-child-rows" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // object that maps a type name to an okra type
+    types,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    variable,
+
+    // function that maps message/enum type name to a Go package alias
+    typePackageAlias,
+
+    // object `{messageType, idFieldName}` describing the Go type of the
+    // messages being read (e.g. "pb.FooBar") and the name of the ID field
+    messages
+}) {
+    // Reminder of the shape of a "read-keyed-child-rows" instruction:
+    //
+    //    {
+    //        'instruction': 'read-keyed-child-rows',
+    //        'destination': {'field': String},
+    //        'destinations': [outputParameter, ...etc]
+    //    }
+
     // Here's what we're going for:
     //
     //     for ; ok; ok = rows.Next() {
-    //         var temp whateverGoType
-    //         err = rows.Scan(&temp) // might use intoDate or intoTimestamp
+    //         var key whateverIDType
+    //         child = &pb.Child{}
+    //         err = rows.Scan(&key, $destinations)
     //         if err != nil {
     //             return
     //         }
-    //         $destination = append($destination, temp)
+    //         message = byID[key]
+    //         if message != nil {
+    //             message.$destination = append(message.$destination, child)
+    //         }
     //     }
-
-    // For each row, we scan one array element into a temporary variable
-    // `temp`. The expression that we pass to `rows.Scan` depends on the type
-    // of the destination array. `intoTemp` is that expression.
-    // If the field is an array, then the element type is `.array`. Otherwise,
-    // it's a FieldMask as so the element type is string.
-    const fieldType = typeByField[instruction.destination.field];
-    const elementType = fieldType.array || {builtin: 'TYPE_STRING'};
-    const intoTemp = fieldDestinationExpression({
-        okraType: elementType,
-        target: {symbol: 'temp'},
+    //
+    // where each of the `$destinations` is a field of `child`, and where the
+    // `append` is instead just an assignment if the destination field is not
+    // repeated. See `performReadKeyedArray`.
+    const fieldName = instruction.destination.field;
+    const repeated = typeByField[fieldName].array !== undefined;
+    const destinationGoField = {dot: ['message', field2go(fieldName)]};
+    const idType = typeByField[messages.idFieldName];
+    const intoKey = fieldDestinationExpression({
+        okraType: idType,
+        target: {symbol: 'key'},
         typePackageAlias
     });
 
-    // If the destination is an array, then we append to it using the `append`
-    // function. If it's a FieldMask, then we append to it using `appendField`
-    // (which we defined).
-    const appendFunctionName = fieldType.array ? 'append' : 'appendField';
-
-    // the array we're appending to
-    const destinationGoArray = {
-        dot: ['message', field2go(instruction.destination.field)]
-    };
-
-    // The following code references this variable.
+    // The following code references these variables.
     variable({name: 'rows', goType: '*sql.Rows'});
+    variable({name: 'message', goType: `*${messages.messageType}`});
+    variable({
+        name: 'byID',
+        goType: `map[${type2go({okraType: idType, typePackageAlias})}]*${messages.messageType}`
+    });
+    variable({
+        name: 'child',
+        goType: type2go({
+            okraType: typeByField[fieldName].array || typeByField[fieldName],
+            typePackageAlias
+        })
+    });
+
+    const readChild = readChildStatements({
+        instruction,
+        typeByField,
+        types,
+        typePackageAlias,
+        // Scan the key first.
+        leadingDestinations: [intoKey]
+    });
 
     return [{
         // for ; ok; ok = rows.Next() {
@@ -847,50 +5069,113 @@ function performReadArray({
                     }
                 }]}},
             body: [
-                // var temp whateverGoType
+                // var key whateverIDType
                 {variable: {
-                    name: 'temp',
-                    // The destination is an array. We want an instance of
-                    // an _element_ of the array.
-                    type: type2go({okraType: elementType, typePackageAlias})
+                    name: 'key',
+                    type: type2go({okraType: idType, typePackageAlias})
                 }},
-                
-                // err = rows.Scan($intoTemp)
+
+                ...readChild,
+
+                // message = byID[key]
                 {assign: {
-                    left: ['err'],
-                    right: [{
-                        call: {
-                            function: {dot: ['rows', 'Scan']},
-                            arguments: [intoTemp]
-                        }
-                    }]
+                    left: ['message'],
+                    right: [{index: {object: 'byID', index: {symbol: 'key'}}}]
                 }},
 
-                // if err != nil {
-                //     return
+                // See the analogous comment in `performReadKeyedArray`.
+                //
+                // if message != nil {
+                //     $destination = append($destination, child)
                 // }
-                ifErrReturn,
-
-                // $destination = append($destination, temp)
-                // or
-                // $destination = appendField($destination, temp)
-                {assign: {
-                    left: [destinationGoArray],
-                    right: [{
-                        call: {
-                            function: appendFunctionName,
-                            arguments: [
-                                destinationGoArray,
-                                {symbol: 'temp'}
-                            ]
-                        }
-                    }]
+                {if: {
+                    condition: {notEqual: {
+                        left: {symbol: 'message'},
+                        right: null
+                    }},
+                    body: [{assign: {
+                        left: [destinationGoField],
+                        right: [repeated
+                            ? {call: {
+                                function: 'append',
+                                arguments: [
+                                    destinationGoField,
+                                    {symbol: 'child'}
+                                ]
+                              }}
+                            : {symbol: 'child'}]
+                    }}]
                 }}
             ]
         }
     }];
 }
 
+// Return an array of statements that scan the current row into a new instance
+// of the message type of the destination field of the specified
+// "read-child-rows" or "read-keyed-child-rows" `instruction`, assigning the
+// instance to the variable `child`. Use the specified `typeByField`, `types`,
+// and `typePackageAlias` to look up the types of the child's fields. Scan into
+// the optionally specified `leadingDestinations` expressions before the
+// child's fields. The statements are:
+//
+//     child = &pb.Child{}
+//     err = rows.Scan($leadingDestinations, $destinations)
+//     if err != nil {
+//         return
+//     }
+function readChildStatements({
+    instruction,
+    typeByField,
+    types,
+    typePackageAlias,
+    leadingDestinations = []
+}) {
+    const fieldName = instruction.destination.field;
+    const fieldType = typeByField[fieldName];
+    const childTypeName = (fieldType.array || fieldType).message;
+    const childFieldTypes = childTypeByField({fieldName, typeByField, types});
+
+    const destinations = instruction.destinations.map(destination => {
+        if (destination === 'ignore') {
+            return {call: {function: 'ignore', arguments: []}};
+        }
+
+        return fieldDestinationExpression({
+            okraType: childFieldTypes[destination.field],
+            target: {dot: ['child', field2go(destination.field)]},
+            typePackageAlias
+        });
+    });
+
+    return [
+        // child = &pb.Child{}
+        {assign: {
+            left: ['child'],
+            right: [{address: {sequenceLiteral: {
+                type: `${typePackageAlias(childTypeName)}.${messageOrEnum2go(childTypeName)}`,
+                elements: []
+            }}}]
+        }},
+
+        // err = rows.Scan($leadingDestinations, $destinations)
+        {assign: {
+            left: ['err'],
+            right: [{
+                call: {
+                    function: {dot: ['rows', 'Scan']},
+                    arguments: [...leadingDestinations, ...destinations]
+                }
+            }]
+        }},
+
+        // if err != nil {
+        //     return
+        // }
+        ifErrReturn
+    ];
+}
+
 // Return an array of statements that perform the specified CRUD "exec"
 // `instruction` in the context implied by the other specified arguments.
 function performExec({
@@ -904,11 +5189,22 @@ function performExec({
     // Note that that variables that are _assumed_ to be in scope, such as
     // `ctx` and `transaction`, don't need to use this function. It's for
     // variables like `rows` and `ok`.
-    // variable,
-    // NOTE: This instruction doesn't use any non-implicit variables.
+    // Only an instruction having `onNoRows` uses non-implicit variables.
+    variable,
 
     // function that returns an expression for whether a field is included in the CRUD operation
-    included
+    included,
+
+    // function that returns an expression for a bound of the page in a "list" operation
+    page,
+
+    // function that returns an expression for a value in a history
+    // instruction
+    history,
+
+    // function that returns an expression for a value in an outbox
+    // instruction
+    outbox
 }) {
     // Reminder of the shape of a "exec" instruction:
     //
@@ -916,7 +5212,8 @@ function performExec({
     //        'instruction': 'exec',
     //        'condition?': {'included': String},
     //        'sql': String,
-    //        'parameters': [inputParameter, ...etc]
+    //        'parameters': [inputParameter, ...etc],
+    //        'onNoRows?': or('conflict', 'unchanged')
     //    }
     
     // Here's what we're going for
@@ -927,18 +5224,45 @@ function performExec({
     //     }
     //
     // or, if there's a "condition," wrap the above in an `if` statement.
+    //
+    // If there's an "onNoRows," then the result is checked:
+    //
+    //     result, err = transaction.ExecContext(ctx, $query, $parameters ...)
+    //     if err != nil {
+    //         return
+    //     }
+    //     affected, err = result.RowsAffected()
+    //     if err != nil {
+    //         return
+    //     }
+    //     if affected == 0 {
+    //         err = versionConflict()
+    //         return
+    //     }
+    //
+    // where the final `if` is omitted if "onNoRows" is "unchanged." Then the
+    // func checks `affected` itself (see `funcByID`).
 
     const parameters = inputParameters2expressions({
         parameters: instruction.parameters,
         typeByField,
-        included
+        included,
+        page,
+        history,
+        outbox
     });
 
+    const checked = instruction.onNoRows !== undefined;
+    if (checked) {
+        variable({name: 'result', goType: 'sql.Result'});
+        variable({name: 'affected', goType: 'int64'});
+    }
+
     // If there's a condition, we'll wrap all of this in an `if`.
     const statements = [
         // _, err = transaction.ExecContext(ctx, $sql, $parameters ...)
         {assign: {
-            left: ['_', 'err'],
+            left: [checked ? 'result' : '_', 'err'],
             right: [{
                 call: {
                     function: {dot: ['transaction', 'ExecContext']},
@@ -957,6 +5281,40 @@ function performExec({
         ifErrReturn
     ];
 
+    if (checked) {
+        statements.push(
+            // affected, err = result.RowsAffected()
+            {assign: {
+                left: ['affected', 'err'],
+                right: [{call: {
+                    function: {dot: ['result', 'RowsAffected']},
+                    arguments: []
+                }}]
+            }},
+            ifErrReturn);
+    }
+
+    if (instruction.onNoRows === 'conflict') {
+        statements.push(
+            // if affected == 0 {
+            //     err = versionConflict()
+            //     return
+            // }
+            {if: {
+                condition: {equal: {left: {symbol: 'affected'}, right: 0}},
+                body: [
+                    {assign: {
+                        left: ['err'],
+                        right: [{call: {
+                            function: 'versionConflict',
+                            arguments: []
+                        }}]
+                    }},
+                    {return: []}
+                ]
+            }});
+    }
+
     if ('condition' in instruction) {
         return [{
             if: {
@@ -980,6 +5338,9 @@ function performExecWithTuples({
     // object that maps a message field name to an okra type
     typeByField,
 
+    // object that maps a type name to an okra type
+    types,
+
     // function that registers a specified `variable({name, goType})` and returns `name`
     // Note that that variables that are _assumed_ to be in scope, such as
     // `ctx` and `transaction`, don't need to use this function. It's for
@@ -989,14 +5350,28 @@ function performExecWithTuples({
     // function that returns an expression for whether a field is included in the CRUD operation
     included
 }) {
-    // Verify that exactly one of the `instruction.parameters` has array or
-    // FieldMask type.
+    // Inserting into a child table (the table of a message-valued field) is
+    // handled separately.
+    if (instruction.parameters.some(parameter => 'child' in parameter)) {
+        return performExecWithChildTuples({
+            instruction,
+            typeByField,
+            types,
+            variable,
+            included
+        });
+    }
+
+    // Verify that exactly one of the `instruction.parameters` has array, map,
+    // or FieldMask type.
     // Also, verify that all of the `instruction.parameters` have the shape
-    // `{field: ...}` or `{index: ...}` (instead of `{included: ...}`).
+    // `{field: ...}`, `{index: ...}`, or `{key: ...}` (instead of
+    // `{included: ...}`).
     instruction.parameters.forEach(parameter => {
-        if (!('field' in parameter) && !('index' in parameter)) {
+        if (!('field' in parameter) && !('index' in parameter) &&
+            !('key' in parameter)) {
             throw Error(`Expected "exec-with-tuples" parameters to be of ` +
-                `{field: ...} or {index: ...} kind, but encountered: ` +
+                `{field: ...}, {index: ...}, or {key: ...} kind, but encountered: ` +
                 `${JSON.stringify(parameter)} in instruction: ` +
                 JSON.stringify(instruction));
         }
@@ -1007,10 +5382,13 @@ function performExecWithTuples({
     // they will refer to the same field name.
     // The idea is that we're inserting into an array table, and we'll need the
     // value of each element in the array (that's the field) and the index of
-    // each value (that's the index parameter).
+    // each value (that's the index parameter). For a map, it's the key
+    // parameter instead of the index parameter.
     const arrayLikes = instruction.parameters.filter(parameter => {
-        const parameterType = typeByField[parameter.field || parameter.index];
+        const parameterType =
+            typeByField[parameter.field || parameter.index || parameter.key];
         return parameterType.array ||
+            parameterType.map ||
             parameterType.builtin === '.google.protobuf.FieldMask';
     });
 
@@ -1021,7 +5399,7 @@ function performExecWithTuples({
     }
 
     const [first, second] = arrayLikes.map(
-        parameter => parameter.field || parameter.index);
+        parameter => parameter.field || parameter.index || parameter.key);
     if (first !== second) {
         throw Error('Expected "exec-with-tuples" array-related or ' +
             'FieldMask-related parameters to refer to the same field, but ' +
@@ -1031,16 +5409,16 @@ function performExecWithTuples({
 
     const arrayLikeField = first; // or `second`
     
-    // If the `arrayLikeField` is an array, then we can `range` loop over it
-    // normally, and we can get its length using `len`. If it's a FieldMask,
-    // though, then we need to `range` loop over its `.Paths` field, and we
-    // query its length using `fieldMaskLen` (which we defined).
+    // If the `arrayLikeField` is an array or a map, then we can `range` loop
+    // over it normally, and we can get its length using `len`. If it's a
+    // FieldMask, though, then we need to `range` loop over its `.Paths` field,
+    // and we query its length using `fieldMaskLen` (which we defined).
     const arrayLikeType = typeByField[arrayLikeField];
-    const lenFunctionName = arrayLikeType.array ? 'len' : 'fieldMaskLen';
-    const rangeArgumentParts = arrayLikeType.array
-        ? ['message', field2go(arrayLikeField)] // e.g. message.Pets
-        : ['message', field2go(arrayLikeField), 'Paths'] // e.g. messages.MustHaves.Paths
-    const arrayElementType = arrayLikeType.array || {builtin: 'TYPE_STRING'};
+    const isFieldMask = arrayLikeType.builtin !== undefined;
+    const lenFunctionName = isFieldMask ? 'fieldMaskLen' : 'len';
+    const rangeArgumentParts = isFieldMask
+        ? ['message', field2go(arrayLikeField), 'Paths'] // e.g. messages.MustHaves.Paths
+        : ['message', field2go(arrayLikeField)] // e.g. message.Pets
 
     // Here's what we're going for:
     //
@@ -1112,8 +5490,12 @@ function performExecWithTuples({
                 // for i, element := range message.$array {
                 //     ...
                 // }
+                // or, for a map,
+                // for key, element := range message.$map {
+                //     ...
+                // }
                 {rangeFor: {
-                    variables: ['i', 'element'],
+                    variables: [arrayLikeType.map ? 'key' : 'i', 'element'],
                     sequence: {dot: rangeArgumentParts},
                     body: [
                         // parameters = append(parameters, [...], element, [...])
@@ -1130,26 +5512,13 @@ function performExecWithTuples({
                                     // array/FieldMask field), while the rest
                                     // will be the other fields repeated again
                                     // this time around the loop.
-                                    ...instruction.parameters.map(parameter => {
-                                        if (parameter.field === arrayLikeField) {
-                                            // the array element
-                                            return inputExpression({
-                                                okraType: arrayElementType,
-                                                expression: {symbol: 'element'}
-                                            });
-                                        }
-                                        else if (parameter.index === arrayLikeField) {
-                                            // the array index
-                                            return {symbol: 'i'};
-                                        }
-                                        else {
-                                            return inputParameter2expression({
-                                                parameter,
-                                                typeByField,
-                                                included
-                                            });
-                                        }
-                                    })
+                                    ...instruction.parameters.map(parameter =>
+                                        tupleParameter2expression({
+                                            parameter,
+                                            arrayLikeField,
+                                            typeByField,
+                                            included
+                                        }))
                                 ]
                             }}]
                         }}
@@ -1191,6 +5560,189 @@ function performExecWithTuples({
     ];
 }
 
+// Return an array of statements that perform the specified CRUD
+// "exec-with-tuples" `instruction`, whose tuples correspond to the elements of
+// a message-valued field (see the `child` parameters in `crud.tisch.js`), in
+// the context implied by the other specified arguments.
+function performExecWithChildTuples({
+    // the "exec-with-tuples" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // object that maps a type name to an okra type
+    types,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    variable,
+
+    // function that returns an expression for whether a field is included in the CRUD operation
+    included
+}) {
+    // All of the `child` parameters must refer to the same message-valued
+    // field.
+    const childFields = [...new Set(instruction.parameters
+        .filter(parameter => 'child' in parameter)
+        .map(parameter => parameter.child))];
+
+    if (childFields.length !== 1) {
+        throw Error('Expected "exec-with-tuples" child parameters to refer ' +
+            'to exactly one message-valued field, but they refer to: ' +
+            JSON.stringify(childFields));
+    }
+
+    const [childField] = childFields;
+    const repeated = typeByField[childField].array !== undefined;
+    const childFieldTypes =
+        childTypeByField({fieldName: childField, typeByField, types});
+    const goField = {dot: ['message', field2go(childField)]};
+
+    // Here's what we're going for, if the child field is repeated:
+    //
+    //     if $included && len(message.$child) != 0 {
+    //         parameters = nil // clear the slice
+    //
+    //         for i, element := range message.$child {
+    //             parameters = append(parameters, [...], element.GetFoo(), [...])
+    //         }
+    //
+    //         _, err = transaction.ExecContext(
+    //             ctx,
+    //             withTuples($sql, $tuple, len(message.$child)),
+    //             parameters...)
+    //
+    //         if err != nil {
+    //             return
+    //         }
+    //     }
+    //
+    // or, if it isn't repeated:
+    //
+    //     if $included && message.$child != nil {
+    //         _, err = transaction.ExecContext(
+    //             ctx,
+    //             withTuples($sql, $tuple, 1),
+    //             [...], message.$child.GetFoo(), [...])
+    //
+    //         if err != nil {
+    //             return
+    //         }
+    //     }
+    //
+    // The fields of the child are read using their getters, because elements
+    // of a repeated message field might be nil.
+
+    // Map each of the instruction's parameters to an expression, where the
+    // child fields are accessed through the object at `elementParts`.
+    function parameterExpressions(elementParts) {
+        return instruction.parameters.map(parameter =>
+            tupleParameter2expression({
+                parameter,
+                arrayLikeField: childField,
+                typeByField,
+                childFieldTypes,
+                elementParts,
+                included
+            }));
+    }
+
+    // `present` is whether there are any tuples.
+    const numTuples = repeated
+        ? {call: {function: 'len', arguments: [goField]}}
+        : 1;
+    const present = repeated
+        ? {notEqual: {left: numTuples, right: 0}}
+        : {notEqual: {left: goField, right: null}};
+
+    // If inclusion is hard-coded to true, then omit that part of the
+    // condition.
+    let condition;
+    if ('condition' in instruction &&
+        included(instruction.condition.included) !== true) {
+        condition = {
+            and: {
+                left: included(instruction.condition.included),
+                right: present
+            }
+        };
+    }
+    else {
+        condition = present;
+    }
+
+    // _, err = transaction.ExecContext(
+    //     ctx,
+    //     withTuples($sql, $tuple, $numTuples),
+    //     $parameters, $rest...)
+    function exec({parameters = [], rest}) {
+        return {assign: {
+            left: ['_', 'err'],
+            right: [{
+                call: {
+                    function: {dot: ['transaction', 'ExecContext']},
+                    arguments: [
+                        {symbol: 'ctx'},
+                        {call: {
+                            function: 'withTuples',
+                            arguments: [
+                                instruction.sql,
+                                instruction.tuple,
+                                numTuples
+                            ]
+                        }},
+                        ...parameters
+                    ],
+                    ...(rest ? {rest} : {})
+                }
+            }]
+        }};
+    }
+
+    let body;
+    if (repeated) {
+        // The following code references this variable.
+        variable({name: 'parameters', goType: '[]interface{}'});
+
+        body = [
+            // parameters = nil
+            {assign: {
+                left: ['parameters'],
+                right: [null]
+            }},
+
+            // for i, element := range message.$child {
+            //     parameters = append(parameters, [...], element.GetFoo(), [...])
+            // }
+            {rangeFor: {
+                variables: ['i', 'element'],
+                sequence: goField,
+                body: [{assign: {
+                    left: ['parameters'],
+                    right: [{call: {
+                        function: 'append',
+                        arguments: [
+                            {symbol: 'parameters'},
+                            ...parameterExpressions(['element'])
+                        ]
+                    }}]
+                }}]
+            }},
+
+            exec({rest: {symbol: 'parameters'}}),
+            ifErrReturn
+        ];
+    }
+    else {
+        body = [
+            exec({parameters: parameterExpressions(goField.dot)}),
+            ifErrReturn
+        ];
+    }
+
+    return [{if: {condition, body}}];
+}
+
 // Finishers
 // =========
 // This section contains functions that walk an AST and possibly modify it. For
@@ -1222,6 +5774,19 @@ function includePrerendered(goFile) {
 
     walk(goFile.declarations, visit);
 
+    // Pre-rendered code can itself depend on other pre-rendered code, e.g. a
+    // helper function that calls another helper function.
+    function addDependencies(name) {
+        (prerendered[name].dependencies || []).forEach(dependency => {
+            if (!(dependency in referenced)) {
+                referenced[dependency] = true;
+                addDependencies(dependency);
+            }
+        });
+    }
+
+    Object.keys(referenced).forEach(addDependencies);
+
     // Fill `goFile` with additional imports and declarations based on what was
     // `referenced`.
     Object.keys(referenced).forEach(name => {
@@ -1253,7 +5818,9 @@ function includeStandardImports(goFile) {
     // {<identifier after import>: <full package name>}
     const standardImports = {
         // `fmt.Errorf` is used in some places.
-        fmt: 'fmt'
+        fmt: 'fmt',
+        // `errors.Is` is used when recording the history of a message.
+        errors: 'errors'
     };
 
     // {<full package name>: null}
@@ -1291,13 +5858,193 @@ function includeStandardImports(goFile) {
         goFile.imports[package] = alias;
     });
 
-    return goFile;
+    return goFile;
+}
+
+// Utilities
+// =========
+// This section contains everything else. It's a mix of functions and constants
+// used throughout the other sections.
+
+// Return an object `{declarations, expressions}` for scanning a row into
+// `message` according to the specified `destinations` of a "read-row" or
+// "read-rows" instruction. `expressions` contains an argument to Rows.Scan for
+// each of `destinations`. Use the specified `typeByField`, `typePackageAlias`,
+// and `messages` to determine the Go types involved.
+//
+// The members of a oneof are not fields of the Go struct. Instead, the struct
+// has one field for the oneof, which points to a "wrapper" struct for the
+// member that is set, e.g. `message.Method = &pb.Payment_Iban{Iban: "..."}`.
+// So, `declarations` contains a statement that declares a wrapper for each
+// member of a oneof, e.g.
+//
+//     var oneofIban = &pb.Payment_Iban{}
+//
+// and the member is scanned into its wrapper, e.g. `intoString(&oneofIban.Iban)`.
+// Then the destination of the oneof itself points the oneof at the wrapper of
+// the member named in its column, e.g.
+//
+//     intoOneof(&message.Method, map[string]interface{}{"iban": oneofIban, ...})
+//
+function scanDestinations({destinations, typeByField, typePackageAlias, messages}) {
+    const declarations = [];
+
+    // {<oneof name>: [<element of a Go map literal>, ...]}
+    const membersByOneof = {};
+
+    destinations
+        .filter(destination =>
+            destination !== 'ignore' &&
+            destination.field !== undefined &&
+            destination.oneof !== undefined)
+        .forEach(({field, oneof}) => {
+            const member = field2go(field); // Go struct field name
+            const wrapperType = `${messages.messageType}_${member}`;
+            const wrapper = `oneof${member}`;
+
+            // var $wrapper = &$wrapperType{}
+            declarations.push({variable: {
+                name: wrapper,
+                type: `*${wrapperType}`,
+                value: {address: {sequenceLiteral: {
+                    type: wrapperType,
+                    elements: []
+                }}}
+            }});
+
+            // "$field": $wrapper
+            const members = membersByOneof[oneof] || [];
+            members.push({raw: `${JSON.stringify(field)}: ${wrapper}`});
+            membersByOneof[oneof] = members;
+        });
+
+    const expressions = destinations.map(destination => {
+        if (destination === 'ignore') {
+            return {call: {function: 'ignore', arguments: []}};
+        }
+
+        if (destination.field === undefined) {
+            // intoOneof(&message.$Oneof, map[string]interface{}{...})
+            return {call: {
+                function: 'intoOneof',
+                arguments: [
+                    {address: {dot: ['message', field2go(destination.oneof)]}},
+                    {sequenceLiteral: {
+                        type: 'map[string]interface{}',
+                        elements: membersByOneof[destination.oneof] || []
+                    }}
+                ]
+            }};
+        }
+
+        const member = field2go(destination.field); // Go struct field name
+        const target = destination.oneof === undefined
+            ? {dot: ['message', member]}
+            : {dot: [`oneof${member}`, member]};
+
+        if (destination.part !== undefined) {
+            // Some builtins are scanned from more than one column, e.g.
+            //
+            //     intoAnyTypeUrl(&message.$Field), intoAnyValue(&message.$Field)
+            return {call: {
+                function: {
+                    type_url: 'intoAnyTypeUrl',
+                    value: 'intoAnyValue',
+                    latitude: 'intoLatLngLatitude',
+                    longitude: 'intoLatLngLongitude',
+                    currency_code: 'intoMoneyCurrencyCode',
+                    amount: 'intoMoneyAmount'
+                }[destination.part],
+                arguments: [{address: target}]
+            }};
+        }
+
+        return fieldDestinationExpression({
+            okraType: typeByField[destination.field],
+            target,
+            typePackageAlias
+        });
+    });
+
+    return {declarations, expressions};
 }
 
-// Utilities
-// =========
-// This section contains everything else. It's a mix of functions and constants
-// used throughout the other sections.
+// Return an object `{goType, expression, operators, zeroIsNull}` describing
+// how a query compares a field of the specified `okraType` with a value (see
+// `queryDeclarations`). `goType` is the Go type of the value, `expression` is
+// a Go AST expression for the value of the variable `value` as an input
+// parameter, and `operators` is an array of `[method, operator, description]`,
+// e.g. `['Lt', '<', 'is less than']`, of the comparisons that apply to the
+// field. `zeroIsNull` is whether the zero value is stored as null. Use the
+// specified `typePackageAlias` function to determine Go package, if
+// necessary.
+function queryValue({okraType, typePackageAlias}) {
+    const value = {symbol: 'value'};
+
+    // A proto3 `optional` field or a wrapper type is compared with a plain
+    // value (e.g. `string` rather than `*string`), which is never null.
+    const plainType = okraType.optional || {
+        '.google.protobuf.DoubleValue': {builtin: 'TYPE_DOUBLE'},
+        '.google.protobuf.FloatValue': {builtin: 'TYPE_FLOAT'},
+        '.google.protobuf.Int64Value': {builtin: 'TYPE_INT64'},
+        '.google.protobuf.UInt64Value': {builtin: 'TYPE_UINT64'},
+        '.google.protobuf.Int32Value': {builtin: 'TYPE_INT32'},
+        '.google.protobuf.UInt32Value': {builtin: 'TYPE_UINT32'},
+        '.google.protobuf.BoolValue': {builtin: 'TYPE_BOOL'},
+        '.google.protobuf.StringValue': {builtin: 'TYPE_STRING'},
+        '.google.protobuf.BytesValue': {builtin: 'TYPE_BYTES'}
+    }[okraType.builtin];
+
+    const comparedType = plainType || okraType;
+    const goType = type2go({okraType: comparedType, typePackageAlias});
+    const expression = plainType === undefined
+        ? inputExpression({okraType, expression: value})
+        : inputExpression({
+            okraType: {optional: plainType},
+            // An `optional bytes` is a slice rather than a pointer.
+            expression: plainType.builtin === 'TYPE_BYTES'
+                ? value
+                : {address: value}
+        });
+
+    const equality = [
+        ['Eq', '=', 'is equal to'],
+        ['Ne', '<>', 'is not equal to']
+    ];
+    const ordering = [
+        ['Lt', '<', 'is less than'],
+        ['Le', '<=', 'is at most'],
+        ['Gt', '>', 'is greater than'],
+        ['Ge', '>=', 'is at least']
+    ];
+    const temporal = [
+        ['Before', '<', 'is before'],
+        ['After', '>', 'is after']
+    ];
+
+    let operators;
+    if (comparedType.enum ||
+        ['TYPE_BOOL', 'TYPE_BYTES'].includes(comparedType.builtin)) {
+        operators = equality;
+    }
+    else if ([
+        '.google.protobuf.Timestamp',
+        '.google.type.Date',
+        '.google.type.TimeOfDay'
+    ].includes(comparedType.builtin)) {
+        operators = [...equality, ...ordering, ...temporal];
+    }
+    else {
+        operators = [...equality, ...ordering];
+    }
+
+    return {
+        goType,
+        expression,
+        operators,
+        zeroIsNull: plainType === undefined
+    };
+}
 
 // Return an AST expression that can be used as an argument to Rows.Scan to
 // scan into the specified `target` of the specified `okra` type. Use the
@@ -1330,10 +6077,64 @@ function fieldDestinationExpression({
                                         arguments: [{symbol: 'value'}]}}]}}}}]}};
     }
 
+    if (okraType.optional && okraType.optional.enum) {
+        const enumType = type2go({okraType: okraType.optional, typePackageAlias});
+        return {
+            // intoOptionalEnum(func(value *int32) { $target = (*$enumType)(value) })
+            call: {
+                function: 'intoOptionalEnum',
+                arguments: [{
+                    unaryOneLineCallback: {
+                        argument: {name: 'value', type: '*int32'},
+                        body: {
+                            assign: {
+                                left: [target],
+                                right: [{
+                                    call: {
+                                        function: `(*${enumType})`,
+                                        arguments: [{symbol: 'value'}]}}]}}}}]}};
+    }
+
+    if (okraType.optional) {
+        // A proto3 `optional` scalar is a pointer, except for `bytes`, where
+        // a nil slice means "unset." `intoBytes` already distinguishes null
+        // (nil) from empty (non-nil).
+        const functionName = ({
+            'TYPE_DOUBLE': 'intoOptionalFloat64',
+            'TYPE_FLOAT': 'intoOptionalFloat32',
+            'TYPE_INT64': 'intoOptionalInt64',
+            'TYPE_UINT64': 'intoOptionalUint64',
+            'TYPE_INT32': 'intoOptionalInt32',
+            'TYPE_UINT32': 'intoOptionalUint32',
+            'TYPE_BOOL': 'intoOptionalBool',
+            'TYPE_STRING': 'intoOptionalString',
+            'TYPE_BYTES': 'intoBytes'
+        }[okraType.optional.builtin]);
+
+        return {
+            // $functionName(&$target)
+            call: {
+                function: functionName,
+                arguments: [{address: target}]}};
+    }
+
+    if (okraType.json) {
+        // intoJSON(&$target)
+        return {
+            call: {
+                function: 'intoJSON',
+                arguments: [{address: target}]}};
+    }
+
     const functionName = ({
         // okra type -> name of function that scans into variables of that type
         '.google.protobuf.Timestamp': 'intoTimestamp',
+        '.google.protobuf.Duration': 'intoDuration',
+        '.google.protobuf.Struct': 'intoStruct',
+        '.google.protobuf.Value': 'intoValue',
+        '.google.protobuf.ListValue': 'intoListValue',
         '.google.type.Date': 'intoDate',
+        '.google.type.TimeOfDay': 'intoTimeOfDay',
         'TYPE_DOUBLE': 'intoFloat64',
         'TYPE_FLOAT': 'intoFloat32',
         'TYPE_INT64': 'intoInt64',
@@ -1342,7 +6143,16 @@ function fieldDestinationExpression({
         'TYPE_UINT32': 'intoUint32',
         'TYPE_BOOL': 'intoBool',
         'TYPE_STRING': 'intoString',
-        'TYPE_BYTES': 'intoBytes'
+        'TYPE_BYTES': 'intoBytes',
+        '.google.protobuf.DoubleValue': 'intoDoubleValue',
+        '.google.protobuf.FloatValue': 'intoFloatValue',
+        '.google.protobuf.Int64Value': 'intoInt64Value',
+        '.google.protobuf.UInt64Value': 'intoUInt64Value',
+        '.google.protobuf.Int32Value': 'intoInt32Value',
+        '.google.protobuf.UInt32Value': 'intoUInt32Value',
+        '.google.protobuf.BoolValue': 'intoBoolValue',
+        '.google.protobuf.StringValue': 'intoStringValue',
+        '.google.protobuf.BytesValue': 'intoBytesValue'
     }[okraType.builtin]);
 
     return {
@@ -1442,20 +6252,22 @@ function typeImports({types, options}) {
 // `beginTransaction` is an array of Go statements common to all CRUD
 // operations. It begins a database transaction and returns an error if that
 // fails. It also ends with a "spacer" to set it apart from whatever
-// statements might follow.
+// statements might follow. If `db` is already a transaction (supplied by the
+// caller), then the Go function `beginTransaction` (see `prerendered.js`)
+// returns a wrapper whose `Commit` and `Rollback` do nothing.
 //
-//     transaction, err = db.BeginTx(ctx, nil)
+//     transaction, err = beginTransaction(ctx, db)
 //     if err != nil {
 //         return
 //     }
 //
 const beginTransaction = Object.freeze([
-    // transaction, err = db.BeginTx(ctx, nil)
+    // transaction, err = beginTransaction(ctx, db)
     {assign: {
         left: ['transaction', 'err'],
         right: [{call: {
-            function: {dot: ['db', 'BeginTx']},
-            arguments: [{symbol: 'ctx'}, null]
+            function: 'beginTransaction',
+            arguments: [{symbol: 'ctx'}, {symbol: 'db'}]
         }}]
     }},
 
@@ -1496,10 +6308,37 @@ const commitTransactionAndReturn = Object.freeze([
     {return: []}
 ]);
 
+// Return an array of Go statements that performs the specified `statements`
+// only if the field named by the specified `condition` (of the form
+// `{included: String}`, and possibly `undefined`) is included in the current
+// CRUD operation, according to the specified `included` function. If there
+// is no `condition`, or if inclusion is hard-coded to true, then return
+// `statements` as is.
+function ifIncluded({condition, included, statements}) {
+    if (condition === undefined) {
+        return statements;
+    }
+
+    const expression = included(condition.included);
+    if (expression === true) {
+        return statements;
+    }
+
+    // if $included {
+    //     $statements
+    // }
+    return [{if: {
+        condition: expression,
+        body: statements
+    }}];
+}
+
 // Return an array of Go statements that set up local variables used to keep
 // track of which variables are "included" in the current CRUD operation. Use
-// the specified `variable` to register local variables.
-function inclusionBoilerplate(variable) {
+// the specified `variable` to register local variables. The members of each
+// oneof in the specified message `type` are included together, under the name
+// of the oneof, if any of them is included.
+function inclusionBoilerplate(variable, type) {
     // This variable is used to check whether a field is "included".
     variable({name: 'included', goType: 'map[string]bool'});
 
@@ -1541,10 +6380,105 @@ function inclusionBoilerplate(variable) {
             }}]
         }},
 
+        // for _, field := range []string{... members of the oneof ...} {
+        //     if included[field] {
+        //         included["$oneof"] = true
+        //     }
+        // }
+        ...Object.entries(oneofMembers(type)).map(([oneof, members]) => ({
+            rangeFor: {
+                variables: ['_', 'field'],
+                sequence: {sequenceLiteral: {
+                    type: '[]string',
+                    elements: members
+                }},
+                body: [{if: {
+                    condition: {index: {
+                        object: 'included',
+                        index: {symbol: 'field'}
+                    }},
+                    body: [{assign: {
+                        left: [{index: {
+                            object: 'included',
+                            index: oneof // the literal string, quoted
+                        }}],
+                        right: [true]
+                    }}]
+                }}]
+            }
+        })),
+
         {spacer: 1}
     ];
 }
 
+// Return an object that maps the name of each oneof in the specified message
+// `type` to an array of the names of the oneof's member fields.
+function oneofMembers(type) {
+    return type.fields
+        .filter(field => field.oneof !== undefined)
+        .reduce((byOneof, {name, oneof}) => {
+            byOneof[oneof] = [...(byOneof[oneof] || []), name];
+            return byOneof;
+        }, {});
+}
+
+// Return the Go type of the ID field of the message type having the specified
+// `typeName`, for use as the key of a Go map, e.g. `byID`. Use the specified
+// `types` and `typePackageAlias` to inspect the message type and name the Go
+// type. Throw an error mentioning the specified `funcName` if the ID cannot be
+// a map key, i.e. if it isn't compared by value in Go.
+function idMapKeyType({funcName, typeName, types, typePackageAlias}) {
+    const {idFieldName, fields} = types[typeName];
+    const idType = fields.find(({name}) => name === idFieldName).type;
+
+    // Slices and pointers to messages can't be compared by value.
+    if (!idType.enum && (
+        idType.builtin === undefined ||
+        idType.builtin === 'TYPE_BYTES' ||
+        idType.builtin.startsWith('.'))) {
+        throw Error(`Unable to generate ${funcName}, because the ID ` +
+            `field ${JSON.stringify(idFieldName)} of ${typeName} has ` +
+            `type ${JSON.stringify(idType)}, which cannot be compared ` +
+            `by value in Go.`);
+    }
+
+    return type2go({okraType: idType, typePackageAlias});
+}
+
+// Return an object that maps each field name of the message type of the
+// message-valued field having the specified `fieldName` to an okra type. Use
+// the specified `typeByField` and `types` to look up the message type.
+function childTypeByField({fieldName, typeByField, types}) {
+    const fieldType = typeByField[fieldName];
+    const childTypeName = (fieldType.array || fieldType).message;
+
+    return types[childTypeName].fields.reduce(
+        (byName, {name, type}) => Object.assign(byName, {[name]: type}),
+        {});
+}
+
+// Return a Go AST statement that adds each of the `messages` read by a
+// "read-rows" instruction to the `byID` map, keyed by the field having the
+// specified `idFieldName`. `byID` must already have been made. For example:
+//
+//     for _, message := range messages {
+//         byID[message.Id] = message
+//     }
+function mapMessagesByID(idFieldName) {
+    return {rangeFor: {
+        variables: ['_', 'message'],
+        sequence: {symbol: 'messages'},
+        body: [{assign: {
+            left: [{index: {
+                object: 'byID',
+                index: {dot: ['message', field2go(idFieldName)]}
+            }}],
+            right: [{symbol: 'message'}]
+        }}]
+    }};
+}
+
 // Return the Go struct field name that would be generated for the specified
 // `protoFieldName`. The convention for protobuf message fields is to use
 // lower_snake_case (but it's not enforced), while the generated Go code uses
@@ -1553,6 +6487,22 @@ function field2go(protoFieldName) {
     return names.normalize(protoFieldName, 'TitleCamelCase');
 }
 
+// Return the plural of the specified `noun`, e.g. for use in the name of a
+// function that operates on many messages. This follows the most common
+// English rules only, e.g. "BoyScout" → "BoyScouts", "Box" → "Boxes", and
+// "Party" → "Parties".
+function pluralize(noun) {
+    if (/(s|x|z|ch|sh)$/.test(noun)) {
+        return noun + 'es';
+    }
+    else if (/[^aeiouAEIOU]y$/.test(noun)) {
+        return noun.slice(0, -1) + 'ies';
+    }
+    else {
+        return noun + 's';
+    }
+}
+
 // Return the Go struct or enum type name that would be generated for the specified
 // `protoName`, where `protoName` is the name of a protobuf message or enum.
 function messageOrEnum2go(protoName) {
@@ -1570,9 +6520,15 @@ function messageOrEnum2go(protoName) {
 // - {builtin: "TYPE_STRING"} → "string"
 // - {builtin: "name"} → "string"
 // - {enum: "Foo"} → "pb.Foo" (or "pb7.Foo" depending on `typePackageAlias`)
+// - {message: "Bar"} → "*pb.Bar"
+// - {array: {message: "Bar"}} → "[]*pb.Bar"
+// - {json: "Bar"} → "*pb.Bar"
+// - {map: {key: {builtin: "TYPE_STRING"}, value: {enum: "Foo"}}} → "map[string]pb.Foo"
 // - {array: {builtin: "TYPE_INT64"}} → "[]int64"
 // - {builtin: ".google.protobuf.Timestamp"} → "*timestamp.Timestamp"
 // - {array: {builtin: ".google.type.Date"}} → "[]*date.Date"
+// - {optional: {builtin: "TYPE_STRING"}} → "*string"
+// - {builtin: ".google.protobuf.StringValue"} → "*wrappers.StringValue"
 //
 function type2go({
     // e.g. `{builtin: 'TYPE_STRING'}`, or `{array: {enum: '.Foo'}}`
@@ -1585,17 +6541,45 @@ function type2go({
         return `[]${type2go({okraType: okraType.array, typePackageAlias})}`;
     }
 
+    if (okraType.map) {
+        const [key, value] = [okraType.map.key, okraType.map.value].map(
+            okraType => type2go({okraType, typePackageAlias}));
+        return `map[${key}]${value}`;
+    }
+
+    if (okraType.optional) {
+        // protoc-gen-go represents an `optional` scalar as a pointer, except
+        // for `bytes`, which is already nillable.
+        const type = type2go({okraType: okraType.optional, typePackageAlias});
+        return okraType.optional.builtin === 'TYPE_BYTES' ? type : `*${type}`;
+    }
+
     if (okraType.enum) {
         const enumName = messageOrEnum2go(okraType.enum);
         const packageAlias = typePackageAlias(okraType.enum);
         return `${packageAlias}.${enumName}`;
     }
 
+    const messageTypeName = okraType.message || okraType.json;
+    if (messageTypeName) {
+        const messageName = messageOrEnum2go(messageTypeName);
+        const packageAlias = typePackageAlias(messageTypeName);
+        return `*${packageAlias}.${messageName}`;
+    }
+
     // See `builtin.tisch.js`.
     return {
         '.google.protobuf.Timestamp': '*timestamp.Timestamp',
+        '.google.protobuf.Duration': '*duration.Duration',
+        '.google.protobuf.Struct': '*structpb.Struct',
+        '.google.protobuf.Value': '*structpb.Value',
+        '.google.protobuf.ListValue': '*structpb.ListValue',
+        '.google.protobuf.Any': '*anypb.Any',
         '.google.protobuf.FieldMask': '*field_mask.FieldMask',
         '.google.type.Date': '*date.Date',
+        '.google.type.TimeOfDay': '*timeofday.TimeOfDay',
+        '.google.type.LatLng': '*latlng.LatLng',
+        '.google.type.Money': '*money.Money',
         'TYPE_DOUBLE': 'float64',
         'TYPE_FLOAT': 'float32',
         'TYPE_INT64': 'int64',
@@ -1604,10 +6588,55 @@ function type2go({
         'TYPE_UINT32': 'uint32',
         'TYPE_BOOL': 'bool',
         'TYPE_STRING': 'string',
-        'TYPE_BYTES': '[]byte'
+        'TYPE_BYTES': '[]byte',
+        '.google.protobuf.DoubleValue': '*wrappers.DoubleValue',
+        '.google.protobuf.FloatValue': '*wrappers.FloatValue',
+        '.google.protobuf.Int64Value': '*wrappers.Int64Value',
+        '.google.protobuf.UInt64Value': '*wrappers.UInt64Value',
+        '.google.protobuf.Int32Value': '*wrappers.Int32Value',
+        '.google.protobuf.UInt32Value': '*wrappers.UInt32Value',
+        '.google.protobuf.BoolValue': '*wrappers.BoolValue',
+        '.google.protobuf.StringValue': '*wrappers.StringValue',
+        '.google.protobuf.BytesValue': '*wrappers.BytesValue'
     }[okraType.builtin];
 }
 
+// `noErrors` is the default `errors` argument to `generate`. It classifies no
+// database error codes.
+const noErrors = Object.freeze({
+    'already-exists': [],
+    'foreign-key': [],
+    'conflict': [],
+    'invalid-argument': []
+});
+
+// Return a Go AST node declaring the `errorClasses` variable used by the
+// pre-rendered `classifyError` function. `errorClasses` maps each database
+// error code in the specified `errors` to the corresponding sentinel error,
+// e.g. `uint64(1062): ErrAlreadyExists`. Error numbers are `uint64` and
+// SQLSTATEs are strings, to match the values returned by `errorCode`.
+function varErrorClasses(errors) {
+    const sentinels = {
+        'already-exists': 'ErrAlreadyExists',
+        'foreign-key': 'ErrForeignKey',
+        'conflict': 'ErrConflict',
+        'invalid-argument': 'ErrInvalidArgument'
+    };
+
+    const entries = Object.entries(sentinels).map(([errorClass, sentinel]) =>
+        errors[errorClass].map(code => {
+            const key = typeof code === 'number' ?
+                `uint64(${code})` : JSON.stringify(code);
+            return `\t${key}: ${sentinel},\n`;
+        })).flat();
+
+    return {raw:
+`// errorClasses maps database error codes, as returned by errorCode, to the
+// classes of errors that CRUD operations can return.
+var errorClasses = map[interface{}]error{
+${entries.join('')}}`};
+}
+
 // `cleanupFunctions` associates Go variables with functions that "clean them
 // up." Each key in `cleanupFunctions` is a JSON-serialized name/type pair, and
 // the values of `cleanupFunctions` are Go AST nodes describing the body of a
@@ -1632,12 +6661,22 @@ function type2go({
 //
 // Note that the value is an array, even if it contains only one statement.
 const cleanupFunctions = {
-    // When an error occurrs, we want to rollback the current transaction.
+    // When an error occurrs, we want to classify it (see `classifyError` in
+    // `prerendered.js`) and rollback the current transaction.
     //
+    //     err = classifyError(err)
     //     if err != nil && transaction != nil {
     //         err = combineErrors(err, transaction.Rollback())
     //     }
-    [JSON.stringify(['transaction', '*sql.Tx'])]: [{
+    [JSON.stringify(['transaction', 'transactor'])]: [{
+        assign: {
+            left: ['err'],
+            right: [{call: {
+                function: 'classifyError',
+                arguments: [{symbol: 'err'}]
+            }}]
+        }
+    }, {
         if: {
             condition: {and: {
                 left: {notEqual: {left: {symbol: 'err'}, right: null}},
@@ -1692,9 +6731,11 @@ const cleanupFunctions = {
 // are associated with a cleanup function, a defer statement invoking the
 // cleanup function will be appended to the specified `statements`. Which
 // variables have cleanup functions is determined by the `cleanupFunctions`
-// global object.
-function variableAdder(variables) {
-    const alreadyDeclared = {};
+// global object. The optional `inScope` array names variables that are
+// already in scope, such as named results, and so are never added.
+function variableAdder(variables, inScope = []) {
+    const alreadyDeclared = Object.fromEntries(
+        inScope.map(name => [name, true]));
 
     return function({name, goType}) {
         if (name in alreadyDeclared) {
@@ -1721,6 +6762,223 @@ function variableAdder(variables) {
     };
 }
 
+// Return an array of statements that perform the specified CRUD
+// "exec-many-with-tuples" `instruction` in the context implied by the other
+// specified arguments.
+function performExecManyWithTuples({
+    // the "exec-many-with-tuples" CRUD instruction
+    instruction,
+
+    // object that maps a message field name to an okra type
+    typeByField,
+
+    // object that maps a type name to an okra type
+    types,
+
+    // function that registers a specified `variable({name, goType})` and returns `name`
+    variable,
+
+    // function that returns an expression for whether a field is included in the CRUD operation
+    included
+}) {
+    // Reminder of the shape of an "exec-many-with-tuples" instruction:
+    //
+    //    {
+    //        'instruction': 'exec-many-with-tuples',
+    //        'tuple': String,
+    //        'sql': String,
+    //        'parameters': [
+    //            or({'field': String},
+    //               {'index': String},
+    //               {'key': String},
+    //               childParameter), ...etc]
+    //    }
+
+    // At most one field may be array-like (array-valued, map-valued, a
+    // FieldMask, or message-valued). If there is one, then there's a tuple for each of its
+    // elements, rather than for each message. See the analogous code in
+    // `performExecWithTuples` and `performExecWithChildTuples`.
+    const arrayLikeFields = [...new Set(instruction.parameters
+        // A `{oneof: ...}` parameter doesn't refer to a field, so exclude it.
+        .filter(parameter => 'field' in parameter || !('oneof' in parameter))
+        .map(parameter =>
+            parameter.child || parameter.field || parameter.index || parameter.key)
+        .filter(fieldName => {
+            const parameterType = typeByField[fieldName];
+            return parameterType.array ||
+                parameterType.map ||
+                parameterType.message ||
+                parameterType.builtin === '.google.protobuf.FieldMask';
+        }))];
+
+    if (arrayLikeFields.length > 1) {
+        throw Error('Expected "exec-many-with-tuples" parameters to refer ' +
+            'to at most one array-related or FieldMask-related field, but ' +
+            'they refer to more: ' + JSON.stringify(arrayLikeFields));
+    }
+
+    const [arrayLikeField] = arrayLikeFields; // possibly `undefined`
+
+    // If the array-like field is message-valued, then its elements' fields
+    // are referred to by `child` parameters.
+    const arrayLikeType = typeByField[arrayLikeField];
+    const childFieldTypes =
+        arrayLikeType && (arrayLikeType.array || arrayLikeType).message
+            ? childTypeByField({fieldName: arrayLikeField, typeByField, types})
+            : undefined;
+
+    // Here's what we're going for:
+    //
+    //     batch = newTupleBatch(transaction, $sql, $tuple)
+    //     for _, message := range messages {
+    //         err = batch.add(ctx, $parameters...)
+    //         if err != nil {
+    //             return
+    //         }
+    //     }
+    //     err = batch.flush(ctx)
+    //     if err != nil {
+    //         return
+    //     }
+    //
+    // or, if there's an array-like field, the `batch.add` is within an inner
+    // loop:
+    //
+    //         for i, element := range message.$array {
+    //             err = batch.add(ctx, $parameters...)
+    //             if err != nil {
+    //                 return
+    //             }
+    //         }
+    //
+    // (with `key` instead of `i` if the array-like field is a map)
+    //
+    // or, if the array-like field is a message-valued field that is not
+    // repeated, the `batch.add` is within an `if message.$child != nil`.
+    //
+    // where `messages` is the slice of messages parameter of the enclosing
+    // func.
+
+    // The following code references this variable.
+    variable({name: 'batch', goType: '*tupleBatch'});
+
+    const addStatements = [
+        // err = batch.add(ctx, $parameters...)
+        {assign: {
+            left: ['err'],
+            right: [{call: {
+                function: {dot: ['batch', 'add']},
+                arguments: [
+                    {symbol: 'ctx'},
+                    ...instruction.parameters.map(parameter =>
+                        tupleParameter2expression({
+                            parameter,
+                            arrayLikeField,
+                            typeByField,
+                            childFieldTypes,
+                            elementParts: arrayLikeType && arrayLikeType.message
+                                // a non-repeated message-valued field
+                                ? ['message', field2go(arrayLikeField)]
+                                : ['element'],
+                            included
+                        }))
+                ]
+            }}]
+        }},
+
+        // if err != nil {
+        //     return
+        // }
+        ifErrReturn
+    ];
+
+    let loopBody;
+    if (arrayLikeField === undefined) {
+        loopBody = addStatements;
+    }
+    else if (arrayLikeType.message) {
+        // if message.$child != nil {
+        //     ...
+        // }
+        loopBody = [{if: {
+            condition: {notEqual: {
+                left: {dot: ['message', field2go(arrayLikeField)]},
+                right: null
+            }},
+            body: addStatements
+        }}];
+    }
+    else {
+        // for i, element := range message.$array {
+        //     ...
+        // }
+        const rangeArgumentParts = arrayLikeType.builtin === undefined
+            ? ['message', field2go(arrayLikeField)] // e.g. message.Pets
+            : ['message', field2go(arrayLikeField), 'Paths']; // e.g. messages.MustHaves.Paths
+        loopBody = [{rangeFor: {
+            variables: [arrayLikeType.map ? 'key' : 'i', 'element'],
+            sequence: {dot: rangeArgumentParts},
+            body: addStatements
+        }}];
+
+        // A FieldMask is a pointer, so it might be nil, in which case it has
+        // no paths.
+        //
+        // if message.$mask != nil {
+        //     for i, element := range message.$mask.Paths {
+        //         ...
+        //     }
+        // }
+        if (arrayLikeType.builtin !== undefined) {
+            loopBody = [{if: {
+                condition: {notEqual: {
+                    left: {dot: ['message', field2go(arrayLikeField)]},
+                    right: null
+                }},
+                body: loopBody
+            }}];
+        }
+    }
+
+    return [
+        // batch = newTupleBatch(transaction, $sql, $tuple)
+        {assign: {
+            left: ['batch'],
+            right: [{call: {
+                function: 'newTupleBatch',
+                arguments: [
+                    {symbol: 'transaction'},
+                    instruction.sql,
+                    instruction.tuple
+                ]
+            }}]
+        }},
+
+        // for _, message := range messages {
+        //     ...
+        // }
+        {rangeFor: {
+            variables: ['_', 'message'],
+            sequence: {symbol: 'messages'},
+            body: loopBody
+        }},
+
+        // err = batch.flush(ctx)
+        {assign: {
+            left: ['err'],
+            right: [{call: {
+                function: {dot: ['batch', 'flush']},
+                arguments: [{symbol: 'ctx'}]
+            }}]
+        }},
+
+        // if err != nil {
+        //     return
+        // }
+        ifErrReturn
+    ];
+}
+
 // Return an a Go AST expression based on the specified `expression` of the
 // specified `okraType` that can appear as input parameters to database
 // methods like `Query` and `Exec`.
@@ -1745,7 +7003,56 @@ function inputExpression({okraType, expression}) {
         };
     }
 
-    // If it's not an enum, then it's a builtin. `uint64` needs special
+    if (okraType.optional && okraType.optional.enum) {
+        // As above, but the enum is a pointer, which must be cast to *int32.
+        return {
+            // fromOptionalInt32((*int32)($expression))
+            call: {
+                function: 'fromOptionalInt32',
+                arguments: [{
+                    call: {
+                        function: '(*int32)', // a conversion, as above
+                        arguments: [expression]
+                    }
+                }]
+            }
+        };
+    }
+
+    if (okraType.optional) {
+        // A proto3 `optional` scalar is null if and only if it's unset.
+        const functionName = ({
+            'TYPE_DOUBLE': 'fromOptionalFloat64',
+            'TYPE_FLOAT': 'fromOptionalFloat32',
+            'TYPE_INT64': 'fromOptionalInt64',
+            'TYPE_UINT64': 'fromOptionalUint64',
+            'TYPE_INT32': 'fromOptionalInt32',
+            'TYPE_UINT32': 'fromOptionalUint32',
+            'TYPE_BOOL': 'fromOptionalBool',
+            'TYPE_STRING': 'fromOptionalString',
+            'TYPE_BYTES': 'fromOptionalBytes'
+        }[okraType.optional.builtin]);
+
+        return {
+            // $functionName($expression)
+            call: {
+                function: functionName,
+                arguments: [expression]
+            }
+        };
+    }
+
+    if (okraType.json) {
+        // fromJSON($expression)
+        return {
+            call: {
+                function: 'fromJSON',
+                arguments: [expression]
+            }
+        };
+    }
+
+    // If it's not an enum or JSON, then it's a builtin. `uint64` needs special
     // treatment, because `uint64` is not a valid `driver.Value`, and so we
     // have to impose the limitation that input values of type `uint64` are
     // never null.
@@ -1759,7 +7066,12 @@ function inputExpression({okraType, expression}) {
         // okra type -> name of function that returns a Valuer for
         // variables of that type
         '.google.protobuf.Timestamp': 'fromTimestamp',
+        '.google.protobuf.Duration': 'fromDuration',
+        '.google.protobuf.Struct': 'fromStruct',
+        '.google.protobuf.Value': 'fromValue',
+        '.google.protobuf.ListValue': 'fromListValue',
         '.google.type.Date': 'fromDate',
+        '.google.type.TimeOfDay': 'fromTimeOfDay',
         'TYPE_DOUBLE': 'fromFloat64',
         'TYPE_FLOAT': 'fromFloat32',
         'TYPE_INT64': 'fromInt64',
@@ -1767,7 +7079,16 @@ function inputExpression({okraType, expression}) {
         'TYPE_UINT32': 'fromUint32',
         'TYPE_BOOL': 'fromBool',
         'TYPE_STRING': 'fromString',
-        'TYPE_BYTES': 'fromBytes'
+        'TYPE_BYTES': 'fromBytes',
+        '.google.protobuf.DoubleValue': 'fromDoubleValue',
+        '.google.protobuf.FloatValue': 'fromFloatValue',
+        '.google.protobuf.Int64Value': 'fromInt64Value',
+        '.google.protobuf.UInt64Value': 'fromUInt64Value',
+        '.google.protobuf.Int32Value': 'fromInt32Value',
+        '.google.protobuf.UInt32Value': 'fromUInt32Value',
+        '.google.protobuf.BoolValue': 'fromBoolValue',
+        '.google.protobuf.StringValue': 'fromStringValue',
+        '.google.protobuf.BytesValue': 'fromBytesValue'
     }[okraType.builtin]);
 
     return {
@@ -1779,16 +7100,159 @@ function inputExpression({okraType, expression}) {
     };
 }
 
+// Return an a Go AST expression for the specified `parameter` of an
+// "exec-with-tuples" or "exec-many-with-tuples" instruction. If the parameter
+// refers to the specified `arrayLikeField`, then it refers either to the
+// current `element` of the array or to its index `i` (or, for a map, to the
+// current `element` value or to its `key`). If the parameter is a
+// `child` parameter, then it refers to a field of the message at the
+// specified `elementParts` (e.g. `['element']`), whose field types are the
+// specified `childFieldTypes`. Otherwise, it's an ordinary parameter.
+function tupleParameter2expression({
+    parameter,
+    arrayLikeField,
+    typeByField,
+    childFieldTypes,
+    elementParts = ['element'],
+    included
+}) {
+    if ('child' in parameter) {
+        // a field of the child message, e.g. `element.GetFoo()`. The getter
+        // of a proto3 `optional` field hides whether it's set, so use the
+        // field itself instead, e.g. `element.Foo`.
+        const okraType = childFieldTypes[parameter.field];
+        const member = okraType.optional
+            ? {dot: [...elementParts, field2go(parameter.field)]}
+            : {call: {
+                function: {dot: [
+                    ...elementParts,
+                    `Get${field2go(parameter.field)}`
+                ]},
+                arguments: []
+            }};
+        return inputExpression({okraType, expression: member});
+    }
+    else if (arrayLikeField !== undefined && parameter.field === arrayLikeField) {
+        // the array element (or map value)
+        const arrayLikeType = typeByField[arrayLikeField];
+        return inputExpression({
+            okraType: arrayLikeType.array ||
+                (arrayLikeType.map && arrayLikeType.map.value) ||
+                {builtin: 'TYPE_STRING'},
+            expression: {symbol: 'element'}
+        });
+    }
+    else if (arrayLikeField !== undefined && parameter.key === arrayLikeField) {
+        // the map key, bound as is: the key column is not null, so a zero
+        // valued key (e.g. "" or 0) must not become null.
+        return {symbol: 'key'};
+    }
+    else if (arrayLikeField !== undefined && parameter.index === arrayLikeField) {
+        // the array index
+        return {symbol: 'i'};
+    }
+    else {
+        return inputParameter2expression({
+            parameter,
+            typeByField,
+            included
+        });
+    }
+}
+
 // Return an a Go AST expression for the specified `parameter` that can appear
 // as input parameters to database methods like `Query` and `Exec`. This code
 // is common to relevant CRUD instructions.
-function inputParameter2expression({parameter, typeByField, included}) {
-    if (parameter.field) {
+function inputParameter2expression({
+    parameter,
+    typeByField,
+    included,
+    page,
+    batch,
+    lookup,
+    history,
+    outbox
+}) {
+    if (parameter.field && parameter.oneof) {
+        // The member of a oneof isn't a field of the Go struct, but it has a
+        // getter. The getter returns a zero value if the member isn't set, so
+        // check whether it's set.
+        //
+        //     fromOneofMember(message, "$field", $input(message.Get$Field()))
+        const okraType = typeByField[parameter.field]; // okra type
+        const getter = `Get${field2go(parameter.field)}`;
+        const expression = {call: {
+            function: {dot: ['message', getter]},
+            arguments: []
+        }};
+        return {call: {
+            function: 'fromOneofMember',
+            arguments: [
+                {symbol: 'message'},
+                parameter.field, // the literal string, quoted
+                inputExpression({okraType, expression})
+            ]
+        }};
+    }
+    else if (parameter.field && parameter.part) {
+        // Some builtins are stored in more than one column, e.g.
+        //
+        //     fromAnyTypeUrl(message.$Field), fromAnyValue(message.$Field)
+        return {call: {
+            function: {
+                type_url: 'fromAnyTypeUrl',
+                value: 'fromAnyValue',
+                latitude: 'fromLatLngLatitude',
+                longitude: 'fromLatLngLongitude',
+                currency_code: 'fromMoneyCurrencyCode',
+                amount: 'fromMoneyAmount'
+            }[parameter.part],
+            arguments: [{dot: ['message', field2go(parameter.field)]}]
+        }};
+    }
+    else if (parameter.field) {
         const okraType = typeByField[parameter.field]; // okra type
         const member = field2go(parameter.field); // Go struct field name
         const expression = {dot: ['message', member]};
         return inputExpression({okraType, expression});
     }
+    else if (parameter.oneof) {
+        // the name of the member that is set, if any
+        //
+        //     fromOneof(message, "$oneof")
+        return {call: {
+            function: 'fromOneof',
+            arguments: [
+                {symbol: 'message'},
+                parameter.oneof // the literal string, quoted
+            ]
+        }};
+    }
+    else if (parameter.page) {
+        // We're referring to one of the bounds of the page being read by a
+        // "list" operation.
+        return page(parameter.page);
+    }
+    else if (parameter.batch) {
+        // We're referring to one of the IDs being read by a "read-many"
+        // operation.
+        return batch(parameter.batch);
+    }
+    else if (parameter.lookup) {
+        // We're referring to the value being looked up by a lookup
+        // operation.
+        return lookup(parameter.lookup);
+    }
+    else if (parameter.history) {
+        // We're referring to one of the values recorded in, or read from, the
+        // history of a message.
+        return history(parameter.history);
+    }
+    else if (parameter.outbox) {
+        // We're referring to one of the values of a change event inserted
+        // into the outbox.
+        return outbox(parameter.outbox);
+    }
     else {
         // Instead of referencing a field value, we're asking whether the
         // field is involved in the current operation.
@@ -1807,10 +7271,37 @@ function inputParameters2expressions({
     typeByField,
 
     // function that returns an expression for whether a field is included in the CRUD operation
-    included
+    included,
+
+    // function that returns an expression for a bound of the page in a "list" operation
+    page,
+
+    // function that returns an expression for an ID in a "read-many" operation
+    batch,
+
+    // function that returns an expression for the value looked up by a
+    // lookup operation
+    lookup,
+
+    // function that returns an expression for a value in a history
+    // instruction
+    history,
+
+    // function that returns an expression for a value in an outbox
+    // instruction
+    outbox
 }) {
     return parameters.map(parameter => 
-        inputParameter2expression({parameter, typeByField, included}));
+        inputParameter2expression({
+            parameter,
+            typeByField,
+            included,
+            page,
+            batch,
+            lookup,
+            history,
+            outbox
+        }));
 }
 
 // Return an array of statements that perform each of the specified
@@ -1823,6 +7314,10 @@ function performInstructions({
     // object that maps a message field name to an okra type
     typeByField,
 
+    // object that maps a type name to an okra type, for looking up the types
+    // of message-valued fields
+    types,
+
     // function that registers a specified `variable({name, goType})` and returns `name`
     // Note that that variables that are _assumed_ to be in scope, such as
     // `ctx`, `message`, `transaction`, and `err`, don't need to use this
@@ -1833,23 +7328,87 @@ function performInstructions({
     included,
 
     // function that maps message/enum type name to a Go package alias
-    typePackageAlias
+    typePackageAlias,
+
+    // function that returns an expression for a bound of the page in a "list"
+    // operation. Only "list" operations refer to pages, so by default this
+    // is an error.
+    page = function (bound) {
+        throw Error('Encountered an instruction that refers to the page ' +
+            'bound ' + JSON.stringify(bound) + ', but the current ' +
+            'operation does not read a page of messages.');
+    },
+
+    // function that returns an expression for an ID in a "read-many"
+    // operation. Only "read-many" operations refer to batches of IDs, so by
+    // default this is an error.
+    batch = function (what) {
+        throw Error('Encountered an instruction that refers to the batch ' +
+            'parameter ' + JSON.stringify(what) + ', but the current ' +
+            'operation does not read a batch of messages.');
+    },
+
+    // function that returns an expression for the value looked up by a
+    // lookup operation. Only lookup operations refer to such a value, so by
+    // default this is an error.
+    lookup = function (fieldName) {
+        throw Error('Encountered an instruction that refers to the lookup ' +
+            'value of the field ' + JSON.stringify(fieldName) + ', but the ' +
+            'current operation is not a lookup.');
+    },
+
+    // function that returns an expression for a value recorded in, or read
+    // from, the history of a message. Only the history instructions refer to
+    // such values, so by default this is an error.
+    history = function (what) {
+        throw Error('Encountered an instruction that refers to the history ' +
+            'parameter ' + JSON.stringify(what) + ', but the current ' +
+            'operation does not involve the history of a message.');
+    },
+
+    // function that returns an expression for a value of a change event
+    // inserted into the outbox. Only the outbox instructions refer to such
+    // values, so by default this is an error.
+    outbox = function (what) {
+        throw Error('Encountered an instruction that refers to the outbox ' +
+            'parameter ' + JSON.stringify(what) + ', but the current ' +
+            'operation does not publish a change to the outbox.');
+    },
+
+    // information about the message type needed by instructions that read
+    // messages, such as "read-row", "read-rows", and "read-keyed-array".
+    // See `funcList`.
+    messages
 }) {
     const handlerByName = {
         'query': performQuery,
+        'query-with-tuples': performQueryWithTuples,
+        'read-result': performReadResult,
         'read-row': performReadRow,
         'read-array': performReadArray,
+        'read-rows': performReadRows,
+        'read-keyed-array': performReadKeyedArray,
+        'read-child-rows': performReadChildRows,
+        'read-keyed-child-rows': performReadKeyedChildRows,
         'exec': performExec,
-        'exec-with-tuples': performExecWithTuples
+        'exec-with-tuples': performExecWithTuples,
+        'exec-many-with-tuples': performExecManyWithTuples
     };
 
     return instructions.map(instruction => {
         const statements = handlerByName[instruction.instruction]({
             instruction,
             typeByField,
+            types,
             variable,
             included,
-            typePackageAlias
+            typePackageAlias,
+            page,
+            batch,
+            lookup,
+            history,
+            outbox,
+            messages
         });
 
         // Append a blank line to separate from the next set of statements.
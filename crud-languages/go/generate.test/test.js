@@ -9,6 +9,7 @@ require('../../../dependencies/node-amd-loader/amd-loader');
 const {proto2types} = require('../../../lib/proto2types');
 const {types2tables} = require('../../../lib/types2tables');
 const {types2crud} = require('../../../sql-dialects/mysql5.6/types2crud');
+const {errors} = require('../../../sql-dialects/mysql5.6/errors');
 const {generate, generateUnrendered} = require('../generate');
 
 // proto2types for boyscouts → {types, options}
@@ -64,6 +65,29 @@ const crud = types2crud(
 const goFile = generateUnrendered({crud, types, options});
 // print(goFile);
 
-const goSource = generate({crud, types, options});
+const goSource = generate({crud, types, options, errors});
 // console.log(goSource);
 
+
+// Map keys are never null, so a zero-valued key (e.g. "" or 0) must be bound
+// as itself, rather than as null, or inserting it would violate the key
+// column's "not null" constraint.
+(function () {
+    const {types, options} = proto2types({
+        protoFiles: [__dirname + '/map-key.proto']
+    });
+    const {legends} = types2tables(types);
+    const crud = types2crud(
+        Object.fromEntries(
+            types.map(type => [type.name, {type, legend: legends[type.name]}])));
+    const goSource = generate({crud, types, options, errors});
+
+    // e.g. "fromString(key)" or "fromUint32(key)"
+    const nulled = goSource.match(/\bfrom[A-Za-z0-9]*\(key\)/);
+    if (nulled !== null) {
+        throw new Error(`map key is bound as ${nulled[0]}, which is null for a zero key`);
+    }
+    if (!/, key, /.test(goSource)) {
+        throw new Error('map key is not bound as itself');
+    }
+}());
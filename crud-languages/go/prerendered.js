@@ -25,10 +25,15 @@ define([], function () {
 //             'declarations': [
 //                 <declaration as defined in ast.tisch.js>,
 //                 ...etc
-//             ]
+//             ],
+//             'dependencies?': [<identifier of other pre-rendered code>, ...etc]
 //         }
 //     }
 //
+// The optional `dependencies` are the identifiers of other pre-rendered
+// sections that the declarations themselves use, and so must be included
+// whenever this section is included.
+//
 // The Go code is indented using tab characters, while this javascript code is
 // indented using four space characters. Please use tabs for the Go code and
 // spaces for the javascript code.
@@ -211,303 +216,2270 @@ func fromDate(source *date.Date) dateValuer {
         ]
     },
 
-    // If a query fails, we return the error. But first, we have to rollback
-    // the transaction. But _that_ can fail. So, if both the query and the
-    // rollback fail, we combine the two errors into one and return the
-    // resulting `CompositeError`. The generated code creates a
-    // `CompositeError` by calling the `combineErrors` function.
-    combineErrors: {
+    // A time of day is handled like a date (see `intoDate` and `fromDate`),
+    // except that the okra representation is a string formatted as
+    // "HH:MM:SS.ffffff" (microsecond precision).
+    intoTimeOfDay: {
         imports: {
-            'strings': null
+            "database/sql": null,
+            "fmt": null,
+            "strconv": null,
+            "strings": null,
+            "google.golang.org/genproto/googleapis/type/timeofday": null
         },
         declarations: [
             {raw:
-`// CompositeError is an error type that contains zero or more error types.
-type CompositeError []error`},
+`type timeOfDayScanner struct {
+	destination  **timeofday.TimeOfDay
+	intermediary sql.NullString // HH:MM:SS.ffffff
+}`
+            },
             {raw:
-`func (errs CompositeError) Error() string {
-	if len(errs) == 0 {
-		return ""
+`func (scanner timeOfDayScanner) Scan(value interface{}) error {
+	err := scanner.intermediary.Scan(value)
+	if err != nil {
+		return err
 	}
 
-	var builder strings.Builder
-	i := 0
-	builder.WriteString(errs[i].Error())
+	if !scanner.intermediary.Valid {
+		// "not valid" means null, which means nil
+		*scanner.destination = nil
+		return nil
+	}
 
-	for i++; i < len(errs); i++ {
-		builder.WriteString("\\n")
-		builder.WriteString(errs[i].Error())
+	timeString := scanner.intermediary.String
+	clock, fraction := timeString, ""
+	if dot := strings.IndexByte(timeString, '.'); dot != -1 {
+		clock, fraction = timeString[:dot], timeString[dot+1:]
 	}
 
-	return builder.String()
-}`
-            },
-            {raw:
-`func combineErrors(errs ...error) CompositeError {
-	var filtered []error
-	for _, err := range errs {
+	var result timeofday.TimeOfDay
+	n, err := fmt.Sscanf(clock, "%d:%d:%d", &result.Hours, &result.Minutes, &result.Seconds)
+	if err != nil {
+		return err
+	}
+	if n != 3 {
+		return fmt.Errorf(
+			"Failed to sscanf a time of day. Expected 3 fields but parsed only %d in string %s",
+			n,
+			timeString)
+	}
+
+	// The fraction is the leading digits of nine, e.g. ".5" is 500000000
+	// nanoseconds.
+	if fraction != "" {
+		nanos, err := strconv.ParseInt((fraction + "000000000")[:9], 10, 32)
 		if err != nil {
-			filtered = append(filtered, err)
+			return err
 		}
+		result.Nanos = int32(nanos)
 	}
 
-	return CompositeError(filtered)
+	*scanner.destination = &result
+	return nil
+}`
+            },
+            {raw:
+`// intoTimeOfDay is a constructor for timeOfDayScanner.
+func intoTimeOfDay(destination **timeofday.TimeOfDay) timeOfDayScanner {
+	return timeOfDayScanner{destination: destination}
 }`
             }
         ]
     },
 
-    withTuples: {
+    fromTimeOfDay: {
         imports: {
-            'strings': null
+            "database/sql/driver": null,
+            "fmt": null,
+            "google.golang.org/genproto/googleapis/type/timeofday": null
         },
         declarations: [
             {raw:
-`// withTuples returns a string consisting of the specified sqlStatement
-// followed by the specified numTuples copies of the specified sqlTuple
-// separated by commas and spaces. numTuples must be greater than zero.
-//
-// For example, the following invocation:
-//
-//     withTuples("insert into foobar(x, y) values", "(?, ?)", 3)
-//
-// returns the following string:
-//
-//     "insert into foobar(x, y) values(?, ?), (?, ?), (?, ?)"
-//
-func withTuples(sqlStatement string, sqlTuple string, numTuples int) string {
-	if numTuples < 1 {
-		panic(fmt.Sprintf("withTuples requires at least one tuple, but %d were specified",
-			numTuples))
-	}
-
-	var builder strings.Builder
-	builder.WriteString(sqlStatement)
-	i := 0
-	builder.WriteString(sqlTuple)
-	for i++; i < numTuples; i++ {
-		builder.WriteString(", ")
-		builder.WriteString(sqlTuple)
+`// timeOfDayValuer is a driver.Valuer that produces a string representation of
+// a timeofday.TimeOfDay.
+type timeOfDayValuer struct {
+	source *timeofday.TimeOfDay
+}`
+            },
+            {raw:
+`func (valuer timeOfDayValuer) Value() (driver.Value, error) {
+	if valuer.source == nil {
+		return nil, nil
 	}
 
-	return builder.String()
+	t := valuer.source // for brevity
+	timeString := fmt.Sprintf("%02d:%02d:%02d.%06d", t.Hours, t.Minutes, t.Seconds, t.Nanos/1000)
+	return driver.Value(timeString), nil
+}`
+            },
+            {raw:
+`// fromTimeOfDay is a constructor for timeOfDayValuer.
+func fromTimeOfDay(source *timeofday.TimeOfDay) timeOfDayValuer {
+	return timeOfDayValuer{source: source}
 }`
             }
         ]
     },
 
-    // A `field_mask.FieldMask` is treated as if it were a slice of strings,
-    // but it's not a slice of strings. It's a `struct` containing a single
-    // field that is a slice of strings. `fieldMaskLen` and `appendField` are
-    // like `len` and `append`, respectively, but deal with `FieldMask`
-    // objects rather than with `[]string` directly.
-    fieldMaskLen: {
+    // A duration is stored as a number of microseconds, like a timestamp.
+    // `intoDuration` and `fromDuration` convert between that and the protobuf
+    // representation (google.protobuf.Duration).
+    intoDuration: {
         imports: {
-            "google.golang.org/genproto/protobuf/field_mask": null
+            "database/sql": null,
+            "github.com/golang/protobuf/ptypes/duration": null
         },
         declarations: [
             {raw:
-`// fieldMaskLen returns the length of the slice of paths within the specified
-// field mask, or returns zero if the mask is nil.
-func fieldMaskLen(mask *field_mask.FieldMask) int {
-	if mask == nil {
-		return 0
-	}
-
-	return len(mask.Paths)
+`type durationScanner struct {
+	destination  **duration.Duration
+	intermediary sql.NullInt64 // microseconds
 }`
-            }
-        ]
-    },
-    appendField: {
-        imports: {
-            "google.golang.org/genproto/protobuf/field_mask": null
-        },
-        declarations: [
+            },
             {raw:
-`// appendField adds the specified string to the end of the paths within the
-// specified field mask and returns the field mask. If the field mask is nil,
-// then a new field mask is first created.
-func appendField(mask *field_mask.FieldMask, fieldName string) *field_mask.FieldMask {
-	if mask == nil {
-		mask = &field_mask.FieldMask{}
+`func (scanner durationScanner) Scan(value interface{}) error {
+	err := scanner.intermediary.Scan(value)
+	if err != nil {
+		return err
 	}
 
-	mask.Paths = append(mask.Paths, fieldName)
-	return mask
+	if !scanner.intermediary.Valid {
+		// "not valid" means null, which means nil
+		*scanner.destination = nil
+		return nil
+	}
+
+	// Seconds and Nanos have the same sign, as does Go's remainder.
+	microseconds := scanner.intermediary.Int64
+	*scanner.destination = &duration.Duration{
+		Seconds: microseconds / 1_000_000,
+		Nanos:   int32(microseconds%1_000_000) * 1000,
+	}
+	return nil
+}`
+            },
+            {raw:
+`// intoDuration is a constructor for durationScanner.
+func intoDuration(destination **duration.Duration) durationScanner {
+	return durationScanner{destination: destination}
 }`
             }
         ]
     },
-    // It is helpful to distinguish "not found" errors from other kinds of
-    // errors. The `noRow` function returns an instance of an error type,
-    // `NoRow` that users can identify using a type switch.
-    noRow: {
+
+    fromDuration: {
         imports: {
-            "fmt": null
+            "database/sql/driver": null,
+            "github.com/golang/protobuf/ptypes/duration": null
         },
         declarations: [
-            {raw: 
-`// NoRow is the error that occurs when a row is expected from SQL but none is
-// available. This is "not found" for "read" operations.
-type NoRow struct{}`
-            },
             {raw:
-`// Error returns the error message associated with the NoRow error.
-func (NoRow) Error() string {
-	return "There is no corresponding row in the database."
+`// durationValuer is a driver.Valuer that produces a numeric representation of
+// a duration.Duration (number of microseconds).
+type durationValuer struct {
+	source *duration.Duration
 }`
             },
-            // It's silly to have a function that just returns `NoRow{}`, but we
-            // currently identify prerendered code snippets my the mentioning
-            // of certain functions (in this case, `noRow`). Rather than extend
-            // that idenfication to consider struct literals, we define this
-            // redundant function.
             {raw:
-`func noRow() NoRow {
-	return NoRow{}
+`func (valuer durationValuer) Value() (driver.Value, error) {
+	if valuer.source == nil {
+		return nil, nil
+	}
+
+	d := valuer.source // for brevity
+	var microseconds int64 = d.Seconds*1_000_000 + int64(d.Nanos)/1000
+
+	return driver.Value(microseconds), nil
 }`
-            }
-        ]
-    },
-    // `ignore()` is used to ignore results from SQL. In particular, it's used
-    // as part of the "are there any rows to update?" check done at the
-    // beginning of "update" CRUD operations.
-    ignore: {
-        imports: {},
-        declarations: [
+            },
             {raw:
-`// ignore returns an output parameter for use in sql.Rows.Scan. The returned
-// value accepts any SQL value and does nothing with it.
-func ignore() interface{} {
-	var dummy interface{}
-	var pointer interface{} = &dummy
-	return pointer
+`// fromDuration is a constructor for durationValuer.
+func fromDuration(source *duration.Duration) durationValuer {
+	return durationValuer{source: source}
 }`
             }
         ]
     },
-    // `intoUint64` has a special implementation, because there is no
-    // sql.NullUint64. You can pass a **uint64 to Rows.Scan, but then you need
-    // code after Scan returns to inspect the resulting *uint64. Instead, here
-    // I define a Scanner that scans into a sql.NullString and then parses a
-    // uint64 from the string. I don't know if this is portable. It works for
-    // the MySQL driver that currently interests me.
-    intoUint64: {
+
+    // When a message stored as JSON is an output parameter in SQL, such as
+    // when reading (getting) a message that has such a field, `intoJSON`
+    // wraps the conversion from the okra representation (protobuf JSON text)
+    // to the protobuf message. The scanner doesn't know the message type
+    // ahead of time, so it allocates one based on the type of `destination`.
+    intoJSON: {
         imports: {
             "database/sql": null,
-            "strconv": null
+            "reflect": null,
+            "google.golang.org/protobuf/encoding/protojson": null,
+            "google.golang.org/protobuf/proto": null
         },
         declarations: [
             {raw:
-`type uint64Scanner struct {
-	destination  *uint64
+`type jsonScanner struct {
+	destination  interface{} // pointer to a pointer to a message, e.g. **pb.Foo
 	intermediary sql.NullString
 }`
             },
             {raw:
-`func (scanner uint64Scanner) Scan(value interface{}) error {
-	if err := scanner.intermediary.Scan(value); err != nil {
+`func (scanner jsonScanner) Scan(value interface{}) error {
+	err := scanner.intermediary.Scan(value)
+	if err != nil {
 		return err
 	}
 
+	pointer := reflect.ValueOf(scanner.destination).Elem() // e.g. *pb.Foo
 	if !scanner.intermediary.Valid {
-		// !Valid -> null -> zero
-		*scanner.destination = 0
+		// "not valid" means null, which means nil
+		pointer.Set(reflect.Zero(pointer.Type()))
 		return nil
 	}
 
-	// parse a base-10 64-bit unsigned integer
-	parsedValue, err := strconv.ParseUint(scanner.intermediary.String, 10, 64)
+	message := reflect.New(pointer.Type().Elem())
+	err = protojson.Unmarshal(
+		[]byte(scanner.intermediary.String),
+		message.Interface().(proto.Message))
 	if err != nil {
 		return err
 	}
 
-	*scanner.destination = parsedValue
+	pointer.Set(message)
 	return nil
 }`
             },
             {raw:
-`// intoUint64 is a constructor for uint64Scanner.
-func intoUint64(destination *uint64) uint64Scanner {
-	return uint64Scanner{destination: destination}
-}`,
-            }
-        ]
-    },
-    // Note that there is no `fromUint64`, because `uint64` is not a valid
-    // `driver.Value` type.
-
-    // intoBytes is trivial: the sql package's default behavior does the right
-    // thing for `[]byte`. I define a wrapper function, even though one is not
-    // necessary, for consistency with the other types.
-    intoBytes: {
-        imports: {},
-        declarations: [
-            {raw:
-`// intoBytes returns its argument. This function is provided for
-// consistency with other output parameter types.
-func intoBytes(destination *[]byte) *[]byte {
-	return destination
+`// intoJSON is a constructor for jsonScanner. The specified destination must be
+// a pointer to a pointer to a protobuf message, e.g. **pb.Foo.
+func intoJSON(destination interface{}) jsonScanner {
+	return jsonScanner{destination: destination}
 }`
             }
         ]
     },
-    // fromBytes is special, because in order to determine the nullness of a
-    // `[]byte`, we have to use the `len` function.
-    fromBytes: {
+
+    // When a message stored as JSON is an input parameter in SQL, such as when
+    // updating a message that has such a field, `fromJSON` wraps the
+    // conversion from the protobuf message to the okra representation
+    // (protobuf JSON text).
+    fromJSON: {
         imports: {
             "database/sql/driver": null,
+            "google.golang.org/protobuf/encoding/protojson": null,
+            "google.golang.org/protobuf/proto": null
         },
         declarations: [
             {raw:
-`// bytesValuer is a driver.Valuer that produces []byte
-type bytesValuer struct {
-	source []byte
+`// jsonValuer is a driver.Valuer that produces the protobuf JSON representation
+// of a message.
+type jsonValuer struct {
+	source proto.Message
 }`
             },
             {raw:
-`func (valuer bytesValuer) Value() (driver.Value, error) {
-	if len(valuer.source) == 0 {
+`func (valuer jsonValuer) Value() (driver.Value, error) {
+	// A nil *pb.Foo is a non-nil proto.Message, but its reflection is invalid.
+	if valuer.source == nil || !valuer.source.ProtoReflect().IsValid() {
 		return nil, nil
 	}
 
-	return valuer.source, nil
+	data, err := protojson.Marshal(valuer.source)
+	if err != nil {
+		return nil, err
+	}
+
+	return driver.Value(string(data)), nil
 }`
             },
             {raw:
-`// fromBytes is a constructor for bytesValuer.
-func fromBytes(source []byte) bytesValuer {
-	return bytesValuer{source: source}
+`// fromJSON is a constructor for jsonValuer.
+func fromJSON(source proto.Message) jsonValuer {
+	return jsonValuer{source: source}
 }`
             }
         ]
     },
-    // intoEnum is special because there can be many enum types, each of which
-    // is just an int32. Rather than have a separate Scanner for each enum
-    // type, I define one that uses int32 and a function that assigns to the
-    // correct enum type. This way, the per-enum type information is
-    // encapsulated in a trivial anonymous function at the call site, e.g.
-    //
-    //     rows.Scan(intoEnum(func(value int32) {message.FavoriteColor = Color(value) })
-    //
-    intoEnum: {
+
+    // The anonymous JSON types google.protobuf.Struct, google.protobuf.Value,
+    // and google.protobuf.ListValue are stored as JSON, just like messages
+    // stored as JSON (see `intoJSON` and `fromJSON`). These functions exist
+    // so that the generated code can name the type involved.
+    intoStruct: {
         imports: {
-            'database/sql': null
+            "google.golang.org/protobuf/types/known/structpb": null
         },
         declarations: [
             {raw:
-`type enumScanner struct {
-	// flush assigns the specified int32 to the destination enum field.
-	// The idea is that enumScanner doesn't know about the underlying
-	// enum type. That information is encapsulated within flush.
-	flush        func(int32)
-	intermediary sql.NullInt64
+`// intoStruct returns a jsonScanner into the specified destination.
+func intoStruct(destination **structpb.Struct) jsonScanner {
+	return intoJSON(destination)
 }`
-            },
-            {raw:
-`func (scanner enumScanner) Scan(value interface{}) error {
-	if err := scanner.intermediary.Scan(value); err != nil {
+            }
+        ],
+        dependencies: ['intoJSON']
+    },
+    fromStruct: {
+        imports: {
+            "google.golang.org/protobuf/types/known/structpb": null
+        },
+        declarations: [
+            {raw:
+`// fromStruct returns a jsonValuer of the specified source.
+func fromStruct(source *structpb.Struct) jsonValuer {
+	return fromJSON(source)
+}`
+            }
+        ],
+        dependencies: ['fromJSON']
+    },
+    intoValue: {
+        imports: {
+            "google.golang.org/protobuf/types/known/structpb": null
+        },
+        declarations: [
+            {raw:
+`// intoValue returns a jsonScanner into the specified destination.
+func intoValue(destination **structpb.Value) jsonScanner {
+	return intoJSON(destination)
+}`
+            }
+        ],
+        dependencies: ['intoJSON']
+    },
+    fromValue: {
+        imports: {
+            "google.golang.org/protobuf/types/known/structpb": null
+        },
+        declarations: [
+            {raw:
+`// fromValue returns a jsonValuer of the specified source.
+func fromValue(source *structpb.Value) jsonValuer {
+	return fromJSON(source)
+}`
+            }
+        ],
+        dependencies: ['fromJSON']
+    },
+    intoListValue: {
+        imports: {
+            "google.golang.org/protobuf/types/known/structpb": null
+        },
+        declarations: [
+            {raw:
+`// intoListValue returns a jsonScanner into the specified destination.
+func intoListValue(destination **structpb.ListValue) jsonScanner {
+	return intoJSON(destination)
+}`
+            }
+        ],
+        dependencies: ['intoJSON']
+    },
+    fromListValue: {
+        imports: {
+            "google.golang.org/protobuf/types/known/structpb": null
+        },
+        declarations: [
+            {raw:
+`// fromListValue returns a jsonValuer of the specified source.
+func fromListValue(source *structpb.ListValue) jsonValuer {
+	return fromJSON(source)
+}`
+            }
+        ],
+        dependencies: ['fromJSON']
+    },
+
+    // A google.protobuf.Any is stored in two columns: one for its type URL and
+    // one for its serialized value. Rows.Scan scans columns in order, and
+    // the type URL column comes first, so `intoAnyTypeUrl` allocates the
+    // destination (or sets it to nil), and then `intoAnyValue` fills in the
+    // value (if there's a destination to fill).
+    intoAnyTypeUrl: {
+        imports: {
+            "database/sql": null,
+            "google.golang.org/protobuf/types/known/anypb": null
+        },
+        declarations: [
+            {raw:
+`type anyTypeUrlScanner struct {
+	destination  **anypb.Any
+	intermediary sql.NullString
+}`
+            },
+            {raw:
+`func (scanner anyTypeUrlScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	if !scanner.intermediary.Valid {
+		*scanner.destination = nil
+		return nil
+	}
+
+	*scanner.destination = &anypb.Any{TypeUrl: scanner.intermediary.String}
+	return nil
+}`
+            },
+            {raw:
+`// intoAnyTypeUrl is a constructor for anyTypeUrlScanner.
+func intoAnyTypeUrl(destination **anypb.Any) anyTypeUrlScanner {
+	return anyTypeUrlScanner{destination: destination}
+}`
+            }
+        ]
+    },
+    intoAnyValue: {
+        imports: {
+            "database/sql": null,
+            "google.golang.org/protobuf/types/known/anypb": null
+        },
+        declarations: [
+            {raw:
+`type anyValueScanner struct {
+	destination  **anypb.Any
+	intermediary sql.NullString
+}`
+            },
+            {raw:
+`func (scanner anyValueScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	// The destination is nil if the type URL was null.
+	if *scanner.destination != nil && scanner.intermediary.Valid {
+		(*scanner.destination).Value = []byte(scanner.intermediary.String)
+	}
+
+	return nil
+}`
+            },
+            {raw:
+`// intoAnyValue is a constructor for anyValueScanner.
+func intoAnyValue(destination **anypb.Any) anyValueScanner {
+	return anyValueScanner{destination: destination}
+}`
+            }
+        ]
+    },
+    fromAnyTypeUrl: {
+        imports: {
+            "google.golang.org/protobuf/types/known/anypb": null
+        },
+        declarations: [
+            {raw:
+`// fromAnyTypeUrl returns the type URL of the specified source, or returns nil
+// if source is nil.
+func fromAnyTypeUrl(source *anypb.Any) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	return source.TypeUrl
+}`
+            }
+        ]
+    },
+    fromAnyValue: {
+        imports: {
+            "google.golang.org/protobuf/types/known/anypb": null
+        },
+        declarations: [
+            {raw:
+`// fromAnyValue returns the serialized value of the specified source, or
+// returns nil if source is nil.
+func fromAnyValue(source *anypb.Any) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	// An empty value is not the same as null.
+	return append([]byte{}, source.Value...)
+}`
+            }
+        ]
+    },
+
+    // A google.type.LatLng is stored in two columns, latitude and longitude,
+    // and is scanned like a google.protobuf.Any (see `intoAnyTypeUrl`).
+    intoLatLngLatitude: {
+        imports: {
+            "database/sql": null,
+            "google.golang.org/genproto/googleapis/type/latlng": null
+        },
+        declarations: [
+            {raw:
+`type latLngLatitudeScanner struct {
+	destination  **latlng.LatLng
+	intermediary sql.NullFloat64
+}`
+            },
+            {raw:
+`func (scanner latLngLatitudeScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	if !scanner.intermediary.Valid {
+		*scanner.destination = nil
+		return nil
+	}
+
+	*scanner.destination = &latlng.LatLng{Latitude: scanner.intermediary.Float64}
+	return nil
+}`
+            },
+            {raw:
+`// intoLatLngLatitude is a constructor for latLngLatitudeScanner.
+func intoLatLngLatitude(destination **latlng.LatLng) latLngLatitudeScanner {
+	return latLngLatitudeScanner{destination: destination}
+}`
+            }
+        ]
+    },
+    intoLatLngLongitude: {
+        imports: {
+            "database/sql": null,
+            "google.golang.org/genproto/googleapis/type/latlng": null
+        },
+        declarations: [
+            {raw:
+`type latLngLongitudeScanner struct {
+	destination  **latlng.LatLng
+	intermediary sql.NullFloat64
+}`
+            },
+            {raw:
+`func (scanner latLngLongitudeScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	// The destination is nil if the latitude was null.
+	if *scanner.destination != nil && scanner.intermediary.Valid {
+		(*scanner.destination).Longitude = scanner.intermediary.Float64
+	}
+
+	return nil
+}`
+            },
+            {raw:
+`// intoLatLngLongitude is a constructor for latLngLongitudeScanner.
+func intoLatLngLongitude(destination **latlng.LatLng) latLngLongitudeScanner {
+	return latLngLongitudeScanner{destination: destination}
+}`
+            }
+        ]
+    },
+    fromLatLngLatitude: {
+        imports: {
+            "google.golang.org/genproto/googleapis/type/latlng": null
+        },
+        declarations: [
+            {raw:
+`// fromLatLngLatitude returns the latitude of the specified source, or returns
+// nil if source is nil.
+func fromLatLngLatitude(source *latlng.LatLng) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	return source.Latitude
+}`
+            }
+        ]
+    },
+    fromLatLngLongitude: {
+        imports: {
+            "google.golang.org/genproto/googleapis/type/latlng": null
+        },
+        declarations: [
+            {raw:
+`// fromLatLngLongitude returns the longitude of the specified source, or
+// returns nil if source is nil.
+func fromLatLngLongitude(source *latlng.LatLng) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	return source.Longitude
+}`
+            }
+        ]
+    },
+
+    // A google.type.Money is stored in two columns, currency code and amount,
+    // and is scanned like a google.protobuf.Any (see `intoAnyTypeUrl`). The
+    // okra representation of the amount is a decimal string with up to nine
+    // digits after the decimal point, e.g. "-12.750000000".
+    intoMoneyCurrencyCode: {
+        imports: {
+            "database/sql": null,
+            "google.golang.org/genproto/googleapis/type/money": null
+        },
+        declarations: [
+            {raw:
+`type moneyCurrencyCodeScanner struct {
+	destination  **money.Money
+	intermediary sql.NullString
+}`
+            },
+            {raw:
+`func (scanner moneyCurrencyCodeScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	if !scanner.intermediary.Valid {
+		*scanner.destination = nil
+		return nil
+	}
+
+	*scanner.destination = &money.Money{CurrencyCode: scanner.intermediary.String}
+	return nil
+}`
+            },
+            {raw:
+`// intoMoneyCurrencyCode is a constructor for moneyCurrencyCodeScanner.
+func intoMoneyCurrencyCode(destination **money.Money) moneyCurrencyCodeScanner {
+	return moneyCurrencyCodeScanner{destination: destination}
+}`
+            }
+        ]
+    },
+    intoMoneyAmount: {
+        imports: {
+            "database/sql": null,
+            "strconv": null,
+            "strings": null,
+            "google.golang.org/genproto/googleapis/type/money": null
+        },
+        declarations: [
+            {raw:
+`type moneyAmountScanner struct {
+	destination  **money.Money
+	intermediary sql.NullString
+}`
+            },
+            {raw:
+`func (scanner moneyAmountScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	// The destination is nil if the currency code was null.
+	if *scanner.destination == nil || !scanner.intermediary.Valid {
+		return nil
+	}
+
+	amount := scanner.intermediary.String
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+	whole, fraction := amount, ""
+	if dot := strings.IndexByte(amount, '.'); dot != -1 {
+		whole, fraction = amount[:dot], amount[dot+1:]
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	// The fraction is the leading digits of nine, e.g. ".75" is 750000000
+	// nanos.
+	var nanos int64
+	if fraction != "" {
+		nanos, err = strconv.ParseInt((fraction + "000000000")[:9], 10, 32)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Units and nanos have the same sign.
+	if negative {
+		units, nanos = -units, -nanos
+	}
+
+	(*scanner.destination).Units = units
+	(*scanner.destination).Nanos = int32(nanos)
+	return nil
+}`
+            },
+            {raw:
+`// intoMoneyAmount is a constructor for moneyAmountScanner.
+func intoMoneyAmount(destination **money.Money) moneyAmountScanner {
+	return moneyAmountScanner{destination: destination}
+}`
+            }
+        ]
+    },
+    fromMoneyCurrencyCode: {
+        imports: {
+            "google.golang.org/genproto/googleapis/type/money": null
+        },
+        declarations: [
+            {raw:
+`// fromMoneyCurrencyCode returns the currency code of the specified source, or
+// returns nil if source is nil.
+func fromMoneyCurrencyCode(source *money.Money) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	return source.CurrencyCode
+}`
+            }
+        ]
+    },
+    fromMoneyAmount: {
+        imports: {
+            "fmt": null,
+            "google.golang.org/genproto/googleapis/type/money": null
+        },
+        declarations: [
+            {raw:
+`// fromMoneyAmount returns the amount of the specified source as a decimal
+// string, or returns nil if source is nil.
+func fromMoneyAmount(source *money.Money) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	// Units and nanos have the same sign.
+	units, nanos, sign := source.Units, source.Nanos, ""
+	if units < 0 || nanos < 0 {
+		units, nanos, sign = -units, -nanos, "-"
+	}
+
+	return fmt.Sprintf("%s%d.%09d", sign, units, nanos)
+}`
+            }
+        ]
+    },
+
+    // The members of a oneof are stored in separate columns, together with a
+    // column containing the name of the member that is set. When reading a
+    // message that has a oneof, each member is scanned into its own "wrapper"
+    // (e.g. *pb.Foo_Bar), and then `intoOneof` points the oneof field of the
+    // message at the wrapper of the member that is set.
+    intoOneof: {
+        imports: {
+            "database/sql": null,
+            "fmt": null,
+            "reflect": null
+        },
+        declarations: [
+            {raw:
+`type oneofScanner struct {
+	destination  interface{}            // pointer to a oneof field, e.g. &message.Bar
+	members      map[string]interface{} // wrapper by field name, e.g. *pb.Foo_Baz
+	intermediary sql.NullString
+}`
+            },
+            {raw:
+`func (scanner oneofScanner) Scan(value interface{}) error {
+	err := scanner.intermediary.Scan(value)
+	if err != nil {
+		return err
+	}
+
+	oneof := reflect.ValueOf(scanner.destination).Elem()
+	if !scanner.intermediary.Valid {
+		// "not valid" means null, which means that no member is set
+		oneof.Set(reflect.Zero(oneof.Type()))
+		return nil
+	}
+
+	member, ok := scanner.members[scanner.intermediary.String]
+	if !ok {
+		return fmt.Errorf("%q is not a member of the oneof", scanner.intermediary.String)
+	}
+
+	oneof.Set(reflect.ValueOf(member))
+	return nil
+}`
+            },
+            {raw:
+`// intoOneof is a constructor for oneofScanner. The specified destination
+// must be a pointer to the oneof field of a protobuf message, and the
+// specified members map the name of each member field to its wrapper.
+func intoOneof(destination interface{}, members map[string]interface{}) oneofScanner {
+	return oneofScanner{destination: destination, members: members}
+}`
+            }
+        ]
+    },
+
+    // When writing a message that has a oneof, `fromOneof` produces the name of
+    // the member that is set, and `fromOneofMember` produces the value of a
+    // member only if it's the member that is set.
+    fromOneof: {
+        imports: {
+            "google.golang.org/protobuf/proto": null,
+            "google.golang.org/protobuf/reflect/protoreflect": null
+        },
+        declarations: [
+            {raw:
+`// fromOneof returns the name of the member field that is set in the oneof
+// having the specified name in the specified message, or returns nil if no
+// member is set.
+func fromOneof(message proto.Message, oneof string) interface{} {
+	reflection := message.ProtoReflect()
+	descriptor := reflection.Descriptor().Oneofs().ByName(protoreflect.Name(oneof))
+	field := reflection.WhichOneof(descriptor)
+	if field == nil {
+		return nil
+	}
+	return string(field.Name())
+}`
+            }
+        ]
+    },
+
+    fromOneofMember: {
+        imports: {
+            "google.golang.org/protobuf/proto": null,
+            "google.golang.org/protobuf/reflect/protoreflect": null
+        },
+        declarations: [
+            {raw:
+`// fromOneofMember returns the specified value if the member field having the
+// specified name is set in the specified message, or returns nil otherwise.
+func fromOneofMember(message proto.Message, field string, value interface{}) interface{} {
+	reflection := message.ProtoReflect()
+	descriptor := reflection.Descriptor().Fields().ByName(protoreflect.Name(field))
+	if !reflection.Has(descriptor) {
+		return nil
+	}
+	return value
+}`
+            }
+        ]
+    },
+
+    // Each CRUD operation accepts a `Database`, which is satisfied by both
+    // `*sql.DB` and `*sql.Tx`. If the caller supplies a `*sql.DB`, then the
+    // operation begins, commits, and (on error) rolls back its own
+    // transaction. If the caller supplies a `*sql.Tx` (or anything else that
+    // can't begin a transaction), then the operation runs within it, and
+    // committing or rolling back is left to the caller. This way, several
+    // CRUD operations, and the caller's own SQL, can be combined atomically.
+    // So that an operation that fails doesn't leave part of its work in the
+    // caller's transaction, the operation sets a savepoint, which it releases
+    // or rolls back to in place of committing or rolling back.
+    // The generated code gets a `transactor` by calling `beginTransaction`.
+    beginTransaction: {
+        imports: {
+            "context": null,
+            "database/sql": null,
+            "fmt": null,
+            "sync/atomic": null
+        },
+        declarations: [
+            {raw:
+`// Database is the interface through which CRUD operations access the
+// database. Both *sql.DB and *sql.Tx satisfy Database. When a CRUD operation
+// is given a *sql.DB, it performs its work within a new transaction that it
+// commits before returning (or rolls back if an error occurs). When a CRUD
+// operation is given a *sql.Tx, it performs its work within that transaction,
+// and does not commit or roll back the transaction; that is left to the
+// caller. If the operation fails, then its work is rolled back to a savepoint
+// set when it began, so that the caller's transaction is as it was before the
+// operation, and can still be used.
+type Database interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}`
+            },
+            {raw:
+`// transactor is a Database that can be committed or rolled back.
+type transactor interface {
+	Database
+	Commit() error
+	Rollback() error
+}`
+            },
+            {raw:
+`// callerTransaction is a transactor wrapping a Database supplied by the
+// caller of a CRUD operation. The transaction belongs to the caller, so
+// committing callerTransaction releases the savepoint set when the operation
+// began, and rolling back callerTransaction rolls back to the savepoint.
+type callerTransaction struct {
+	Database
+	ctx       context.Context
+	savepoint string
+}`
+            },
+            {raw:
+`func (transaction callerTransaction) Commit() error {
+	_, err := transaction.ExecContext(transaction.ctx, "release savepoint "+transaction.savepoint)
+	return err
+}`
+            },
+            {raw:
+`// Rollback rolls back to the savepoint even if the operation's context is
+// canceled, so that the caller's transaction can still be used.
+func (transaction callerTransaction) Rollback() error {
+	_, err := transaction.ExecContext(context.Background(), "rollback to savepoint "+transaction.savepoint)
+	return err
+}`
+            },
+            {raw:
+`// savepoints is the number of savepoints set by CRUD operations, so that
+// each savepoint has a different name, even when one operation is performed
+// within another's transaction.
+var savepoints uint64`
+            },
+            {raw:
+`// beginTransaction returns a transactor for use by a CRUD operation. If the
+// specified db can begin a transaction (e.g. it's a *sql.DB), then begin one
+// subject to the specified cancellation context ctx. Otherwise (e.g. it's a
+// *sql.Tx), set a savepoint in db, and return a transactor that uses db and
+// the savepoint (see callerTransaction).
+func beginTransaction(ctx context.Context, db Database) (transactor, error) {
+	beginner, ok := db.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (*sql.Tx, error)
+	})
+	if !ok {
+		savepoint := fmt.Sprintf("okra_%d", atomic.AddUint64(&savepoints, 1))
+		_, err := db.ExecContext(ctx, "savepoint "+savepoint)
+		if err != nil {
+			return nil, err
+		}
+		return callerTransaction{db, ctx, savepoint}, nil
+	}
+
+	transaction, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		// Return an untyped nil, so that the caller's "transaction != nil"
+		// check is not fooled by a nil *sql.Tx.
+		return nil, err
+	}
+
+	return transaction, nil
+}`
+            }
+        ]
+    },
+
+    // If a query fails, we return the error. But first, we have to rollback
+    // the transaction. But _that_ can fail. So, if both the query and the
+    // rollback fail, we combine the two errors into one and return the
+    // resulting `CompositeError`. The generated code creates a
+    // `CompositeError` by calling the `combineErrors` function.
+    combineErrors: {
+        imports: {
+            'errors': null,
+            'strings': null
+        },
+        declarations: [
+            {raw:
+`// CompositeError is an error type that contains zero or more error types.
+type CompositeError []error`},
+            {raw:
+`func (errs CompositeError) Error() string {
+	if len(errs) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	i := 0
+	builder.WriteString(errs[i].Error())
+
+	for i++; i < len(errs); i++ {
+		builder.WriteString("\\n")
+		builder.WriteString(errs[i].Error())
+	}
+
+	return builder.String()
+}`
+            },
+            {raw:
+`// Unwrap returns the errors contained in errs.
+func (errs CompositeError) Unwrap() []error {
+	return errs
+}`
+            },
+            {raw:
+`// Is returns whether any of the errors contained in errs is target, as
+// determined by errors.Is. It allows errors.Is to inspect a CompositeError
+// even in versions of Go that do not support the multiple-error Unwrap.
+func (errs CompositeError) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}`
+            },
+            {raw:
+`// As finds the first of the errors contained in errs that matches target,
+// as determined by errors.As. It allows errors.As to inspect a
+// CompositeError even in versions of Go that do not support the
+// multiple-error Unwrap.
+func (errs CompositeError) As(target interface{}) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}`
+            },
+            {raw:
+`func combineErrors(errs ...error) CompositeError {
+	var filtered []error
+	for _, err := range errs {
+		if err != nil {
+			filtered = append(filtered, err)
+		}
+	}
+
+	return CompositeError(filtered)
+}`
+            }
+        ]
+    },
+
+    // Errors reported by the database are classified, so that callers can
+    // use `errors.Is` to distinguish, e.g., a duplicate key from a deadlock
+    // without knowing which database is in use. The classification of
+    // database-specific error codes, `errorClasses`, is generated separately,
+    // because it depends on the SQL dialect.
+    classifyError: {
+        imports: {
+            'errors': null,
+            'fmt': null,
+            'reflect': null
+        },
+        declarations: [
+            {raw:
+`// These are the classes of errors that CRUD operations can return. Use
+// errors.Is to check whether an error returned by a CRUD operation belongs
+// to a class.
+var (
+	// ErrNotFound means that the message to be read, updated, or deleted is
+	// not in the database. See NoRow.
+	ErrNotFound = errors.New("not found")
+
+	// ErrAlreadyExists means that the message to be created is already in the
+	// database.
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrForeignKey means that a message refers to something that is not in
+	// the database, such as an unknown enum value, or that something in the
+	// database refers to a message to be deleted.
+	ErrForeignKey = errors.New("foreign key violation")
+
+	// ErrConflict means that the operation conflicted with a concurrent
+	// operation, e.g. due to a deadlock. Retrying the operation might
+	// succeed.
+	ErrConflict = errors.New("conflict")
+
+	// ErrInvalidArgument means that an argument to the operation, such as a
+	// field of a message, is not acceptable to the database.
+	ErrInvalidArgument = errors.New("invalid argument")
+)`
+            },
+            {raw:
+`// Error is an error reported by the database that belongs to one of the
+// classes of errors, e.g. ErrAlreadyExists. errors.Is(err, Class) is true for
+// an *Error err, and errors.Unwrap(err) is the database driver's error.
+type Error struct {
+	Class error
+	Err   error
+}`
+            },
+            {raw:
+`func (err *Error) Error() string {
+	return fmt.Sprintf("%v: %v", err.Class, err.Err)
+}`
+            },
+            {raw:
+`// Is returns whether the specified target is the class of err.
+func (err *Error) Is(target error) bool {
+	return target == err.Class
+}`
+            },
+            {raw:
+`// Unwrap returns the database driver's error.
+func (err *Error) Unwrap() error {
+	return err.Err
+}`
+            },
+            {raw:
+`// classifyError returns an *Error wrapping the specified err if err, or an
+// error that it wraps, has a code in errorClasses. Otherwise, classifyError
+// returns err.
+func classifyError(err error) error {
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		class, ok := errorClasses[errorCode(cause)]
+		if ok {
+			return &Error{Class: class, Err: err}
+		}
+	}
+	return err
+}`
+            },
+            {raw:
+`// errorCode returns the code of the specified err, if err is an error from a
+// database driver, or returns nil otherwise. The code is either a uint64
+// vendor-specific error number or a string SQLSTATE. The types of the
+// database drivers' errors aren't known here, so errorCode looks for:
+//
+// - a method "Code() int", as in modernc.org/sqlite,
+// - a field "Number", as in github.com/go-sql-driver/mysql,
+// - a field "ExtendedCode", as in github.com/mattn/go-sqlite3, or
+// - a field "Code", as in github.com/lib/pq and github.com/jackc/pgconn.
+func errorCode(err error) interface{} {
+	value := reflect.ValueOf(err)
+	if method := value.MethodByName("Code"); method.IsValid() {
+		methodType := method.Type()
+		if methodType.NumIn() == 0 && methodType.NumOut() == 1 &&
+			methodType.Out(0).Kind() == reflect.Int {
+			return uint64(method.Call(nil)[0].Int())
+		}
+	}
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	number := value.FieldByName("Number")
+	switch number.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return number.Uint()
+	}
+
+	extendedCode := value.FieldByName("ExtendedCode")
+	if extendedCode.Kind() == reflect.Int {
+		return uint64(extendedCode.Int())
+	}
+
+	code := value.FieldByName("Code")
+	if code.Kind() == reflect.String {
+		return code.String()
+	}
+
+	return nil
+}`
+            }
+        ]
+    },
+
+    // An update's field mask names the fields to update. A name that isn't a
+    // field of the message is an error, rather than being ignored.
+    checkFieldMask: {
+        imports: {},
+        declarations: [
+            {raw:
+`// checkFieldMask returns an error of class ErrInvalidArgument if the
+// specified fieldMask contains a name that is not among the specified fields,
+// or returns nil otherwise.
+func checkFieldMask(fieldMask []string, fields ...string) error {
+	for _, name := range fieldMask {
+		found := false
+		for _, field := range fields {
+			if name == field {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return invalidArgument("field mask contains %q, which is not a field of the message", name)
+		}
+	}
+
+	return nil
+}`
+            }
+        ],
+        dependencies: ['invalidArgument']
+    },
+
+    invalidArgument: {
+        imports: {
+            'fmt': null
+        },
+        declarations: [
+            {raw:
+`// invalidArgument returns an *Error of class ErrInvalidArgument whose message
+// is formatted from the specified format and args, as by fmt.Errorf.
+func invalidArgument(format string, args ...interface{}) error {
+	return &Error{Class: ErrInvalidArgument, Err: fmt.Errorf(format, args...)}
+}`
+            }
+        ],
+        dependencies: ['classifyError']
+    },
+
+    // The "list" functions return a page of messages together with an opaque
+    // token that identifies the next page. The token is the ID of the last
+    // message in the page, serialized as JSON and then encoded as URL-safe
+    // base64. `encodePageToken` produces a token, and `decodePageToken`
+    // consumes one.
+    encodePageToken: {
+        imports: {
+            'encoding/base64': null,
+            'encoding/json': null
+        },
+        declarations: [
+            {raw:
+`// encodePageToken returns an opaque string that identifies the specified id
+// as the last message of a page.
+func encodePageToken(id interface{}) (string, error) {
+	data, err := json.Marshal(id)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}`
+            }
+        ]
+    },
+
+    decodePageToken: {
+        imports: {
+            'encoding/base64': null,
+            'encoding/json': null
+        },
+        declarations: [
+            {raw:
+`// decodePageToken parses the specified token, as produced by encodePageToken,
+// into the specified id, which must be a pointer.
+func decodePageToken(token string, id interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return invalidArgument("invalid page token %q: %w", token, err)
+	}
+
+	err = json.Unmarshal(data, id)
+	if err != nil {
+		return invalidArgument("invalid page token %q: %w", token, err)
+	}
+
+	return nil
+}`
+            }
+        ],
+        dependencies: ['invalidArgument']
+    },
+
+    withTuples: {
+        imports: {
+            'fmt': null,
+            'strconv': null,
+            'strings': null
+        },
+        declarations: [
+            {raw:
+`// withTuples returns a string consisting of the specified sqlStatement
+// followed by the specified numTuples copies of the specified sqlTuple
+// separated by commas and spaces. numTuples must be greater than zero.
+//
+// For example, the following invocation:
+//
+//     withTuples("insert into foobar(x, y) values", "(?, ?)", 3)
+//
+// returns the following string:
+//
+//     "insert into foobar(x, y) values(?, ?), (?, ?), (?, ?)"
+//
+// If sqlTuple contains numbered parameters, as in PostgreSQL, then the
+// parameters of each copy are numbered after those of the previous copy. For
+// example, the following invocation:
+//
+//     withTuples("insert into foobar(x, y) values", "($1, $2)", 3)
+//
+// returns the following string:
+//
+//     "insert into foobar(x, y) values($1, $2), ($3, $4), ($5, $6)"
+//
+func withTuples(sqlStatement string, sqlTuple string, numTuples int) string {
+	if numTuples < 1 {
+		panic(fmt.Sprintf("withTuples requires at least one tuple, but %d were specified",
+			numTuples))
+	}
+
+	numParameters := maxParameterNumber(sqlTuple)
+	var builder strings.Builder
+	builder.WriteString(sqlStatement)
+	i := 0
+	builder.WriteString(sqlTuple)
+	for i++; i < numTuples; i++ {
+		builder.WriteString(", ")
+		writeRenumbered(&builder, sqlTuple, i*numParameters)
+	}
+
+	return builder.String()
+}`
+            },
+            {raw:
+`// maxParameterNumber returns the largest n among the numbered parameters
+// "$n" in the specified sqlFragment, or returns zero if there are none.
+func maxParameterNumber(sqlFragment string) int {
+	max := 0
+	for _, field := range strings.Split(sqlFragment, "$")[1:] {
+		end := 0
+		for end < len(field) && '0' <= field[end] && field[end] <= '9' {
+			end++
+		}
+		number, err := strconv.Atoi(field[:end])
+		if err == nil && number > max {
+			max = number
+		}
+	}
+	return max
+}`
+            },
+            {raw:
+`// writeRenumbered writes the specified sqlFragment to the specified builder,
+// adding the specified offset to the number n of each numbered parameter "$n"
+// in sqlFragment.
+func writeRenumbered(builder *strings.Builder, sqlFragment string, offset int) {
+	fields := strings.Split(sqlFragment, "$")
+	builder.WriteString(fields[0])
+	for _, field := range fields[1:] {
+		builder.WriteString("$")
+		end := 0
+		for end < len(field) && '0' <= field[end] && field[end] <= '9' {
+			end++
+		}
+		number, err := strconv.Atoi(field[:end])
+		if err != nil {
+			builder.WriteString(field)
+			continue
+		}
+		builder.WriteString(strconv.Itoa(number + offset))
+		builder.WriteString(field[end:])
+	}
+}`
+            }
+        ]
+    },
+
+    // Query builders, e.g. `BoyScoutQuery()`, build the "where" clause of a
+    // query from conditions on columns. `compareColumn` produces a condition,
+    // and `buildQuery` combines the conditions, orderings, and limit of a
+    // query with the SQL that selects the IDs of the messages. Similarly,
+    // `buildCount` combines conditions with the SQL that counts messages.
+    compareColumn: {
+        imports: {
+            'database/sql/driver': null,
+            'strings': null
+        },
+        declarations: [
+            {raw:
+`// condition is a boolean SQL expression on the columns of a message table,
+// together with its parameters. If the SQL contains numbered parameters, as
+// in PostgreSQL, then they're numbered as if the condition were the entire
+// statement, i.e. beginning with "$1". If the condition could not be created,
+// then err is not nil.
+type condition struct {
+	sql        string
+	parameters []interface{}
+	err        error
+}`
+            },
+            {raw:
+`// compareColumn returns a condition that compares the specified column with
+// the specified value using the specified operator, e.g. "=" or "<". The
+// specified parameter is how the value appears in SQL, e.g. "?" or "$1".
+//
+// The zero value of an ordinary scalar field is stored as null, so if value
+// is null, then "=" checks that the column is null, and "<>" checks that the
+// column is not null. Conversely, "<>" with a value that is not null is true
+// also when the column is null. Other operators are never true for null.
+func compareColumn(column string, operator string, parameter string, value interface{}) condition {
+	var driverValue driver.Value = value
+	if valuer, ok := value.(driver.Valuer); ok {
+		var err error
+		driverValue, err = valuer.Value()
+		if err != nil {
+			return condition{err: err}
+		}
+	}
+
+	if driverValue == nil {
+		switch operator {
+		case "=":
+			return columnIsNull(column)
+		case "<>":
+			return columnIsNotNull(column)
+		}
+	} else if operator == "<>" {
+		return condition{
+			sql:        "(" + column + " <> " + parameter + " or " + column + " is null)",
+			parameters: []interface{}{value}}
+	}
+
+	return condition{
+		sql:        column + " " + operator + " " + parameter,
+		parameters: []interface{}{value}}
+}`
+            },
+            {raw:
+`// columnIsNull returns a condition that is true when the specified column is
+// null.
+func columnIsNull(column string) condition {
+	return condition{sql: column + " is null"}
+}`
+            },
+            {raw:
+`// columnIsNotNull returns a condition that is true when the specified column
+// is not null.
+func columnIsNotNull(column string) condition {
+	return condition{sql: column + " is not null"}
+}`
+            },
+            {raw:
+`// orConditions returns a condition that is true when either of the specified
+// left or right conditions is true.
+func orConditions(left condition, right condition) condition {
+	if left.err != nil {
+		return left
+	}
+	if right.err != nil {
+		return right
+	}
+
+	var builder strings.Builder
+	builder.WriteString("(")
+	builder.WriteString(left.sql)
+	builder.WriteString(" or ")
+	writeRenumbered(&builder, right.sql, len(left.parameters))
+	builder.WriteString(")")
+
+	var parameters []interface{}
+	parameters = append(parameters, left.parameters...)
+	parameters = append(parameters, right.parameters...)
+	return condition{sql: builder.String(), parameters: parameters}
+}`
+            }
+        ],
+        dependencies: ['withTuples']
+    },
+
+    writeWhere: {
+        imports: {
+            'strings': null
+        },
+        declarations: [
+            {raw:
+`// writeWhere writes to the specified sqlText a "where" clause requiring all
+// of the specified conditions, if there are any, preceded by a space. The
+// parameters of the conditions are renumbered, if necessary, so that they
+// follow one another. Return the parameters of the conditions, or return a
+// non-nil error if any of the conditions could not be created.
+func writeWhere(sqlText *strings.Builder, conditions []condition) ([]interface{}, error) {
+	var parameters []interface{}
+	for i, where := range conditions {
+		if where.err != nil {
+			return nil, where.err
+		}
+		if i == 0 {
+			sqlText.WriteString(" where ")
+		} else {
+			sqlText.WriteString(" and ")
+		}
+		writeRenumbered(sqlText, where.sql, len(parameters))
+		parameters = append(parameters, where.parameters...)
+	}
+
+	return parameters, nil
+}`
+            }
+        ],
+        dependencies: ['compareColumn']
+    },
+
+    buildCount: {
+        imports: {
+            'strings': null
+        },
+        declarations: [
+            {raw:
+`// buildCount returns the SQL, and its parameters, of a query that counts the
+// messages satisfying all of the specified conditions. The specified
+// selectCount counts all of the messages, e.g.
+// "select count(*) from boy_scout". Return a non-nil error if any of the
+// conditions could not be created.
+func buildCount(selectCount string, conditions []condition) (string, []interface{}, error) {
+	var sqlText strings.Builder
+	sqlText.WriteString(selectCount)
+	parameters, err := writeWhere(&sqlText, conditions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sqlText.WriteString(";")
+	return sqlText.String(), parameters, nil
+}`
+            }
+        ],
+        dependencies: ['writeWhere']
+    },
+
+    buildQuery: {
+        imports: {
+            'strconv': null,
+            'strings': null
+        },
+        declarations: [
+            {raw:
+`// queryBuilder is the state of a query builder, e.g. BoyScoutQuery(). All
+// of the conditions must be true of a message for the query to select it.
+// The messages are ordered by the orderings, e.g. "name desc", and then by
+// ID. If limit is positive, then at most that many messages are selected.
+type queryBuilder struct {
+	conditions []condition
+	orderings  []string
+	limit      int
+}`
+            },
+            {raw:
+`// buildQuery returns the SQL, and its parameters, of the query described by
+// the specified builder. The specified selectIDs selects the IDs of all of
+// the messages, e.g. "select id from boy_scout", and the specified key is
+// the ID column, e.g. "id". Return a non-nil error if any of the builder's
+// conditions could not be created.
+func buildQuery(builder queryBuilder, selectIDs string, key string) (string, []interface{}, error) {
+	var sqlText strings.Builder
+	sqlText.WriteString(selectIDs)
+	parameters, err := writeWhere(&sqlText, builder.conditions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sqlText.WriteString(" order by ")
+	for _, ordering := range builder.orderings {
+		sqlText.WriteString(ordering)
+		sqlText.WriteString(", ")
+	}
+	sqlText.WriteString(key)
+
+	if builder.limit > 0 {
+		sqlText.WriteString(" limit ")
+		sqlText.WriteString(strconv.Itoa(builder.limit))
+	}
+
+	sqlText.WriteString(";")
+	return sqlText.String(), parameters, nil
+}`
+            }
+        ],
+        dependencies: ['writeWhere']
+    },
+
+    // Operations on many messages at once, such as "create-many," insert the
+    // rows of many messages using one statement per table. Databases limit
+    // the size of a statement, so the rows are accumulated in a `tupleBatch`,
+    // which executes a statement whenever the next row would not fit.
+    newTupleBatch: {
+        imports: {
+            'context': null,
+            'database/sql/driver': null
+        },
+        declarations: [
+            {raw:
+`// MaxStatementBytes is the maximum estimated size, in bytes, of a SQL
+// statement executed by an operation on many messages at once, e.g. the
+// "create many" operation. Larger statements are divided into smaller ones.
+// MaxStatementBytes must be less than the database server's
+// max_allowed_packet, which is 4 MiB by default in MySQL 5.6.
+var MaxStatementBytes = 3 << 20`
+            },
+            {raw:
+`// maxStatementParameters is the maximum number of parameters that a prepared
+// SQL statement can have. MySQL and PostgreSQL allow 65535, but SQLite allows
+// only 32766 (since version 3.32.0).
+const maxStatementParameters = 32766`
+            },
+            {raw:
+`// tupleBatch accumulates copies of a SQL tuple, e.g. "(?, ?, ?)", to follow a
+// SQL statement, e.g. "insert into foobar(x, y, z) values". It executes the
+// statement whenever adding another tuple would exceed the limits on the
+// size of a statement.
+type tupleBatch struct {
+	transaction  transactor
+	sqlStatement string
+	sqlTuple     string
+	parameters   []interface{}
+	numTuples    int
+	numBytes     int
+}`
+            },
+            {raw:
+`// newTupleBatch returns a tupleBatch for the specified sqlStatement and
+// sqlTuple that executes statements using the specified transaction.
+func newTupleBatch(transaction transactor, sqlStatement string, sqlTuple string) *tupleBatch {
+	return &tupleBatch{
+		transaction:  transaction,
+		sqlStatement: sqlStatement,
+		sqlTuple:     sqlTuple,
+		numBytes:     len(sqlStatement)}
+}`
+            },
+            {raw:
+`// add appends to the batch a tuple having the specified parameters. If the
+// tuple would not fit in the batch, then first flush the batch.
+func (batch *tupleBatch) add(ctx context.Context, parameters ...interface{}) error {
+	numBytes := len(", ") + len(batch.sqlTuple)
+	for _, parameter := range parameters {
+		numBytes += parameterSize(parameter)
+	}
+
+	if batch.numTuples != 0 &&
+		(len(batch.parameters)+len(parameters) > maxStatementParameters ||
+			batch.numBytes+numBytes > MaxStatementBytes) {
+		err := batch.flush(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	batch.parameters = append(batch.parameters, parameters...)
+	batch.numTuples++
+	batch.numBytes += numBytes
+	return nil
+}`
+            },
+            {raw:
+`// flush executes the statement with the tuples in the batch, if any, and
+// then empties the batch.
+func (batch *tupleBatch) flush(ctx context.Context) error {
+	if batch.numTuples == 0 {
+		return nil
+	}
+
+	_, err := batch.transaction.ExecContext(
+		ctx,
+		withTuples(batch.sqlStatement, batch.sqlTuple, batch.numTuples),
+		batch.parameters...)
+
+	batch.parameters = nil
+	batch.numTuples = 0
+	batch.numBytes = len(batch.sqlStatement)
+	return err
+}`
+            },
+            {raw:
+`// parameterSize returns an estimate of the number of bytes occupied by the
+// specified SQL parameter when it is sent to the database.
+func parameterSize(parameter interface{}) int {
+	if valuer, ok := parameter.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err == nil {
+			parameter = value
+		}
+	}
+
+	// Each parameter is accompanied by its type and length.
+	const overhead = 16
+
+	switch value := parameter.(type) {
+	case string:
+		return overhead + len(value)
+	case []byte:
+		return overhead + len(value)
+	default:
+		return overhead + 8
+	}
+}`
+            }
+        ],
+        dependencies: ['beginTransaction', 'withTuples']
+    },
+
+    // A `field_mask.FieldMask` is treated as if it were a slice of strings,
+    // but it's not a slice of strings. It's a `struct` containing a single
+    // field that is a slice of strings. `fieldMaskLen` and `appendField` are
+    // like `len` and `append`, respectively, but deal with `FieldMask`
+    // objects rather than with `[]string` directly.
+    fieldMaskLen: {
+        imports: {
+            "google.golang.org/genproto/protobuf/field_mask": null
+        },
+        declarations: [
+            {raw:
+`// fieldMaskLen returns the length of the slice of paths within the specified
+// field mask, or returns zero if the mask is nil.
+func fieldMaskLen(mask *field_mask.FieldMask) int {
+	if mask == nil {
+		return 0
+	}
+
+	return len(mask.Paths)
+}`
+            }
+        ]
+    },
+    appendField: {
+        imports: {
+            "google.golang.org/genproto/protobuf/field_mask": null
+        },
+        declarations: [
+            {raw:
+`// appendField adds the specified string to the end of the paths within the
+// specified field mask and returns the field mask. If the field mask is nil,
+// then a new field mask is first created.
+func appendField(mask *field_mask.FieldMask, fieldName string) *field_mask.FieldMask {
+	if mask == nil {
+		mask = &field_mask.FieldMask{}
+	}
+
+	mask.Paths = append(mask.Paths, fieldName)
+	return mask
+}`
+            }
+        ]
+    },
+    // It is helpful to distinguish "not found" errors from other kinds of
+    // errors. The `noRow` function returns an instance of an error type,
+    // `NoRow` that users can identify using a type switch.
+    noRow: {
+        imports: {},
+        declarations: [
+            {raw: 
+`// NoRow is the error that occurs when a row is expected from SQL but none is
+// available. This is "not found" for "read" operations.
+type NoRow struct{}`
+            },
+            {raw:
+`// Error returns the error message associated with the NoRow error.
+func (NoRow) Error() string {
+	return "There is no corresponding row in the database."
+}`
+            },
+            {raw:
+`// Unwrap returns ErrNotFound, so that errors.Is(err, ErrNotFound) is true
+// for a NoRow error.
+func (NoRow) Unwrap() error {
+	return ErrNotFound
+}`
+            },
+            // It's silly to have a function that just returns `NoRow{}`, but we
+            // currently identify prerendered code snippets my the mentioning
+            // of certain functions (in this case, `noRow`). Rather than extend
+            // that idenfication to consider struct literals, we define this
+            // redundant function.
+            {raw:
+`func noRow() NoRow {
+	return NoRow{}
+}`
+            }
+        ],
+        dependencies: ['classifyError']
+    },
+    // An update of a message having a version field fails with a
+    // `VersionConflict` if the message in the database has a different
+    // version. As with `noRow`, the function exists so that the snippet is
+    // included when `versionConflict` is mentioned.
+    versionConflict: {
+        imports: {},
+        declarations: [
+            {raw:
+`// VersionConflict is the error that occurs when a message having a version
+// field is updated, but the version of the message in the database differs
+// from that of the updated message, i.e. the message was modified since it
+// was read. Read the message again before retrying the update.
+type VersionConflict struct{}`
+            },
+            {raw:
+`// Error returns the error message associated with the VersionConflict error.
+func (VersionConflict) Error() string {
+	return "The version of the message in the database differs from that of the update."
+}`
+            },
+            {raw:
+`// Unwrap returns ErrConflict, so that errors.Is(err, ErrConflict) is true
+// for a VersionConflict error.
+func (VersionConflict) Unwrap() error {
+	return ErrConflict
+}`
+            },
+            {raw:
+`func versionConflict() VersionConflict {
+	return VersionConflict{}
+}`
+            }
+        ],
+        dependencies: ['classifyError']
+    },
+    // A MySQL upsert of a message fails with a `UniqueViolation` if the
+    // message conflicts with a different message in a unique index. As with
+    // `noRow`, the function exists so that the snippet is included when
+    // `uniqueViolation` is mentioned.
+    uniqueViolation: {
+        imports: {},
+        declarations: [
+            {raw:
+`// UniqueViolation is the error that occurs when an upsert of a message
+// conflicts with a different message in a unique index, i.e. a field that
+// must be unique has the same value as in another message. The upsert is not
+// applied.
+type UniqueViolation struct{}`
+            },
+            {raw:
+`// Error returns the error message associated with the UniqueViolation error.
+func (UniqueViolation) Error() string {
+	return "A unique field of the message has the same value as in another message."
+}`
+            },
+            {raw:
+`// Unwrap returns ErrAlreadyExists, so that errors.Is(err, ErrAlreadyExists)
+// is true for a UniqueViolation error.
+func (UniqueViolation) Unwrap() error {
+	return ErrAlreadyExists
+}`
+            },
+            {raw:
+`func uniqueViolation() UniqueViolation {
+	return UniqueViolation{}
+}`
+            }
+        ],
+        dependencies: ['classifyError']
+    },
+    // The history of a message records who changed it, if known. The caller
+    // says who by attaching an actor to the context passed to a CRUD
+    // operation using `WithActor`, and the generated code retrieves it using
+    // `actorOf`.
+    actorOf: {
+        imports: {
+            'context': null
+        },
+        declarations: [
+            {raw:
+`// actorKey is the key of the actor in a context.Context (see WithActor).
+type actorKey struct{}`
+            },
+            {raw:
+`// WithActor returns a copy of the specified ctx that has the specified actor,
+// e.g. a user name. When a CRUD operation is given such a context, its changes
+// are attributed to the actor in the history of the changed messages.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}`
+            },
+            {raw:
+`// actorOf returns the actor of the specified ctx (see WithActor), or returns
+// nil if ctx has no actor. The result is suitable as an input parameter in
+// SQL.
+func actorOf(ctx context.Context) interface{} {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	if !ok {
+		return nil
+	}
+
+	return actor
+}`
+            }
+        ]
+    },
+    // The history of a message records the whole message after each change,
+    // serialized in the protobuf binary format.
+    marshalMessage: {
+        imports: {
+            'google.golang.org/protobuf/proto': null
+        },
+        declarations: [
+            {raw:
+`// marshalMessage returns the protobuf binary serialization of the specified
+// message.
+func marshalMessage(message proto.Message) ([]byte, error) {
+	return proto.Marshal(message)
+}`
+            }
+        ]
+    },
+    unmarshalMessage: {
+        imports: {
+            'google.golang.org/protobuf/proto': null
+        },
+        declarations: [
+            {raw:
+`// unmarshalMessage parses the specified protobuf binary serialization into
+// the specified message.
+func unmarshalMessage(serialized []byte, message proto.Message) error {
+	return proto.Unmarshal(serialized, message)
+}`
+            }
+        ]
+    },
+    // When changes to messages are inserted into the outbox, the ID of a
+    // message of any type is stored in the same column, encoded as JSON (as
+    // in a page token; see `encodePageToken`).
+    encodeOutboxID: {
+        imports: {
+            'encoding/json': null
+        },
+        declarations: [
+            {raw:
+`// encodeOutboxID returns the specified id encoded as JSON, for use as the ID
+// of the message of an OutboxEvent.
+func encodeOutboxID(id interface{}) (string, error) {
+	data, err := json.Marshal(id)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}`
+            }
+        ]
+    },
+    // The field mask of an event in the outbox is stored as comma-separated
+    // field names, or as null if the operation changed the whole message.
+    joinFieldMask: {
+        imports: {
+            'strings': null
+        },
+        declarations: [
+            {raw:
+`// joinFieldMask returns the specified fieldMask as comma-separated field
+// names, or returns nil if fieldMask is empty. The result is suitable as an
+// input parameter in SQL.
+func joinFieldMask(fieldMask []string) interface{} {
+	if len(fieldMask) == 0 {
+		return nil
+	}
+
+	return strings.Join(fieldMask, ",")
+}`
+            }
+        ]
+    },
+    // The generated `ClaimOutboxEvents` and `AcknowledgeOutboxEvent` pass the
+    // SQL of the dialect (see `outbox.tisch.js`) to these functions, which do
+    // the work of polling the outbox.
+    claimOutboxEvents: {
+        imports: {
+            'context': null,
+            'crypto/rand': null,
+            'database/sql': null,
+            'encoding/hex': null,
+            'strings': null,
+            'time': null
+        },
+        declarations: [
+            {raw:
+`// OutboxEvent is a change to a message, inserted into the outbox by the CRUD
+// operation that made the change, in the same transaction. Claim events using
+// ClaimOutboxEvents, and acknowledge each event once it has been dealt with
+// (e.g. published to a message bus) using AcknowledgeOutboxEvent.
+type OutboxEvent struct {
+	// Sequence is the position of the event in the outbox. Events are claimed
+	// in order of their Sequence.
+	Sequence int64
+	// TypeName is the fully qualified name of the message type, e.g.
+	// ".scouts.BoyScout".
+	TypeName string
+	// ID is the ID of the message, encoded as JSON.
+	ID string
+	// Operation is the name of the operation that changed the message, e.g.
+	// "update".
+	Operation string
+	// FieldMask names the fields that the operation changed, or is nil if the
+	// operation changed the whole message.
+	FieldMask []string
+	// Message is the message after the change, serialized in the protobuf
+	// binary format, or is nil if the message was deleted.
+	Message []byte
+
+	claim string // token identifying the claim on the event
+}`
+            },
+            {raw:
+`// newOutboxClaim returns a random token identifying a claim on events in the
+// outbox.
+func newOutboxClaim() (string, error) {
+	var token [16]byte
+	_, err := rand.Read(token[:])
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token[:]), nil
+}`
+            },
+            {raw:
+`// claimOutboxEvents claims up to the specified limit of events in the outbox
+// of the specified db, as described by ClaimOutboxEvents, subject to the
+// specified cancellation context ctx. The specified claimSQL and readSQL are
+// the statements that claim the events and then read them.
+func claimOutboxEvents(ctx context.Context, db Database, limit int, lease time.Duration, claimSQL string, readSQL string) (events []OutboxEvent, err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+
+	if limit < 1 {
+		err = invalidArgument("limit must be positive, but is %d", limit)
+		return
+	}
+
+	claim, err := newOutboxClaim()
+	if err != nil {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	_, err = transaction.ExecContext(ctx, claimSQL, claim, lease.Microseconds(), limit)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, readSQL, claim)
+	if err != nil {
+		return
+	}
+	for rows.Next() {
+		var event OutboxEvent
+		var fieldMask sql.NullString
+		err = rows.Scan(&event.Sequence, &event.TypeName, &event.ID, &event.Operation, &fieldMask, &event.Message)
+		if err != nil {
+			return
+		}
+		if fieldMask.Valid {
+			event.FieldMask = strings.Split(fieldMask.String, ",")
+		}
+		event.claim = claim
+		events = append(events, event)
+	}
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	err = transaction.Commit()
+	return
+}`
+            }
+        ],
+        dependencies: [
+            'beginTransaction', 'classifyError', 'combineErrors',
+            'invalidArgument'
+        ]
+    },
+    acknowledgeOutboxEvent: {
+        imports: {
+            'context': null
+        },
+        declarations: [
+            {raw:
+`// acknowledgeOutboxEvent deletes the specified event from the outbox of the
+// specified db, as described by AcknowledgeOutboxEvent, subject to the
+// specified cancellation context ctx. The specified acknowledgeSQL is the
+// statement that deletes the event.
+func acknowledgeOutboxEvent(ctx context.Context, db Database, event OutboxEvent, acknowledgeSQL string) (err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	_, err = transaction.ExecContext(ctx, acknowledgeSQL, event.Sequence, event.claim)
+	if err != nil {
+		return
+	}
+
+	err = transaction.Commit()
+	return
+}`
+            }
+        ],
+        dependencies: [
+            'claimOutboxEvents', 'beginTransaction', 'classifyError',
+            'combineErrors'
+        ]
+    },
+    // `ignore()` is used to ignore results from SQL. In particular, it's used
+    // as part of the "are there any rows to update?" check done at the
+    // beginning of "update" CRUD operations.
+    ignore: {
+        imports: {},
+        declarations: [
+            {raw:
+`// ignore returns an output parameter for use in sql.Rows.Scan. The returned
+// value accepts any SQL value and does nothing with it.
+func ignore() interface{} {
+	var dummy interface{}
+	var pointer interface{} = &dummy
+	return pointer
+}`
+            }
+        ]
+    },
+    // `scanIf` is used to ignore results from SQL for fields that are
+    // excluded from an operation, such as a "read" with a field mask.
+    scanIf: {
+        imports: {},
+        declarations: [
+            {raw:
+`// scanIf returns the specified destination if the specified condition is
+// true, or returns ignore() otherwise. It's for use in sql.Rows.Scan, where
+// only some of the destinations are to be assigned.
+func scanIf(condition bool, destination interface{}) interface{} {
+	if condition {
+		return destination
+	}
+	return ignore()
+}`
+            }
+        ],
+        dependencies: ['ignore']
+    },
+    // `intoUint64` has a special implementation, because there is no
+    // sql.NullUint64. You can pass a **uint64 to Rows.Scan, but then you need
+    // code after Scan returns to inspect the resulting *uint64. Instead, here
+    // I define a Scanner that scans into a sql.NullString and then parses a
+    // uint64 from the string. I don't know if this is portable. It works for
+    // the MySQL driver that currently interests me.
+    intoUint64: {
+        imports: {
+            "database/sql": null,
+            "strconv": null
+        },
+        declarations: [
+            {raw:
+`type uint64Scanner struct {
+	destination  *uint64
+	intermediary sql.NullString
+}`
+            },
+            {raw:
+`func (scanner uint64Scanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	if !scanner.intermediary.Valid {
+		// !Valid -> null -> zero
+		*scanner.destination = 0
+		return nil
+	}
+
+	// parse a base-10 64-bit unsigned integer
+	parsedValue, err := strconv.ParseUint(scanner.intermediary.String, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*scanner.destination = parsedValue
+	return nil
+}`
+            },
+            {raw:
+`// intoUint64 is a constructor for uint64Scanner.
+func intoUint64(destination *uint64) uint64Scanner {
+	return uint64Scanner{destination: destination}
+}`,
+            }
+        ]
+    },
+    // Note that there is no `fromUint64`, because `uint64` is not a valid
+    // `driver.Value` type.
+
+    // intoBytes is trivial: the sql package's default behavior does the right
+    // thing for `[]byte`. I define a wrapper function, even though one is not
+    // necessary, for consistency with the other types.
+    intoBytes: {
+        imports: {},
+        declarations: [
+            {raw:
+`// intoBytes returns its argument. This function is provided for
+// consistency with other output parameter types.
+func intoBytes(destination *[]byte) *[]byte {
+	return destination
+}`
+            }
+        ]
+    },
+    // fromBytes is special, because in order to determine the nullness of a
+    // `[]byte`, we have to use the `len` function.
+    fromBytes: {
+        imports: {
+            "database/sql/driver": null,
+        },
+        declarations: [
+            {raw:
+`// bytesValuer is a driver.Valuer that produces []byte
+type bytesValuer struct {
+	source []byte
+}`
+            },
+            {raw:
+`func (valuer bytesValuer) Value() (driver.Value, error) {
+	if len(valuer.source) == 0 {
+		return nil, nil
+	}
+
+	return valuer.source, nil
+}`
+            },
+            {raw:
+`// fromBytes is a constructor for bytesValuer.
+func fromBytes(source []byte) bytesValuer {
+	return bytesValuer{source: source}
+}`
+            }
+        ]
+    },
+    // intoEnum is special because there can be many enum types, each of which
+    // is just an int32. Rather than have a separate Scanner for each enum
+    // type, I define one that uses int32 and a function that assigns to the
+    // correct enum type. This way, the per-enum type information is
+    // encapsulated in a trivial anonymous function at the call site, e.g.
+    //
+    //     rows.Scan(intoEnum(func(value int32) {message.FavoriteColor = Color(value) })
+    //
+    intoEnum: {
+        imports: {
+            'database/sql': null
+        },
+        declarations: [
+            {raw:
+`type enumScanner struct {
+	// flush assigns the specified int32 to the destination enum field.
+	// The idea is that enumScanner doesn't know about the underlying
+	// enum type. That information is encapsulated within flush.
+	flush        func(int32)
+	intermediary sql.NullInt64
+}`
+            },
+            {raw:
+`func (scanner enumScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
 		return err
 	}
 
@@ -523,6 +2495,258 @@ func fromBytes(source []byte) bytesValuer {
 `// intoEnum is a constructor for enumScanner.
 func intoEnum(flush func(int32)) enumScanner {
 	return enumScanner{flush: flush}
+}`
+            }
+        ]
+    },
+    // intoOptionalEnum is like intoEnum, but for proto3 `optional` enum
+    // fields, which are pointers. Null is nil rather than zero.
+    intoOptionalEnum: {
+        imports: {
+            'database/sql': null
+        },
+        declarations: [
+            {raw:
+`type optionalEnumScanner struct {
+	// flush assigns the specified *int32 to the destination enum field.
+	// See enumScanner.
+	flush        func(*int32)
+	intermediary sql.NullInt64
+}`
+            },
+            {raw:
+`func (scanner optionalEnumScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	if !scanner.intermediary.Valid {
+		scanner.flush(nil)
+		return nil
+	}
+
+	intValue := int32(scanner.intermediary.Int64)
+	scanner.flush(&intValue)
+	return nil
+}`
+            },
+            {raw:
+`// intoOptionalEnum is a constructor for optionalEnumScanner.
+func intoOptionalEnum(flush func(*int32)) optionalEnumScanner {
+	return optionalEnumScanner{flush: flush}
+}`
+            }
+        ]
+    },
+    // `uint64` is not a valid `driver.Value` type (see `intoUint64`), so
+    // `fromOptionalUint64` and `fromUInt64Value` return the `uint64` itself,
+    // as is done for non-optional `uint64` fields, or nil if it's unset.
+    fromOptionalUint64: {
+        imports: {},
+        declarations: [
+            {raw:
+`// fromOptionalUint64 returns the value pointed to by the specified source, or
+// returns nil if source is nil.
+func fromOptionalUint64(source *uint64) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	return *source
+}`
+            }
+        ]
+    },
+    fromUInt64Value: {
+        imports: {
+            "github.com/golang/protobuf/ptypes/wrappers": null
+        },
+        declarations: [
+            {raw:
+`// fromUInt64Value returns the value of the specified source, or returns nil if
+// source is nil.
+func fromUInt64Value(source *wrappers.UInt64Value) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	return source.Value
+}`
+            }
+        ]
+    },
+    // `intoOptionalUint64` and `intoUInt64Value` parse a string, for the same
+    // reason as does `intoUint64`.
+    intoOptionalUint64: {
+        imports: {
+            "database/sql": null,
+            "strconv": null
+        },
+        declarations: [
+            {raw:
+`type optionalUint64Scanner struct {
+	destination  **uint64
+	intermediary sql.NullString
+}`
+            },
+            {raw:
+`func (scanner optionalUint64Scanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	if !scanner.intermediary.Valid {
+		// !Valid -> null -> nil
+		*scanner.destination = nil
+		return nil
+	}
+
+	parsedValue, err := strconv.ParseUint(scanner.intermediary.String, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*scanner.destination = &parsedValue
+	return nil
+}`
+            },
+            {raw:
+`// intoOptionalUint64 is a constructor for optionalUint64Scanner.
+func intoOptionalUint64(destination **uint64) optionalUint64Scanner {
+	return optionalUint64Scanner{destination: destination}
+}`
+            }
+        ]
+    },
+    intoUInt64Value: {
+        imports: {
+            "database/sql": null,
+            "github.com/golang/protobuf/ptypes/wrappers": null,
+            "strconv": null
+        },
+        declarations: [
+            {raw:
+`type uint64WrapperScanner struct {
+	destination  **wrappers.UInt64Value
+	intermediary sql.NullString
+}`
+            },
+            {raw:
+`func (scanner uint64WrapperScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	if !scanner.intermediary.Valid {
+		// !Valid -> null -> nil
+		*scanner.destination = nil
+		return nil
+	}
+
+	parsedValue, err := strconv.ParseUint(scanner.intermediary.String, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*scanner.destination = &wrappers.UInt64Value{Value: parsedValue}
+	return nil
+}`
+            },
+            {raw:
+`// intoUInt64Value is a constructor for uint64WrapperScanner.
+func intoUInt64Value(destination **wrappers.UInt64Value) uint64WrapperScanner {
+	return uint64WrapperScanner{destination: destination}
+}`
+            }
+        ]
+    },
+    // A proto3 `optional` bytes field is a `[]byte`, not a pointer. It's
+    // unset if it's nil, and so, unlike `fromBytes`, `fromOptionalBytes`
+    // produces null only for a nil slice. There's no `intoOptionalBytes`,
+    // because `intoBytes` already scans null as nil and empty as non-nil.
+    fromOptionalBytes: {
+        imports: {
+            "database/sql/driver": null,
+        },
+        declarations: [
+            {raw:
+`// optionalBytesValuer is a driver.Valuer that produces []byte, or null if the
+// source is nil
+type optionalBytesValuer struct {
+	source []byte
+}`
+            },
+            {raw:
+`func (valuer optionalBytesValuer) Value() (driver.Value, error) {
+	if valuer.source == nil {
+		return nil, nil
+	}
+
+	return valuer.source, nil
+}`
+            },
+            {raw:
+`// fromOptionalBytes is a constructor for optionalBytesValuer.
+func fromOptionalBytes(source []byte) optionalBytesValuer {
+	return optionalBytesValuer{source: source}
+}`
+            }
+        ]
+    },
+    fromBytesValue: {
+        imports: {
+            "github.com/golang/protobuf/ptypes/wrappers": null
+        },
+        declarations: [
+            {raw:
+`// fromBytesValue returns a driver.Valuer that produces the value of the
+// specified source, or null if source is nil.
+func fromBytesValue(source *wrappers.BytesValue) optionalBytesValuer {
+	if source == nil {
+		return optionalBytesValuer{}
+	}
+	if source.Value == nil {
+		// present but empty, which is not the same as null
+		return optionalBytesValuer{source: []byte{}}
+	}
+
+	return optionalBytesValuer{source: source.Value}
+}`
+            }
+        ],
+        dependencies: ['fromOptionalBytes']
+    },
+    intoBytesValue: {
+        imports: {
+            "database/sql": null,
+            "github.com/golang/protobuf/ptypes/wrappers": null
+        },
+        declarations: [
+            {raw:
+`type bytesWrapperScanner struct {
+	destination  **wrappers.BytesValue
+	intermediary sql.NullString
+}`
+            },
+            {raw:
+`func (scanner bytesWrapperScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	if !scanner.intermediary.Valid {
+		*scanner.destination = nil
+		return nil
+	}
+
+	*scanner.destination = &wrappers.BytesValue{Value: []byte(scanner.intermediary.String)}
+	return nil
+}`
+            },
+            {raw:
+`// intoBytesValue is a constructor for bytesWrapperScanner.
+func intoBytesValue(destination **wrappers.BytesValue) bytesWrapperScanner {
+	return bytesWrapperScanner{destination: destination}
 }`
             }
         ]
@@ -536,27 +2760,34 @@ func intoEnum(flush func(int32)) enumScanner {
 // The following code registers the following Go functions: fromFloat64,
 // intoFloat64, fromFloat32, intoFloat32, fromInt64, intoInt64, fromInt32,
 // intoInt32, fromUint32, intoUint32, fromBool, intoBool, fromString, and
-// intoString.
+// intoString. For proto3 `optional` fields, it also registers the
+// "fromOptional" and "intoOptional" variants of those functions, e.g.
+// fromOptionalString, and for the wrapper types, e.g.
+// google.protobuf.StringValue, it registers fromStringValue,
+// intoStringValue, etc. The optional and wrapper variants map nil to null and
+// back, so that a zero value is distinct from an unset value.
 Object.entries({
     // Each entry has the following structure:
     //
     //     <Go type name>: {
     //         nullType: <Go sql null wrapper type>,
     //         valueType: <Go type for inserting into database>,
-    //         zeroValue: <Go zero value>
+    //         zeroValue: <Go zero value>,
+    //         wrapper: <name of the google.protobuf wrapper type>
     //     }
     //
-    float64: {nullType: 'sql.NullFloat64', valueType: 'float64', zeroValue: '0'},
-    float32: {nullType: 'sql.NullFloat64', valueType: 'float64', zeroValue: '0'},
-    int64: {nullType: 'sql.NullInt64', valueType: 'int64', zeroValue: '0'},
-    int32: {nullType: 'sql.NullInt64', valueType: 'int64', zeroValue: '0'},
-    uint32: {nullType: 'sql.NullInt64', valueType: 'int64', zeroValue: '0'},
+    float64: {nullType: 'sql.NullFloat64', valueType: 'float64', zeroValue: '0', wrapper: 'DoubleValue'},
+    float32: {nullType: 'sql.NullFloat64', valueType: 'float64', zeroValue: '0', wrapper: 'FloatValue'},
+    int64: {nullType: 'sql.NullInt64', valueType: 'int64', zeroValue: '0', wrapper: 'Int64Value'},
+    int32: {nullType: 'sql.NullInt64', valueType: 'int64', zeroValue: '0', wrapper: 'Int32Value'},
+    uint32: {nullType: 'sql.NullInt64', valueType: 'int64', zeroValue: '0', wrapper: 'UInt32Value'},
     // bool is strange: Go false -> SQL null, because false value cannot be
     // distinguished from field absence. It's the same as with the numeric
     // types, but is more conspicuous with bool because it has only two values.
-    bool: {nullType: 'sql.NullBool', valueType: 'bool', zeroValue: 'false'},
-    string: {nullType: 'sql.NullString', valueType: 'string', zeroValue: '""'}
-}).forEach(([type, {nullType, valueType, zeroValue}]) => {
+    // Use an `optional bool` or a `google.protobuf.BoolValue` to store false.
+    bool: {nullType: 'sql.NullBool', valueType: 'bool', zeroValue: 'false', wrapper: 'BoolValue'},
+    string: {nullType: 'sql.NullString', valueType: 'string', zeroValue: '""', wrapper: 'StringValue'}
+}).forEach(([type, {nullType, valueType, zeroValue, wrapper}]) => {
     // There are two functions associated with each type: `from____` and
     // `into____`. Each of those two functions has associated with it a helper
     // type (a Valuer or a Scanner, respectively), and each of those helper
@@ -633,6 +2864,134 @@ func from${typeTitle}(source ${type}) ${type}Valuer {
 `// into${typeTitle} is a constructor for ${type}Scanner.
 func into${typeTitle}(destination *${type}) ${type}Scanner {
 	return ${type}Scanner{destination: destination}
+}`
+            }
+        ]
+    };
+
+    // e.g. "fromOptionalBool"
+    prerenderedDeclarations[`fromOptional${typeTitle}`] = {
+        imports: {
+            "database/sql/driver": null,
+        },
+        declarations: [
+            {raw:
+`// optional${typeTitle}Valuer is a driver.Valuer that produces ${type}, or null
+// if the source is nil
+type optional${typeTitle}Valuer struct {
+	source *${type}
+}`
+            },
+            {raw:
+`func (valuer optional${typeTitle}Valuer) Value() (driver.Value, error) {
+	if valuer.source == nil {
+		return nil, nil
+	}
+
+	return ${valueType}(*valuer.source), nil
+}`
+            },
+            {raw:
+`// fromOptional${typeTitle} is a constructor for optional${typeTitle}Valuer.
+func fromOptional${typeTitle}(source *${type}) optional${typeTitle}Valuer {
+	return optional${typeTitle}Valuer{source: source}
+}`
+            }
+        ]
+    };
+
+    // e.g. "intoOptionalBool"
+    prerenderedDeclarations[`intoOptional${typeTitle}`] = {
+        imports: {
+            "database/sql": null
+        },
+        declarations: [
+            {raw:
+`type optional${typeTitle}Scanner struct {
+	destination  **${type}
+	intermediary ${nullType}
+}`
+            },
+            {raw:
+`func (scanner optional${typeTitle}Scanner) Scan(value interface{}) error {
+	err := scanner.intermediary.Scan(value)
+	if err != nil {
+		return err
+	}
+
+	if scanner.intermediary.Valid {
+		scanned := ${type}(scanner.intermediary.${valueField})
+		*scanner.destination = &scanned
+	} else {
+		*scanner.destination = nil
+	}
+
+	return nil
+}`
+            },
+            {raw:
+`// intoOptional${typeTitle} is a constructor for optional${typeTitle}Scanner.
+func intoOptional${typeTitle}(destination **${type}) optional${typeTitle}Scanner {
+	return optional${typeTitle}Scanner{destination: destination}
+}`
+            }
+        ]
+    };
+
+    // e.g. "fromBoolValue"
+    prerenderedDeclarations[`from${wrapper}`] = {
+        imports: {
+            "github.com/golang/protobuf/ptypes/wrappers": null
+        },
+        declarations: [
+            {raw:
+`// from${wrapper} returns a driver.Valuer that produces the value of the
+// specified source, or null if source is nil.
+func from${wrapper}(source *wrappers.${wrapper}) optional${typeTitle}Valuer {
+	if source == nil {
+		return optional${typeTitle}Valuer{}
+	}
+
+	return optional${typeTitle}Valuer{source: &source.Value}
+}`
+            }
+        ],
+        dependencies: [`fromOptional${typeTitle}`]
+    };
+
+    // e.g. "intoBoolValue"
+    prerenderedDeclarations[`into${wrapper}`] = {
+        imports: {
+            "database/sql": null,
+            "github.com/golang/protobuf/ptypes/wrappers": null
+        },
+        declarations: [
+            {raw:
+`type ${type}WrapperScanner struct {
+	destination  **wrappers.${wrapper}
+	intermediary ${nullType}
+}`
+            },
+            {raw:
+`func (scanner ${type}WrapperScanner) Scan(value interface{}) error {
+	err := scanner.intermediary.Scan(value)
+	if err != nil {
+		return err
+	}
+
+	if scanner.intermediary.Valid {
+		*scanner.destination = &wrappers.${wrapper}{Value: ${type}(scanner.intermediary.${valueField})}
+	} else {
+		*scanner.destination = nil
+	}
+
+	return nil
+}`
+            },
+            {raw:
+`// into${wrapper} is a constructor for ${type}WrapperScanner.
+func into${wrapper}(destination **wrappers.${wrapper}) ${type}WrapperScanner {
+	return ${type}WrapperScanner{destination: destination}
 }`
             }
         ]
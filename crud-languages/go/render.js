@@ -94,6 +94,14 @@ function stringifyExpression(expression) {
         const {left, right} = expression.and;
         return [left, right].map(stringifyExpression).join(' && ');
     }
+    else if (expression.or) {
+        const {left, right} = expression.or;
+        return [left, right].map(stringifyExpression).join(' || ');
+    }
+    else if (expression.plus) {
+        const {left, right} = expression.plus;
+        return [left, right].map(stringifyExpression).join(' + ');
+    }
     else if (expression.not) {
         const argument = expression.not;
         // We might need to put parentheses around `argument`; it depends.
@@ -146,8 +154,12 @@ function stringifyParameter({name, type}) {
 function renderVariable({name, type, value}, lines) {
     // Since a variable is something like
     //     var foo type = value
-    // we can reuse the "foo type" as rendered for function parameters.
-    const parameter = stringifyParameter({name, type});
+    // we can reuse the "foo type" as rendered for function parameters. The
+    // type can be omitted if there's a value, i.e.
+    //     var foo = value
+    const parameter = type === undefined
+        ? name
+        : stringifyParameter({name, type});
     if (value === undefined) {
         lines.push(`var ${parameter}`);
     }
@@ -318,6 +330,7 @@ function renderFunction(func, lines) {
     // See `ast.tisch.js` for the shapes of `parameters`, `variables`, etc.
     const {
         documentation,
+        receiver,
         name,
         parameters,
         results,
@@ -335,8 +348,17 @@ function renderFunction(func, lines) {
     //     $variables
     //     $statements
     // }
+    //
+    // or, if there's a receiver,
+    //
+    // func ($receiver) $name($parameters) $results {
+    //     ...
+    // }
+    const receiverList = receiver === undefined
+        ? ''
+        : `(${stringifyParameter(receiver)}) `; // +space
     const parameterList = `(${parameters.map(stringifyParameter).join(', ')})`;
-    lines.push(`func ${name}${parameterList} ${stringifyResults(results)}{`);
+    lines.push(`func ${receiverList}${name}${parameterList} ${stringifyResults(results)}{`);
 
     // Each variable gets a `var`, but additionally might have a `defer func() ...`.
     variables.forEach(variable => {
@@ -398,6 +420,22 @@ function renderFile(goFile, lines) {
         if (declaration.function) {
             renderFunction(declaration.function, lines);
         }
+        else if (declaration.type) {
+            // type $name $definition
+            const {documentation, name, definition} = declaration.type;
+            if (documentation !== undefined) {
+                renderDocumentation(documentation, lines);
+            }
+            lines.push(`type ${name} ${definition}`);
+        }
+        else if (declaration.variable) {
+            // var $name $type = $value
+            const {documentation, ...variable} = declaration.variable;
+            if (documentation !== undefined) {
+                renderDocumentation(documentation, lines);
+            }
+            renderVariable(variable, lines);
+        }
         else {
             // `lines` will still apply indentation logic to the first line of
             // `declaration.raw`, but since `goFile` is rendered at indentation
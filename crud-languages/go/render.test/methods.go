@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+)
+
+// counter counts things.
+type counter struct{ count int }
+
+// theCounter is the only counter.
+var theCounter = &counter{}
+
+// increment adds one to the count of c.
+func (c *counter) increment() {
+	c.count = c.count + 1
+}
+
+func main() {
+	theCounter.increment()
+	fmt.Println(theCounter.count)
+}
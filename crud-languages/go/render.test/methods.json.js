@@ -0,0 +1,68 @@
+({
+    package: 'main',
+    imports: {
+        'fmt': null
+    },
+    declarations: [{
+            type: {
+                documentation: 'counter counts things.',
+                name: 'counter',
+                definition: 'struct{ count int }'
+            }
+        },
+
+        {
+            variable: {
+                documentation: 'theCounter is the only counter.',
+                name: 'theCounter',
+                value: {address: {sequenceLiteral: {
+                    type: 'counter',
+                    elements: []
+                }}}
+            }
+        },
+
+        {
+            function: {
+                documentation: 'increment adds one to the count of c.',
+                receiver: {name: 'c', type: '*counter'},
+                name: 'increment',
+                parameters: [],
+                results: [],
+                body: {
+                    variables: [],
+                    statements: [{
+                        assign: {
+                            left: [{dot: ['c', 'count']}],
+                            right: [{plus: {
+                                left: {dot: ['c', 'count']},
+                                right: 1
+                            }}]
+                        }
+                    }]
+                }
+            }
+        },
+
+        {
+            function: {
+                name: 'main',
+                parameters: [],
+                results: [],
+                body: {
+                    variables: [],
+                    statements: [
+                        {call: {
+                            function: {dot: ['theCounter', 'increment']},
+                            arguments: []
+                        }},
+                        {call: {
+                            function: {dot: ['fmt', 'Println']},
+                            arguments: [{dot: ['theCounter', 'count']}]
+                        }}
+                    ]
+                }
+            }
+        }
+    ]
+})
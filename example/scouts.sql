@@ -21,6 +21,8 @@ create table `boy_scout`(
     `iana_country_code` varchar(512) null comment 'playing with naming conventions',
     `what_about_this` bigint null,
     `big_unsigned_int` bigint unsigned null comment 'uint64 is special',
+    `dues_currency_code` char(3) null comment 'currency code of dues',
+    `dues_amount` decimal(38, 9) null comment 'amount of dues',
     primary key (`id`),
     foreign key (`rank`) references `rank`(`id`))
 engine = InnoDB
@@ -10,6 +10,7 @@ import (
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	date "google.golang.org/genproto/googleapis/type/date"
+	money "google.golang.org/genproto/googleapis/type/money"
 	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -181,6 +182,8 @@ type BoyScout struct {
 	Mask *field_mask.FieldMask `protobuf:"bytes,15,opt,name=mask,proto3" json:"mask,omitempty"`
 	// uint64 is special
 	BigUnsignedInt uint64 `protobuf:"varint,16,opt,name=big_unsigned_int,json=bigUnsignedInt,proto3" json:"big_unsigned_int,omitempty"`
+	// stored in two columns, currency code and amount
+	Dues *money.Money `protobuf:"bytes,17,opt,name=dues,proto3" json:"dues,omitempty"`
 }
 
 func (x *BoyScout) Reset() {
@@ -327,6 +330,13 @@ func (x *BoyScout) GetBigUnsignedInt() uint64 {
 	return 0
 }
 
+func (x *BoyScout) GetDues() *money.Money {
+	if x != nil {
+		return x.Dues
+	}
+	return nil
+}
+
 type GirlScout struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -385,74 +395,78 @@ var file_src_boyscouts_com_type_scouts_scouts_proto_rawDesc = []byte{
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73,
 	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
-	0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0xf9, 0x04, 0x0a, 0x08, 0x42, 0x6f, 0x79, 0x53, 0x63, 0x6f, 0x75, 0x74, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09,
-	0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x66, 0x75, 0x6c, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x68, 0x6f,
-	0x72, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
-	0x68, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x62, 0x69, 0x72, 0x74,
-	0x68, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x09,
-	0x62, 0x69, 0x72, 0x74, 0x68, 0x64, 0x61, 0x74, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x6a, 0x6f, 0x69,
-	0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x69,
-	0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f,
-	0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
-	0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
-	0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61,
-	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
-	0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70,
-	0x61, 0x63, 0x6b, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x73, 0x2e, 0x52,
-	0x61, 0x6e, 0x6b, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x25, 0x0a, 0x06, 0x62, 0x61, 0x64,
-	0x67, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x73, 0x63, 0x6f, 0x75,
-	0x74, 0x73, 0x2e, 0x42, 0x61, 0x64, 0x67, 0x65, 0x52, 0x06, 0x62, 0x61, 0x64, 0x67, 0x65, 0x73,
-	0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x6f, 0x6e,
-	0x67, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69,
-	0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x49, 0x41, 0x4e, 0x41, 0x5f,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0f, 0x49, 0x41, 0x4e, 0x41, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x43,
-	0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x77, 0x68, 0x61, 0x74, 0x41, 0x62, 0x6f, 0x75, 0x74,
-	0x54, 0x68, 0x69, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x77, 0x68, 0x61, 0x74,
-	0x41, 0x62, 0x6f, 0x75, 0x74, 0x54, 0x68, 0x69, 0x73, 0x12, 0x36, 0x0a, 0x0d, 0x63, 0x61, 0x6d,
-	0x70, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x72, 0x69, 0x70, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44,
-	0x61, 0x74, 0x65, 0x52, 0x0c, 0x63, 0x61, 0x6d, 0x70, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x69, 0x70,
-	0x73, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x73,
-	0x6b, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x69, 0x67, 0x5f, 0x75, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65,
-	0x64, 0x5f, 0x69, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x62, 0x69, 0x67,
-	0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x49, 0x6e, 0x74, 0x22, 0x1b, 0x0a, 0x09, 0x47,
-	0x69, 0x72, 0x6c, 0x53, 0x63, 0x6f, 0x75, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x2a, 0xad, 0x01, 0x0a, 0x04, 0x52, 0x61, 0x6e,
-	0x6b, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
-	0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x43, 0x55, 0x42, 0x5f,
-	0x53, 0x43, 0x4f, 0x55, 0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x41, 0x4e, 0x4b, 0x5f,
-	0x57, 0x45, 0x42, 0x45, 0x4c, 0x4f, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x41, 0x4e, 0x4b,
-	0x5f, 0x42, 0x4f, 0x59, 0x5f, 0x53, 0x43, 0x4f, 0x55, 0x54, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10,
-	0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x45, 0x41, 0x47, 0x4c, 0x45, 0x5f, 0x53, 0x43, 0x4f, 0x55, 0x54,
-	0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x53, 0x50, 0x41, 0x43, 0x45,
-	0x5f, 0x43, 0x41, 0x44, 0x45, 0x54, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x41, 0x4e, 0x4b,
-	0x5f, 0x53, 0x41, 0x4d, 0x55, 0x52, 0x41, 0x49, 0x10, 0x06, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x41,
-	0x4e, 0x4b, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x41, 0x4c, 0x5f,
-	0x42, 0x4f, 0x57, 0x4c, 0x45, 0x52, 0x10, 0x07, 0x2a, 0xb4, 0x01, 0x0a, 0x05, 0x42, 0x61, 0x64,
-	0x67, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e,
-	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x57,
-	0x4f, 0x4f, 0x44, 0x57, 0x4f, 0x52, 0x4b, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12,
-	0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x46, 0x49, 0x52, 0x45, 0x53, 0x54, 0x41, 0x52, 0x54, 0x49,
-	0x4e, 0x47, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x52, 0x41,
-	0x49, 0x4e, 0x4d, 0x41, 0x4b, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x41,
-	0x44, 0x47, 0x45, 0x5f, 0x41, 0x53, 0x53, 0x4b, 0x49, 0x43, 0x4b, 0x49, 0x4e, 0x47, 0x10, 0x04,
-	0x12, 0x18, 0x0a, 0x14, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x48, 0x45, 0x41, 0x44, 0x53, 0x43,
-	0x52, 0x41, 0x54, 0x43, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x42, 0x41,
-	0x44, 0x47, 0x45, 0x5f, 0x42, 0x41, 0x4c, 0x4c, 0x45, 0x54, 0x10, 0x06, 0x12, 0x11, 0x0a, 0x0d,
-	0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x46, 0x49, 0x53, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x07, 0x42,
-	0x22, 0x5a, 0x20, 0x62, 0x6f, 0x79, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x73, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x73, 0x3b, 0x73, 0x63, 0x6f,
-	0x75, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x17, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x6d, 0x6f, 0x6e,
+	0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa1, 0x05, 0x0a, 0x08, 0x42, 0x6f, 0x79,
+	0x53, 0x63, 0x6f, 0x75, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x75, 0x6c, 0x6c, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x2f, 0x0a, 0x09, 0x62, 0x69, 0x72, 0x74, 0x68, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x09, 0x62, 0x69, 0x72, 0x74, 0x68, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x08, 0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x20, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c,
+	0x2e, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x73, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x04, 0x72, 0x61,
+	0x6e, 0x6b, 0x12, 0x25, 0x0a, 0x06, 0x62, 0x61, 0x64, 0x67, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x73, 0x2e, 0x42, 0x61, 0x64, 0x67,
+	0x65, 0x52, 0x06, 0x62, 0x61, 0x64, 0x67, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x61, 0x76,
+	0x6f, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0d, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x73,
+	0x12, 0x2a, 0x0a, 0x11, 0x49, 0x41, 0x4e, 0x41, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x49, 0x41, 0x4e,
+	0x41, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d,
+	0x77, 0x68, 0x61, 0x74, 0x41, 0x62, 0x6f, 0x75, 0x74, 0x54, 0x68, 0x69, 0x73, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0d, 0x77, 0x68, 0x61, 0x74, 0x41, 0x62, 0x6f, 0x75, 0x74, 0x54, 0x68,
+	0x69, 0x73, 0x12, 0x36, 0x0a, 0x0d, 0x63, 0x61, 0x6d, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x72,
+	0x69, 0x70, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x63, 0x61,
+	0x6d, 0x70, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x69, 0x70, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x61,
+	0x73, 0x6b, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4d, 0x61, 0x73, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x69,
+	0x67, 0x5f, 0x75, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x74, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x62, 0x69, 0x67, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x49, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x64, 0x75, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x52, 0x04, 0x64, 0x75, 0x65, 0x73, 0x22, 0x1b, 0x0a, 0x09,
+	0x47, 0x69, 0x72, 0x6c, 0x53, 0x63, 0x6f, 0x75, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x2a, 0xad, 0x01, 0x0a, 0x04, 0x52, 0x61,
+	0x6e, 0x6b, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x43, 0x55, 0x42,
+	0x5f, 0x53, 0x43, 0x4f, 0x55, 0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x41, 0x4e, 0x4b,
+	0x5f, 0x57, 0x45, 0x42, 0x45, 0x4c, 0x4f, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x41, 0x4e,
+	0x4b, 0x5f, 0x42, 0x4f, 0x59, 0x5f, 0x53, 0x43, 0x4f, 0x55, 0x54, 0x10, 0x03, 0x12, 0x14, 0x0a,
+	0x10, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x45, 0x41, 0x47, 0x4c, 0x45, 0x5f, 0x53, 0x43, 0x4f, 0x55,
+	0x54, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x41, 0x4e, 0x4b, 0x5f, 0x53, 0x50, 0x41, 0x43,
+	0x45, 0x5f, 0x43, 0x41, 0x44, 0x45, 0x54, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x41, 0x4e,
+	0x4b, 0x5f, 0x53, 0x41, 0x4d, 0x55, 0x52, 0x41, 0x49, 0x10, 0x06, 0x12, 0x1c, 0x0a, 0x18, 0x52,
+	0x41, 0x4e, 0x4b, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x41, 0x4c,
+	0x5f, 0x42, 0x4f, 0x57, 0x4c, 0x45, 0x52, 0x10, 0x07, 0x2a, 0xb4, 0x01, 0x0a, 0x05, 0x42, 0x61,
+	0x64, 0x67, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f,
+	0x57, 0x4f, 0x4f, 0x44, 0x57, 0x4f, 0x52, 0x4b, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x16, 0x0a,
+	0x12, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x46, 0x49, 0x52, 0x45, 0x53, 0x54, 0x41, 0x52, 0x54,
+	0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x52,
+	0x41, 0x49, 0x4e, 0x4d, 0x41, 0x4b, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x42,
+	0x41, 0x44, 0x47, 0x45, 0x5f, 0x41, 0x53, 0x53, 0x4b, 0x49, 0x43, 0x4b, 0x49, 0x4e, 0x47, 0x10,
+	0x04, 0x12, 0x18, 0x0a, 0x14, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x48, 0x45, 0x41, 0x44, 0x53,
+	0x43, 0x52, 0x41, 0x54, 0x43, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x42,
+	0x41, 0x44, 0x47, 0x45, 0x5f, 0x42, 0x41, 0x4c, 0x4c, 0x45, 0x54, 0x10, 0x06, 0x12, 0x11, 0x0a,
+	0x0d, 0x42, 0x41, 0x44, 0x47, 0x45, 0x5f, 0x46, 0x49, 0x53, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x07,
+	0x42, 0x22, 0x5a, 0x20, 0x62, 0x6f, 0x79, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x73, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x73, 0x63, 0x6f, 0x75, 0x74, 0x73, 0x3b, 0x73, 0x63,
+	0x6f, 0x75, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -477,6 +491,7 @@ var file_src_boyscouts_com_type_scouts_scouts_proto_goTypes = []interface{}{
 	(*date.Date)(nil),            // 4: google.type.Date
 	(*timestamp.Timestamp)(nil),  // 5: google.protobuf.Timestamp
 	(*field_mask.FieldMask)(nil), // 6: google.protobuf.FieldMask
+	(*money.Money)(nil),          // 7: google.type.Money
 }
 var file_src_boyscouts_com_type_scouts_scouts_proto_depIdxs = []int32{
 	4, // 0: scouts.BoyScout.birthdate:type_name -> google.type.Date
@@ -485,11 +500,12 @@ var file_src_boyscouts_com_type_scouts_scouts_proto_depIdxs = []int32{
 	1, // 3: scouts.BoyScout.badges:type_name -> scouts.Badge
 	4, // 4: scouts.BoyScout.camping_trips:type_name -> google.type.Date
 	6, // 5: scouts.BoyScout.mask:type_name -> google.protobuf.FieldMask
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	7, // 6: scouts.BoyScout.dues:type_name -> google.type.Money
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_src_boyscouts_com_type_scouts_scouts_proto_init() }
@@ -9,32 +9,39 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/money"
 	"google.golang.org/genproto/protobuf/field_mask"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 // CreateBoyScout adds the specified message to the specified db, subject to the
 // specified cancellation context ctx. Return nil on success, or return a
 // non-nil value if an error occurs.
-func CreateBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout) (err error) {
-	var transaction *sql.Tx
+func CreateBoyScout(ctx context.Context, db Database, message *pb.BoyScout) (err error) {
+	var transaction transactor
 	defer func() {
+		err = classifyError(err)
 		if err != nil && transaction != nil {
 			err = combineErrors(err, transaction.Rollback())
 		}
 	}()
 	var parameters []interface{}
 
-	transaction, err = db.BeginTx(ctx, nil)
+	transaction, err = beginTransaction(ctx, db)
 	if err != nil {
 		return
 	}
 
-	_, err = transaction.ExecContext(ctx, "insert into `boy_scout`( `id`, `full_name`, `short_name`, `birthdate`, `join_time`, `country_code`, `language_code`, `pack_code`, `rank`, `iana_country_code`, `what_about_this`, `big_unsigned_int`) values (?, ?, ?, ?, from_unixtime(cast(? / 1000000.0 as decimal(20, 6))), ?, ?, ?, ?, ?, ?, ?);", fromString(message.Id), fromString(message.FullName), fromString(message.ShortName), fromDate(message.Birthdate), fromTimestamp(message.JoinTime), fromString(message.CountryCode), fromString(message.LanguageCode), fromUint32(message.PackCode), fromInt32(int32(message.Rank)), fromString(message.IANACountryCode), fromInt64(message.WhatAboutThis), message.BigUnsignedInt)
+	_, err = transaction.ExecContext(ctx, "insert into `boy_scout`( `id`, `full_name`, `short_name`, `birthdate`, `join_time`, `country_code`, `language_code`, `pack_code`, `rank`, `iana_country_code`, `what_about_this`, `big_unsigned_int`, `dues_currency_code`, `dues_amount`) values (?, ?, ?, ?, from_unixtime(cast(? / 1000000.0 as decimal(20, 6))), ?, ?, ?, ?, ?, ?, ?, ?, ?);", fromString(message.Id), fromString(message.FullName), fromString(message.ShortName), fromDate(message.Birthdate), fromTimestamp(message.JoinTime), fromString(message.CountryCode), fromString(message.LanguageCode), fromUint32(message.PackCode), fromInt32(int32(message.Rank)), fromString(message.IANACountryCode), fromInt64(message.WhatAboutThis), message.BigUnsignedInt, fromMoneyCurrencyCode(message.Dues), fromMoneyAmount(message.Dues))
 	if err != nil {
 		return
 	}
@@ -88,12 +95,16 @@ func CreateBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout) (err
 }
 
 // ReadBoyScout reads from the specified db into the specified message, where
-// the ID of the message must be pre-populated by the caller. On success, the
-// error returned will be nil. On error, the error returned will not be nil.
-// The specified cancellation context ctx is forwarded wherever appropriate.
-func ReadBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout) (err error) {
-	var transaction *sql.Tx
+// the ID of the message must be pre-populated by the caller. Each element of
+// the specified fieldMask is the name of a field in message whose value is to
+// be read. Fields not in fieldMask are not modified. If fieldMask is empty,
+// then all fields are read. On success, the error returned will be nil. On
+// error, the error returned will not be nil. The specified cancellation
+// context ctx is forwarded wherever appropriate.
+func ReadBoyScout(ctx context.Context, db Database, message *pb.BoyScout, fieldMask []string) (err error) {
+	var transaction transactor
 	defer func() {
+		err = classifyError(err)
 		if err != nil && transaction != nil {
 			err = combineErrors(err, transaction.Rollback())
 		}
@@ -105,13 +116,23 @@ func ReadBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout) (err er
 		}
 	}()
 	var ok bool
+	var included map[string]bool
+
+	if len(fieldMask) == 0 {
+		fieldMask = []string{"id", "full_name", "short_name", "birthdate", "join_time", "country_code", "language_code", "pack_code", "rank", "badges", "favorite_songs", "IANA_country_code", "whatAboutThis", "camping_trips", "mask", "big_unsigned_int", "dues"}
+	}
+
+	included = make(map[string]bool, len(fieldMask))
+	for _, field := range fieldMask {
+		included[field] = true
+	}
 
-	transaction, err = db.BeginTx(ctx, nil)
+	transaction, err = beginTransaction(ctx, db)
 	if err != nil {
 		return
 	}
 
-	rows, err = transaction.QueryContext(ctx, "select `id`, `full_name`, `short_name`, `birthdate`, floor(unix_timestamp(`join_time`) * 1000000), `country_code`, `language_code`, `pack_code`, `rank`, `iana_country_code`, `what_about_this`, `big_unsigned_int` from `boy_scout` where `id` = ?;", fromString(message.Id))
+	rows, err = transaction.QueryContext(ctx, "select `id`, case when ? then `full_name` else null end, case when ? then `short_name` else null end, case when ? then `birthdate` else null end, case when ? then floor(unix_timestamp(`join_time`) * 1000000) else null end, case when ? then `country_code` else null end, case when ? then `language_code` else null end, case when ? then `pack_code` else null end, case when ? then `rank` else null end, case when ? then `iana_country_code` else null end, case when ? then `what_about_this` else null end, case when ? then `big_unsigned_int` else null end, case when ? then `dues_currency_code` else null end, case when ? then `dues_amount` else null end from `boy_scout` where `id` = ?;", included["full_name"], included["short_name"], included["birthdate"], included["join_time"], included["country_code"], included["language_code"], included["pack_code"], included["rank"], included["IANA_country_code"], included["whatAboutThis"], included["big_unsigned_int"], included["dues"], included["dues"], fromString(message.Id))
 	if err != nil {
 		return
 	}
@@ -122,70 +143,86 @@ func ReadBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout) (err er
 		return
 	}
 
-	err = rows.Scan(intoString(&message.Id), intoString(&message.FullName), intoString(&message.ShortName), intoDate(&message.Birthdate), intoTimestamp(&message.JoinTime), intoString(&message.CountryCode), intoString(&message.LanguageCode), intoUint32(&message.PackCode), intoEnum(func(value int32) { message.Rank = pb.Rank(value) }), intoString(&message.IANACountryCode), intoInt64(&message.WhatAboutThis), intoUint64(&message.BigUnsignedInt))
+	err = rows.Scan(intoString(&message.Id), scanIf(included["full_name"], intoString(&message.FullName)), scanIf(included["short_name"], intoString(&message.ShortName)), scanIf(included["birthdate"], intoDate(&message.Birthdate)), scanIf(included["join_time"], intoTimestamp(&message.JoinTime)), scanIf(included["country_code"], intoString(&message.CountryCode)), scanIf(included["language_code"], intoString(&message.LanguageCode)), scanIf(included["pack_code"], intoUint32(&message.PackCode)), scanIf(included["rank"], intoEnum(func(value int32) { message.Rank = pb.Rank(value) })), scanIf(included["IANA_country_code"], intoString(&message.IANACountryCode)), scanIf(included["whatAboutThis"], intoInt64(&message.WhatAboutThis)), scanIf(included["big_unsigned_int"], intoUint64(&message.BigUnsignedInt)), scanIf(included["dues"], intoMoneyCurrencyCode(&message.Dues)), scanIf(included["dues"], intoMoneyAmount(&message.Dues)))
 	if err != nil {
 		return
 	}
 	rows.Next()
 
-	rows, err = transaction.QueryContext(ctx, "select `value` from `boy_scout_badges` where `id` = ? order by `ordinality`;", fromString(message.Id))
-	if err != nil {
-		return
-	}
-	ok = rows.Next()
-
-	for ; ok; ok = rows.Next() {
-		var temp pb.Badge
-		err = rows.Scan(intoEnum(func(value int32) { temp = pb.Badge(value) }))
+	if included["badges"] {
+		rows, err = transaction.QueryContext(ctx, "select `value` from `boy_scout_badges` where `id` = ? order by `ordinality`;", fromString(message.Id))
 		if err != nil {
 			return
 		}
-		message.Badges = append(message.Badges, temp)
+		ok = rows.Next()
 	}
 
-	rows, err = transaction.QueryContext(ctx, "select `value` from `boy_scout_favorite_songs` where `id` = ? order by `ordinality`;", fromString(message.Id))
-	if err != nil {
-		return
+	if included["badges"] {
+		for ; ok; ok = rows.Next() {
+			var temp pb.Badge
+			err = rows.Scan(intoEnum(func(value int32) { temp = pb.Badge(value) }))
+			if err != nil {
+				return
+			}
+			message.Badges = append(message.Badges, temp)
+		}
 	}
-	ok = rows.Next()
 
-	for ; ok; ok = rows.Next() {
-		var temp string
-		err = rows.Scan(intoString(&temp))
+	if included["favorite_songs"] {
+		rows, err = transaction.QueryContext(ctx, "select `value` from `boy_scout_favorite_songs` where `id` = ? order by `ordinality`;", fromString(message.Id))
 		if err != nil {
 			return
 		}
-		message.FavoriteSongs = append(message.FavoriteSongs, temp)
+		ok = rows.Next()
 	}
 
-	rows, err = transaction.QueryContext(ctx, "select `value` from `boy_scout_camping_trips` where `id` = ? order by `ordinality`;", fromString(message.Id))
-	if err != nil {
-		return
+	if included["favorite_songs"] {
+		for ; ok; ok = rows.Next() {
+			var temp string
+			err = rows.Scan(intoString(&temp))
+			if err != nil {
+				return
+			}
+			message.FavoriteSongs = append(message.FavoriteSongs, temp)
+		}
 	}
-	ok = rows.Next()
 
-	for ; ok; ok = rows.Next() {
-		var temp *date.Date
-		err = rows.Scan(intoDate(&temp))
+	if included["camping_trips"] {
+		rows, err = transaction.QueryContext(ctx, "select `value` from `boy_scout_camping_trips` where `id` = ? order by `ordinality`;", fromString(message.Id))
 		if err != nil {
 			return
 		}
-		message.CampingTrips = append(message.CampingTrips, temp)
+		ok = rows.Next()
 	}
 
-	rows, err = transaction.QueryContext(ctx, "select `value` from `boy_scout_mask` where `id` = ? order by `ordinality`;", fromString(message.Id))
-	if err != nil {
-		return
+	if included["camping_trips"] {
+		for ; ok; ok = rows.Next() {
+			var temp *date.Date
+			err = rows.Scan(intoDate(&temp))
+			if err != nil {
+				return
+			}
+			message.CampingTrips = append(message.CampingTrips, temp)
+		}
 	}
-	ok = rows.Next()
 
-	for ; ok; ok = rows.Next() {
-		var temp string
-		err = rows.Scan(intoString(&temp))
+	if included["mask"] {
+		rows, err = transaction.QueryContext(ctx, "select `value` from `boy_scout_mask` where `id` = ? order by `ordinality`;", fromString(message.Id))
 		if err != nil {
 			return
 		}
-		message.Mask = appendField(message.Mask, temp)
+		ok = rows.Next()
+	}
+
+	if included["mask"] {
+		for ; ok; ok = rows.Next() {
+			var temp string
+			err = rows.Scan(intoString(&temp))
+			if err != nil {
+				return
+			}
+			message.Mask = appendField(message.Mask, temp)
+		}
 	}
 
 	err = transaction.Commit()
@@ -196,10 +233,14 @@ func ReadBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout) (err er
 // message that are indicated by the specified fieldMask, subject to
 // specified cancellation context ctx. Each element of fieldMask is the
 // name of a field in message whose value is to be used in the database
-// update. Return nil on success, or a non-nil error if an error occurs.
-func UpdateBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout, fieldMask []string) (err error) {
-	var transaction *sql.Tx
+// update. Return nil on success, or a non-nil error if an error occurs. If
+// fieldMask is empty, then there is nothing to update, and nil is returned
+// without consulting db. If fieldMask contains anything other than the name of
+// a field in message, then the error returned is of class ErrInvalidArgument.
+func UpdateBoyScout(ctx context.Context, db Database, message *pb.BoyScout, fieldMask []string) (err error) {
+	var transaction transactor
 	defer func() {
+		err = classifyError(err)
 		if err != nil && transaction != nil {
 			err = combineErrors(err, transaction.Rollback())
 		}
@@ -214,12 +255,20 @@ func UpdateBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout, field
 	var parameters []interface{}
 	var included map[string]bool
 
+	if len(fieldMask) == 0 {
+		return
+	}
+	err = checkFieldMask(fieldMask, "id", "full_name", "short_name", "birthdate", "join_time", "country_code", "language_code", "pack_code", "rank", "badges", "favorite_songs", "IANA_country_code", "whatAboutThis", "camping_trips", "mask", "big_unsigned_int", "dues")
+	if err != nil {
+		return
+	}
+
 	included = make(map[string]bool, len(fieldMask))
 	for _, field := range fieldMask {
 		included[field] = true
 	}
 
-	transaction, err = db.BeginTx(ctx, nil)
+	transaction, err = beginTransaction(ctx, db)
 	if err != nil {
 		return
 	}
@@ -241,7 +290,7 @@ func UpdateBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout, field
 	}
 	rows.Next()
 
-	_, err = transaction.ExecContext(ctx, "update `boy_scout` set `full_name` = case when ? then ? else `full_name` end, `short_name` = case when ? then ? else `short_name` end, `birthdate` = case when ? then ? else `birthdate` end, `join_time` = case when ? then from_unixtime(cast(? / 1000000.0 as decimal(20, 6))) else `join_time` end, `country_code` = case when ? then ? else `country_code` end, `language_code` = case when ? then ? else `language_code` end, `pack_code` = case when ? then ? else `pack_code` end, `rank` = case when ? then ? else `rank` end, `iana_country_code` = case when ? then ? else `iana_country_code` end, `what_about_this` = case when ? then ? else `what_about_this` end, `big_unsigned_int` = case when ? then ? else `big_unsigned_int` end where `id` = ?;", included["full_name"], fromString(message.FullName), included["short_name"], fromString(message.ShortName), included["birthdate"], fromDate(message.Birthdate), included["join_time"], fromTimestamp(message.JoinTime), included["country_code"], fromString(message.CountryCode), included["language_code"], fromString(message.LanguageCode), included["pack_code"], fromUint32(message.PackCode), included["rank"], fromInt32(int32(message.Rank)), included["IANA_country_code"], fromString(message.IANACountryCode), included["whatAboutThis"], fromInt64(message.WhatAboutThis), included["big_unsigned_int"], message.BigUnsignedInt, fromString(message.Id))
+	_, err = transaction.ExecContext(ctx, "update `boy_scout` set `full_name` = case when ? then ? else `full_name` end, `short_name` = case when ? then ? else `short_name` end, `birthdate` = case when ? then ? else `birthdate` end, `join_time` = case when ? then from_unixtime(cast(? / 1000000.0 as decimal(20, 6))) else `join_time` end, `country_code` = case when ? then ? else `country_code` end, `language_code` = case when ? then ? else `language_code` end, `pack_code` = case when ? then ? else `pack_code` end, `rank` = case when ? then ? else `rank` end, `iana_country_code` = case when ? then ? else `iana_country_code` end, `what_about_this` = case when ? then ? else `what_about_this` end, `big_unsigned_int` = case when ? then ? else `big_unsigned_int` end, `dues_currency_code` = case when ? then ? else `dues_currency_code` end, `dues_amount` = case when ? then ? else `dues_amount` end where `id` = ?;", included["full_name"], fromString(message.FullName), included["short_name"], fromString(message.ShortName), included["birthdate"], fromDate(message.Birthdate), included["join_time"], fromTimestamp(message.JoinTime), included["country_code"], fromString(message.CountryCode), included["language_code"], fromString(message.LanguageCode), included["pack_code"], fromUint32(message.PackCode), included["rank"], fromInt32(int32(message.Rank)), included["IANA_country_code"], fromString(message.IANACountryCode), included["whatAboutThis"], fromInt64(message.WhatAboutThis), included["big_unsigned_int"], message.BigUnsignedInt, included["dues"], fromMoneyCurrencyCode(message.Dues), included["dues"], fromMoneyAmount(message.Dues), fromString(message.Id))
 	if err != nil {
 		return
 	}
@@ -327,17 +376,18 @@ func UpdateBoyScout(ctx context.Context, db *sql.DB, message *pb.BoyScout, field
 // returned will be nil. On error, the error returned will not be nil. It is
 // not considered an error if there is no message having the specified id in
 // the database; i.e. deletions are idempotent.
-func DeleteBoyScout(ctx context.Context, db *sql.DB, id string) (err error) {
+func DeleteBoyScout(ctx context.Context, db Database, id string) (err error) {
 	var message pb.BoyScout
-	var transaction *sql.Tx
+	var transaction transactor
 	defer func() {
+		err = classifyError(err)
 		if err != nil && transaction != nil {
 			err = combineErrors(err, transaction.Rollback())
 		}
 	}()
 
 	message.Id = id
-	transaction, err = db.BeginTx(ctx, nil)
+	transaction, err = beginTransaction(ctx, db)
 	if err != nil {
 		return
 	}
@@ -371,42 +421,116 @@ func DeleteBoyScout(ctx context.Context, db *sql.DB, id string) (err error) {
 	return
 }
 
-// CreateGirlScout adds the specified message to the specified db, subject to the
-// specified cancellation context ctx. Return nil on success, or return a
-// non-nil value if an error occurs.
-func CreateGirlScout(ctx context.Context, db *sql.DB, message *pb.GirlScout) (err error) {
-	var transaction *sql.Tx
+// UpsertBoyScout adds the specified message to the specified db, or replaces the
+// message in the db that has the same ID, subject to the specified cancellation
+// context ctx. Return nil on success, or return a non-nil value if an error
+// occurs.
+func UpsertBoyScout(ctx context.Context, db Database, message *pb.BoyScout) (err error) {
+	var transaction transactor
 	defer func() {
+		err = classifyError(err)
 		if err != nil && transaction != nil {
 			err = combineErrors(err, transaction.Rollback())
 		}
 	}()
+	var parameters []interface{}
 
-	transaction, err = db.BeginTx(ctx, nil)
+	transaction, err = beginTransaction(ctx, db)
 	if err != nil {
 		return
 	}
 
-	_, err = transaction.ExecContext(ctx, "insert into `girl_scout`( `id`) values (?);", fromString(message.Id))
+	_, err = transaction.ExecContext(ctx, "insert into `boy_scout`( `id`, `full_name`, `short_name`, `birthdate`, `join_time`, `country_code`, `language_code`, `pack_code`, `rank`, `iana_country_code`, `what_about_this`, `big_unsigned_int`, `dues_currency_code`, `dues_amount`) values (?, ?, ?, ?, from_unixtime(cast(? / 1000000.0 as decimal(20, 6))), ?, ?, ?, ?, ?, ?, ?, ?, ?) on duplicate key update `full_name` = values(`full_name`), `short_name` = values(`short_name`), `birthdate` = values(`birthdate`), `join_time` = values(`join_time`), `country_code` = values(`country_code`), `language_code` = values(`language_code`), `pack_code` = values(`pack_code`), `rank` = values(`rank`), `iana_country_code` = values(`iana_country_code`), `what_about_this` = values(`what_about_this`), `big_unsigned_int` = values(`big_unsigned_int`), `dues_currency_code` = values(`dues_currency_code`), `dues_amount` = values(`dues_amount`);", fromString(message.Id), fromString(message.FullName), fromString(message.ShortName), fromDate(message.Birthdate), fromTimestamp(message.JoinTime), fromString(message.CountryCode), fromString(message.LanguageCode), fromUint32(message.PackCode), fromInt32(int32(message.Rank)), fromString(message.IANACountryCode), fromInt64(message.WhatAboutThis), message.BigUnsignedInt, fromMoneyCurrencyCode(message.Dues), fromMoneyAmount(message.Dues))
+	if err != nil {
+		return
+	}
+
+	_, err = transaction.ExecContext(ctx, "delete from `boy_scout_badges` where `id` = ?;", fromString(message.Id))
+	if err != nil {
+		return
+	}
+
+	if len(message.Badges) != 0 {
+		parameters = nil
+		for i, element := range message.Badges {
+			parameters = append(parameters, fromString(message.Id), i, fromInt32(int32(element)))
+		}
+		_, err = transaction.ExecContext(ctx, withTuples("insert into `boy_scout_badges`( `id`, `ordinality`, `value`) values", "(?, ?, ?)", len(message.Badges)), parameters...)
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = transaction.ExecContext(ctx, "delete from `boy_scout_favorite_songs` where `id` = ?;", fromString(message.Id))
+	if err != nil {
+		return
+	}
+
+	if len(message.FavoriteSongs) != 0 {
+		parameters = nil
+		for i, element := range message.FavoriteSongs {
+			parameters = append(parameters, fromString(message.Id), i, fromString(element))
+		}
+		_, err = transaction.ExecContext(ctx, withTuples("insert into `boy_scout_favorite_songs`( `id`, `ordinality`, `value`) values", "(?, ?, ?)", len(message.FavoriteSongs)), parameters...)
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = transaction.ExecContext(ctx, "delete from `boy_scout_camping_trips` where `id` = ?;", fromString(message.Id))
+	if err != nil {
+		return
+	}
+
+	if len(message.CampingTrips) != 0 {
+		parameters = nil
+		for i, element := range message.CampingTrips {
+			parameters = append(parameters, fromString(message.Id), i, fromDate(element))
+		}
+		_, err = transaction.ExecContext(ctx, withTuples("insert into `boy_scout_camping_trips`( `id`, `ordinality`, `value`) values", "(?, ?, ?)", len(message.CampingTrips)), parameters...)
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = transaction.ExecContext(ctx, "delete from `boy_scout_mask` where `id` = ?;", fromString(message.Id))
 	if err != nil {
 		return
 	}
 
+	if fieldMaskLen(message.Mask) != 0 {
+		parameters = nil
+		for i, element := range message.Mask.Paths {
+			parameters = append(parameters, fromString(message.Id), i, fromString(element))
+		}
+		_, err = transaction.ExecContext(ctx, withTuples("insert into `boy_scout_mask`( `id`, `ordinality`, `value`) values", "(?, ?, ?)", fieldMaskLen(message.Mask)), parameters...)
+		if err != nil {
+			return
+		}
+	}
+
 	err = transaction.Commit()
 	return
 }
 
-// ReadGirlScout reads from the specified db into the specified message, where
-// the ID of the message must be pre-populated by the caller. On success, the
-// error returned will be nil. On error, the error returned will not be nil.
-// The specified cancellation context ctx is forwarded wherever appropriate.
-func ReadGirlScout(ctx context.Context, db *sql.DB, message *pb.GirlScout) (err error) {
-	var transaction *sql.Tx
+// ListBoyScouts reads from the specified db at most the specified pageSize
+// messages, in order of their IDs, subject to the specified cancellation
+// context ctx. If the specified pageToken is empty, then the page begins with
+// the first message. Otherwise, pageToken must be a nextPageToken returned by
+// a previous call to ListBoyScouts, and the page begins with the message
+// after the last message of that call's page. On success, return the messages
+// and a nextPageToken for the next page, and a nil error. If there are no more
+// messages after the returned page, then nextPageToken is empty. Note that the
+// last page might be empty. On error, the error returned will not be nil.
+func ListBoyScouts(ctx context.Context, db Database, pageSize int, pageToken string) (messages []*pb.BoyScout, nextPageToken string, err error) {
+	var transaction transactor
 	defer func() {
+		err = classifyError(err)
 		if err != nil && transaction != nil {
 			err = combineErrors(err, transaction.Rollback())
 		}
 	}()
+	var after string
 	var rows *sql.Rows
 	defer func() {
 		if rows != nil {
@@ -414,45 +538,157 @@ func ReadGirlScout(ctx context.Context, db *sql.DB, message *pb.GirlScout) (err
 		}
 	}()
 	var ok bool
+	var message *pb.BoyScout
+	var last string
+	var byID map[string]*pb.BoyScout
+
+	if pageSize < 1 {
+		err = invalidArgument("ListBoyScouts requires a positive pageSize, but %d was specified", pageSize)
+		return
+	}
+
+	if pageToken != "" {
+		err = decodePageToken(pageToken, &after)
+		if err != nil {
+			return
+		}
+	}
 
-	transaction, err = db.BeginTx(ctx, nil)
+	transaction, err = beginTransaction(ctx, db)
 	if err != nil {
 		return
 	}
 
-	rows, err = transaction.QueryContext(ctx, "select `id` from `girl_scout` where `id` = ?;", fromString(message.Id))
+	rows, err = transaction.QueryContext(ctx, "select `id`, `full_name`, `short_name`, `birthdate`, floor(unix_timestamp(`join_time`) * 1000000), `country_code`, `language_code`, `pack_code`, `rank`, `iana_country_code`, `what_about_this`, `big_unsigned_int`, `dues_currency_code`, `dues_amount` from `boy_scout` where ? or `id` > ? order by `id` limit ?;", pageToken == "", fromString(after), pageSize)
 	if err != nil {
 		return
 	}
 	ok = rows.Next()
 
-	if !ok {
-		err = noRow()
+	for ; ok; ok = rows.Next() {
+		message = &pb.BoyScout{}
+		err = rows.Scan(intoString(&message.Id), intoString(&message.FullName), intoString(&message.ShortName), intoDate(&message.Birthdate), intoTimestamp(&message.JoinTime), intoString(&message.CountryCode), intoString(&message.LanguageCode), intoUint32(&message.PackCode), intoEnum(func(value int32) { message.Rank = pb.Rank(value) }), intoString(&message.IANACountryCode), intoInt64(&message.WhatAboutThis), intoUint64(&message.BigUnsignedInt), intoMoneyCurrencyCode(&message.Dues), intoMoneyAmount(&message.Dues))
+		if err != nil {
+			return
+		}
+		messages = append(messages, message)
+	}
+
+	if len(messages) == 0 {
+		err = transaction.Commit()
 		return
 	}
 
-	err = rows.Scan(intoString(&message.Id))
+	byID = make(map[string]*pb.BoyScout, len(messages))
+	for _, message := range messages {
+		byID[message.Id] = message
+	}
+	last = messages[len(messages)-1].Id
+
+	rows, err = transaction.QueryContext(ctx, "select `id`, `value` from `boy_scout_badges` where (? or `id` > ?) and `id` <= ? order by `id`, `ordinality`;", pageToken == "", fromString(after), fromString(last))
 	if err != nil {
 		return
 	}
-	rows.Next()
+	ok = rows.Next()
+
+	for ; ok; ok = rows.Next() {
+		var key string
+		var temp pb.Badge
+		err = rows.Scan(intoString(&key), intoEnum(func(value int32) { temp = pb.Badge(value) }))
+		if err != nil {
+			return
+		}
+		message = byID[key]
+		if message != nil {
+			message.Badges = append(message.Badges, temp)
+		}
+	}
+
+	rows, err = transaction.QueryContext(ctx, "select `id`, `value` from `boy_scout_favorite_songs` where (? or `id` > ?) and `id` <= ? order by `id`, `ordinality`;", pageToken == "", fromString(after), fromString(last))
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	for ; ok; ok = rows.Next() {
+		var key string
+		var temp string
+		err = rows.Scan(intoString(&key), intoString(&temp))
+		if err != nil {
+			return
+		}
+		message = byID[key]
+		if message != nil {
+			message.FavoriteSongs = append(message.FavoriteSongs, temp)
+		}
+	}
+
+	rows, err = transaction.QueryContext(ctx, "select `id`, `value` from `boy_scout_camping_trips` where (? or `id` > ?) and `id` <= ? order by `id`, `ordinality`;", pageToken == "", fromString(after), fromString(last))
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	for ; ok; ok = rows.Next() {
+		var key string
+		var temp *date.Date
+		err = rows.Scan(intoString(&key), intoDate(&temp))
+		if err != nil {
+			return
+		}
+		message = byID[key]
+		if message != nil {
+			message.CampingTrips = append(message.CampingTrips, temp)
+		}
+	}
+
+	rows, err = transaction.QueryContext(ctx, "select `id`, `value` from `boy_scout_mask` where (? or `id` > ?) and `id` <= ? order by `id`, `ordinality`;", pageToken == "", fromString(after), fromString(last))
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	for ; ok; ok = rows.Next() {
+		var key string
+		var temp string
+		err = rows.Scan(intoString(&key), intoString(&temp))
+		if err != nil {
+			return
+		}
+		message = byID[key]
+		if message != nil {
+			message.Mask = appendField(message.Mask, temp)
+		}
+	}
+
+	if len(messages) == pageSize {
+		nextPageToken, err = encodePageToken(messages[len(messages)-1].Id)
+		if err != nil {
+			return
+		}
+	}
 
 	err = transaction.Commit()
 	return
 }
 
-// UpdateGirlScout updates within the specified db the fields of the specified
-// message that are indicated by the specified fieldMask, subject to
-// specified cancellation context ctx. Each element of fieldMask is the
-// name of a field in message whose value is to be used in the database
-// update. Return nil on success, or a non-nil error if an error occurs.
-func UpdateGirlScout(ctx context.Context, db *sql.DB, message *pb.GirlScout, fieldMask []string) (err error) {
-	var transaction *sql.Tx
+// ReadBoyScouts reads from the specified db the messages having any of the
+// specified ids, subject to the specified cancellation context ctx. Each table
+// is queried once for all of the ids, or once per chunk of the ids if there are
+// more than a statement can have parameters. On success, return a map from ID to
+// message, and a nil error. IDs for which there is no message are absent from
+// the map. On error, the error returned will not be nil.
+func ReadBoyScouts(ctx context.Context, db Database, ids []string) (byID map[string]*pb.BoyScout, err error) {
+	var transaction transactor
 	defer func() {
+		err = classifyError(err)
 		if err != nil && transaction != nil {
 			err = combineErrors(err, transaction.Rollback())
 		}
 	}()
+	var messages []*pb.BoyScout
+	var remaining []string
+	var parameters []interface{}
 	var rows *sql.Rows
 	defer func() {
 		if rows != nil {
@@ -460,60 +696,2058 @@ func UpdateGirlScout(ctx context.Context, db *sql.DB, message *pb.GirlScout, fie
 		}
 	}()
 	var ok bool
+	var message *pb.BoyScout
+
+	byID = make(map[string]*pb.BoyScout, len(ids))
+	if len(ids) == 0 {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	remaining = ids
+	for len(remaining) != 0 {
+		ids = remaining
+		if len(ids) > maxStatementParameters {
+			ids = ids[:maxStatementParameters]
+		}
+		remaining = remaining[len(ids):]
+		messages = nil
+
+		parameters = nil
+		for _, id := range ids {
+			parameters = append(parameters, fromString(id))
+		}
+		rows, err = transaction.QueryContext(ctx, withTuples("select `id`, `full_name`, `short_name`, `birthdate`, floor(unix_timestamp(`join_time`) * 1000000), `country_code`, `language_code`, `pack_code`, `rank`, `iana_country_code`, `what_about_this`, `big_unsigned_int`, `dues_currency_code`, `dues_amount` from `boy_scout` where `id` in (", "?", len(ids))+");", parameters...)
+		if err != nil {
+			return
+		}
+		ok = rows.Next()
+
+		for ; ok; ok = rows.Next() {
+			message = &pb.BoyScout{}
+			err = rows.Scan(intoString(&message.Id), intoString(&message.FullName), intoString(&message.ShortName), intoDate(&message.Birthdate), intoTimestamp(&message.JoinTime), intoString(&message.CountryCode), intoString(&message.LanguageCode), intoUint32(&message.PackCode), intoEnum(func(value int32) { message.Rank = pb.Rank(value) }), intoString(&message.IANACountryCode), intoInt64(&message.WhatAboutThis), intoUint64(&message.BigUnsignedInt), intoMoneyCurrencyCode(&message.Dues), intoMoneyAmount(&message.Dues))
+			if err != nil {
+				return
+			}
+			messages = append(messages, message)
+		}
+
+		for _, message := range messages {
+			byID[message.Id] = message
+		}
+
+		parameters = nil
+		for _, id := range ids {
+			parameters = append(parameters, fromString(id))
+		}
+		rows, err = transaction.QueryContext(ctx, withTuples("select `id`, `value` from `boy_scout_badges` where `id` in (", "?", len(ids))+") order by `id`, `ordinality`;", parameters...)
+		if err != nil {
+			return
+		}
+		ok = rows.Next()
+
+		for ; ok; ok = rows.Next() {
+			var key string
+			var temp pb.Badge
+			err = rows.Scan(intoString(&key), intoEnum(func(value int32) { temp = pb.Badge(value) }))
+			if err != nil {
+				return
+			}
+			message = byID[key]
+			if message != nil {
+				message.Badges = append(message.Badges, temp)
+			}
+		}
+
+		parameters = nil
+		for _, id := range ids {
+			parameters = append(parameters, fromString(id))
+		}
+		rows, err = transaction.QueryContext(ctx, withTuples("select `id`, `value` from `boy_scout_favorite_songs` where `id` in (", "?", len(ids))+") order by `id`, `ordinality`;", parameters...)
+		if err != nil {
+			return
+		}
+		ok = rows.Next()
+
+		for ; ok; ok = rows.Next() {
+			var key string
+			var temp string
+			err = rows.Scan(intoString(&key), intoString(&temp))
+			if err != nil {
+				return
+			}
+			message = byID[key]
+			if message != nil {
+				message.FavoriteSongs = append(message.FavoriteSongs, temp)
+			}
+		}
+
+		parameters = nil
+		for _, id := range ids {
+			parameters = append(parameters, fromString(id))
+		}
+		rows, err = transaction.QueryContext(ctx, withTuples("select `id`, `value` from `boy_scout_camping_trips` where `id` in (", "?", len(ids))+") order by `id`, `ordinality`;", parameters...)
+		if err != nil {
+			return
+		}
+		ok = rows.Next()
+
+		for ; ok; ok = rows.Next() {
+			var key string
+			var temp *date.Date
+			err = rows.Scan(intoString(&key), intoDate(&temp))
+			if err != nil {
+				return
+			}
+			message = byID[key]
+			if message != nil {
+				message.CampingTrips = append(message.CampingTrips, temp)
+			}
+		}
+
+		parameters = nil
+		for _, id := range ids {
+			parameters = append(parameters, fromString(id))
+		}
+		rows, err = transaction.QueryContext(ctx, withTuples("select `id`, `value` from `boy_scout_mask` where `id` in (", "?", len(ids))+") order by `id`, `ordinality`;", parameters...)
+		if err != nil {
+			return
+		}
+		ok = rows.Next()
+
+		for ; ok; ok = rows.Next() {
+			var key string
+			var temp string
+			err = rows.Scan(intoString(&key), intoString(&temp))
+			if err != nil {
+				return
+			}
+			message = byID[key]
+			if message != nil {
+				message.Mask = appendField(message.Mask, temp)
+			}
+		}
+
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// CreateBoyScouts adds the specified messages to the specified db, subject to the
+// specified cancellation context ctx. Each table is inserted into using as few
+// statements as fit within MaxStatementBytes. Either all of the messages are
+// added, or none of them are. Return nil on success, or return a non-nil value
+// if an error occurs.
+func CreateBoyScouts(ctx context.Context, db Database, messages []*pb.BoyScout) (err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var batch *tupleBatch
+
+	if len(messages) == 0 {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	batch = newTupleBatch(transaction, "insert into `boy_scout`( `id`, `full_name`, `short_name`, `birthdate`, `join_time`, `country_code`, `language_code`, `pack_code`, `rank`, `iana_country_code`, `what_about_this`, `big_unsigned_int`, `dues_currency_code`, `dues_amount`) values", "(?, ?, ?, ?, from_unixtime(cast(? / 1000000.0 as decimal(20, 6))), ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	for _, message := range messages {
+		err = batch.add(ctx, fromString(message.Id), fromString(message.FullName), fromString(message.ShortName), fromDate(message.Birthdate), fromTimestamp(message.JoinTime), fromString(message.CountryCode), fromString(message.LanguageCode), fromUint32(message.PackCode), fromInt32(int32(message.Rank)), fromString(message.IANACountryCode), fromInt64(message.WhatAboutThis), message.BigUnsignedInt, fromMoneyCurrencyCode(message.Dues), fromMoneyAmount(message.Dues))
+		if err != nil {
+			return
+		}
+	}
+	err = batch.flush(ctx)
+	if err != nil {
+		return
+	}
+
+	batch = newTupleBatch(transaction, "insert into `boy_scout_badges`( `id`, `ordinality`, `value`) values", "(?, ?, ?)")
+	for _, message := range messages {
+		for i, element := range message.Badges {
+			err = batch.add(ctx, fromString(message.Id), i, fromInt32(int32(element)))
+			if err != nil {
+				return
+			}
+		}
+	}
+	err = batch.flush(ctx)
+	if err != nil {
+		return
+	}
+
+	batch = newTupleBatch(transaction, "insert into `boy_scout_favorite_songs`( `id`, `ordinality`, `value`) values", "(?, ?, ?)")
+	for _, message := range messages {
+		for i, element := range message.FavoriteSongs {
+			err = batch.add(ctx, fromString(message.Id), i, fromString(element))
+			if err != nil {
+				return
+			}
+		}
+	}
+	err = batch.flush(ctx)
+	if err != nil {
+		return
+	}
+
+	batch = newTupleBatch(transaction, "insert into `boy_scout_camping_trips`( `id`, `ordinality`, `value`) values", "(?, ?, ?)")
+	for _, message := range messages {
+		for i, element := range message.CampingTrips {
+			err = batch.add(ctx, fromString(message.Id), i, fromDate(element))
+			if err != nil {
+				return
+			}
+		}
+	}
+	err = batch.flush(ctx)
+	if err != nil {
+		return
+	}
+
+	batch = newTupleBatch(transaction, "insert into `boy_scout_mask`( `id`, `ordinality`, `value`) values", "(?, ?, ?)")
+	for _, message := range messages {
+		if message.Mask != nil {
+			for i, element := range message.Mask.Paths {
+				err = batch.add(ctx, fromString(message.Id), i, fromString(element))
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	err = batch.flush(ctx)
+	if err != nil {
+		return
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// ExistsBoyScout returns whether there is a message having the specified id in
+// the specified db, subject to the specified cancellation context ctx. The
+// message itself is not read. On error, the error returned will not be nil.
+func ExistsBoyScout(ctx context.Context, db Database, id string) (exists bool, err error) {
+	var message pb.BoyScout
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var ok bool
+
+	message.Id = id
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, "select exists (select null from `boy_scout` where `id` = ?);", fromString(message.Id))
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	if ok {
+		err = rows.Scan(&exists)
+		if err != nil {
+			return
+		}
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// CountBoyScouts returns the number of messages in the specified db that satisfy
+// all of the specified conditions, subject to the specified cancellation
+// context ctx. If no conditions are specified, then all of the messages are
+// counted. On error, the error returned will not be nil.
+func CountBoyScouts(ctx context.Context, db Database, conditions ...BoyScoutCondition) (count int64, err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var all []condition
+	var sqlText string
+	var parameters []interface{}
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var ok bool
+
+	for _, where := range conditions {
+		all = append(all, where.condition)
+	}
+	sqlText, parameters, err = buildCount("select count(*) from `boy_scout`", all)
+	if err != nil {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, sqlText, parameters...)
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	if ok {
+		err = rows.Scan(&count)
+		if err != nil {
+			return
+		}
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// BoyScoutQueryBuilder builds a query that reads BoyScout messages. Use
+// BoyScoutQuery to create one.
+type BoyScoutQueryBuilder struct{ query queryBuilder }
+
+// BoyScoutQuery returns a new query builder that, until conditions are
+// added to it, reads all of the BoyScout messages, in order of their IDs.
+// For example:
+//
+//     messages, err := BoyScoutQuery().Where(condition).Limit(10).Read(ctx, db)
+func BoyScoutQuery() *BoyScoutQueryBuilder {
+	return &BoyScoutQueryBuilder{}
+}
+
+// Where adds the specified condition to the query, and returns the builder.
+// A message is read only if all of the conditions added are true of it.
+func (builder *BoyScoutQueryBuilder) Where(where BoyScoutCondition) *BoyScoutQueryBuilder {
+	builder.query.conditions = append(builder.query.conditions, where.condition)
+	return builder
+}
+
+// And is a synonym for Where.
+func (builder *BoyScoutQueryBuilder) And(where BoyScoutCondition) *BoyScoutQueryBuilder {
+	builder.query.conditions = append(builder.query.conditions, where.condition)
+	return builder
+}
+
+// OrderBy orders the messages read by the specified orderings, and returns
+// the builder. Messages that are equal according to all of the orderings are
+// ordered by their IDs.
+func (builder *BoyScoutQueryBuilder) OrderBy(orderings ...BoyScoutOrdering) *BoyScoutQueryBuilder {
+	for _, ordering := range orderings {
+		builder.query.orderings = append(builder.query.orderings, ordering.sql)
+	}
+	return builder
+}
+
+// Limit reads at most the specified limit of messages, and returns the
+// builder. A limit that is not positive means no limit.
+func (builder *BoyScoutQueryBuilder) Limit(limit int) *BoyScoutQueryBuilder {
+	builder.query.limit = limit
+	return builder
+}
+
+// Read reads from the specified db the messages selected by the query,
+// subject to the specified cancellation context ctx. The messages are read
+// within one transaction. On success, return the messages and a nil error. On
+// error, the error returned will not be nil.
+func (builder *BoyScoutQueryBuilder) Read(ctx context.Context, db Database) (messages []*pb.BoyScout, err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var sqlText string
+	var parameters []interface{}
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var id string
+	var ids []string
+	var byID map[string]*pb.BoyScout
+
+	sqlText, parameters, err = buildQuery(builder.query, "select `id` from `boy_scout`", "`id`")
+	if err != nil {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, sqlText, parameters...)
+	if err != nil {
+		return
+	}
+
+	for rows.Next() {
+		err = rows.Scan(intoString(&id))
+		if err != nil {
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	byID, err = ReadBoyScouts(ctx, transaction, ids)
+	if err != nil {
+		return
+	}
+
+	for _, key := range ids {
+		if byID[key] != nil {
+			messages = append(messages, byID[key])
+		}
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// BoyScoutCondition is a condition on BoyScout messages, for use with
+// BoyScoutQueryBuilder. Conditions are returned by the methods of column
+// variables, e.g. BoyScoutId.Eq.
+type BoyScoutCondition struct{ condition }
+
+// Or returns a condition that is true when either the left or the specified
+// right condition is true.
+func (left BoyScoutCondition) Or(right BoyScoutCondition) BoyScoutCondition {
+	return BoyScoutCondition{orConditions(left.condition, right.condition)}
+}
+
+// BoyScoutOrdering is an order in which BoyScoutQueryBuilder reads messages.
+// Orderings are returned by the methods of column variables, e.g.
+// BoyScoutId.Desc.
+type BoyScoutOrdering struct{ sql string }
+
+// BoyScoutIdColumn is the type of BoyScoutId, which refers to the
+// id field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutIdColumn struct{}
+
+// BoyScoutId refers to the id field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutId BoyScoutIdColumn
+
+// Eq returns a condition that is true when the id field
+// is equal to the specified value.
+func (BoyScoutIdColumn) Eq(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`id`", "=", "?", fromString(value))}
+}
+
+// Ne returns a condition that is true when the id field
+// is not equal to the specified value.
+func (BoyScoutIdColumn) Ne(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`id`", "<>", "?", fromString(value))}
+}
+
+// Lt returns a condition that is true when the id field
+// is less than the specified value.
+func (BoyScoutIdColumn) Lt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`id`", "<", "?", fromString(value))}
+}
+
+// Le returns a condition that is true when the id field
+// is at most the specified value.
+func (BoyScoutIdColumn) Le(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`id`", "<=", "?", fromString(value))}
+}
+
+// Gt returns a condition that is true when the id field
+// is greater than the specified value.
+func (BoyScoutIdColumn) Gt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`id`", ">", "?", fromString(value))}
+}
+
+// Ge returns a condition that is true when the id field
+// is at least the specified value.
+func (BoyScoutIdColumn) Ge(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`id`", ">=", "?", fromString(value))}
+}
+
+// IsNull returns a condition that is true when the id field is null.
+func (BoyScoutIdColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`id`")}
+}
+
+// IsNotNull returns a condition that is true when the id field is not
+// null.
+func (BoyScoutIdColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`id`")}
+}
+
+// Asc returns an ordering by the id field, ascending.
+func (BoyScoutIdColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`id` asc"}
+}
+
+// Desc returns an ordering by the id field, descending.
+func (BoyScoutIdColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`id` desc"}
+}
+
+// BoyScoutFullNameColumn is the type of BoyScoutFullName, which refers to the
+// full_name field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutFullNameColumn struct{}
+
+// BoyScoutFullName refers to the full_name field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutFullName BoyScoutFullNameColumn
+
+// Eq returns a condition that is true when the full_name field
+// is equal to the specified value.
+func (BoyScoutFullNameColumn) Eq(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`full_name`", "=", "?", fromString(value))}
+}
+
+// Ne returns a condition that is true when the full_name field
+// is not equal to the specified value.
+func (BoyScoutFullNameColumn) Ne(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`full_name`", "<>", "?", fromString(value))}
+}
+
+// Lt returns a condition that is true when the full_name field
+// is less than the specified value.
+func (BoyScoutFullNameColumn) Lt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`full_name`", "<", "?", fromString(value))}
+}
+
+// Le returns a condition that is true when the full_name field
+// is at most the specified value.
+func (BoyScoutFullNameColumn) Le(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`full_name`", "<=", "?", fromString(value))}
+}
+
+// Gt returns a condition that is true when the full_name field
+// is greater than the specified value.
+func (BoyScoutFullNameColumn) Gt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`full_name`", ">", "?", fromString(value))}
+}
+
+// Ge returns a condition that is true when the full_name field
+// is at least the specified value.
+func (BoyScoutFullNameColumn) Ge(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`full_name`", ">=", "?", fromString(value))}
+}
+
+// IsNull returns a condition that is true when the full_name field is null.
+func (BoyScoutFullNameColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`full_name`")}
+}
+
+// IsNotNull returns a condition that is true when the full_name field is not
+// null.
+func (BoyScoutFullNameColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`full_name`")}
+}
+
+// Asc returns an ordering by the full_name field, ascending.
+func (BoyScoutFullNameColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`full_name` asc"}
+}
+
+// Desc returns an ordering by the full_name field, descending.
+func (BoyScoutFullNameColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`full_name` desc"}
+}
+
+// BoyScoutShortNameColumn is the type of BoyScoutShortName, which refers to the
+// short_name field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutShortNameColumn struct{}
+
+// BoyScoutShortName refers to the short_name field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutShortName BoyScoutShortNameColumn
+
+// Eq returns a condition that is true when the short_name field
+// is equal to the specified value.
+func (BoyScoutShortNameColumn) Eq(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`short_name`", "=", "?", fromString(value))}
+}
+
+// Ne returns a condition that is true when the short_name field
+// is not equal to the specified value.
+func (BoyScoutShortNameColumn) Ne(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`short_name`", "<>", "?", fromString(value))}
+}
+
+// Lt returns a condition that is true when the short_name field
+// is less than the specified value.
+func (BoyScoutShortNameColumn) Lt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`short_name`", "<", "?", fromString(value))}
+}
+
+// Le returns a condition that is true when the short_name field
+// is at most the specified value.
+func (BoyScoutShortNameColumn) Le(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`short_name`", "<=", "?", fromString(value))}
+}
+
+// Gt returns a condition that is true when the short_name field
+// is greater than the specified value.
+func (BoyScoutShortNameColumn) Gt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`short_name`", ">", "?", fromString(value))}
+}
+
+// Ge returns a condition that is true when the short_name field
+// is at least the specified value.
+func (BoyScoutShortNameColumn) Ge(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`short_name`", ">=", "?", fromString(value))}
+}
+
+// IsNull returns a condition that is true when the short_name field is null.
+func (BoyScoutShortNameColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`short_name`")}
+}
+
+// IsNotNull returns a condition that is true when the short_name field is not
+// null.
+func (BoyScoutShortNameColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`short_name`")}
+}
+
+// Asc returns an ordering by the short_name field, ascending.
+func (BoyScoutShortNameColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`short_name` asc"}
+}
+
+// Desc returns an ordering by the short_name field, descending.
+func (BoyScoutShortNameColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`short_name` desc"}
+}
+
+// BoyScoutBirthdateColumn is the type of BoyScoutBirthdate, which refers to the
+// birthdate field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutBirthdateColumn struct{}
+
+// BoyScoutBirthdate refers to the birthdate field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutBirthdate BoyScoutBirthdateColumn
+
+// Eq returns a condition that is true when the birthdate field
+// is equal to the specified value.
+func (BoyScoutBirthdateColumn) Eq(value *date.Date) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`birthdate`", "=", "?", fromDate(value))}
+}
+
+// Ne returns a condition that is true when the birthdate field
+// is not equal to the specified value.
+func (BoyScoutBirthdateColumn) Ne(value *date.Date) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`birthdate`", "<>", "?", fromDate(value))}
+}
+
+// Lt returns a condition that is true when the birthdate field
+// is less than the specified value.
+func (BoyScoutBirthdateColumn) Lt(value *date.Date) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`birthdate`", "<", "?", fromDate(value))}
+}
+
+// Le returns a condition that is true when the birthdate field
+// is at most the specified value.
+func (BoyScoutBirthdateColumn) Le(value *date.Date) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`birthdate`", "<=", "?", fromDate(value))}
+}
+
+// Gt returns a condition that is true when the birthdate field
+// is greater than the specified value.
+func (BoyScoutBirthdateColumn) Gt(value *date.Date) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`birthdate`", ">", "?", fromDate(value))}
+}
+
+// Ge returns a condition that is true when the birthdate field
+// is at least the specified value.
+func (BoyScoutBirthdateColumn) Ge(value *date.Date) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`birthdate`", ">=", "?", fromDate(value))}
+}
+
+// Before returns a condition that is true when the birthdate field
+// is before the specified value.
+func (BoyScoutBirthdateColumn) Before(value *date.Date) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`birthdate`", "<", "?", fromDate(value))}
+}
+
+// After returns a condition that is true when the birthdate field
+// is after the specified value.
+func (BoyScoutBirthdateColumn) After(value *date.Date) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`birthdate`", ">", "?", fromDate(value))}
+}
+
+// IsNull returns a condition that is true when the birthdate field is null.
+func (BoyScoutBirthdateColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`birthdate`")}
+}
+
+// IsNotNull returns a condition that is true when the birthdate field is not
+// null.
+func (BoyScoutBirthdateColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`birthdate`")}
+}
+
+// Asc returns an ordering by the birthdate field, ascending.
+func (BoyScoutBirthdateColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`birthdate` asc"}
+}
+
+// Desc returns an ordering by the birthdate field, descending.
+func (BoyScoutBirthdateColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`birthdate` desc"}
+}
+
+// BoyScoutJoinTimeColumn is the type of BoyScoutJoinTime, which refers to the
+// join_time field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutJoinTimeColumn struct{}
+
+// BoyScoutJoinTime refers to the join_time field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutJoinTime BoyScoutJoinTimeColumn
+
+// Eq returns a condition that is true when the join_time field
+// is equal to the specified value.
+func (BoyScoutJoinTimeColumn) Eq(value *timestamp.Timestamp) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`join_time`", "=", "from_unixtime(cast(? / 1000000.0 as decimal(20, 6)))", fromTimestamp(value))}
+}
+
+// Ne returns a condition that is true when the join_time field
+// is not equal to the specified value.
+func (BoyScoutJoinTimeColumn) Ne(value *timestamp.Timestamp) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`join_time`", "<>", "from_unixtime(cast(? / 1000000.0 as decimal(20, 6)))", fromTimestamp(value))}
+}
+
+// Lt returns a condition that is true when the join_time field
+// is less than the specified value.
+func (BoyScoutJoinTimeColumn) Lt(value *timestamp.Timestamp) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`join_time`", "<", "from_unixtime(cast(? / 1000000.0 as decimal(20, 6)))", fromTimestamp(value))}
+}
+
+// Le returns a condition that is true when the join_time field
+// is at most the specified value.
+func (BoyScoutJoinTimeColumn) Le(value *timestamp.Timestamp) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`join_time`", "<=", "from_unixtime(cast(? / 1000000.0 as decimal(20, 6)))", fromTimestamp(value))}
+}
+
+// Gt returns a condition that is true when the join_time field
+// is greater than the specified value.
+func (BoyScoutJoinTimeColumn) Gt(value *timestamp.Timestamp) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`join_time`", ">", "from_unixtime(cast(? / 1000000.0 as decimal(20, 6)))", fromTimestamp(value))}
+}
+
+// Ge returns a condition that is true when the join_time field
+// is at least the specified value.
+func (BoyScoutJoinTimeColumn) Ge(value *timestamp.Timestamp) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`join_time`", ">=", "from_unixtime(cast(? / 1000000.0 as decimal(20, 6)))", fromTimestamp(value))}
+}
+
+// Before returns a condition that is true when the join_time field
+// is before the specified value.
+func (BoyScoutJoinTimeColumn) Before(value *timestamp.Timestamp) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`join_time`", "<", "from_unixtime(cast(? / 1000000.0 as decimal(20, 6)))", fromTimestamp(value))}
+}
+
+// After returns a condition that is true when the join_time field
+// is after the specified value.
+func (BoyScoutJoinTimeColumn) After(value *timestamp.Timestamp) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`join_time`", ">", "from_unixtime(cast(? / 1000000.0 as decimal(20, 6)))", fromTimestamp(value))}
+}
+
+// IsNull returns a condition that is true when the join_time field is null.
+func (BoyScoutJoinTimeColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`join_time`")}
+}
+
+// IsNotNull returns a condition that is true when the join_time field is not
+// null.
+func (BoyScoutJoinTimeColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`join_time`")}
+}
+
+// Asc returns an ordering by the join_time field, ascending.
+func (BoyScoutJoinTimeColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`join_time` asc"}
+}
+
+// Desc returns an ordering by the join_time field, descending.
+func (BoyScoutJoinTimeColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`join_time` desc"}
+}
+
+// BoyScoutCountryCodeColumn is the type of BoyScoutCountryCode, which refers to the
+// country_code field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutCountryCodeColumn struct{}
+
+// BoyScoutCountryCode refers to the country_code field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutCountryCode BoyScoutCountryCodeColumn
+
+// Eq returns a condition that is true when the country_code field
+// is equal to the specified value.
+func (BoyScoutCountryCodeColumn) Eq(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`country_code`", "=", "?", fromString(value))}
+}
+
+// Ne returns a condition that is true when the country_code field
+// is not equal to the specified value.
+func (BoyScoutCountryCodeColumn) Ne(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`country_code`", "<>", "?", fromString(value))}
+}
+
+// Lt returns a condition that is true when the country_code field
+// is less than the specified value.
+func (BoyScoutCountryCodeColumn) Lt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`country_code`", "<", "?", fromString(value))}
+}
+
+// Le returns a condition that is true when the country_code field
+// is at most the specified value.
+func (BoyScoutCountryCodeColumn) Le(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`country_code`", "<=", "?", fromString(value))}
+}
+
+// Gt returns a condition that is true when the country_code field
+// is greater than the specified value.
+func (BoyScoutCountryCodeColumn) Gt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`country_code`", ">", "?", fromString(value))}
+}
+
+// Ge returns a condition that is true when the country_code field
+// is at least the specified value.
+func (BoyScoutCountryCodeColumn) Ge(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`country_code`", ">=", "?", fromString(value))}
+}
+
+// IsNull returns a condition that is true when the country_code field is null.
+func (BoyScoutCountryCodeColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`country_code`")}
+}
+
+// IsNotNull returns a condition that is true when the country_code field is not
+// null.
+func (BoyScoutCountryCodeColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`country_code`")}
+}
+
+// Asc returns an ordering by the country_code field, ascending.
+func (BoyScoutCountryCodeColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`country_code` asc"}
+}
+
+// Desc returns an ordering by the country_code field, descending.
+func (BoyScoutCountryCodeColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`country_code` desc"}
+}
+
+// BoyScoutLanguageCodeColumn is the type of BoyScoutLanguageCode, which refers to the
+// language_code field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutLanguageCodeColumn struct{}
+
+// BoyScoutLanguageCode refers to the language_code field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutLanguageCode BoyScoutLanguageCodeColumn
+
+// Eq returns a condition that is true when the language_code field
+// is equal to the specified value.
+func (BoyScoutLanguageCodeColumn) Eq(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`language_code`", "=", "?", fromString(value))}
+}
+
+// Ne returns a condition that is true when the language_code field
+// is not equal to the specified value.
+func (BoyScoutLanguageCodeColumn) Ne(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`language_code`", "<>", "?", fromString(value))}
+}
+
+// Lt returns a condition that is true when the language_code field
+// is less than the specified value.
+func (BoyScoutLanguageCodeColumn) Lt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`language_code`", "<", "?", fromString(value))}
+}
+
+// Le returns a condition that is true when the language_code field
+// is at most the specified value.
+func (BoyScoutLanguageCodeColumn) Le(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`language_code`", "<=", "?", fromString(value))}
+}
+
+// Gt returns a condition that is true when the language_code field
+// is greater than the specified value.
+func (BoyScoutLanguageCodeColumn) Gt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`language_code`", ">", "?", fromString(value))}
+}
+
+// Ge returns a condition that is true when the language_code field
+// is at least the specified value.
+func (BoyScoutLanguageCodeColumn) Ge(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`language_code`", ">=", "?", fromString(value))}
+}
+
+// IsNull returns a condition that is true when the language_code field is null.
+func (BoyScoutLanguageCodeColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`language_code`")}
+}
+
+// IsNotNull returns a condition that is true when the language_code field is not
+// null.
+func (BoyScoutLanguageCodeColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`language_code`")}
+}
+
+// Asc returns an ordering by the language_code field, ascending.
+func (BoyScoutLanguageCodeColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`language_code` asc"}
+}
+
+// Desc returns an ordering by the language_code field, descending.
+func (BoyScoutLanguageCodeColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`language_code` desc"}
+}
+
+// BoyScoutPackCodeColumn is the type of BoyScoutPackCode, which refers to the
+// pack_code field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutPackCodeColumn struct{}
+
+// BoyScoutPackCode refers to the pack_code field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutPackCode BoyScoutPackCodeColumn
+
+// Eq returns a condition that is true when the pack_code field
+// is equal to the specified value.
+func (BoyScoutPackCodeColumn) Eq(value uint32) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`pack_code`", "=", "?", fromUint32(value))}
+}
+
+// Ne returns a condition that is true when the pack_code field
+// is not equal to the specified value.
+func (BoyScoutPackCodeColumn) Ne(value uint32) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`pack_code`", "<>", "?", fromUint32(value))}
+}
+
+// Lt returns a condition that is true when the pack_code field
+// is less than the specified value.
+func (BoyScoutPackCodeColumn) Lt(value uint32) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`pack_code`", "<", "?", fromUint32(value))}
+}
+
+// Le returns a condition that is true when the pack_code field
+// is at most the specified value.
+func (BoyScoutPackCodeColumn) Le(value uint32) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`pack_code`", "<=", "?", fromUint32(value))}
+}
+
+// Gt returns a condition that is true when the pack_code field
+// is greater than the specified value.
+func (BoyScoutPackCodeColumn) Gt(value uint32) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`pack_code`", ">", "?", fromUint32(value))}
+}
+
+// Ge returns a condition that is true when the pack_code field
+// is at least the specified value.
+func (BoyScoutPackCodeColumn) Ge(value uint32) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`pack_code`", ">=", "?", fromUint32(value))}
+}
+
+// IsNull returns a condition that is true when the pack_code field is null.
+func (BoyScoutPackCodeColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`pack_code`")}
+}
+
+// IsNotNull returns a condition that is true when the pack_code field is not
+// null.
+func (BoyScoutPackCodeColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`pack_code`")}
+}
+
+// Asc returns an ordering by the pack_code field, ascending.
+func (BoyScoutPackCodeColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`pack_code` asc"}
+}
+
+// Desc returns an ordering by the pack_code field, descending.
+func (BoyScoutPackCodeColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`pack_code` desc"}
+}
+
+// BoyScoutRankColumn is the type of BoyScoutRank, which refers to the
+// rank field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutRankColumn struct{}
+
+// BoyScoutRank refers to the rank field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutRank BoyScoutRankColumn
+
+// Eq returns a condition that is true when the rank field
+// is equal to the specified value.
+func (BoyScoutRankColumn) Eq(value pb.Rank) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`rank`", "=", "?", fromInt32(int32(value)))}
+}
+
+// Ne returns a condition that is true when the rank field
+// is not equal to the specified value.
+func (BoyScoutRankColumn) Ne(value pb.Rank) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`rank`", "<>", "?", fromInt32(int32(value)))}
+}
+
+// IsNull returns a condition that is true when the rank field is null.
+func (BoyScoutRankColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`rank`")}
+}
+
+// IsNotNull returns a condition that is true when the rank field is not
+// null.
+func (BoyScoutRankColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`rank`")}
+}
+
+// Asc returns an ordering by the rank field, ascending.
+func (BoyScoutRankColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`rank` asc"}
+}
+
+// Desc returns an ordering by the rank field, descending.
+func (BoyScoutRankColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`rank` desc"}
+}
+
+// BoyScoutIANACountryCodeColumn is the type of BoyScoutIANACountryCode, which refers to the
+// IANA_country_code field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutIANACountryCodeColumn struct{}
+
+// BoyScoutIANACountryCode refers to the IANA_country_code field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutIANACountryCode BoyScoutIANACountryCodeColumn
+
+// Eq returns a condition that is true when the IANA_country_code field
+// is equal to the specified value.
+func (BoyScoutIANACountryCodeColumn) Eq(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`iana_country_code`", "=", "?", fromString(value))}
+}
+
+// Ne returns a condition that is true when the IANA_country_code field
+// is not equal to the specified value.
+func (BoyScoutIANACountryCodeColumn) Ne(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`iana_country_code`", "<>", "?", fromString(value))}
+}
+
+// Lt returns a condition that is true when the IANA_country_code field
+// is less than the specified value.
+func (BoyScoutIANACountryCodeColumn) Lt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`iana_country_code`", "<", "?", fromString(value))}
+}
+
+// Le returns a condition that is true when the IANA_country_code field
+// is at most the specified value.
+func (BoyScoutIANACountryCodeColumn) Le(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`iana_country_code`", "<=", "?", fromString(value))}
+}
+
+// Gt returns a condition that is true when the IANA_country_code field
+// is greater than the specified value.
+func (BoyScoutIANACountryCodeColumn) Gt(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`iana_country_code`", ">", "?", fromString(value))}
+}
+
+// Ge returns a condition that is true when the IANA_country_code field
+// is at least the specified value.
+func (BoyScoutIANACountryCodeColumn) Ge(value string) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`iana_country_code`", ">=", "?", fromString(value))}
+}
+
+// IsNull returns a condition that is true when the IANA_country_code field is null.
+func (BoyScoutIANACountryCodeColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`iana_country_code`")}
+}
+
+// IsNotNull returns a condition that is true when the IANA_country_code field is not
+// null.
+func (BoyScoutIANACountryCodeColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`iana_country_code`")}
+}
+
+// Asc returns an ordering by the IANA_country_code field, ascending.
+func (BoyScoutIANACountryCodeColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`iana_country_code` asc"}
+}
+
+// Desc returns an ordering by the IANA_country_code field, descending.
+func (BoyScoutIANACountryCodeColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`iana_country_code` desc"}
+}
+
+// BoyScoutWhatAboutThisColumn is the type of BoyScoutWhatAboutThis, which refers to the
+// whatAboutThis field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutWhatAboutThisColumn struct{}
+
+// BoyScoutWhatAboutThis refers to the whatAboutThis field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutWhatAboutThis BoyScoutWhatAboutThisColumn
+
+// Eq returns a condition that is true when the whatAboutThis field
+// is equal to the specified value.
+func (BoyScoutWhatAboutThisColumn) Eq(value int64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`what_about_this`", "=", "?", fromInt64(value))}
+}
+
+// Ne returns a condition that is true when the whatAboutThis field
+// is not equal to the specified value.
+func (BoyScoutWhatAboutThisColumn) Ne(value int64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`what_about_this`", "<>", "?", fromInt64(value))}
+}
+
+// Lt returns a condition that is true when the whatAboutThis field
+// is less than the specified value.
+func (BoyScoutWhatAboutThisColumn) Lt(value int64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`what_about_this`", "<", "?", fromInt64(value))}
+}
+
+// Le returns a condition that is true when the whatAboutThis field
+// is at most the specified value.
+func (BoyScoutWhatAboutThisColumn) Le(value int64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`what_about_this`", "<=", "?", fromInt64(value))}
+}
+
+// Gt returns a condition that is true when the whatAboutThis field
+// is greater than the specified value.
+func (BoyScoutWhatAboutThisColumn) Gt(value int64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`what_about_this`", ">", "?", fromInt64(value))}
+}
+
+// Ge returns a condition that is true when the whatAboutThis field
+// is at least the specified value.
+func (BoyScoutWhatAboutThisColumn) Ge(value int64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`what_about_this`", ">=", "?", fromInt64(value))}
+}
+
+// IsNull returns a condition that is true when the whatAboutThis field is null.
+func (BoyScoutWhatAboutThisColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`what_about_this`")}
+}
+
+// IsNotNull returns a condition that is true when the whatAboutThis field is not
+// null.
+func (BoyScoutWhatAboutThisColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`what_about_this`")}
+}
+
+// Asc returns an ordering by the whatAboutThis field, ascending.
+func (BoyScoutWhatAboutThisColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`what_about_this` asc"}
+}
+
+// Desc returns an ordering by the whatAboutThis field, descending.
+func (BoyScoutWhatAboutThisColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`what_about_this` desc"}
+}
+
+// BoyScoutBigUnsignedIntColumn is the type of BoyScoutBigUnsignedInt, which refers to the
+// big_unsigned_int field of BoyScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type BoyScoutBigUnsignedIntColumn struct{}
+
+// BoyScoutBigUnsignedInt refers to the big_unsigned_int field of BoyScout messages
+// in queries (see BoyScoutQuery).
+var BoyScoutBigUnsignedInt BoyScoutBigUnsignedIntColumn
+
+// Eq returns a condition that is true when the big_unsigned_int field
+// is equal to the specified value.
+func (BoyScoutBigUnsignedIntColumn) Eq(value uint64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`big_unsigned_int`", "=", "?", value)}
+}
+
+// Ne returns a condition that is true when the big_unsigned_int field
+// is not equal to the specified value.
+func (BoyScoutBigUnsignedIntColumn) Ne(value uint64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`big_unsigned_int`", "<>", "?", value)}
+}
+
+// Lt returns a condition that is true when the big_unsigned_int field
+// is less than the specified value.
+func (BoyScoutBigUnsignedIntColumn) Lt(value uint64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`big_unsigned_int`", "<", "?", value)}
+}
+
+// Le returns a condition that is true when the big_unsigned_int field
+// is at most the specified value.
+func (BoyScoutBigUnsignedIntColumn) Le(value uint64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`big_unsigned_int`", "<=", "?", value)}
+}
+
+// Gt returns a condition that is true when the big_unsigned_int field
+// is greater than the specified value.
+func (BoyScoutBigUnsignedIntColumn) Gt(value uint64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`big_unsigned_int`", ">", "?", value)}
+}
+
+// Ge returns a condition that is true when the big_unsigned_int field
+// is at least the specified value.
+func (BoyScoutBigUnsignedIntColumn) Ge(value uint64) BoyScoutCondition {
+	return BoyScoutCondition{compareColumn("`big_unsigned_int`", ">=", "?", value)}
+}
+
+// IsNull returns a condition that is true when the big_unsigned_int field is null.
+func (BoyScoutBigUnsignedIntColumn) IsNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNull("`big_unsigned_int`")}
+}
+
+// IsNotNull returns a condition that is true when the big_unsigned_int field is not
+// null.
+func (BoyScoutBigUnsignedIntColumn) IsNotNull() BoyScoutCondition {
+	return BoyScoutCondition{columnIsNotNull("`big_unsigned_int`")}
+}
+
+// Asc returns an ordering by the big_unsigned_int field, ascending.
+func (BoyScoutBigUnsignedIntColumn) Asc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`big_unsigned_int` asc"}
+}
+
+// Desc returns an ordering by the big_unsigned_int field, descending.
+func (BoyScoutBigUnsignedIntColumn) Desc() BoyScoutOrdering {
+	return BoyScoutOrdering{"`big_unsigned_int` desc"}
+}
+
+// CreateGirlScout adds the specified message to the specified db, subject to the
+// specified cancellation context ctx. Return nil on success, or return a
+// non-nil value if an error occurs.
+func CreateGirlScout(ctx context.Context, db Database, message *pb.GirlScout) (err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	_, err = transaction.ExecContext(ctx, "insert into `girl_scout`( `id`) values (?);", fromString(message.Id))
+	if err != nil {
+		return
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// ReadGirlScout reads from the specified db into the specified message, where
+// the ID of the message must be pre-populated by the caller. Each element of
+// the specified fieldMask is the name of a field in message whose value is to
+// be read. Fields not in fieldMask are not modified. If fieldMask is empty,
+// then all fields are read. On success, the error returned will be nil. On
+// error, the error returned will not be nil. The specified cancellation
+// context ctx is forwarded wherever appropriate.
+func ReadGirlScout(ctx context.Context, db Database, message *pb.GirlScout, fieldMask []string) (err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var ok bool
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, "select `id` from `girl_scout` where `id` = ?;", fromString(message.Id))
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	if !ok {
+		err = noRow()
+		return
+	}
+
+	err = rows.Scan(intoString(&message.Id))
+	if err != nil {
+		return
+	}
+	rows.Next()
+
+	err = transaction.Commit()
+	return
+}
+
+// UpdateGirlScout updates within the specified db the fields of the specified
+// message that are indicated by the specified fieldMask, subject to
+// specified cancellation context ctx. Each element of fieldMask is the
+// name of a field in message whose value is to be used in the database
+// update. Return nil on success, or a non-nil error if an error occurs. If
+// fieldMask is empty, then there is nothing to update, and nil is returned
+// without consulting db. If fieldMask contains anything other than the name of
+// a field in message, then the error returned is of class ErrInvalidArgument.
+func UpdateGirlScout(ctx context.Context, db Database, message *pb.GirlScout, fieldMask []string) (err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var ok bool
+
+	if len(fieldMask) == 0 {
+		return
+	}
+	err = checkFieldMask(fieldMask, "id")
+	if err != nil {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, "select null from `girl_scout` where `id` = ?;", fromString(message.Id))
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	if !ok {
+		err = noRow()
+		return
+	}
+
+	err = rows.Scan(ignore())
+	if err != nil {
+		return
+	}
+	rows.Next()
+
+	err = transaction.Commit()
+	return
+}
+
+// DeleteGirlScout deletes the message having the specified id from the specified
+// db, subject to the specified cancellation context ctx. On success, the error
+// returned will be nil. On error, the error returned will not be nil. It is
+// not considered an error if there is no message having the specified id in
+// the database; i.e. deletions are idempotent.
+func DeleteGirlScout(ctx context.Context, db Database, id string) (err error) {
+	var message pb.GirlScout
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+
+	message.Id = id
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	_, err = transaction.ExecContext(ctx, "delete from `girl_scout` where `id` = ?;", fromString(message.Id))
+	if err != nil {
+		return
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// UpsertGirlScout adds the specified message to the specified db, or replaces the
+// message in the db that has the same ID, subject to the specified cancellation
+// context ctx. Return nil on success, or return a non-nil value if an error
+// occurs.
+func UpsertGirlScout(ctx context.Context, db Database, message *pb.GirlScout) (err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	_, err = transaction.ExecContext(ctx, "insert into `girl_scout`( `id`) values (?) on duplicate key update `id` = `id`;", fromString(message.Id))
+	if err != nil {
+		return
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// ListGirlScouts reads from the specified db at most the specified pageSize
+// messages, in order of their IDs, subject to the specified cancellation
+// context ctx. If the specified pageToken is empty, then the page begins with
+// the first message. Otherwise, pageToken must be a nextPageToken returned by
+// a previous call to ListGirlScouts, and the page begins with the message
+// after the last message of that call's page. On success, return the messages
+// and a nextPageToken for the next page, and a nil error. If there are no more
+// messages after the returned page, then nextPageToken is empty. Note that the
+// last page might be empty. On error, the error returned will not be nil.
+func ListGirlScouts(ctx context.Context, db Database, pageSize int, pageToken string) (messages []*pb.GirlScout, nextPageToken string, err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var after string
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var ok bool
+	var message *pb.GirlScout
+
+	if pageSize < 1 {
+		err = invalidArgument("ListGirlScouts requires a positive pageSize, but %d was specified", pageSize)
+		return
+	}
+
+	if pageToken != "" {
+		err = decodePageToken(pageToken, &after)
+		if err != nil {
+			return
+		}
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, "select `id` from `girl_scout` where ? or `id` > ? order by `id` limit ?;", pageToken == "", fromString(after), pageSize)
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	for ; ok; ok = rows.Next() {
+		message = &pb.GirlScout{}
+		err = rows.Scan(intoString(&message.Id))
+		if err != nil {
+			return
+		}
+		messages = append(messages, message)
+	}
+
+	if len(messages) == 0 {
+		err = transaction.Commit()
+		return
+	}
+
+	if len(messages) == pageSize {
+		nextPageToken, err = encodePageToken(messages[len(messages)-1].Id)
+		if err != nil {
+			return
+		}
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// ReadGirlScouts reads from the specified db the messages having any of the
+// specified ids, subject to the specified cancellation context ctx. Each table
+// is queried once for all of the ids, or once per chunk of the ids if there are
+// more than a statement can have parameters. On success, return a map from ID to
+// message, and a nil error. IDs for which there is no message are absent from
+// the map. On error, the error returned will not be nil.
+func ReadGirlScouts(ctx context.Context, db Database, ids []string) (byID map[string]*pb.GirlScout, err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var messages []*pb.GirlScout
+	var remaining []string
+	var parameters []interface{}
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var ok bool
+	var message *pb.GirlScout
+
+	byID = make(map[string]*pb.GirlScout, len(ids))
+	if len(ids) == 0 {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	remaining = ids
+	for len(remaining) != 0 {
+		ids = remaining
+		if len(ids) > maxStatementParameters {
+			ids = ids[:maxStatementParameters]
+		}
+		remaining = remaining[len(ids):]
+		messages = nil
+
+		parameters = nil
+		for _, id := range ids {
+			parameters = append(parameters, fromString(id))
+		}
+		rows, err = transaction.QueryContext(ctx, withTuples("select `id` from `girl_scout` where `id` in (", "?", len(ids))+");", parameters...)
+		if err != nil {
+			return
+		}
+		ok = rows.Next()
+
+		for ; ok; ok = rows.Next() {
+			message = &pb.GirlScout{}
+			err = rows.Scan(intoString(&message.Id))
+			if err != nil {
+				return
+			}
+			messages = append(messages, message)
+		}
+
+		for _, message := range messages {
+			byID[message.Id] = message
+		}
+
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// CreateGirlScouts adds the specified messages to the specified db, subject to the
+// specified cancellation context ctx. Each table is inserted into using as few
+// statements as fit within MaxStatementBytes. Either all of the messages are
+// added, or none of them are. Return nil on success, or return a non-nil value
+// if an error occurs.
+func CreateGirlScouts(ctx context.Context, db Database, messages []*pb.GirlScout) (err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var batch *tupleBatch
+
+	if len(messages) == 0 {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	batch = newTupleBatch(transaction, "insert into `girl_scout`( `id`) values", "(?)")
+	for _, message := range messages {
+		err = batch.add(ctx, fromString(message.Id))
+		if err != nil {
+			return
+		}
+	}
+	err = batch.flush(ctx)
+	if err != nil {
+		return
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// ExistsGirlScout returns whether there is a message having the specified id in
+// the specified db, subject to the specified cancellation context ctx. The
+// message itself is not read. On error, the error returned will not be nil.
+func ExistsGirlScout(ctx context.Context, db Database, id string) (exists bool, err error) {
+	var message pb.GirlScout
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var ok bool
+
+	message.Id = id
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, "select exists (select null from `girl_scout` where `id` = ?);", fromString(message.Id))
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	if ok {
+		err = rows.Scan(&exists)
+		if err != nil {
+			return
+		}
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// CountGirlScouts returns the number of messages in the specified db that satisfy
+// all of the specified conditions, subject to the specified cancellation
+// context ctx. If no conditions are specified, then all of the messages are
+// counted. On error, the error returned will not be nil.
+func CountGirlScouts(ctx context.Context, db Database, conditions ...GirlScoutCondition) (count int64, err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var all []condition
+	var sqlText string
+	var parameters []interface{}
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var ok bool
+
+	for _, where := range conditions {
+		all = append(all, where.condition)
+	}
+	sqlText, parameters, err = buildCount("select count(*) from `girl_scout`", all)
+	if err != nil {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, sqlText, parameters...)
+	if err != nil {
+		return
+	}
+	ok = rows.Next()
+
+	if ok {
+		err = rows.Scan(&count)
+		if err != nil {
+			return
+		}
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// GirlScoutQueryBuilder builds a query that reads GirlScout messages. Use
+// GirlScoutQuery to create one.
+type GirlScoutQueryBuilder struct{ query queryBuilder }
+
+// GirlScoutQuery returns a new query builder that, until conditions are
+// added to it, reads all of the GirlScout messages, in order of their IDs.
+// For example:
+//
+//     messages, err := GirlScoutQuery().Where(condition).Limit(10).Read(ctx, db)
+func GirlScoutQuery() *GirlScoutQueryBuilder {
+	return &GirlScoutQueryBuilder{}
+}
+
+// Where adds the specified condition to the query, and returns the builder.
+// A message is read only if all of the conditions added are true of it.
+func (builder *GirlScoutQueryBuilder) Where(where GirlScoutCondition) *GirlScoutQueryBuilder {
+	builder.query.conditions = append(builder.query.conditions, where.condition)
+	return builder
+}
+
+// And is a synonym for Where.
+func (builder *GirlScoutQueryBuilder) And(where GirlScoutCondition) *GirlScoutQueryBuilder {
+	builder.query.conditions = append(builder.query.conditions, where.condition)
+	return builder
+}
+
+// OrderBy orders the messages read by the specified orderings, and returns
+// the builder. Messages that are equal according to all of the orderings are
+// ordered by their IDs.
+func (builder *GirlScoutQueryBuilder) OrderBy(orderings ...GirlScoutOrdering) *GirlScoutQueryBuilder {
+	for _, ordering := range orderings {
+		builder.query.orderings = append(builder.query.orderings, ordering.sql)
+	}
+	return builder
+}
+
+// Limit reads at most the specified limit of messages, and returns the
+// builder. A limit that is not positive means no limit.
+func (builder *GirlScoutQueryBuilder) Limit(limit int) *GirlScoutQueryBuilder {
+	builder.query.limit = limit
+	return builder
+}
+
+// Read reads from the specified db the messages selected by the query,
+// subject to the specified cancellation context ctx. The messages are read
+// within one transaction. On success, return the messages and a nil error. On
+// error, the error returned will not be nil.
+func (builder *GirlScoutQueryBuilder) Read(ctx context.Context, db Database) (messages []*pb.GirlScout, err error) {
+	var transaction transactor
+	defer func() {
+		err = classifyError(err)
+		if err != nil && transaction != nil {
+			err = combineErrors(err, transaction.Rollback())
+		}
+	}()
+	var sqlText string
+	var parameters []interface{}
+	var rows *sql.Rows
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+	var id string
+	var ids []string
+	var byID map[string]*pb.GirlScout
+
+	sqlText, parameters, err = buildQuery(builder.query, "select `id` from `girl_scout`", "`id`")
+	if err != nil {
+		return
+	}
+
+	transaction, err = beginTransaction(ctx, db)
+	if err != nil {
+		return
+	}
+
+	rows, err = transaction.QueryContext(ctx, sqlText, parameters...)
+	if err != nil {
+		return
+	}
+
+	for rows.Next() {
+		err = rows.Scan(intoString(&id))
+		if err != nil {
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	byID, err = ReadGirlScouts(ctx, transaction, ids)
+	if err != nil {
+		return
+	}
+
+	for _, key := range ids {
+		if byID[key] != nil {
+			messages = append(messages, byID[key])
+		}
+	}
+
+	err = transaction.Commit()
+	return
+}
+
+// GirlScoutCondition is a condition on GirlScout messages, for use with
+// GirlScoutQueryBuilder. Conditions are returned by the methods of column
+// variables, e.g. GirlScoutId.Eq.
+type GirlScoutCondition struct{ condition }
+
+// Or returns a condition that is true when either the left or the specified
+// right condition is true.
+func (left GirlScoutCondition) Or(right GirlScoutCondition) GirlScoutCondition {
+	return GirlScoutCondition{orConditions(left.condition, right.condition)}
+}
+
+// GirlScoutOrdering is an order in which GirlScoutQueryBuilder reads messages.
+// Orderings are returned by the methods of column variables, e.g.
+// GirlScoutId.Desc.
+type GirlScoutOrdering struct{ sql string }
+
+// GirlScoutIdColumn is the type of GirlScoutId, which refers to the
+// id field of GirlScout messages in queries.
+// The zero value of the field is stored as null, so Eq of the zero value
+// checks that the field is null.
+type GirlScoutIdColumn struct{}
+
+// GirlScoutId refers to the id field of GirlScout messages
+// in queries (see GirlScoutQuery).
+var GirlScoutId GirlScoutIdColumn
+
+// Eq returns a condition that is true when the id field
+// is equal to the specified value.
+func (GirlScoutIdColumn) Eq(value string) GirlScoutCondition {
+	return GirlScoutCondition{compareColumn("`id`", "=", "?", fromString(value))}
+}
+
+// Ne returns a condition that is true when the id field
+// is not equal to the specified value.
+func (GirlScoutIdColumn) Ne(value string) GirlScoutCondition {
+	return GirlScoutCondition{compareColumn("`id`", "<>", "?", fromString(value))}
+}
+
+// Lt returns a condition that is true when the id field
+// is less than the specified value.
+func (GirlScoutIdColumn) Lt(value string) GirlScoutCondition {
+	return GirlScoutCondition{compareColumn("`id`", "<", "?", fromString(value))}
+}
+
+// Le returns a condition that is true when the id field
+// is at most the specified value.
+func (GirlScoutIdColumn) Le(value string) GirlScoutCondition {
+	return GirlScoutCondition{compareColumn("`id`", "<=", "?", fromString(value))}
+}
+
+// Gt returns a condition that is true when the id field
+// is greater than the specified value.
+func (GirlScoutIdColumn) Gt(value string) GirlScoutCondition {
+	return GirlScoutCondition{compareColumn("`id`", ">", "?", fromString(value))}
+}
+
+// Ge returns a condition that is true when the id field
+// is at least the specified value.
+func (GirlScoutIdColumn) Ge(value string) GirlScoutCondition {
+	return GirlScoutCondition{compareColumn("`id`", ">=", "?", fromString(value))}
+}
+
+// IsNull returns a condition that is true when the id field is null.
+func (GirlScoutIdColumn) IsNull() GirlScoutCondition {
+	return GirlScoutCondition{columnIsNull("`id`")}
+}
+
+// IsNotNull returns a condition that is true when the id field is not
+// null.
+func (GirlScoutIdColumn) IsNotNull() GirlScoutCondition {
+	return GirlScoutCondition{columnIsNotNull("`id`")}
+}
+
+// Asc returns an ordering by the id field, ascending.
+func (GirlScoutIdColumn) Asc() GirlScoutOrdering {
+	return GirlScoutOrdering{"`id` asc"}
+}
+
+// Desc returns an ordering by the id field, descending.
+func (GirlScoutIdColumn) Desc() GirlScoutOrdering {
+	return GirlScoutOrdering{"`id` desc"}
+}
+
+// errorClasses maps database error codes, as returned by errorCode, to the
+// classes of errors that CRUD operations can return.
+var errorClasses = map[interface{}]error{
+	uint64(1022): ErrAlreadyExists,
+	uint64(1062): ErrAlreadyExists,
+	uint64(1586): ErrAlreadyExists,
+	uint64(1216): ErrForeignKey,
+	uint64(1217): ErrForeignKey,
+	uint64(1451): ErrForeignKey,
+	uint64(1452): ErrForeignKey,
+	uint64(1205): ErrConflict,
+	uint64(1213): ErrConflict,
+	uint64(1048): ErrInvalidArgument,
+	uint64(1264): ErrInvalidArgument,
+	uint64(1292): ErrInvalidArgument,
+	uint64(1366): ErrInvalidArgument,
+	uint64(1406): ErrInvalidArgument,
+}
+
+// These are the classes of errors that CRUD operations can return. Use
+// errors.Is to check whether an error returned by a CRUD operation belongs
+// to a class.
+var (
+	// ErrNotFound means that the message to be read, updated, or deleted is
+	// not in the database. See NoRow.
+	ErrNotFound = errors.New("not found")
+
+	// ErrAlreadyExists means that the message to be created is already in the
+	// database.
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrForeignKey means that a message refers to something that is not in
+	// the database, such as an unknown enum value, or that something in the
+	// database refers to a message to be deleted.
+	ErrForeignKey = errors.New("foreign key violation")
+
+	// ErrConflict means that the operation conflicted with a concurrent
+	// operation, e.g. due to a deadlock. Retrying the operation might
+	// succeed.
+	ErrConflict = errors.New("conflict")
+
+	// ErrInvalidArgument means that an argument to the operation, such as a
+	// field of a message, is not acceptable to the database.
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+// Error is an error reported by the database that belongs to one of the
+// classes of errors, e.g. ErrAlreadyExists. errors.Is(err, Class) is true for
+// an *Error err, and errors.Unwrap(err) is the database driver's error.
+type Error struct {
+	Class error
+	Err   error
+}
 
-	transaction, err = db.BeginTx(ctx, nil)
-	if err != nil {
-		return
-	}
+func (err *Error) Error() string {
+	return fmt.Sprintf("%v: %v", err.Class, err.Err)
+}
 
-	rows, err = transaction.QueryContext(ctx, "select null from `girl_scout` where `id` = ?;", fromString(message.Id))
-	if err != nil {
-		return
+// Is returns whether the specified target is the class of err.
+func (err *Error) Is(target error) bool {
+	return target == err.Class
+}
+
+// Unwrap returns the database driver's error.
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// classifyError returns an *Error wrapping the specified err if err, or an
+// error that it wraps, has a code in errorClasses. Otherwise, classifyError
+// returns err.
+func classifyError(err error) error {
+	for cause := err; cause != nil; cause = errors.Unwrap(cause) {
+		class, ok := errorClasses[errorCode(cause)]
+		if ok {
+			return &Error{Class: class, Err: err}
+		}
 	}
-	ok = rows.Next()
+	return err
+}
 
-	if !ok {
-		err = noRow()
-		return
+// errorCode returns the code of the specified err, if err is an error from a
+// database driver, or returns nil otherwise. The code is either a uint64
+// vendor-specific error number or a string SQLSTATE. The types of the
+// database drivers' errors aren't known here, so errorCode looks for:
+//
+// - a method "Code() int", as in modernc.org/sqlite,
+// - a field "Number", as in github.com/go-sql-driver/mysql,
+// - a field "ExtendedCode", as in github.com/mattn/go-sqlite3, or
+// - a field "Code", as in github.com/lib/pq and github.com/jackc/pgconn.
+func errorCode(err error) interface{} {
+	value := reflect.ValueOf(err)
+	if method := value.MethodByName("Code"); method.IsValid() {
+		methodType := method.Type()
+		if methodType.NumIn() == 0 && methodType.NumOut() == 1 &&
+			methodType.Out(0).Kind() == reflect.Int {
+			return uint64(method.Call(nil)[0].Int())
+		}
 	}
 
-	err = rows.Scan(ignore())
-	if err != nil {
-		return
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
 	}
-	rows.Next()
 
-	err = transaction.Commit()
-	return
-}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
 
-// DeleteGirlScout deletes the message having the specified id from the specified
-// db, subject to the specified cancellation context ctx. On success, the error
-// returned will be nil. On error, the error returned will not be nil. It is
-// not considered an error if there is no message having the specified id in
-// the database; i.e. deletions are idempotent.
-func DeleteGirlScout(ctx context.Context, db *sql.DB, id string) (err error) {
-	var message pb.GirlScout
-	var transaction *sql.Tx
-	defer func() {
-		if err != nil && transaction != nil {
-			err = combineErrors(err, transaction.Rollback())
-		}
-	}()
+	number := value.FieldByName("Number")
+	switch number.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return number.Uint()
+	}
 
-	message.Id = id
-	transaction, err = db.BeginTx(ctx, nil)
-	if err != nil {
-		return
+	extendedCode := value.FieldByName("ExtendedCode")
+	if extendedCode.Kind() == reflect.Int {
+		return uint64(extendedCode.Int())
 	}
 
-	_, err = transaction.ExecContext(ctx, "delete from `girl_scout` where `id` = ?;", fromString(message.Id))
-	if err != nil {
-		return
+	code := value.FieldByName("Code")
+	if code.Kind() == reflect.String {
+		return code.String()
 	}
 
-	err = transaction.Commit()
-	return
+	return nil
 }
 
 // CompositeError is an error type that contains zero or more error types.
@@ -536,6 +2770,36 @@ func (errs CompositeError) Error() string {
 	return builder.String()
 }
 
+// Unwrap returns the errors contained in errs.
+func (errs CompositeError) Unwrap() []error {
+	return errs
+}
+
+// Is returns whether any of the errors contained in errs is target, as
+// determined by errors.Is. It allows errors.Is to inspect a CompositeError
+// even in versions of Go that do not support the multiple-error Unwrap.
+func (errs CompositeError) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors contained in errs that matches target,
+// as determined by errors.As. It allows errors.As to inspect a
+// CompositeError even in versions of Go that do not support the
+// multiple-error Unwrap.
+func (errs CompositeError) As(target interface{}) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
 func combineErrors(errs ...error) CompositeError {
 	var filtered []error
 	for _, err := range errs {
@@ -547,6 +2811,82 @@ func combineErrors(errs ...error) CompositeError {
 	return CompositeError(filtered)
 }
 
+// Database is the interface through which CRUD operations access the
+// database. Both *sql.DB and *sql.Tx satisfy Database. When a CRUD operation
+// is given a *sql.DB, it performs its work within a new transaction that it
+// commits before returning (or rolls back if an error occurs). When a CRUD
+// operation is given a *sql.Tx, it performs its work within that transaction,
+// and does not commit or roll back the transaction; that is left to the
+// caller. If the operation fails, then its work is rolled back to a savepoint
+// set when it began, so that the caller's transaction is as it was before the
+// operation, and can still be used.
+type Database interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// transactor is a Database that can be committed or rolled back.
+type transactor interface {
+	Database
+	Commit() error
+	Rollback() error
+}
+
+// callerTransaction is a transactor wrapping a Database supplied by the
+// caller of a CRUD operation. The transaction belongs to the caller, so
+// committing callerTransaction releases the savepoint set when the operation
+// began, and rolling back callerTransaction rolls back to the savepoint.
+type callerTransaction struct {
+	Database
+	ctx       context.Context
+	savepoint string
+}
+
+func (transaction callerTransaction) Commit() error {
+	_, err := transaction.ExecContext(transaction.ctx, "release savepoint "+transaction.savepoint)
+	return err
+}
+
+// Rollback rolls back to the savepoint even if the operation's context is
+// canceled, so that the caller's transaction can still be used.
+func (transaction callerTransaction) Rollback() error {
+	_, err := transaction.ExecContext(context.Background(), "rollback to savepoint "+transaction.savepoint)
+	return err
+}
+
+// savepoints is the number of savepoints set by CRUD operations, so that
+// each savepoint has a different name, even when one operation is performed
+// within another's transaction.
+var savepoints uint64
+
+// beginTransaction returns a transactor for use by a CRUD operation. If the
+// specified db can begin a transaction (e.g. it's a *sql.DB), then begin one
+// subject to the specified cancellation context ctx. Otherwise (e.g. it's a
+// *sql.Tx), set a savepoint in db, and return a transactor that uses db and
+// the savepoint (see callerTransaction).
+func beginTransaction(ctx context.Context, db Database) (transactor, error) {
+	beginner, ok := db.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (*sql.Tx, error)
+	})
+	if !ok {
+		savepoint := fmt.Sprintf("okra_%d", atomic.AddUint64(&savepoints, 1))
+		_, err := db.ExecContext(ctx, "savepoint "+savepoint)
+		if err != nil {
+			return nil, err
+		}
+		return callerTransaction{db, ctx, savepoint}, nil
+	}
+
+	transaction, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		// Return an untyped nil, so that the caller's "transaction != nil"
+		// check is not fooled by a nil *sql.Tx.
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
 // stringValuer is a driver.Valuer that produces string
 type stringValuer struct {
 	source string
@@ -662,6 +3002,32 @@ func fromInt64(source int64) int64Valuer {
 	return int64Valuer{source: source}
 }
 
+// fromMoneyCurrencyCode returns the currency code of the specified source, or
+// returns nil if source is nil.
+func fromMoneyCurrencyCode(source *money.Money) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	return source.CurrencyCode
+}
+
+// fromMoneyAmount returns the amount of the specified source as a decimal
+// string, or returns nil if source is nil.
+func fromMoneyAmount(source *money.Money) interface{} {
+	if source == nil {
+		return nil
+	}
+
+	// Units and nanos have the same sign.
+	units, nanos, sign := source.Units, source.Nanos, ""
+	if units < 0 || nanos < 0 {
+		units, nanos, sign = -units, -nanos, "-"
+	}
+
+	return fmt.Sprintf("%s%d.%09d", sign, units, nanos)
+}
+
 // withTuples returns a string consisting of the specified sqlStatement
 // followed by the specified numTuples copies of the specified sqlTuple
 // separated by commas and spaces. numTuples must be greater than zero.
@@ -672,26 +3038,75 @@ func fromInt64(source int64) int64Valuer {
 //
 // returns the following string:
 //
-//     "insert into foobar(x, y) values(?, ?), (?, ?), (?, ?)"
+//	"insert into foobar(x, y) values(?, ?), (?, ?), (?, ?)"
+//
+// If sqlTuple contains numbered parameters, as in PostgreSQL, then the
+// parameters of each copy are numbered after those of the previous copy. For
+// example, the following invocation:
+//
+//	withTuples("insert into foobar(x, y) values", "($1, $2)", 3)
 //
+// returns the following string:
+//
+//	"insert into foobar(x, y) values($1, $2), ($3, $4), ($5, $6)"
 func withTuples(sqlStatement string, sqlTuple string, numTuples int) string {
 	if numTuples < 1 {
 		panic(fmt.Sprintf("withTuples requires at least one tuple, but %d were specified",
 			numTuples))
 	}
 
+	numParameters := maxParameterNumber(sqlTuple)
 	var builder strings.Builder
 	builder.WriteString(sqlStatement)
 	i := 0
 	builder.WriteString(sqlTuple)
 	for i++; i < numTuples; i++ {
 		builder.WriteString(", ")
-		builder.WriteString(sqlTuple)
+		writeRenumbered(&builder, sqlTuple, i*numParameters)
 	}
 
 	return builder.String()
 }
 
+// maxParameterNumber returns the largest n among the numbered parameters
+// "$n" in the specified sqlFragment, or returns zero if there are none.
+func maxParameterNumber(sqlFragment string) int {
+	max := 0
+	for _, field := range strings.Split(sqlFragment, "$")[1:] {
+		end := 0
+		for end < len(field) && '0' <= field[end] && field[end] <= '9' {
+			end++
+		}
+		number, err := strconv.Atoi(field[:end])
+		if err == nil && number > max {
+			max = number
+		}
+	}
+	return max
+}
+
+// writeRenumbered writes the specified sqlFragment to the specified builder,
+// adding the specified offset to the number n of each numbered parameter "$n"
+// in sqlFragment.
+func writeRenumbered(builder *strings.Builder, sqlFragment string, offset int) {
+	fields := strings.Split(sqlFragment, "$")
+	builder.WriteString(fields[0])
+	for _, field := range fields[1:] {
+		builder.WriteString("$")
+		end := 0
+		for end < len(field) && '0' <= field[end] && field[end] <= '9' {
+			end++
+		}
+		number, err := strconv.Atoi(field[:end])
+		if err != nil {
+			builder.WriteString(field)
+			continue
+		}
+		builder.WriteString(strconv.Itoa(number + offset))
+		builder.WriteString(field[end:])
+	}
+}
+
 // fieldMaskLen returns the length of the slice of paths within the specified
 // field mask, or returns zero if the mask is nil.
 func fieldMaskLen(mask *field_mask.FieldMask) int {
@@ -711,6 +3126,12 @@ func (NoRow) Error() string {
 	return "There is no corresponding row in the database."
 }
 
+// Unwrap returns ErrNotFound, so that errors.Is(err, ErrNotFound) is true
+// for a NoRow error.
+func (NoRow) Unwrap() error {
+	return ErrNotFound
+}
+
 func noRow() NoRow {
 	return NoRow{}
 }
@@ -740,6 +3161,16 @@ func intoString(destination *string) stringScanner {
 	return stringScanner{destination: destination}
 }
 
+// scanIf returns the specified destination if the specified condition is
+// true, or returns ignore() otherwise. It's for use in sql.Rows.Scan, where
+// only some of the destinations are to be assigned.
+func scanIf(condition bool, destination interface{}) interface{} {
+	if condition {
+		return destination
+	}
+	return ignore()
+}
+
 type dateScanner struct {
 	destination  **date.Date
 	intermediary sql.NullString // YYYY-MM-DD
@@ -916,6 +3347,83 @@ func intoUint64(destination *uint64) uint64Scanner {
 	return uint64Scanner{destination: destination}
 }
 
+type moneyCurrencyCodeScanner struct {
+	destination  **money.Money
+	intermediary sql.NullString
+}
+
+func (scanner moneyCurrencyCodeScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	if !scanner.intermediary.Valid {
+		*scanner.destination = nil
+		return nil
+	}
+
+	*scanner.destination = &money.Money{CurrencyCode: scanner.intermediary.String}
+	return nil
+}
+
+// intoMoneyCurrencyCode is a constructor for moneyCurrencyCodeScanner.
+func intoMoneyCurrencyCode(destination **money.Money) moneyCurrencyCodeScanner {
+	return moneyCurrencyCodeScanner{destination: destination}
+}
+
+type moneyAmountScanner struct {
+	destination  **money.Money
+	intermediary sql.NullString
+}
+
+func (scanner moneyAmountScanner) Scan(value interface{}) error {
+	if err := scanner.intermediary.Scan(value); err != nil {
+		return err
+	}
+
+	// The destination is nil if the currency code was null.
+	if *scanner.destination == nil || !scanner.intermediary.Valid {
+		return nil
+	}
+
+	amount := scanner.intermediary.String
+	negative := strings.HasPrefix(amount, "-")
+	amount = strings.TrimPrefix(amount, "-")
+	whole, fraction := amount, ""
+	if dot := strings.IndexByte(amount, '.'); dot != -1 {
+		whole, fraction = amount[:dot], amount[dot+1:]
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	// The fraction is the leading digits of nine, e.g. ".75" is 750000000
+	// nanos.
+	var nanos int64
+	if fraction != "" {
+		nanos, err = strconv.ParseInt((fraction + "000000000")[:9], 10, 32)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Units and nanos have the same sign.
+	if negative {
+		units, nanos = -units, -nanos
+	}
+
+	(*scanner.destination).Units = units
+	(*scanner.destination).Nanos = int32(nanos)
+	return nil
+}
+
+// intoMoneyAmount is a constructor for moneyAmountScanner.
+func intoMoneyAmount(destination **money.Money) moneyAmountScanner {
+	return moneyAmountScanner{destination: destination}
+}
+
 // appendField adds the specified string to the end of the paths within the
 // specified field mask and returns the field mask. If the field mask is nil,
 // then a new field mask is first created.
@@ -928,6 +3436,26 @@ func appendField(mask *field_mask.FieldMask, fieldName string) *field_mask.Field
 	return mask
 }
 
+// checkFieldMask returns an error of class ErrInvalidArgument if the
+// specified fieldMask contains a name that is not among the specified fields,
+// or returns nil otherwise.
+func checkFieldMask(fieldMask []string, fields ...string) error {
+	for _, name := range fieldMask {
+		found := false
+		for _, field := range fields {
+			if name == field {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return invalidArgument("field mask contains %q, which is not a field of the message", name)
+		}
+	}
+
+	return nil
+}
+
 // ignore returns an output parameter for use in sql.Rows.Scan. The returned
 // value accepts any SQL value and does nothing with it.
 func ignore() interface{} {
@@ -935,3 +3463,296 @@ func ignore() interface{} {
 	var pointer interface{} = &dummy
 	return pointer
 }
+
+// invalidArgument returns an *Error of class ErrInvalidArgument whose message
+// is formatted from the specified format and args, as by fmt.Errorf.
+func invalidArgument(format string, args ...interface{}) error {
+	return &Error{Class: ErrInvalidArgument, Err: fmt.Errorf(format, args...)}
+}
+
+// decodePageToken parses the specified token, as produced by encodePageToken,
+// into the specified id, which must be a pointer.
+func decodePageToken(token string, id interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return invalidArgument("invalid page token %q: %w", token, err)
+	}
+
+	err = json.Unmarshal(data, id)
+	if err != nil {
+		return invalidArgument("invalid page token %q: %w", token, err)
+	}
+
+	return nil
+}
+
+// encodePageToken returns an opaque string that identifies the specified id
+// as the last message of a page.
+func encodePageToken(id interface{}) (string, error) {
+	data, err := json.Marshal(id)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// MaxStatementBytes is the maximum estimated size, in bytes, of a SQL
+// statement executed by an operation on many messages at once, e.g. the
+// "create many" operation. Larger statements are divided into smaller ones.
+// MaxStatementBytes must be less than the database server's
+// max_allowed_packet, which is 4 MiB by default in MySQL 5.6.
+var MaxStatementBytes = 3 << 20
+
+// maxStatementParameters is the maximum number of parameters that a prepared
+// SQL statement can have. MySQL and PostgreSQL allow 65535, but SQLite allows
+// only 32766 (since version 3.32.0).
+const maxStatementParameters = 32766
+
+// tupleBatch accumulates copies of a SQL tuple, e.g. "(?, ?, ?)", to follow a
+// SQL statement, e.g. "insert into foobar(x, y, z) values". It executes the
+// statement whenever adding another tuple would exceed the limits on the
+// size of a statement.
+type tupleBatch struct {
+	transaction  transactor
+	sqlStatement string
+	sqlTuple     string
+	parameters   []interface{}
+	numTuples    int
+	numBytes     int
+}
+
+// newTupleBatch returns a tupleBatch for the specified sqlStatement and
+// sqlTuple that executes statements using the specified transaction.
+func newTupleBatch(transaction transactor, sqlStatement string, sqlTuple string) *tupleBatch {
+	return &tupleBatch{
+		transaction:  transaction,
+		sqlStatement: sqlStatement,
+		sqlTuple:     sqlTuple,
+		numBytes:     len(sqlStatement)}
+}
+
+// add appends to the batch a tuple having the specified parameters. If the
+// tuple would not fit in the batch, then first flush the batch.
+func (batch *tupleBatch) add(ctx context.Context, parameters ...interface{}) error {
+	numBytes := len(", ") + len(batch.sqlTuple)
+	for _, parameter := range parameters {
+		numBytes += parameterSize(parameter)
+	}
+
+	if batch.numTuples != 0 &&
+		(len(batch.parameters)+len(parameters) > maxStatementParameters ||
+			batch.numBytes+numBytes > MaxStatementBytes) {
+		err := batch.flush(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	batch.parameters = append(batch.parameters, parameters...)
+	batch.numTuples++
+	batch.numBytes += numBytes
+	return nil
+}
+
+// flush executes the statement with the tuples in the batch, if any, and
+// then empties the batch.
+func (batch *tupleBatch) flush(ctx context.Context) error {
+	if batch.numTuples == 0 {
+		return nil
+	}
+
+	_, err := batch.transaction.ExecContext(
+		ctx,
+		withTuples(batch.sqlStatement, batch.sqlTuple, batch.numTuples),
+		batch.parameters...)
+
+	batch.parameters = nil
+	batch.numTuples = 0
+	batch.numBytes = len(batch.sqlStatement)
+	return err
+}
+
+// parameterSize returns an estimate of the number of bytes occupied by the
+// specified SQL parameter when it is sent to the database.
+func parameterSize(parameter interface{}) int {
+	if valuer, ok := parameter.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err == nil {
+			parameter = value
+		}
+	}
+
+	// Each parameter is accompanied by its type and length.
+	const overhead = 16
+
+	switch value := parameter.(type) {
+	case string:
+		return overhead + len(value)
+	case []byte:
+		return overhead + len(value)
+	default:
+		return overhead + 8
+	}
+}
+
+// buildCount returns the SQL, and its parameters, of a query that counts the
+// messages satisfying all of the specified conditions. The specified
+// selectCount counts all of the messages, e.g.
+// "select count(*) from boy_scout". Return a non-nil error if any of the
+// conditions could not be created.
+func buildCount(selectCount string, conditions []condition) (string, []interface{}, error) {
+	var sqlText strings.Builder
+	sqlText.WriteString(selectCount)
+	parameters, err := writeWhere(&sqlText, conditions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sqlText.WriteString(";")
+	return sqlText.String(), parameters, nil
+}
+
+// queryBuilder is the state of a query builder, e.g. BoyScoutQuery(). All
+// of the conditions must be true of a message for the query to select it.
+// The messages are ordered by the orderings, e.g. "name desc", and then by
+// ID. If limit is positive, then at most that many messages are selected.
+type queryBuilder struct {
+	conditions []condition
+	orderings  []string
+	limit      int
+}
+
+// buildQuery returns the SQL, and its parameters, of the query described by
+// the specified builder. The specified selectIDs selects the IDs of all of
+// the messages, e.g. "select id from boy_scout", and the specified key is
+// the ID column, e.g. "id". Return a non-nil error if any of the builder's
+// conditions could not be created.
+func buildQuery(builder queryBuilder, selectIDs string, key string) (string, []interface{}, error) {
+	var sqlText strings.Builder
+	sqlText.WriteString(selectIDs)
+	parameters, err := writeWhere(&sqlText, builder.conditions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sqlText.WriteString(" order by ")
+	for _, ordering := range builder.orderings {
+		sqlText.WriteString(ordering)
+		sqlText.WriteString(", ")
+	}
+	sqlText.WriteString(key)
+
+	if builder.limit > 0 {
+		sqlText.WriteString(" limit ")
+		sqlText.WriteString(strconv.Itoa(builder.limit))
+	}
+
+	sqlText.WriteString(";")
+	return sqlText.String(), parameters, nil
+}
+
+// condition is a boolean SQL expression on the columns of a message table,
+// together with its parameters. If the SQL contains numbered parameters, as
+// in PostgreSQL, then they're numbered as if the condition were the entire
+// statement, i.e. beginning with "$1". If the condition could not be created,
+// then err is not nil.
+type condition struct {
+	sql        string
+	parameters []interface{}
+	err        error
+}
+
+// compareColumn returns a condition that compares the specified column with
+// the specified value using the specified operator, e.g. "=" or "<". The
+// specified parameter is how the value appears in SQL, e.g. "?" or "$1".
+//
+// The zero value of an ordinary scalar field is stored as null, so if value
+// is null, then "=" checks that the column is null, and "<>" checks that the
+// column is not null. Conversely, "<>" with a value that is not null is true
+// also when the column is null. Other operators are never true for null.
+func compareColumn(column string, operator string, parameter string, value interface{}) condition {
+	var driverValue driver.Value = value
+	if valuer, ok := value.(driver.Valuer); ok {
+		var err error
+		driverValue, err = valuer.Value()
+		if err != nil {
+			return condition{err: err}
+		}
+	}
+
+	if driverValue == nil {
+		switch operator {
+		case "=":
+			return columnIsNull(column)
+		case "<>":
+			return columnIsNotNull(column)
+		}
+	} else if operator == "<>" {
+		return condition{
+			sql:        "(" + column + " <> " + parameter + " or " + column + " is null)",
+			parameters: []interface{}{value}}
+	}
+
+	return condition{
+		sql:        column + " " + operator + " " + parameter,
+		parameters: []interface{}{value}}
+}
+
+// columnIsNull returns a condition that is true when the specified column is
+// null.
+func columnIsNull(column string) condition {
+	return condition{sql: column + " is null"}
+}
+
+// columnIsNotNull returns a condition that is true when the specified column
+// is not null.
+func columnIsNotNull(column string) condition {
+	return condition{sql: column + " is not null"}
+}
+
+// orConditions returns a condition that is true when either of the specified
+// left or right conditions is true.
+func orConditions(left condition, right condition) condition {
+	if left.err != nil {
+		return left
+	}
+	if right.err != nil {
+		return right
+	}
+
+	var builder strings.Builder
+	builder.WriteString("(")
+	builder.WriteString(left.sql)
+	builder.WriteString(" or ")
+	writeRenumbered(&builder, right.sql, len(left.parameters))
+	builder.WriteString(")")
+
+	var parameters []interface{}
+	parameters = append(parameters, left.parameters...)
+	parameters = append(parameters, right.parameters...)
+	return condition{sql: builder.String(), parameters: parameters}
+}
+
+// writeWhere writes to the specified sqlText a "where" clause requiring all
+// of the specified conditions, if there are any, preceded by a space. The
+// parameters of the conditions are renumbered, if necessary, so that they
+// follow one another. Return the parameters of the conditions, or return a
+// non-nil error if any of the conditions could not be created.
+func writeWhere(sqlText *strings.Builder, conditions []condition) ([]interface{}, error) {
+	var parameters []interface{}
+	for i, where := range conditions {
+		if where.err != nil {
+			return nil, where.err
+		}
+		if i == 0 {
+			sqlText.WriteString(" where ")
+		} else {
+			sqlText.WriteString(" and ")
+		}
+		writeRenumbered(sqlText, where.sql, len(parameters))
+		parameters = append(parameters, where.parameters...)
+	}
+
+	return parameters, nil
+}
@@ -50,7 +50,7 @@ func main() {
 	fmt.Println("create error?: ", err)
 
 	for i := 0; i < 3; i++ {
-		err := crud.ReadBoyScout(ctx, db, &ted)
+		err := crud.ReadBoyScout(ctx, db, &ted, nil)
 		fmt.Println(ted)
 		fmt.Println("read error?: ", err)
 	}
@@ -0,0 +1,269 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	pb "boyscouts.com/type/scouts"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/proto"
+
+	_ "modernc.org/sqlite"
+)
+
+// openDatabase returns a connection to a new in-memory SQLite database whose
+// tables are defined by schema.sql (see the makefile).
+func openDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+	schema, err := os.ReadFile("schema.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each connection to ":memory:" is a different database, so use only one.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec(string(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// createScouts adds some Boy Scouts to the specified db.
+func createScouts(t *testing.T, db *sql.DB) {
+	t.Helper()
+	scouts := []*pb.BoyScout{
+		{Id: "ted", Rank: pb.Rank_RANK_EAGLE_SCOUT, PackCode: 4},
+		{Id: "bill", Rank: pb.Rank_RANK_EAGLE_SCOUT, PackCode: 7},
+		{Id: "rufus", Rank: pb.Rank_RANK_CUB_SCOUT, PackCode: 7},
+	}
+	for _, scout := range scouts {
+		err := CreateBoyScout(context.Background(), db, scout)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCountBoyScouts(t *testing.T) {
+	db := openDatabase(t)
+	createScouts(t, db)
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		name       string
+		conditions []BoyScoutCondition
+		expected   int64
+	}{
+		{"all", nil, 3},
+		{"eagle", []BoyScoutCondition{
+			BoyScoutRank.Eq(pb.Rank_RANK_EAGLE_SCOUT)}, 2},
+		{"eagle in pack 7", []BoyScoutCondition{
+			BoyScoutRank.Eq(pb.Rank_RANK_EAGLE_SCOUT),
+			BoyScoutPackCode.Eq(7)}, 1},
+		{"eagle or pack 7", []BoyScoutCondition{
+			BoyScoutRank.Eq(pb.Rank_RANK_EAGLE_SCOUT).Or(
+				BoyScoutPackCode.Eq(7))}, 3},
+		{"none", []BoyScoutCondition{
+			BoyScoutRank.Eq(pb.Rank_RANK_SAMURAI)}, 0},
+	} {
+		count, err := CountBoyScouts(ctx, db, test.conditions...)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if count != test.expected {
+			t.Errorf("%s: counted %d, expected %d", test.name, count, test.expected)
+		}
+	}
+}
+
+func TestExistsBoyScout(t *testing.T) {
+	db := openDatabase(t)
+	createScouts(t, db)
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		id       string
+		expected bool
+	}{
+		{"ted", true},
+		{"rufus", true},
+		{"missing", false},
+	} {
+		exists, err := ExistsBoyScout(ctx, db, test.id)
+		if err != nil {
+			t.Fatalf("%s: %v", test.id, err)
+		}
+		if exists != test.expected {
+			t.Errorf("%s: exists is %v, expected %v", test.id, exists, test.expected)
+		}
+	}
+}
+
+func TestCreateBoyScouts(t *testing.T) {
+	db := openDatabase(t)
+	ctx := context.Background()
+
+	// Only the first scout has a field mask. A nil field mask has no paths.
+	scouts := []*pb.BoyScout{
+		{Id: "ted", Mask: &field_mask.FieldMask{Paths: []string{"rank"}}},
+		{Id: "bill"},
+	}
+	err := CreateBoyScouts(ctx, db, scouts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byID, err := ReadBoyScouts(ctx, db, []string{"ted", "bill"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths := byID["ted"].GetMask().GetPaths(); len(paths) != 1 || paths[0] != "rank" {
+		t.Errorf("ted: mask has paths %v, expected [rank]", paths)
+	}
+	if paths := byID["bill"].GetMask().GetPaths(); len(paths) != 0 {
+		t.Errorf("bill: mask has paths %v, expected none", paths)
+	}
+}
+
+func TestBoyScoutDues(t *testing.T) {
+	db := openDatabase(t)
+	ctx := context.Background()
+
+	// The amount is stored as a decimal string, e.g. "-0.000000005", and its
+	// units and nanos have the same sign when read back.
+	dues := map[string]*money.Money{
+		"ted":   {CurrencyCode: "USD", Units: 12, Nanos: 500000000},
+		"bill":  {CurrencyCode: "USD", Units: -1, Nanos: -750000000},
+		"rufus": {CurrencyCode: "EUR", Units: 0, Nanos: -5},
+		"sam":   {CurrencyCode: "JPY", Units: 100},
+		"joe":   nil,
+	}
+	var scouts []*pb.BoyScout
+	var ids []string
+	for id, amount := range dues {
+		scouts = append(scouts, &pb.BoyScout{Id: id, Dues: amount})
+		ids = append(ids, id)
+	}
+	err := CreateBoyScouts(ctx, db, scouts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byID, err := ReadBoyScouts(ctx, db, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id, expected := range dues {
+		if actual := byID[id].GetDues(); !proto.Equal(actual, expected) {
+			t.Errorf("%s: dues are %v, expected %v", id, actual, expected)
+		}
+	}
+}
+
+func TestUpdateBoyScoutFieldMask(t *testing.T) {
+	db := openDatabase(t)
+	createScouts(t, db)
+	ctx := context.Background()
+
+	// An empty field mask updates nothing, so it doesn't matter that there is
+	// no such scout.
+	err := UpdateBoyScout(ctx, db, &pb.BoyScout{Id: "missing"}, nil)
+	if err != nil {
+		t.Errorf("empty field mask: %v", err)
+	}
+
+	// A field mask containing something other than a field is invalid, even
+	// if it also contains a field. Nothing is updated.
+	samurai := &pb.BoyScout{Id: "ted", Rank: pb.Rank_RANK_SAMURAI}
+	err = UpdateBoyScout(ctx, db, samurai, []string{"rank", "rnak"})
+	if !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("unknown field: returned %v, expected ErrInvalidArgument", err)
+	}
+	ted := &pb.BoyScout{Id: "ted"}
+	err = ReadBoyScout(ctx, db, ted, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ted.Rank != pb.Rank_RANK_EAGLE_SCOUT {
+		t.Errorf("ted has rank %v, expected %v", ted.Rank, pb.Rank_RANK_EAGLE_SCOUT)
+	}
+}
+
+func TestReadBoyScoutsManyIDs(t *testing.T) {
+	db := openDatabase(t)
+	createScouts(t, db)
+	ctx := context.Background()
+
+	// There are more IDs than a statement can have parameters, so they're
+	// read in more than one chunk. "rufus" is in the last chunk.
+	ids := []string{"ted"}
+	for i := 0; i < 40000; i++ {
+		ids = append(ids, fmt.Sprintf("missing %d", i))
+	}
+	ids = append(ids, "rufus")
+
+	byID, err := ReadBoyScouts(ctx, db, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byID) != 2 || byID["ted"] == nil || byID["rufus"] == nil {
+		t.Errorf("read %v, expected ted and rufus", byID)
+	}
+}
+
+func TestCallerTransaction(t *testing.T) {
+	db := openDatabase(t)
+	ctx := context.Background()
+
+	transaction, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer transaction.Rollback()
+
+	err = CreateBoyScout(ctx, transaction, &pb.BoyScout{Id: "ted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// There are enough scouts that they're inserted by more than one
+	// statement, and the last scout is already in the database, so the last
+	// statement fails after the others have inserted rows. None of those rows
+	// remain in the transaction.
+	var scouts []*pb.BoyScout
+	for i := 0; i < 10000; i++ {
+		scouts = append(scouts, &pb.BoyScout{Id: fmt.Sprintf("scout %d", i)})
+	}
+	scouts = append(scouts, &pb.BoyScout{Id: "ted"})
+	err = CreateBoyScouts(ctx, transaction, scouts)
+	if !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("creating the scouts returned %v, expected ErrAlreadyExists", err)
+	}
+
+	// The transaction can still be used, and contains only the first scout.
+	err = CreateBoyScout(ctx, transaction, &pb.BoyScout{Id: "bill"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = transaction.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := CountBoyScouts(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("counted %d scouts, expected 2", count)
+	}
+}
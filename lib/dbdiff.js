@@ -114,14 +114,41 @@ function indexOfFirstMismatch(left, right) {
     }(0));
 }
 
+// Return the columns of the specified `table`, which is a version of the
+// specified `tableBefore`, in the order that they would have in the database
+// if `tableBefore` were migrated to `table`. The timestamp columns of a table
+// (see `timestamp` in `table.tisch.js`) come after its other columns, but
+// columns added after the timestamp columns are appended in the database. So,
+// the other columns that are in `tableBefore` come first, followed by the
+// timestamp columns that are in `tableBefore`, followed by the rest.
+function columnsInDatabaseOrder(tableBefore, table) {
+    const isTimestamp = column => column.timestamp !== undefined;
+    const numOthersBefore = tableBefore.columns
+        .filter(column => !isTimestamp(column)).length;
+    const timestampNamesBefore = tableBefore.columns
+        .filter(isTimestamp)
+        .map(column => column.name);
+    const others = table.columns.filter(column => !isTimestamp(column));
+    const timestamps = table.columns.filter(isTimestamp);
+    const isBefore = column => timestampNamesBefore.includes(column.name);
+
+    return [
+        ...others.slice(0, numOthersBefore),
+        ...timestamps.filter(isBefore),
+        ...others.slice(numOthersBefore),
+        ...timestamps.filter(column => !isBefore(column))
+    ];
+}
+
 // Return an array of alterations to make to the specified `tableBefore` so
 // that its definition (columns, documentation) matches the specified
 // `tableAfter`. An alteration satisfies the `alteration.tisch.js` schema.
 function diffDefinition(tableBefore, tableAfter) {
-    // There are three kinds of alterations:
+    // There are four kinds of alterations:
     // - altered table description
     // - added column(s)
     // - altered columns
+    // - added indices
     //
     // Look for each, and for any that applies, add an "alteration" to `alterations`.
     const alterations = []; // the return value
@@ -138,17 +165,24 @@ function diffDefinition(tableBefore, tableAfter) {
         });
     }
 
+    // Timestamp columns come after the other columns of a table, so a column
+    // added to a table that has timestamp columns appears before them, even
+    // though the database appends it after them. Compare the columns in the
+    // order in which they're migrated (see `columnsInDatabaseOrder`).
+    const beforeColumns = columnsInDatabaseOrder(tableBefore, tableBefore);
+    const afterColumns = columnsInDatabaseOrder(tableBefore, tableAfter);
+
     // Look for added columns.
-    const beforeNumColumns = tableBefore.columns.length;
-    const afterNumColumns = tableAfter.columns.length;
+    const beforeNumColumns = beforeColumns.length;
+    const afterNumColumns = afterColumns.length;
     if (afterNumColumns < beforeNumColumns) {
         throw Error(`The "after" version of this table has fewer columns than ` +
             `the "before." Columns before: ${tableBefore.columns} Columns `+
             `after: ${tableAfter.columns}`);
     }
 
-    const beforeColumnNames = tableBefore.columns.map(column => column.name);
-    const afterColumnNames = tableAfter.columns.map(column => column.name);
+    const beforeColumnNames = beforeColumns.map(column => column.name);
+    const afterColumnNames = afterColumns.map(column => column.name);
     const mismatchIndex = indexOfFirstMismatch(beforeColumnNames, afterColumnNames);
 
     if (mismatchIndex === afterNumColumns) {
@@ -156,7 +190,7 @@ function diffDefinition(tableBefore, tableAfter) {
     }
     else if (mismatchIndex === beforeNumColumns) {
         // Columns were added.
-        alterations.push(...tableAfter.columns.slice(mismatchIndex).map(column => {
+        alterations.push(...afterColumns.slice(mismatchIndex).map(column => {
             const alteration = {
                 kind: 'appendColumn',
                 name: column.name,
@@ -169,7 +203,7 @@ function diffDefinition(tableBefore, tableAfter) {
                     str(tableAfter));
             }
 
-            ['foreignKey', 'description'].forEach(property => {
+            ['maxLength', 'foreignKey', 'description'].forEach(property => {
                 if (property in column) {
                     alteration[property] = column[property];
                 }
@@ -187,17 +221,19 @@ function diffDefinition(tableBefore, tableAfter) {
     }
 
     // Look for modified columns.
-    tableAfter.columns.slice(0, mismatchIndex).forEach((column, i) => {
-        const beforeColumn = tableBefore.columns[i];
+    afterColumns.slice(0, mismatchIndex).forEach((column, i) => {
+        const beforeColumn = beforeColumns[i];
 
-        // Same as the "after" column, except no need to mention foreign key.
+        // Same as the "after" column, except no need to mention foreign key
+        // or timestamp.
         const alteration = {
             kind: 'alterColumn',
             ...column
         };
         delete alteration.foreignKey;
+        delete alteration.timestamp;
 
-        const isAltered = ['type', 'description'].some(
+        const isAltered = ['type', 'maxLength', 'description'].some(
             property => column[property] !== beforeColumn[property]);
 
         if (isAltered) {
@@ -205,6 +241,28 @@ function diffDefinition(tableBefore, tableAfter) {
         }
     });
 
+    // Look for added indices. An index is identified by its columns and
+    // whether it's unique, so changing either is removing one index and
+    // adding another.
+    const indexKey = index => str([index.columns, Boolean(index.unique)]);
+    const beforeIndexKeys = (tableBefore.indices || []).map(indexKey);
+    const afterIndexKeys = (tableAfter.indices || []).map(indexKey);
+    const removedIndexKeys = beforeIndexKeys.filter(
+        key => !afterIndexKeys.includes(key));
+    if (removedIndexKeys.length !== 0) {
+        throw Error(`Table has invalid modifications. Indices cannot be ` +
+            `removed. Removed indices (columns and uniqueness): ` +
+            `${removedIndexKeys.join(', ')} table before: ` +
+            `${str(tableBefore)} table after: ${str(tableAfter)}`);
+    }
+
+    alterations.push(...(tableAfter.indices || [])
+        .filter(index => !beforeIndexKeys.includes(indexKey(index)))
+        .map(index => ({
+            kind: 'addIndex',
+            ...index
+        })));
+
     return alterations;
 }
 
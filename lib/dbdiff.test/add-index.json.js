@@ -0,0 +1,36 @@
+// An index added to a table, whether on an existing column or on a new one,
+// is an alteration of the table.
+({
+    tablesBefore: {
+        scout: {
+            name: 'scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'rank', type: 'TYPE_INT32', nullable: true}
+            ],
+            indices: [
+                {columns: ['rank']}
+            ]
+        }
+    },
+
+    tablesAfter: {
+        scout: {
+            name: 'scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'rank', type: 'TYPE_INT32', nullable: true},
+                {name: 'pack_code', type: 'TYPE_UINT32', nullable: true},
+                {name: 'email', type: 'TYPE_STRING', maxLength: 191,
+                 nullable: true}
+            ],
+            indices: [
+                {columns: ['rank']},
+                {columns: ['pack_code']},
+                {columns: ['email'], unique: true}
+            ]
+        }
+    }
+})
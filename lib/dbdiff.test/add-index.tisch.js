@@ -0,0 +1,27 @@
+({
+    allTables: Any,
+    newTables: {},
+    modifications: {
+        scout: {
+            alterations: [{
+                kind: 'appendColumn',
+                name: 'pack_code',
+                type: 'TYPE_UINT32'
+            }, {
+                kind: 'appendColumn',
+                name: 'email',
+                type: 'TYPE_STRING',
+                maxLength: 191
+            }, {
+                kind: 'addIndex',
+                columns: ['pack_code']
+            }, {
+                kind: 'addIndex',
+                columns: ['email'],
+                unique: true
+            }],
+            insertions: [],
+            updates: []
+        }
+    }
+})
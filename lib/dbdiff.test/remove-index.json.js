@@ -0,0 +1,33 @@
+// Indices cannot be removed. Making an index unique removes the index that
+// isn't, so that is not supported either.
+({
+    tablesBefore: {
+        scout: {
+            name: 'scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'email', type: 'TYPE_STRING', maxLength: 191,
+                 nullable: true}
+            ],
+            indices: [
+                {columns: ['email']}
+            ]
+        }
+    },
+
+    tablesAfter: {
+        scout: {
+            name: 'scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'email', type: 'TYPE_STRING', maxLength: 191,
+                 nullable: true}
+            ],
+            indices: [
+                {columns: ['email'], unique: true}
+            ]
+        }
+    }
+})
@@ -0,0 +1,33 @@
+// A column added to a table that has timestamp columns comes before them in
+// the table definition, but is appended in the database.
+({
+    tablesBefore: {
+        scout: {
+            name: 'scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'created_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, timestamp: 'created'},
+                {name: 'updated_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, timestamp: 'updated'}
+            ]
+        }
+    },
+
+    tablesAfter: {
+        scout: {
+            name: 'scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'name', type: 'TYPE_STRING', nullable: true},
+                {name: 'created_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, timestamp: 'created'},
+                {name: 'updated_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, timestamp: 'updated',
+                 description: 'when the row was last updated'}
+            ]
+        }
+    }
+})
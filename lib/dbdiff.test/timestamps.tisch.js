@@ -0,0 +1,21 @@
+({
+    allTables: Any,
+    newTables: {},
+    modifications: {
+        scout: {
+            alterations: [{
+                kind: 'appendColumn',
+                name: 'name',
+                type: 'TYPE_STRING'
+            }, {
+                kind: 'alterColumn',
+                name: 'updated_at',
+                type: '.google.protobuf.Timestamp',
+                nullable: true,
+                description: 'when the row was last updated'
+            }],
+            insertions: [],
+            updates: []
+        }
+    }
+})
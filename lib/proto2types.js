@@ -33,13 +33,28 @@ function proto2types(options) {
         // "id" field). The type names must be fully qualified, e.g. type "Foo"
         // in package "lol.wut" is "lol.wut.Foo" or, equivalently (for this
         // purpose), ".lol.wut.Foo".
-        idFields = {}
+        idFields = {},
+
+        // jsonFields :: [<type name or field name>, ...]
+        // By default, a message-valued field is stored in a child table (see
+        // `types2tables`). `jsonFields` allows you to instead store the field
+        // as a single JSON column. Each name is either that of a message type
+        // (e.g. "lol.wut.Foo"), in which case every field of that type is
+        // stored as JSON, or that of a particular field, qualified by the
+        // name of its message type (e.g. "lol.wut.Bar.foo"). The values of
+        // a map field may be stored as JSON in the same way. As with
+        // `idFields`, the leading "." is optional.
+        jsonFields = []
     } = options;
 
     if (!Array.isArray(protoFiles)) {
         throw Error('Specify an array of .proto files to compile.');
     }
 
+    // jsonNames :: {<fully qualified type or field name>: true}
+    const jsonNames = Object.fromEntries(jsonFields.map(name =>
+        [name.startsWith('.') ? name : '.' + name, true]));
+
     // Execute the protoc compiler wrapper as a subprocess. It produces a JSON
     // object.
     const protoInfo = invokeProtocJson(protoIncludePaths, protoFiles);
@@ -65,25 +80,45 @@ function proto2types(options) {
     const typesByName = protoInfo.protoFile.reduce((typesByName, file) => {
         const packageName = '.' + file.package;
 
-        (file.messageType || [])
-            // omit "built-in" messages (e.g. .google.protobuf.Timestamp)
-            .filter(message => !builtinMessage(packageName + '.' + message.name))
-            .map(message => message2type(
-                {fileName: file.name, packageName, descriptor: message, idFields}))
-            .forEach(type => typesByName[type.name] = type);
+        // Messages and enums can be declared within other messages, in which
+        // case their names are qualified by the enclosing message's name, e.g.
+        // ".foo.Parent.Child". `scopeName` is either a package name or such a
+        // message name.
+        function addDeclarations(scopeName, {messageType, enumType}) {
+            (messageType || [])
+                // omit "built-in" messages (e.g. .google.protobuf.Timestamp)
+                .filter(message => !builtinMessage(scopeName + '.' + message.name))
+                // omit the entry types of map fields (see `message2type`)
+                .filter(message => !isMapEntry(message))
+                .forEach(message => {
+                    const type = message2type({
+                        fileName: file.name,
+                        packageName: scopeName,
+                        descriptor: message,
+                        idFields,
+                        jsonNames
+                    });
+                    typesByName[type.name] = type;
+
+                    addDeclarations(type.name, {
+                        messageType: message.nestedType,
+                        enumType: message.enumType
+                    });
+                });
+
+            (enumType || [])
+                .map(anEnum => enum2type(
+                    {fileName: file.name, packageName: scopeName, descriptor: anEnum}))
+                .forEach(type => typesByName[type.name] = type);
+        }
 
-        (file.enumType || [])
-            .map(anEnum => enum2type(
-                {fileName: file.name, packageName, descriptor: anEnum}))
-            .forEach(type => typesByName[type.name] = type);
+        addDeclarations(packageName, file);
 
         return typesByName;
     }, {});    
 
     // Identify the types that will be the roots of the tree of types to
-    // generate. Note that since messages-fields-of-messages are not supported,
-    // the tree is only ever at most one layer deep from a root (the only
-    // possible children being enum fields of a message).
+    // generate.
     if (rootTypes === undefined) {
         // No root types specified, so use the message/enum types in
         // `protoInfo.fileToGenerate` (the files from the command line,
@@ -116,34 +151,57 @@ function proto2types(options) {
     }
 
     // The resulting array of types are the types whose names are in
-    // `rootTypes`, plus the types of any enum fields of messages in
-    // `rootTypes`.
+    // `rootTypes`, plus the types of any enum or message fields of messages in
+    // `rootTypes`, and so on for the fields of those messages.
     // Build up the result by name in an object (`resultTypes`) to avoid dupes,
     // and then return an array of the object's values.
     const resultTypes = {};
-    rootTypes.forEach(typeName => {
-        const type = typesByName[typeName];
 
+    // nestedTypeNames :: {<type name>: true}
+    // These are the message types that are the type of some field of another
+    // message. They're stored in child tables keyed by the ID of the parent,
+    // or in JSON columns, so they don't need an ID of their own.
+    const nestedTypeNames = {};
+
+    function addType(typeName) {
         if (typeName in resultTypes) {
             return; // already seen it
         }
 
+        const type = typesByName[typeName];
         resultTypes[typeName] = type;
         if (type.kind !== 'message') {
             return; // no fields to process
         }
 
         type.fields.forEach(field => {
-            const type = field.type;
-            const enumTypeName =
-                type.enum || (type.array && type.array.enum);
-            if (!enumTypeName || enumTypeName in resultTypes) {
-                return;
+            const fieldType =
+                field.type.array || (field.type.map && field.type.map.value) ||
+                field.type.optional || field.type;
+            const messageTypeName = fieldType.message || fieldType.json;
+            if (messageTypeName) {
+                nestedTypeNames[messageTypeName] = true;
             }
 
-            resultTypes[enumTypeName] = typesByName[enumTypeName];
+            const fieldTypeName = fieldType.enum || messageTypeName;
+            if (fieldTypeName) {
+                addType(fieldTypeName);
+            }
+        });
+    }
+
+    rootTypes.forEach(addType);
+
+    // A message type that doesn't appear as a field of another message will
+    // have its own table, so it must have an ID.
+    Object.values(resultTypes)
+        .filter(type => type.kind === 'message' &&
+                        type.idFieldName === undefined &&
+                        !(type.name in nestedTypeNames))
+        .forEach(type => {
+            throw Error(`The type ${type.name} does not have the expected ID ` +
+                        `field named "id".`);
         });
-    });
 
     const results = Object.values(resultTypes);
 
@@ -163,30 +221,160 @@ function proto2types(options) {
 // `descriptor` is the representation of the message within the protoc compiler
 // (and its plugins). Use the specified `idFields` to determine which field of
 // the type is considered its ID. If there's no override in `idFields`, use the
-// "id" field.
-function message2type({fileName, packageName, descriptor, idFields}) {
+// field having the `(okra.id)` option, or else the "id" field. If there's no
+// override and no such field, then the returned type has no ID, which is
+// acceptable only if it is the type of a field in some other message. The
+// field having the `(okra.version)` option, if any, is the type's version. Use the
+// specified `jsonNames` to determine which message-valued fields are stored as
+// JSON (see `field2fieldType`). Fields having the `(okra.ignore)` option are
+// omitted. See `okra/options.proto` for the other options.
+function message2type({fileName, packageName, descriptor, idFields, jsonNames}) {
     const typeName = packageName + '.' + descriptor.name;
+    const fields = (descriptor.field || [])
+        .filter(field => !okraOption(field.options, 'ignore'));
+
+    const idOptionFields = fields.filter(field => okraOption(field.options, 'id'));
+    if (idOptionFields.length > 1) {
+        throw Error(`The type ${typeName} has more than one field with the ` +
+                    `(okra.id) option: ` +
+                    idOptionFields.map(field => field.name).join(', '));
+    }
 
     // support both ".foo.bar" and "foo.bar" keys in `idFields`, hence the slice.
-    const idField = idFields[typeName] || idFields[typeName.slice(1)] || "id";
-    if (!descriptor.field.some(field => field.name === idField)) {
+    const idFieldOverride = idFields[typeName] || idFields[typeName.slice(1)] ||
+        idOptionFields.map(field => field.name)[0];
+    const idField = idFieldOverride || "id";
+    const hasIdField = fields.some(field => field.name === idField);
+    if (idFieldOverride !== undefined && !hasIdField) {
         throw Error(`The type ${typeName} does not have the expected ID ` +
                     `field named ${JSON.stringify(idField)}.`);
     }
 
+    const versionOptionFields = fields
+        .filter(field => okraOption(field.options, 'version'));
+    if (versionOptionFields.length > 1) {
+        throw Error(`The type ${typeName} has more than one field with the ` +
+                    `(okra.version) option: ` +
+                    versionOptionFields.map(field => field.name).join(', '));
+    }
+
+    // A map field is a repeated field of a generated "entry" message type
+    // nested within this message. The entry type has a "key" field and a
+    // "value" field.
+    // mapEntries :: {<entry type name>: <descriptor of entry type>}
+    const mapEntries = Object.fromEntries((descriptor.nestedType || [])
+        .filter(isMapEntry)
+        .map(entry => [typeName + '.' + entry.name, entry]));
+
+    const tableName = okraOption(descriptor.options, 'table_name');
+    const softDelete = okraOption(descriptor.options, 'soft_delete');
+
     return withDocs(descriptor.location, {
         kind: 'message',
         file: fileName,
         name: typeName,
-        idFieldName: idField,
-        fields: descriptor.field.map(field => withDocs(field.location, {
-            id: field.number,
-            name: field.name,
-            type: field2fieldType(field, typeName)
-        }))
+        ...(tableName ? {tableName} : {}),
+        ...(hasIdField ? {idFieldName: idField} : {}),
+        ...(versionOptionFields.length
+            ? {versionFieldName: versionOptionFields[0].name}
+            : {}),
+        ...(softDelete ? {softDelete} : {}),
+        fields: fields.map(field => {
+            // A field can be stored as JSON because of its name, or because
+            // of the name of its message type (or, for a map field, the
+            // message type of its values).
+            const entry = mapEntries[field.typeName];
+            const valueField = entry &&
+                entry.field.find(({name}) => name === 'value');
+            const json = jsonNames[typeName + '.' + field.name] ||
+                jsonNames[(valueField || field).typeName] ||
+                false;
+
+            return withDocs(field.location, {
+                id: field.number,
+                name: field.name,
+                type: entry === undefined
+                    ? field2fieldType(field, {json})
+                    : mapEntry2fieldType(entry, {json}),
+                ...oneofOf(field, descriptor),
+                ...fieldOptions(field)
+            });
+        })
     });
 }
 
+// Return an object containing the properties of a `type.tisch.js` field that
+// are derived from the okra options of the specified protobuf message `field`:
+// `columnName`, `maxLength`, `indexed`, `unique`, and `timestamp`. Options
+// that aren't set are omitted.
+function fieldOptions(field) {
+    const columnName = okraOption(field.options, 'column_name');
+    const maxLength = okraOption(field.options, 'max_length');
+    const indexed = okraOption(field.options, 'index');
+    const unique = okraOption(field.options, 'unique');
+    const timestamps = ['created', 'updated']
+        .filter(timestamp => okraOption(field.options, `${timestamp}_at`));
+    if (timestamps.length > 1) {
+        throw Error(`The field ${field.name} has both the (okra.created_at) ` +
+                    'and the (okra.updated_at) options.');
+    }
+
+    return {
+        ...(columnName ? {columnName} : {}),
+        ...(maxLength ? {maxLength} : {}),
+        ...(indexed ? {indexed} : {}),
+        ...(unique ? {unique} : {}),
+        ...(timestamps.length ? {timestamp: timestamps[0]} : {})
+    };
+}
+
+// Return the value of the okra custom option having the specified `name`
+// (e.g. "table_name") within the specified descriptor `options`, or return
+// `undefined` if the option is not set. See `okra/options.proto`. protojson
+// renders an extension as a property whose key is the fully qualified name of
+// the extension in square brackets, e.g. "[okra.table_name]".
+function okraOption(options = {}, name) {
+    return options[`[okra.${name}]`];
+}
+
+// Return `{oneof: <name>}` if the specified `field` is a member of one of the
+// `oneof`s declared in the specified message `descriptor`, or return `{}`
+// otherwise. A proto3 `optional` field is implemented by protoc as the sole
+// member of a "synthetic" oneof, but that's not a oneof as far as okra is
+// concerned.
+function oneofOf(field, descriptor) {
+    if (field.oneofIndex === undefined || field.proto3Optional) {
+        return {};
+    }
+
+    return {oneof: descriptor.oneofDecl[field.oneofIndex].name};
+}
+
+// Return whether the specified message `descriptor` is the generated "entry"
+// type of a protobuf map field.
+function isMapEntry(descriptor) {
+    return Boolean(descriptor.options && descriptor.options.mapEntry);
+}
+
+// Return the okra type of a map field whose entry type has the specified
+// `descriptor`. If the specified `json` is true, then message values are
+// stored as JSON. Otherwise, message values are not supported.
+function mapEntry2fieldType(descriptor, {json}) {
+    const [keyField, valueField] = ['key', 'value'].map(name =>
+        descriptor.field.find(field => field.name === name));
+
+    const key = field2fieldType(keyField, {json: false});
+    const value = field2fieldType(valueField, {json});
+    if (value.message !== undefined) {
+        throw Error(`The map entry type ${descriptor.name} has values of ` +
+                    `the message type ${value.message}. Map values that ` +
+                    `are messages must be stored as JSON (see ` +
+                    `\`jsonFields\`).`);
+    }
+
+    return {'map': {key, value}};
+}
+
 // Return a `type.tisch.js` object describing a protobuf enum defined in the
 // specified protobuf `packageName` and having the specified `descriptor`, where
 // `descriptor` is the representation of the enum within the protoc compiler
@@ -213,8 +401,25 @@ function enum2type({fileName, packageName, descriptor}) {
 function builtinMessage(typeName) {
     return {
         '.google.protobuf.Timestamp': true,
+        '.google.protobuf.Duration': true,
         '.google.protobuf.FieldMask': true,
-        '.google.type.Date': true
+        '.google.protobuf.Struct': true,
+        '.google.protobuf.Value': true,
+        '.google.protobuf.ListValue': true,
+        '.google.protobuf.Any': true,
+        '.google.type.Date': true,
+        '.google.type.TimeOfDay': true,
+        '.google.type.LatLng': true,
+        '.google.type.Money': true,
+        '.google.protobuf.DoubleValue': true,
+        '.google.protobuf.FloatValue': true,
+        '.google.protobuf.Int64Value': true,
+        '.google.protobuf.UInt64Value': true,
+        '.google.protobuf.Int32Value': true,
+        '.google.protobuf.UInt32Value': true,
+        '.google.protobuf.BoolValue': true,
+        '.google.protobuf.StringValue': true,
+        '.google.protobuf.BytesValue': true
     }[typeName] || false;
 }
 
@@ -250,17 +455,29 @@ function builtinNameFromFieldType(fieldType) {
     return result;
 }
 
-// Return the okra type of the specified protobuf message field `field`. The
-// specified `parentTypeName` is used in error diagnostics.
-function field2fieldType(field, parentTypeName) {
+// Return the okra type of the specified protobuf message field `field`. If the
+// specified `json` is true, then a message-valued `field` is stored as JSON
+// rather than in a child table.
+function field2fieldType(field, {json}) {
     let type;
 
     if (field.type === 'TYPE_MESSAGE' && builtinMessage(field.typeName)) {
         type = {'builtin': field.typeName};
     }
+    else if (field.type === 'TYPE_MESSAGE' && json) {
+        // The message will be stored as a JSON column (or, if `field` is
+        // repeated, as a JSON column in an array table).
+        type = {'json': field.typeName};
+    }
+    else if (json) {
+        throw Error(`Field ${field.name} is specified as a JSON field, but ` +
+                    `its type ${field.typeName || field.type} is not a ` +
+                    `message type (or is a built-in message type).`);
+    }
     else if (field.type === 'TYPE_MESSAGE') {
-        throw Error(`Field ${field.name} of message ${parentTypeName} is a `+
-                    `message. Message fields of messages are not supported.`);
+        // The fields of the message type will be stored in a child table. See
+        // `types2tables`.
+        type = {'message': field.typeName};
     }
     else if (field.type === 'TYPE_ENUM') {
         type = {'enum': field.typeName};
@@ -272,6 +489,13 @@ function field2fieldType(field, parentTypeName) {
     if (field.label === 'LABEL_REPEATED') {
         type = {'array': type};
     }
+    // A proto3 `optional` message field is no different from any other
+    // message field (it's already nullable), but an `optional` scalar or
+    // enum field can be unset, which is distinct from being zero.
+    else if (field.proto3Optional && (type.enum || type.builtin) &&
+             !builtinMessage(type.builtin)) {
+        type = {'optional': type};
+    }
 
     return type;
 }
@@ -291,8 +515,8 @@ function withDocs(location, object) {
 
 // Return a string of documentation extracted from the comments in the
 // specified source `location` object, or return `undefined` if `location`
-// contains no comments.
-function location2docs(location) {
+// contains no comments or is itself `undefined`.
+function location2docs(location = {}) {
     return [
         // 'leadingDetachedComments' are omitted, since they usually don't
         // refer to the thing below them after the blank line.
@@ -305,6 +529,10 @@ function invokeProtocJson(protoIncludePaths, protoFiles) {
     // it work, add the parent directory for each .proto file.
     protoIncludePaths = [...protoIncludePaths, ...protoFiles.map(path.dirname)];
 
+    // Also add the root of this repository, so that .proto files can
+    // `import "okra/options.proto";`.
+    protoIncludePaths.push(path.normalize(path.join(__dirname, '..')));
+
     const includeArgs = protoIncludePaths.map(path => `--proto_path=${path}`);
 
     const compilerPath =
@@ -0,0 +1,67 @@
+[{
+    kind: 'message',
+    file: 'message-field.proto',
+    name: '.foobar.Parent',
+    idFieldName: 'id',
+    fields: [{
+        id: 1,
+        name: 'id',
+        type: {builtin: 'TYPE_INT64'}
+    }, {
+        id: 2,
+        name: 'child',
+        type: {message: '.foobar.Child'},
+        description: String
+    }, {
+        id: 3,
+        name: 'children',
+        type: {array: {message: '.foobar.Child'}},
+        description: String
+    }, {
+        id: 4,
+        name: 'pet',
+        type: {message: '.foobar.Parent.Pet'}
+    }]
+}, {
+    kind: 'message',
+    file: 'message-field.proto',
+    name: '.foobar.Child',
+    description: String,
+    fields: [{
+        id: 1,
+        name: 'name',
+        type: {builtin: 'TYPE_STRING'}
+    }, {
+        id: 2,
+        name: 'age',
+        type: {builtin: 'TYPE_INT32'}
+    }]
+}, {
+    kind: 'message',
+    file: 'message-field.proto',
+    name: '.foobar.Parent.Pet',
+    description: String,
+    fields: [{
+        id: 1,
+        name: 'name',
+        type: {builtin: 'TYPE_STRING'}
+    }, {
+        id: 2,
+        name: 'kind',
+        type: {enum: '.foobar.Parent.Pet.Kind'}
+    }]
+}, {
+    kind: 'enum',
+    file: 'message-field.proto',
+    name: '.foobar.Parent.Pet.Kind',
+    values: [{
+        id: 0,
+        name: 'UNKNOWN'
+    }, {
+        id: 1,
+        name: 'DOG'
+    }, {
+        id: 2,
+        name: 'CAT'
+    }]
+}]
@@ -0,0 +1,33 @@
+// This schema describes the expected output of `okra-options.proto`. Note
+// that the ignored field, "nickname", is absent.
+[{
+    kind: 'message',
+    file: 'okra-options.proto',
+    name: '.scouts.BoyScout',
+    description: String,
+    tableName: 'scout',
+    idFieldName: 'uuid',
+    versionFieldName: 'revision',
+    softDelete: true,
+    fields: [{
+        id: 1,
+        name: 'uuid',
+        type: {builtin: 'TYPE_STRING'},
+        maxLength: 36
+    }, {
+        id: 2,
+        name: 'full_name',
+        type: {builtin: 'TYPE_STRING'},
+        columnName: 'name',
+        maxLength: 200
+    }, {
+        id: 3,
+        name: 'country_code',
+        type: {builtin: 'TYPE_STRING'},
+        indexed: true
+    }, {
+        id: 5,
+        name: 'revision',
+        type: {builtin: 'TYPE_INT64'}
+    }]
+}]
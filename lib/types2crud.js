@@ -0,0 +1,2100 @@
+// This module exports a function, `sqlCrud`, that returns the functions
+// `types2crud` and `outboxPoller` for a SQL dialect. `types2crud` produces a
+// description of create-read-update-delete (CRUD) operations for a given set
+// of types and their legends. `outboxPoller` describes the statements with
+// which generated code polls the outbox table (see `outbox.tisch.js`).
+//
+// The structure of the CRUD instructions is the same in every dialect. What
+// differs is the SQL, and `sqlCrud` takes an object, `dialect`, describing
+// those differences. Each module in `sql-dialects/` defines its dialect and
+// exports the resulting functions. `dialect` has the following properties:
+//
+// - `quoteName(name)` returns the SQL identifier `name`, quoted.
+// - `numberedParameters` is whether parameters are numbered (e.g. "$1")
+//   rather than written "?".
+// - `selector({columnName, fieldType})` returns SQL that selects the column
+//   named `columnName`, whose field has the type `fieldType`, in the
+//   representation that Okra uses for that type.
+// - `parameter(fieldType)` returns SQL that references a parameter for a
+//   field of type `fieldType` given in the representation that Okra uses.
+// - `currentTimestamp()` returns SQL for the current time, as stored in a
+//   `.google.protobuf.Timestamp` column.
+// - `integerParameter` is SQL that references a 64-bit integer parameter,
+//   including when the parameter is null.
+// - `insertedValue(column)` returns SQL for the value that an upsert would
+//   have inserted into `column`, for use in `onConflict`.
+// - `onConflict({keyColumnName, assignments})` returns the clause of an
+//   upsert's "insert" statement that performs the `assignments` when a row
+//   having the same key already exists.
+// - `upsertUpdatesOtherRows` is whether the clause returned by `onConflict`
+//   applies to a conflict in any unique index, rather than only to a conflict
+//   in the primary key. If so, then an upsert might update a row other than
+//   that of the message, and so checks beforehand that it won't.
+// - `historyOrdinality({table, id, ordinality, idParameter})` returns SQL,
+//   for use in a tuple of an "insert ... values" statement into a message's
+//   history, for the ordinality of the message's next record (see
+//   `historyMessages`).
+// - `claimOutboxEvents({table, sequence, claim, claimedAt})` returns the SQL
+//   of the "claim" statement of the outbox poller (see `outboxPoller`).
+//
+// Parameters in SQL returned by `dialect` are written "?", even if
+// `numberedParameters` is true. They're numbered later.
+
+define(['../schemas/schemas', '../dependencies/tisch/tisch'],
+function (schemas, tisch) {
+'use strict';
+
+// Return a `RegExp` object compiled from a pattern that is the disjunction of
+// the patterns of the specified `regexes`. The "global" flag ("g") will be
+// used.
+function combinePatterns(...regexes) {
+    const pattern = regexes.map(regex => `(${regex.source})`).join('|');
+    const flags = 'g';
+    return new RegExp(pattern, flags);
+}
+
+// Return a function that returns a modified version of a specified `sqlText`
+// that has had unnecessary whitespace removed. This allows SQL template
+// strings to be formatted however is convenient in this code, and then reduced
+// to something consistent for output. If the specified `numberedParameters` is
+// true, then the function also replaces the first "?" in `sqlText` with "$1",
+// the second with "$2", and so on. This is also done for the tuples in
+// "...-with-tuples" instructions, whose parameters generated code then
+// renumbers in each copy of a tuple (e.g. "($1, $2), ($3, $4)").
+function makeSqline(numberedParameters) {
+    const regex = combinePatterns(
+        /\s+/, // whitespace
+        /'[^']*'/, // 'single-quoted string'
+        /`[^`]*`/, // `backtick-quoted string`
+        /"[^"]*"/, // "double-quoted string"
+        /--[^\n]*\n/, // -- line comment
+        /\/\*(\*[^/]|[^*])*\*\//, // /* block comment */
+        /\?/); // parameter
+
+    return sqlText => {
+        let numParameters = 0;
+        const replace = token => {
+            if (token.match(/^\s+$/)) {
+                return ' ';
+            }
+            if (token === '?' && numberedParameters) {
+                return `$${++numParameters}`;
+            }
+            return token;
+        };
+
+        return sqlText.replace(regex, replace).trim();
+    };
+}
+
+// Return the functions `types2crud` and `outboxPoller` (as an object) for the
+// SQL dialect described by the specified `dialect`. See the top of this file.
+function sqlCrud(dialect) {
+    const {quoteName, selector, parameter, currentTimestamp} = dialect;
+    const sqline = makeSqline(dialect.numberedParameters);
+
+    // Return the name and type of the column that, together with the message
+    // ID, identifies a row in the table of an array-like field having the
+    // specified `arrayType`. Arrays (and FieldMasks) are keyed by position
+    // ("ordinality"), while maps are keyed by their keys ("key").
+    function arrayPositionColumn(arrayType) {
+        if (arrayType.map) {
+            return {columnName: 'key', fieldType: arrayType.map.key};
+        }
+        return {columnName: 'ordinality', fieldType: {builtin: 'TYPE_UINT32'}};
+    }
+
+    // Return the type of the "value" column in the table of an array-like field
+    // having the specified `arrayType`. If `arrayType` refers to an actual
+    // array, then the type of its elements is `arrayType.array`. If it's a map,
+    // then the type is that of the map's values. However, if `arrayType` is a
+    // FieldMask, then the type of its elements is string.
+    function arrayElementType(arrayType) {
+        if (arrayType.map) {
+            return arrayType.map.value;
+        }
+        return arrayType.array || {builtin: 'TYPE_STRING'};
+    }
+
+    // Return a CRUD instruction for adding values into the specified
+    // `arrayTableName` from the specified `arrayField` of the message type
+    // having the specified `messageIdField`, where the `messageIdField` has the
+    // specified `messageIdFieldType` and the elements of the array field have
+    // the specified `arrayFieldType`. The returned instruction will require
+    // that `arrayField` is included in the operation (if `arrayField` is not
+    // included, a code generator will not perform the instruction). If
+    // `arrayField` is a map, then its entries are added as (id, key, value)
+    // rows.
+    function instructionInsertArray({
+        arrayTableName,
+        messageIdField,
+        messageIdFieldType,
+        arrayField,
+        arrayFieldType
+    }) {
+        const position = arrayPositionColumn(arrayFieldType);
+        const map = arrayFieldType.map !== undefined;
+
+        return {
+            instruction: 'exec-with-tuples',
+            condition: {included: arrayField},
+            // tuple is, e.g. "(?, ?, ?)" or "(?, ?, from_unixtime(...))"
+            tuple: sqline('(' + [
+                messageIdFieldType,
+                position.fieldType,
+                map ? arrayElementType(arrayFieldType) : arrayFieldType
+            ].map(parameter).join(', ') + ')'),
+            sql: sqline(`insert into
+                ${quoteName(arrayTableName)}(
+                    ${quoteName('id')},
+                    ${quoteName(position.columnName)},
+                    ${quoteName('value')})
+                values `),
+            parameters: [
+                {field: messageIdField},
+                map ? {key: arrayField} : {index: arrayField},
+                {field: arrayField}
+            ]
+        };
+    }
+
+    // Return a CRUD instruction for selecting rows from the specified
+    // `arrayTableName` representing the specified `arrayField` having the
+    // specified `arrayType` in the message type having the specified
+    // `messageIdField` with the specified `messageIdFieldType`. The returned
+    // instruction will require that `arrayField` is included in the operation.
+    // If `arrayField` is a map, then each resulting row is a (key, value) pair.
+    function instructionSelectArray({
+        arrayTableName,
+        arrayField,
+        arrayType, // type of the array itself, e.g. `{array: ...}`
+        messageIdField,
+        messageIdFieldType
+    }) {
+        const elementType = arrayElementType(arrayType);
+        const position = arrayPositionColumn(arrayType);
+
+        // It's important that we select only the `value` column (and, for maps,
+        // the `key` column before it), so that the generated code then knows
+        // what to put into the array field.
+        const selectors = [
+            ...(arrayType.map ? [selector(position)] : []),
+            selector({columnName: 'value', fieldType: elementType})
+        ];
+
+        return {
+            instruction: 'query',
+            condition: {included: arrayField},
+            sql: sqline(`select ${selectors.join(', ')}
+                    from ${quoteName(arrayTableName)}
+                    where ${quoteName('id')} = ${parameter(messageIdFieldType)}
+                    order by ${quoteName(position.columnName)};`),
+            parameters: [
+                {field: messageIdField}
+            ]
+        };
+    }
+
+    // Return a CRUD instruction that deletes all rows from the specified
+    // `arrayTableName` whose message ID column ("id") of the specified type
+    // `messageIdFieldType` has the same value as the specified `messageIdField`
+    // (`messageIdField` is the _name_ of the field whose value we're interested
+    // in). Optionally specify a `conditionField`, which makes the returned
+    // instruction applicable only if that field is included in the relevant
+    // operation (e.g. deleting values before replacing them in an "update," but
+    // only if we're updating that field).
+    function instructionDeleteArray({
+        arrayTableName,
+        messageIdField,
+        messageIdFieldType,
+        conditionField
+    }) {
+        return {
+            instruction: 'exec',
+            sql: sqline(`delete from ${quoteName(arrayTableName)}
+                    where ${quoteName('id')} = ${parameter(messageIdFieldType)};`),
+            parameters: [
+                {field: messageIdField}
+            ],
+            // "condition" is optional. Let it appear only if it has a value.
+            ...(conditionField ? {condition: {included: conditionField}} : {})
+        };
+    }
+
+    // Return an object that maps the name of each field of the child message
+    // type described by the specified `childSource` (an element of a legend's
+    // `fieldSources` having a "messageTypeName") to the type of that field. Use
+    // the specified `types` to look up the child message type.
+    function childFieldTypes({childSource, types}) {
+        return Object.fromEntries(
+            types[childSource.messageTypeName].type.fields.map(
+                ({name, type}) => [name, type]));
+    }
+
+    // Return a CRUD instruction for adding rows into the child table of the
+    // message-valued field described by the specified `childSource` (an element
+    // of a legend's `fieldSources` having a "messageTypeName"), where the field
+    // belongs to the message type having the specified `messageIdField` of the
+    // specified `messageIdFieldType`. The field is repeated if the specified
+    // `repeated` is true. Use the specified `types` to look up the child
+    // message type. The returned instruction will require that the field is
+    // included in the operation.
+    function instructionInsertChild({
+        childSource,
+        types,
+        messageIdField,
+        messageIdFieldType,
+        repeated
+    }) {
+        const fieldTypes = childFieldTypes({childSource, types});
+        const {fieldName, tableName, fieldSources} = childSource;
+
+        return {
+            instruction: 'exec-with-tuples',
+            condition: {included: fieldName},
+            // tuple is, e.g. "(?, ?, ?, ?)", where the first is the parent ID,
+            // the second is the ordinality (only if `repeated`), and the rest
+            // are the fields of the child message.
+            tuple: sqline('(' + [
+                messageIdFieldType,
+                ...(repeated ? [{builtin: 'TYPE_UINT32'}] : []),
+                ...fieldSources.map(({fieldName}) => fieldTypes[fieldName])
+            ].map(parameter).join(', ') + ')'),
+            sql: sqline(`insert into
+                ${quoteName(tableName)}(${[
+                    'parent_id',
+                    ...(repeated ? ['ordinality'] : []),
+                    ...fieldSources.map(({columnName}) => columnName)
+                ].map(quoteName).join(', ')})
+                values `),
+            parameters: [
+                {field: messageIdField},
+                ...(repeated ? [{index: fieldName}] : []),
+                ...fieldSources.map(source =>
+                    ({child: fieldName, field: source.fieldName}))
+            ]
+        };
+    }
+
+    // Return an array of SQL expressions that select the columns of the child
+    // table described by the specified `childSource`, and an array of the
+    // corresponding output parameters, as an object
+    // `{selectors, destinations}`. Use the specified `types` to look up the
+    // child message type.
+    function childSelectors({childSource, types}) {
+        const fieldTypes = childFieldTypes({childSource, types});
+        const {fieldSources} = childSource;
+
+        return {
+            selectors: fieldSources.map(({fieldName, columnName}) =>
+                selector({columnName, fieldType: fieldTypes[fieldName]})),
+            destinations: fieldSources.map(({fieldName}) => ({field: fieldName}))
+        };
+    }
+
+    // Return an array of CRUD instructions that read the rows of the child
+    // table described by the specified `childSource` into the message-valued
+    // field that it describes, where the field belongs to the message type
+    // having the specified `messageIdField` of the specified
+    // `messageIdFieldType`. The field is repeated if the specified `repeated`
+    // is true. Use the specified `types` to look up the child message type. The
+    // returned instructions will require that the field is included in the
+    // operation.
+    function instructionsSelectChild({
+        childSource,
+        types,
+        messageIdField,
+        messageIdFieldType,
+        repeated
+    }) {
+        const {selectors, destinations} = childSelectors({childSource, types});
+        const {fieldName, tableName} = childSource;
+        const orderBy = repeated ? ` order by ${quoteName('ordinality')}` : '';
+
+        return [
+            // e.g.
+            // select sku, quantity from order_items where parent_id = ?
+            // order by ordinality;
+            {
+                instruction: 'query',
+                condition: {included: fieldName},
+                sql: sqline(`select ${selectors.join(', ')}
+                    from ${quoteName(tableName)}
+                    where ${quoteName('parent_id')} = ${parameter(messageIdFieldType)}${orderBy};`),
+                parameters: [
+                    {field: messageIdField}
+                ]
+            },
+
+            // e.g.
+            // for row in result:
+            //     row.scan(&order.items.push_back())
+            {
+                instruction: 'read-child-rows',
+                destination: {field: fieldName},
+                destinations
+            }
+        ];
+    }
+
+    // Return a CRUD instruction that deletes all rows from the specified
+    // `childTableName` whose parent ID column ("parent_id") of the specified
+    // type `messageIdFieldType` has the same value as the specified
+    // `messageIdField`. Optionally specify a `conditionField`, which makes the
+    // returned instruction applicable only if that field is included in the
+    // relevant operation. See `instructionDeleteArray`.
+    function instructionDeleteChild({
+        childTableName,
+        messageIdField,
+        messageIdFieldType,
+        conditionField
+    }) {
+        return {
+            instruction: 'exec',
+            sql: sqline(`delete from ${quoteName(childTableName)}
+                    where ${quoteName('parent_id')} = ${parameter(messageIdFieldType)};`),
+            parameters: [
+                {field: messageIdField}
+            ],
+            // "condition" is optional. Let it appear only if it has a value.
+            ...(conditionField ? {condition: {included: conditionField}} : {})
+        };
+    }
+
+    // Return the specified SQL `condition` on the rows of the message table
+    // described by the specified `legend`, amended to be false for deleted rows
+    // if the message type is soft-deleted (see `deletedColumn` in
+    // `legend.tisch.js`). Otherwise, return `condition` as is.
+    function sqlNotDeleted({legend, condition}) {
+        if (legend.deletedColumn === undefined) {
+            return condition;
+        }
+        return `(${condition}) and ${quoteName(legend.deletedColumn)} is null`;
+    }
+
+    // Return whether changes to messages of the specified `legend` are
+    // recorded in a history table or inserted into the outbox. If so, then the
+    // statement of an operation that might change nothing, e.g. deleting a
+    // message that is already deleted, says so (see "onNoRows" in
+    // `crud.tisch.js`), so that nothing is recorded.
+    function changesRecorded(legend) {
+        return legend.historyTableName !== undefined ||
+            legend.outboxTableName !== undefined;
+    }
+
+    // Return a CRUD instruction that selects the scalar fields of an instance
+    // of the specified `type` from the database. Use the specified `legend` to
+    // map message fields to table columns. Fields other than the ID are
+    // selected only if they are included in the operation; otherwise, they're
+    // null. A deleted message is not selected.
+    function instructionSelectMessage({type, legend}) {
+        const {scalarFieldSources} = byMultiplicity(legend.fieldSources);
+        const keyColumnName = scalarFieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const optionalFieldSources = scalarFieldSources
+            .filter(({fieldName}) => fieldName !== type.idFieldName);
+        const selectors = scalarFieldSources
+            .map(source => {
+                const column = selector({
+                    columnName: source.columnName,
+                    fieldType: scalarSourceType(source, fieldTypes)
+                });
+                if (source.fieldName === type.idFieldName) {
+                    return column;
+                }
+                return `case when ? then ${column} else null end`;
+            });
+        const idFieldType = fieldTypes[type.idFieldName];
+
+        return {
+            instruction: 'query',
+            sql: sqline(`select ${selectors.join(', ')}
+                from ${quoteName(legend.tableName)}
+                where ${sqlNotDeleted({
+                    legend,
+                    condition: `${quoteName(keyColumnName)} = ${parameter(idFieldType)}`
+                })};`),
+            parameters: [
+                ...optionalFieldSources.map(source =>
+                    ({included: scalarSourceInclusion(source)})),
+                {field: type.idFieldName}
+            ]
+        };
+    }
+
+    // Return an array of CRUD instructions that check whether there is a
+    // particular instance of the specified `type` in the database, and produces
+    // an error (by failing to read a result row) if there isn't. This mechanism
+    // is used by updates to verify that there is anything to update. A deleted
+    // message doesn't count.
+    function instructionsMessageExists({type, legend}) {
+        const keyColumnName = legend.fieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const idFieldType = fieldTypes[type.idFieldName];
+
+        return [
+            // Select something (null) for each matching row. The idea is that
+            // if there are no matching rows, then the following read-row will
+            // fail. There's guaranteed to be no more than one row, because
+            // we're querying on a primary key column.
+            {
+                instruction: 'query',
+                sql: sqline(`select null
+                    from ${quoteName(legend.tableName)}
+                    where ${sqlNotDeleted({
+                        legend,
+                        condition: `${quoteName(keyColumnName)} = ${parameter(idFieldType)}`
+                    })};`),
+                parameters: [
+                    {field: type.idFieldName}
+                ]
+            },
+            // This read-row will succeed if the above query produced any rows.
+            // It will fail if there are no rows. Ignore the column value.
+            {
+                instruction: 'read-row',
+                destinations: ['ignore']
+            }
+        ];
+    }
+
+    // Return an array of CRUD instructions that fail with a unique violation if
+    // a row other than that of a particular instance of the specified `type`
+    // has the same value in any of the type's unique fields. Use the specified
+    // `legend` to find the message table, and the specified `scalarFieldInfos`
+    // (as in "upsert") to find the columns of the unique fields. Deleted
+    // messages count, since their rows are still in the unique indices. If the
+    // type has no unique fields, then return an empty array.
+    function instructionsNoOtherUniqueRow({type, legend, scalarFieldInfos}) {
+        const uniqueFieldNames = type.fields
+            .filter(({unique}) => unique)
+            .map(({name}) => name);
+        if (uniqueFieldNames.length === 0) {
+            return [];
+        }
+
+        const keySource = scalarFieldInfos
+            .find(({fieldName}) => fieldName === type.idFieldName);
+
+        // A field stored in more than one column (e.g. a `LatLng`) has the
+        // same value only if all of its columns do, e.g.
+        // (`latitude` = ? and `longitude` = ?)
+        const uniqueSources = uniqueFieldNames.map(name =>
+            scalarFieldInfos.filter(({fieldName}) => fieldName === name));
+        const sameValue = uniqueSources.map(sources =>
+            '(' + sources.map(({columnName, fieldType}) =>
+                `${quoteName(columnName)} = ${parameter(fieldType)}`)
+                .join(' and ') + ')');
+
+        return [
+            // Select something (null) if there are no other rows having any of
+            // the unique values. The idea is that if there are such rows, then
+            // the following read-row will fail.
+            {
+                instruction: 'query',
+                sql: sqline(`select null
+                    from (select count(*) as conflicts
+                        from ${quoteName(legend.tableName)}
+                        where (${sameValue.join(' or ')})
+                        and ${quoteName(keySource.columnName)} <> ${parameter(keySource.fieldType)})
+                        as counted
+                    where conflicts = 0;`),
+                parameters: [
+                    ...uniqueSources.flat().map(scalarSourceParameter),
+                    {field: type.idFieldName}
+                ]
+            },
+            // This read-row will fail if the above query produced no rows.
+            {
+                instruction: 'read-row',
+                destinations: ['ignore'],
+                onNoRow: 'uniqueViolation'
+            }
+        ];
+    }
+
+    // Return an array of CRUD instructions that check whether there is a
+    // particular instance of the specified `type` in the database, and read the
+    // answer into the result of the operation. Use the specified `legend` to
+    // map message fields to table columns. Unlike `instructionsMessageExists`,
+    // it is not an error if there is no such instance. As there, a deleted
+    // message doesn't count.
+    function instructionsExistsMessage({type, legend}) {
+        const keyColumnName = legend.fieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const idFieldType = fieldTypes[type.idFieldName];
+
+        return [
+            // e.g.
+            // select exists (select null from boyscout where id = ?);
+            {
+                instruction: 'query',
+                sql: sqline(`select exists (select null
+                    from ${quoteName(legend.tableName)}
+                    where ${sqlNotDeleted({
+                        legend,
+                        condition: `${quoteName(keyColumnName)} = ${parameter(idFieldType)}`
+                    })});`),
+                parameters: [
+                    {field: type.idFieldName}
+                ]
+            },
+            {
+                instruction: 'read-result',
+                destination: 'exists'
+            }
+        ];
+    }
+
+    // Return a snippet of SQL that sets the value at the specified `columnName`
+    // to either a parameterized value or to itself (a no-op) depending on a
+    // parameterized boolean. The boolean says whether to update the column. The
+    // SQL snipped returned by this function is intended to be used as one of
+    // the "..." in "update foo set ..., ..., ...".
+    function sqlClauseUpdateColumn({columnName, fieldType}) {
+        const name = quoteName(columnName);
+
+        // The first parameter will be bound to a predicate answering the
+        // question of whether `columnName` should be updated. The second
+        // parameter will be bound to the new value for `columnName`, or to
+        // anything (e.g. null) if the column is not to be updated.
+        const boolParameter = parameter({builtin: 'TYPE_BOOL'});
+        const valueParameter = parameter(fieldType);
+        return `${name} = case
+            when ${boolParameter} then ${valueParameter}
+            else ${name}
+          end`;
+    }
+
+    // Return a snippet of SQL that increments the version at the specified
+    // `columnName`, and a snippet of SQL that is true if the version at
+    // `columnName` is a parameterized value. The version of a message is stored
+    // like any other integer field, so zero is stored as null. If `tableName`
+    // is specified, then the increment reads the column qualified by it, as is
+    // needed in the "on conflict" clause of an upsert.
+    function sqlVersionClauses({columnName, tableName}) {
+        const name = quoteName(columnName);
+        const current = tableName === undefined
+            ? name
+            : `${quoteName(tableName)}.${name}`;
+        return {
+            increment: `${name} = coalesce(${current}, 0) + 1`,
+            condition: `coalesce(${name}, 0) = coalesce(${dialect.integerParameter}, 0)`
+        };
+    }
+
+    // Return a CRUD instruction that updates the scalar fields of an instance
+    // of the specified `type` in the database, or return `undefined` if `type`
+    // does not have any updatable fields. Use the specified `legend` to map
+    // message fields to table columns. If `type` has a version field, then the
+    // update applies only if the version in the database is that of the
+    // message, and the version is incremented.
+    function instructionUpdateMessage({type, legend}) {
+        const {scalarFieldSources} = byMultiplicity(legend.fieldSources);
+        const keyColumnName = scalarFieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const idFieldType = fieldTypes[type.idFieldName];
+
+        // Exclude the ID field from those that we might update (we're never
+        // going to change the primary key of a row), and also add the type of
+        // each field (for use by `sqlClauseUpdateColumn`). The version field,
+        // if any, is excluded too, because it's incremented rather than set, as
+        // are fields populated from timestamp columns, which aren't written.
+        // Note that some sources, such as a oneof's discriminator, have no
+        // `fieldName`, so compare against the version field only if there is
+        // one.
+        const isVersion = ({fieldName}) =>
+            type.versionFieldName !== undefined &&
+            fieldName === type.versionFieldName;
+        const scalarFieldInfos = scalarFieldSources
+            .filter(source => source.fieldName !== type.idFieldName &&
+                              !isVersion(source))
+            .filter(isWritten)
+            .map(entry => ({...entry, fieldType: scalarSourceType(entry, fieldTypes)}));
+        const versionSource = scalarFieldSources.find(isVersion);
+        const version = versionSource && sqlVersionClauses(versionSource);
+
+        // The "updated" timestamp column, if any, is set to the current time.
+        const setClauses = [
+            ...scalarFieldInfos.map(sqlClauseUpdateColumn),
+            ...(version ? [version.increment] : []),
+            ...timestampColumnNames({legend, inserted: false})
+                .map(column => `${quoteName(column)} = ${currentTimestamp()}`)
+        ];
+
+        if (setClauses.length === 0) {
+            // If there are no scalar fields other than the ID (and no version
+            // or timestamps), then there's nothing to update, so return
+            // `undefined` to indicate this to the caller.
+            return;
+        }
+
+        const whereClauses = [
+            `${quoteName(keyColumnName)} = ${parameter(idFieldType)}`,
+            ...(version ? [version.condition] : [])
+        ];
+
+        return {
+            instruction: 'exec',
+            sql: sqline(`update ${quoteName(legend.tableName)}
+                set ${setClauses.join(', ')}
+                where ${whereClauses.join(' and ')};`),
+            parameters: [
+                // Each of the possibly-updated fields has two parameters: one
+                // that's a boolean saying whether to update it, and another
+                // that's the new value if it's to be updated.
+                ...scalarFieldInfos.map(source => [
+                    {included: scalarSourceInclusion(source)},
+                    scalarSourceParameter(source)
+                ]).flat(),
+
+                // The next parameter is the primary key of the message, for the
+                // `where` clause in the `update` statement.
+                {field: type.idFieldName},
+
+                // The last parameter, if any, is the version of the message,
+                // also for the `where` clause.
+                ...(version ? [{field: type.versionFieldName}] : [])
+            ],
+            // If the version didn't match, then no rows were updated.
+            ...(version ? {onNoRows: 'conflict'} : {})
+        };
+    }
+
+    // Return whether the specified scalar field `source` is written by
+    // statements that insert or update a row, i.e. whether it isn't populated
+    // from a timestamp column. The timestamps are determined by the database,
+    // not by the message.
+    function isWritten(source) {
+        return source.timestamp === undefined;
+    }
+
+    // Return the names of the timestamp columns, if any, of the message table
+    // described by the specified `legend` that are set by a statement that
+    // inserts a row, if `inserted` is true, or that updates a row, otherwise.
+    // Inserting a row sets both of the timestamp columns, while updating a row
+    // sets only the "updated" column.
+    function timestampColumnNames({legend, inserted}) {
+        const {timestamps} = legend;
+        if (timestamps === undefined) {
+            return [];
+        }
+        return inserted
+            ? [timestamps.created, timestamps.updated]
+            : [timestamps.updated];
+    }
+
+    // Return `{columns, values}`, where `columns` is the SQL for the columns
+    // that a statement that inserts a row into the message table described by
+    // the specified `legend` sets, and `values` is the SQL for their values.
+    // Each of the specified `scalarFieldInfos` (written scalar field sources
+    // with their `fieldType`) has a column whose value is a parameter, and each
+    // timestamp column's value is the current time.
+    function insertedColumns({legend, scalarFieldInfos}) {
+        const timestampColumns = timestampColumnNames({legend, inserted: true});
+        return {
+            columns: [
+                ...scalarFieldInfos.map(({columnName}) => quoteName(columnName)),
+                ...timestampColumns.map(quoteName)
+            ].join(', '),
+            values: [
+                ...scalarFieldInfos.map(({fieldType}) => parameter(fieldType)),
+                ...timestampColumns.map(() => currentTimestamp())
+            ].join(', ')
+        };
+    }
+
+    // Deal the specified `fieldSources` array into three arrays: one for scalar
+    // fields, one for array-like fields, and one for message-valued ("child")
+    // fields. An array-like field is an array, a map, or a FieldMask.
+    function byMultiplicity(fieldSources) {
+        // We can distinguish scalar fields (e.g. int32, string) from array
+        // fields (e.g. repeated int32) by the presence of a "tableName"
+        // property in the corresponding element of the type's legend's
+        // `.fieldSources`. Values of array fields are stored in dedicated
+        // tables, so they're associated with a "tableName", while scalar fields
+        // are not (they're stored in the message type's table). Message-valued
+        // fields are stored in dedicated tables too, but their sources
+        // additionally have "fieldSources" for the columns of the child message
+        // type. The source of a oneof is counted among the scalar fields, since
+        // it's a column in the message's table.
+        return {
+            scalarFieldSources: fieldSources.filter(source => !('tableName' in source)),
+            arrayFieldSources: fieldSources.filter(source =>
+                'tableName' in source && !('fieldSources' in source)),
+            childFieldSources: fieldSources.filter(source => 'fieldSources' in source)
+        };
+    }
+
+    // Return the okra type of the column of the specified scalar field `source`
+    // (see `byMultiplicity`). Use the specified `fieldTypes` to look up the
+    // type of a field. The source of a oneof is not a field; its column
+    // contains the name of the member field that is set. A field stored in more
+    // than one column has a source for each of its parts, e.g. the latitude of
+    // a `google.type.LatLng`.
+    function scalarSourceType(source, fieldTypes) {
+        if ('part' in source) {
+            // See `multiColumnBuiltins` in `types2tables.js`. The amount of a
+            // `google.type.Money` is exchanged as a decimal string.
+            return {builtin: {
+                type_url: 'TYPE_STRING',
+                value: 'TYPE_BYTES',
+                latitude: 'TYPE_DOUBLE',
+                longitude: 'TYPE_DOUBLE',
+                currency_code: 'TYPE_STRING',
+                amount: 'TYPE_STRING'
+            }[source.part]};
+        }
+        if ('fieldName' in source) {
+            return fieldTypes[source.fieldName];
+        }
+        return {builtin: 'TYPE_STRING'};
+    }
+
+    // Return the CRUD input or output parameter that refers to the column of
+    // the specified scalar field `source` (see `byMultiplicity`).
+    function scalarSourceParameter(source) {
+        if (!('fieldName' in source)) {
+            return {oneof: source.oneofName};
+        }
+        if ('oneofName' in source) {
+            return {field: source.fieldName, oneof: source.oneofName};
+        }
+        if ('part' in source) {
+            return {field: source.fieldName, part: source.part};
+        }
+        return {field: source.fieldName};
+    }
+
+    // Return the name by which the column of the specified scalar field
+    // `source` is included in, or excluded from, a CRUD operation (see
+    // `byMultiplicity`). The members of a oneof are included or excluded
+    // together, along with the oneof itself, by the name of the oneof.
+    function scalarSourceInclusion(source) {
+        return source.oneofName || source.fieldName;
+    }
+
+    //    _____                _       
+    //   / ____|              | |      
+    //  | |     _ __ ___  __ _| |_ ___ 
+    //  | |    | '__/ _ \/ _` | __/ _ \
+    //  | |____| | |  __/ (_| | ||  __/
+    //   \_____|_|  \___|\__,_|\__\___|
+    //  Return an array of CRUD instructions that add a new instance of the
+    // specified `type` to the database. Use the specified `legend` to map
+    // message fields to table columns, and the specified `types` to look up
+    // child message types.
+    function instructionsCreateMessage({type, legend, types}) {
+        const {
+            scalarFieldSources,
+            arrayFieldSources,
+            childFieldSources
+        } = byMultiplicity(legend.fieldSources);
+
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        // Fields populated from timestamp columns aren't written.
+        const scalarFieldInfos = scalarFieldSources
+            .filter(isWritten)
+            .map(entry => ({...entry, fieldType: scalarSourceType(entry, fieldTypes)}));
+        const {columns, values} = insertedColumns({legend, scalarFieldInfos});
+
+        return [
+            // Insert a new row into the table of the message type, specifying
+            // all non-array fields (and the timestamps, if any).
+            {
+                instruction: 'exec',
+                sql: sqline(`insert into ${quoteName(legend.tableName)}(
+                    ${columns})
+                    values (${values});`),
+                parameters: scalarFieldInfos.map(scalarSourceParameter)
+            },
+
+            // For each array field, add rows to the corresponding table.
+            ...arrayFieldSources.map(({fieldName, tableName}) =>
+                instructionInsertArray({
+                    arrayTableName: tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    arrayField: fieldName,
+                    arrayFieldType: fieldTypes[fieldName]
+                })),
+
+            // For each message field, add rows to the corresponding child
+            // table.
+            ...childFieldSources.map(childSource =>
+                instructionInsertChild({
+                    childSource,
+                    types,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    repeated: fieldTypes[childSource.fieldName].array !== undefined
+                }))
+        ];
+    }
+
+    //    _____                _         __  __
+    //   / ____|              | |       |  \/  |
+    //  | |     _ __ ___  __ _| |_ ___  | \  / | __ _ _ __  _   _
+    //  | |    | '__/ _ \/ _` | __/ _ \ | |\/| |/ _` | '_ \| | | |
+    //  | |____| | |  __/ (_| | ||  __/ | |  | | (_| | | | | |_| |
+    //   \_____|_|  \___|\__,_|\__\___| |_|  |_|\__,_|_| |_|\__, |
+    //                                                       __/ |
+    //                                                      |___/
+    //
+    // Return an array of CRUD instructions that add many new instances of the
+    // specified `type` to the database. Use the specified `legend` to map
+    // message fields to table columns.
+    //
+    // Each table is inserted into using multi-row "insert" statements, rather
+    // than once per message (or once per message per array field).
+    function instructionsCreateManyMessages({type, legend, types}) {
+        const {
+            scalarFieldSources,
+            arrayFieldSources,
+            childFieldSources
+        } = byMultiplicity(legend.fieldSources);
+
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        // Fields populated from timestamp columns aren't written.
+        const scalarFieldInfos = scalarFieldSources
+            .filter(isWritten)
+            .map(entry => ({...entry, fieldType: scalarSourceType(entry, fieldTypes)}));
+        const {columns, values} = insertedColumns({legend, scalarFieldInfos});
+
+        return [
+            // Insert one row per message into the table of the message type,
+            // specifying all non-array fields (and the timestamps, if any).
+            {
+                instruction: 'exec-many-with-tuples',
+                tuple: sqline(`(${values})`),
+                sql: sqline(`insert into ${quoteName(legend.tableName)}(
+                    ${columns})
+                    values `),
+                parameters: scalarFieldInfos.map(scalarSourceParameter)
+            },
+
+            // For each array field, add rows to the corresponding table. The
+            // statement is the same as when creating one message, but the
+            // instruction is different.
+            ...arrayFieldSources.map(({fieldName, tableName}) => {
+                const {tuple, sql, parameters} = instructionInsertArray({
+                    arrayTableName: tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    arrayField: fieldName,
+                    arrayFieldType: fieldTypes[fieldName]
+                });
+
+                return {instruction: 'exec-many-with-tuples', tuple, sql, parameters};
+            }),
+
+            // Likewise for each message field and its child table.
+            ...childFieldSources.map(childSource => {
+                const {tuple, sql, parameters} = instructionInsertChild({
+                    childSource,
+                    types,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    repeated: fieldTypes[childSource.fieldName].array !== undefined
+                });
+
+                return {instruction: 'exec-many-with-tuples', tuple, sql, parameters};
+            })
+        ];
+    }
+
+    //   _____                _ 
+    //  |  __ \              | |
+    //  | |__) |___  __ _  __| |
+    //  |  _  // _ \/ _` |/ _` |
+    //  | | \ \  __/ (_| | (_| |
+    //  |_|  \_\___|\__,_|\__,_|
+    //
+    // Return an array of CRUD instructions that read an instance of the
+    // specified message `type` from the database. Use the specified `legend` to
+    // map message fields to table columns.
+    function instructionsReadMessage({type, legend, types}) {
+        const {
+            scalarFieldSources,
+            arrayFieldSources,
+            childFieldSources
+        } = byMultiplicity(legend.fieldSources);
+
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+
+        return [
+            // Query the message table.
+            instructionSelectMessage({type, legend}),
+
+            // Read the resulting row.
+            {
+                instruction: 'read-row',
+                destinations: scalarFieldSources.map(scalarSourceParameter)
+            },
+
+            // For each array field:
+            // - query array table
+            // - read results into array field
+            ...arrayFieldSources.map(({fieldName, tableName}) => [
+                // e.g.
+                // select value from boyscout_badges where id = ?;
+                instructionSelectArray({
+                    arrayTableName: tableName,
+                    arrayField: fieldName,
+                    arrayType: fieldTypes[fieldName],
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName]
+                }),
+
+                // e.g.
+                // for row in result:
+                //     row.scan(&boyscout.badges.push_back())
+                {
+                    instruction: 'read-array',
+                    destination: {field: fieldName}
+            }
+            ]).flat(),
+
+            // For each message field:
+            // - query child table
+            // - read results into message field
+            ...childFieldSources.map(childSource =>
+                instructionsSelectChild({
+                    childSource,
+                    types,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    repeated: fieldTypes[childSource.fieldName].array !== undefined
+                })).flat()
+        ];
+    }
+
+    //   _    _           _       _       
+    //  | |  | |         | |     | |      
+    //  | |  | |_ __   __| | __ _| |_ ___ 
+    //  | |  | | '_ \ / _` |/ _` | __/ _ \
+    //  | |__| | |_) | (_| | (_| | ||  __/
+    //   \____/| .__/ \__,_|\__,_|\__\___|
+    //         | |                        
+    //         |_|
+    //
+    // Return an array of CRUD instructions that update an instance of the
+    // specified message `type` in the database. Use the specified `legend` to
+    // map message fields to table columns.
+    function instructionsUpdateMessage({type, legend, types}) {
+        // "Update" is interesting because it takes field inclusion into account
+        // (i.e. when somebody does an update, they can specify some subset of
+        // message fields to be updated, rather than all of them).
+        const {arrayFieldSources, childFieldSources} =
+            byMultiplicity(legend.fieldSources);
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+
+        return [
+            // Check that there is a matching message to be updated.
+            ...instructionsMessageExists({type, legend}),
+
+            // Update the message table. `instructionUpdateMessage` will return
+            // `undefined` if no instruction is needed, so we "filter out" that
+            // case here.
+            ...[instructionUpdateMessage({type, legend})].filter(op => op),
+
+            // For each array field:
+            // - remove old (all) values from array table (only if the array is
+            //   included)
+            // - insert new values into array table (only if the array is included)
+            ...arrayFieldSources.map(({fieldName, tableName}) => [
+                // e.g.
+                // delete from boyscout_badges where id = ?;
+                instructionDeleteArray({
+                    arrayTableName: tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    conditionField: fieldName
+                }),
+
+                // e.g.
+                // insert into boyscout_badges values (?, ?, ?), (?, ?, ?) ...
+                instructionInsertArray({
+                    arrayTableName: tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    arrayField: fieldName,
+                    arrayFieldType: fieldTypes[fieldName]
+                })
+            ]).flat(),
+
+            // Likewise for each message field and its child table.
+            ...childFieldSources.map(childSource => [
+                instructionDeleteChild({
+                    childTableName: childSource.tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    conditionField: childSource.fieldName
+                }),
+                instructionInsertChild({
+                    childSource,
+                    types,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    repeated: fieldTypes[childSource.fieldName].array !== undefined
+                })
+            ]).flat()
+        ];
+    }
+
+    //   _____       _      _       
+    //  |  __ \     | |    | |      
+    //  | |  | | ___| | ___| |_ ___ 
+    //  | |  | |/ _ \ |/ _ \ __/ _ \
+    //  | |__| |  __/ |  __/ ||  __/
+    //  |_____/ \___|_|\___|\__\___|
+    //
+    // Return an array of CRUD instructions that delete an instance of the
+    // specified message `type` from the database. Use the specified `legend` to
+    // map message fields to table columns. If the message type is soft-deleted,
+    // then these are the instructions of the "purge" operation, while "delete"
+    // instead marks the instance as deleted (see
+    // `instructionsSoftDeleteMessage`).
+    function instructionsDeleteMessage({type, legend, types}) {
+        const {
+            scalarFieldSources,
+            arrayFieldSources,
+            childFieldSources
+        } = byMultiplicity(legend.fieldSources);
+
+        const keyColumnName = scalarFieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const idFieldType = fieldTypes[type.idFieldName];
+
+        return [
+            // Rows in array tables and child tables need to be deleted first,
+            // since they have foreign keys referencing the row in the message
+            // table.
+            ...arrayFieldSources.map(({tableName}) =>
+                instructionDeleteArray({
+                    arrayTableName: tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: idFieldType
+                })),
+            ...childFieldSources.map(({tableName}) =>
+                instructionDeleteChild({
+                    childTableName: tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: idFieldType
+                })),
+
+            // Once we've deleted everything that references the instance's row
+            // in the message table, we can delete that row.
+            {
+                instruction: 'exec',
+                sql: sqline(`delete from ${quoteName(legend.tableName)}
+                    where ${quoteName(keyColumnName)} = ${parameter(idFieldType)};`),
+                parameters: [
+                    {field: type.idFieldName}
+                ],
+                // If there was no such row, then nothing was deleted.
+                ...(changesRecorded(legend) ? {onNoRows: 'unchanged'} : {})
+            }
+        ];
+    }
+
+    // Return an array of CRUD instructions that mark an instance of the
+    // specified soft-deleted message `type` as deleted, unless it is already
+    // deleted. Use the specified `legend` to map message fields to table
+    // columns. Unlike in `instructionsDeleteMessage`, the rows of the instance
+    // are kept, including those in array tables and child tables, so that the
+    // instance can be undeleted (see `instructionsUndeleteMessage`).
+    function instructionsSoftDeleteMessage({type, legend}) {
+        const keyColumnName = legend.fieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const idFieldType = fieldTypes[type.idFieldName];
+        const deletedColumnName = quoteName(legend.deletedColumn);
+
+        return [
+            // e.g.
+            // update boyscout set deleted_at = current_timestamp
+            // where (id = ?) and deleted_at is null;
+            {
+                instruction: 'exec',
+                sql: sqline(`update ${quoteName(legend.tableName)}
+                    set ${deletedColumnName} = ${currentTimestamp()}
+                    where ${sqlNotDeleted({
+                        legend,
+                        condition: `${quoteName(keyColumnName)} = ${parameter(idFieldType)}`
+                    })};`),
+                parameters: [
+                    {field: type.idFieldName}
+                ],
+                // If there was no such row, or it was already deleted, then
+                // nothing was deleted.
+                ...(changesRecorded(legend) ? {onNoRows: 'unchanged'} : {})
+            }
+        ];
+    }
+
+    // Return an array of CRUD instructions that restore an instance of the
+    // specified soft-deleted message `type` that was marked as deleted (see
+    // `instructionsSoftDeleteMessage`). Use the specified `legend` to map
+    // message fields to table columns.
+    function instructionsUndeleteMessage({type, legend}) {
+        const keyColumnName = legend.fieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const idFieldType = fieldTypes[type.idFieldName];
+
+        return [
+            // e.g.
+            // update boyscout set deleted_at = null
+            // where id = ? and deleted_at is not null;
+            {
+                instruction: 'exec',
+                sql: sqline(`update ${quoteName(legend.tableName)}
+                    set ${quoteName(legend.deletedColumn)} = null
+                    where ${quoteName(keyColumnName)} = ${parameter(idFieldType)}
+                        and ${quoteName(legend.deletedColumn)} is not null;`),
+                parameters: [
+                    {field: type.idFieldName}
+                ],
+                // If there was no such row, or it wasn't deleted, then nothing
+                // was restored.
+                ...(changesRecorded(legend) ? {onNoRows: 'unchanged'} : {})
+            }
+        ];
+    }
+
+    //   _    _                     _
+    //  | |  | |                   | |
+    //  | |  | |_ __  ___  ___ _ __| |_
+    //  | |  | | '_ \/ __|/ _ \ '__| __|
+    //  | |__| | |_) \__ \  __/ |  | |_
+    //   \____/| .__/|___/\___|_|   \__|
+    //         | |
+    //         |_|
+    //
+    // Return an array of CRUD instructions that add an instance of the
+    // specified message `type` to the database, or replace the instance already
+    // there having the same ID. Use the specified `legend` to map message
+    // fields to table columns. If the instance already there was deleted (see
+    // `instructionsSoftDeleteMessage`), then it's replaced and no longer
+    // deleted.
+    function instructionsUpsertMessage({type, legend, types}) {
+        const {
+            scalarFieldSources,
+            arrayFieldSources,
+            childFieldSources
+        } = byMultiplicity(legend.fieldSources);
+
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        // Fields populated from timestamp columns aren't written.
+        const scalarFieldInfos = scalarFieldSources
+            .filter(isWritten)
+            .map(entry => ({...entry, fieldType: scalarSourceType(entry, fieldTypes)}));
+        const {columns, values} = insertedColumns({legend, scalarFieldInfos});
+
+        // If the message table has columns other than the key, then a
+        // conflicting key updates them to their inserted values, except that
+        // the "created" timestamp, if any, is left alone, and the version, if
+        // any, is incremented regardless of the message's version. Otherwise,
+        // there's nothing to update, and `assignments` is empty.
+        const isVersion = ({fieldName}) =>
+            type.versionFieldName !== undefined &&
+            fieldName === type.versionFieldName;
+        const updatedColumnNames = scalarFieldInfos
+            .filter(({fieldName}) => fieldName !== type.idFieldName)
+            .filter(source => !isVersion(source))
+            .map(({columnName}) => quoteName(columnName));
+        const versionSource = scalarFieldInfos.find(isVersion);
+        const keyColumnName = quoteName(scalarFieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName);
+        const updatedTimestamps = timestampColumnNames({legend, inserted: false})
+            .map(quoteName);
+        const assignments = [
+            ...updatedColumnNames.map(column =>
+                `${column} = ${dialect.insertedValue(column)}`),
+            ...(versionSource === undefined
+                ? []
+                : [sqlVersionClauses({
+                    columnName: versionSource.columnName,
+                    tableName: legend.tableName
+                  }).increment]),
+            ...updatedTimestamps.map(column => `${column} = ${currentTimestamp()}`),
+            ...(legend.deletedColumn === undefined
+                ? []
+                : [`${quoteName(legend.deletedColumn)} = null`])
+        ];
+
+        return [
+            // If the message would conflict with a different row in a unique
+            // index, then the insert below would update that row instead. Fail
+            // the upsert in that case, before anything is written.
+            ...(dialect.upsertUpdatesOtherRows
+                ? instructionsNoOtherUniqueRow({type, legend, scalarFieldInfos})
+                : []),
+
+            // Insert a new row into the table of the message type, specifying
+            // all non-array fields, or update the existing row.
+            {
+                instruction: 'exec',
+                sql: sqline(`insert into ${quoteName(legend.tableName)}(
+                    ${columns})
+                    values (${values})
+                    ${dialect.onConflict({keyColumnName, assignments})};`),
+                parameters: scalarFieldInfos.map(scalarSourceParameter)
+            },
+
+            // For each array field, replace the rows in the corresponding
+            // table.
+            ...arrayFieldSources.map(({fieldName, tableName}) => [
+                instructionDeleteArray({
+                    arrayTableName: tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName]
+                }),
+                instructionInsertArray({
+                    arrayTableName: tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    arrayField: fieldName,
+                    arrayFieldType: fieldTypes[fieldName]
+                })
+            ]).flat(),
+
+            // Likewise for each message field and its child table.
+            ...childFieldSources.map(childSource => [
+                instructionDeleteChild({
+                    childTableName: childSource.tableName,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName]
+                }),
+                instructionInsertChild({
+                    childSource,
+                    types,
+                    messageIdField: type.idFieldName,
+                    messageIdFieldType: fieldTypes[type.idFieldName],
+                    repeated: fieldTypes[childSource.fieldName].array !== undefined
+                })
+            ]).flat()
+        ];
+    }
+
+    //   _      _     _
+    //  | |    (_)   | |
+    //  | |     _ ___| |_
+    //  | |    | / __| __|
+    //  | |____| \__ \ |_
+    //  |______|_|___/\__|
+    //
+    // Return an array of CRUD instructions that read a page of instances of the
+    // specified message `type` from the database, in order of their IDs. Use
+    // the specified `legend` to map message fields to table columns.
+    //
+    // Pagination is "keyset" based: a page begins after the ID of the last
+    // message in the previous page (unless it is the first page), rather than
+    // at some offset. Each array table is then queried for the rows belonging
+    // to the range of IDs in the page.
+    function instructionsListMessages({type, legend, types}) {
+        const {
+            scalarFieldSources,
+            arrayFieldSources,
+            childFieldSources
+        } = byMultiplicity(legend.fieldSources);
+
+        const keyColumnName = scalarFieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const selectors = scalarFieldSources
+            .map(source => selector({
+                columnName: source.columnName,
+                fieldType: scalarSourceType(source, fieldTypes)
+            }));
+        const idFieldType = fieldTypes[type.idFieldName];
+        const boolParameter = parameter({builtin: 'TYPE_BOOL'});
+        const idParameter = parameter(idFieldType);
+
+        return [
+            // Query a page of the message table.
+            {
+                instruction: 'query',
+                sql: sqline(`select ${selectors.join(', ')}
+                    from ${quoteName(legend.tableName)}
+                    where ${sqlNotDeleted({
+                        legend,
+                        condition: `${boolParameter} or ${quoteName(keyColumnName)} > ${idParameter}`
+                    })}
+                    order by ${quoteName(keyColumnName)}
+                    limit ?;`),
+                parameters: [
+                    {page: 'first'},
+                    {page: 'after'},
+                    {page: 'size'}
+                ]
+            },
+
+            // Read each resulting row into a new message.
+            {
+                instruction: 'read-rows',
+                destinations: scalarFieldSources.map(scalarSourceParameter)
+            },
+
+            // For each array field:
+            // - query the array table for all IDs within the page
+            // - read results into the array field of the message having the ID
+            ...arrayFieldSources.map(({fieldName, tableName}) => {
+                const arrayType = fieldTypes[fieldName];
+                const elementType = arrayElementType(arrayType);
+                const position = arrayPositionColumn(arrayType);
+                const keySelector = arrayType.map ? `${selector(position)}, ` : '';
+                const id = quoteName('id');
+
+                return [
+                    // e.g.
+                    // select id, value from boyscout_badges
+                    // where (? or id > ?) and id <= ?
+                    // order by id, ordinality;
+                    {
+                        instruction: 'query',
+                        sql: sqline(`select
+                                ${selector({columnName: 'id', fieldType: idFieldType})},
+                                ${keySelector}${selector({columnName: 'value', fieldType: elementType})}
+                            from ${quoteName(tableName)}
+                            where (${boolParameter} or ${id} > ${idParameter})
+                                and ${id} <= ${idParameter}
+                            order by ${id}, ${quoteName(position.columnName)};`),
+                        parameters: [
+                            {page: 'first'},
+                            {page: 'after'},
+                            {page: 'last'}
+                        ]
+                    },
+
+                    // e.g.
+                    // for row in result:
+                    //     row.scan(&id, &byID[id].badges.push_back())
+                    {
+                        instruction: 'read-keyed-array',
+                        destination: {field: fieldName}
+                    }
+                ];
+            }).flat(),
+
+            // For each message field:
+            // - query the child table for all IDs within the page
+            // - read results into the message field of the message having the ID
+            ...childFieldSources.map(childSource => {
+                const {selectors, destinations} =
+                    childSelectors({childSource, types});
+                const parentId = quoteName('parent_id');
+                const repeated = fieldTypes[childSource.fieldName].array !== undefined;
+                const ordinality = repeated ? `, ${quoteName('ordinality')}` : '';
+
+                return [
+                    // e.g.
+                    // select parent_id, sku, quantity from order_items
+                    // where (? or parent_id > ?) and parent_id <= ?
+                    // order by parent_id, ordinality;
+                    {
+                        instruction: 'query',
+                        sql: sqline(`select
+                                ${selector({columnName: 'parent_id', fieldType: idFieldType})},
+                                ${selectors.join(', ')}
+                            from ${quoteName(childSource.tableName)}
+                            where (${boolParameter} or ${parentId} > ${idParameter})
+                                and ${parentId} <= ${idParameter}
+                            order by ${parentId}${ordinality};`),
+                        parameters: [
+                            {page: 'first'},
+                            {page: 'after'},
+                            {page: 'last'}
+                        ]
+                    },
+
+                    // e.g.
+                    // for row in result:
+                    //     row.scan(&id, &byID[id].items.push_back())
+                    {
+                        instruction: 'read-keyed-child-rows',
+                        destination: {field: childSource.fieldName},
+                        destinations
+                    }
+                ];
+            }).flat()
+        ];
+    }
+
+    //   _____                _   __  __
+    //  |  __ \              | | |  \/  |
+    //  | |__) |___  __ _  __| | | \  / | __ _ _ __  _   _
+    //  |  _  // _ \/ _` |/ _` | | |\/| |/ _` | '_ \| | | |
+    //  | | \ \  __/ (_| | (_| | | |  | | (_| | | | | |_| |
+    //  |_|  \_\___|\__,_|\__,_| |_|  |_|\__,_|_| |_|\__, |
+    //                                                __/ |
+    //                                               |___/
+    //
+    // Return an array of CRUD instructions that read the instances of the
+    // specified message `type` having any of a set of IDs from the database.
+    // Use the specified `legend` to map message fields to table columns.
+    //
+    // The message table and each array table are queried once for all of the
+    // IDs, rather than once per ID.
+    function instructionsReadManyMessages({type, legend, types}) {
+        const {
+            scalarFieldSources,
+            arrayFieldSources,
+            childFieldSources
+        } = byMultiplicity(legend.fieldSources);
+
+        const keyColumnName = scalarFieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const selectors = scalarFieldSources
+            .map(source => selector({
+                columnName: source.columnName,
+                fieldType: scalarSourceType(source, fieldTypes)
+            }));
+        const idFieldType = fieldTypes[type.idFieldName];
+
+        return [
+            // Query the message table for all of the IDs.
+            {
+                instruction: 'query-with-tuples',
+                tuple: sqline(parameter(idFieldType)),
+                sql: sqline(`select ${selectors.join(', ')}
+                    from ${quoteName(legend.tableName)}
+                    where ${quoteName(keyColumnName)} in (`),
+                // Deleted messages are not read.
+                suffix: legend.deletedColumn === undefined
+                    ? ');'
+                    : `) and ${quoteName(legend.deletedColumn)} is null;`,
+                parameters: [{batch: 'id'}]
+            },
+
+            // Read each resulting row into a new message.
+            {
+                instruction: 'read-rows',
+                destinations: scalarFieldSources.map(scalarSourceParameter)
+            },
+
+            // For each array field:
+            // - query the array table for all of the IDs
+            // - read results into the array field of the message having the ID
+            ...arrayFieldSources.map(({fieldName, tableName}) => {
+                const arrayType = fieldTypes[fieldName];
+                const elementType = arrayElementType(arrayType);
+                const position = arrayPositionColumn(arrayType);
+                const keySelector = arrayType.map ? `${selector(position)}, ` : '';
+
+                return [
+                    // e.g.
+                    // select id, value from boyscout_badges
+                    // where id in (?, ?, ...)
+                    // order by id, ordinality;
+                    {
+                        instruction: 'query-with-tuples',
+                        tuple: sqline(parameter(idFieldType)),
+                        sql: sqline(`select
+                                ${selector({columnName: 'id', fieldType: idFieldType})},
+                                ${keySelector}${selector({columnName: 'value', fieldType: elementType})}
+                            from ${quoteName(tableName)}
+                            where ${quoteName('id')} in (`),
+                        suffix: sqline(`)
+                            order by ${quoteName('id')}, ${quoteName(position.columnName)};`),
+                        parameters: [{batch: 'id'}]
+                    },
+
+                    // e.g.
+                    // for row in result:
+                    //     row.scan(&id, &byID[id].badges.push_back())
+                    {
+                        instruction: 'read-keyed-array',
+                        destination: {field: fieldName}
+                    }
+                ];
+            }).flat(),
+
+            // For each message field:
+            // - query the child table for all of the IDs
+            // - read results into the message field of the message having the ID
+            ...childFieldSources.map(childSource => {
+                const {selectors, destinations} =
+                    childSelectors({childSource, types});
+                const repeated = fieldTypes[childSource.fieldName].array !== undefined;
+                const ordinality = repeated ? `, ${quoteName('ordinality')}` : '';
+
+                return [
+                    // e.g.
+                    // select parent_id, sku, quantity from order_items
+                    // where parent_id in (?, ?, ...)
+                    // order by parent_id, ordinality;
+                    {
+                        instruction: 'query-with-tuples',
+                        tuple: sqline(parameter(idFieldType)),
+                        sql: sqline(`select
+                                ${selector({columnName: 'parent_id', fieldType: idFieldType})},
+                                ${selectors.join(', ')}
+                            from ${quoteName(childSource.tableName)}
+                            where ${quoteName('parent_id')} in (`),
+                        suffix: sqline(`)
+                            order by ${quoteName('parent_id')}${ordinality};`),
+                        parameters: [{batch: 'id'}]
+                    },
+
+                    // e.g.
+                    // for row in result:
+                    //     row.scan(&id, &byID[id].items.push_back())
+                    {
+                        instruction: 'read-keyed-child-rows',
+                        destination: {field: childSource.fieldName},
+                        destinations
+                    }
+                ];
+            }).flat()
+        ];
+    }
+
+    //   _                 _                
+    //  | |               | |               
+    //  | |     ___   ___ | | ___   _ _ __  
+    //  | |    / _ \ / _ \| |/ / | | | '_ \ 
+    //  | |___| (_) | (_) |   <| |_| | |_) |
+    //  |______\___/ \___/|_|\_\\__,_| .__/ 
+    //                               | |    
+    //                               |_|    
+    //
+    // Return an array of CRUD instructions that read the instances of the
+    // specified message `type` whose specified indexed `field` has a particular
+    // value (the "lookup" parameter), in order of their IDs. Use the specified
+    // `legend` to map message fields to table columns.
+    //
+    // As with "read-many," the message table and each array table are queried
+    // once. The array tables and child tables are queried for the IDs of the
+    // messages having the value.
+    function instructionsLookupMessages({type, legend, types, field}) {
+        const {
+            scalarFieldSources,
+            arrayFieldSources,
+            childFieldSources
+        } = byMultiplicity(legend.fieldSources);
+
+        const keyColumnName = scalarFieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const lookupSource = scalarFieldSources
+            .find(({fieldName}) => fieldName === field.name);
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const selectors = scalarFieldSources
+            .map(source => selector({
+                columnName: source.columnName,
+                fieldType: scalarSourceType(source, fieldTypes)
+            }));
+        const idFieldType = fieldTypes[type.idFieldName];
+
+        // e.g. `country_code` = ?
+        // Deleted messages are not looked up.
+        const condition = sqlNotDeleted({
+            legend,
+            condition: `${quoteName(lookupSource.columnName)} = ` +
+                parameter(scalarSourceType(lookupSource, fieldTypes))
+        });
+
+        // e.g. select `id` from `boyscout` where `country_code` = ?
+        const idsQuery = sqline(`select ${quoteName(keyColumnName)}
+            from ${quoteName(legend.tableName)}
+            where ${condition}`);
+
+        return [
+            // Query the message table for the value.
+            {
+                instruction: 'query',
+                sql: sqline(`select ${selectors.join(', ')}
+                    from ${quoteName(legend.tableName)}
+                    where ${condition}
+                    order by ${quoteName(keyColumnName)};`),
+                parameters: [{lookup: field.name}]
+            },
+
+            // Read each resulting row into a new message.
+            {
+                instruction: 'read-rows',
+                destinations: scalarFieldSources.map(scalarSourceParameter)
+            },
+
+            // For each array field:
+            // - query the array table for the IDs of the messages having the value
+            // - read results into the array field of the message having the ID
+            ...arrayFieldSources.map(({fieldName, tableName}) => {
+                const arrayType = fieldTypes[fieldName];
+                const elementType = arrayElementType(arrayType);
+                const position = arrayPositionColumn(arrayType);
+                const keySelector = arrayType.map ? `${selector(position)}, ` : '';
+                const id = quoteName('id');
+
+                return [
+                    // e.g.
+                    // select id, value from boyscout_badges
+                    // where id in
+                    //     (select id from boyscout where country_code = ?)
+                    // order by id, ordinality;
+                    {
+                        instruction: 'query',
+                        sql: sqline(`select
+                                ${selector({columnName: 'id', fieldType: idFieldType})},
+                                ${keySelector}${selector({columnName: 'value', fieldType: elementType})}
+                            from ${quoteName(tableName)}
+                            where ${id} in (${idsQuery})
+                            order by ${id}, ${quoteName(position.columnName)};`),
+                        parameters: [{lookup: field.name}]
+                    },
+
+                    // e.g.
+                    // for row in result:
+                    //     row.scan(&id, &byID[id].badges.push_back())
+                    {
+                        instruction: 'read-keyed-array',
+                        destination: {field: fieldName}
+                    }
+                ];
+            }).flat(),
+
+            // For each message field:
+            // - query the child table for the IDs of the messages having the value
+            // - read results into the message field of the message having the ID
+            ...childFieldSources.map(childSource => {
+                const {selectors, destinations} =
+                    childSelectors({childSource, types});
+                const parentId = quoteName('parent_id');
+                const repeated = fieldTypes[childSource.fieldName].array !== undefined;
+                const ordinality = repeated ? `, ${quoteName('ordinality')}` : '';
+
+                return [
+                    // e.g.
+                    // select parent_id, sku, quantity from order_items
+                    // where parent_id in (select id from order where store = ?)
+                    // order by parent_id, ordinality;
+                    {
+                        instruction: 'query',
+                        sql: sqline(`select
+                                ${selector({columnName: 'parent_id', fieldType: idFieldType})},
+                                ${selectors.join(', ')}
+                            from ${quoteName(childSource.tableName)}
+                            where ${parentId} in (${idsQuery})
+                            order by ${parentId}${ordinality};`),
+                        parameters: [{lookup: field.name}]
+                    },
+
+                    // e.g.
+                    // for row in result:
+                    //     row.scan(&id, &byID[id].items.push_back())
+                    {
+                        instruction: 'read-keyed-child-rows',
+                        destination: {field: childSource.fieldName},
+                        destinations
+                    }
+                ];
+            }).flat()
+        ];
+    }
+
+    // Return the fields of the specified message `type` that can be looked up,
+    // i.e. that are indexed, and that are stored in a single column of the
+    // message table. Use the specified `legend` to find the columns.
+    function lookupFields({type, legend}) {
+        return type.fields.filter(field =>
+            (field.indexed || field.unique) &&
+            field.name !== type.idFieldName &&
+            legend.fieldSources.filter(source =>
+                source.fieldName === field.name &&
+                'columnName' in source &&
+                !('part' in source)).length === 1);
+    }
+
+    //    ____                        
+    //   / __ \                       
+    //  | |  | |_   _  ___ _ __ _   _ 
+    //  | |  | | | | |/ _ \ '__| | | |
+    //  | |__| | |_| |  __/ |  | |_| |
+    //   \___\_\\__,_|\___|_|   \__, |
+    //                           __/ |
+    //                          |___/ 
+    //
+    // Return a description of the specified message `type` that generated code
+    // can use to build queries at runtime, e.g. "the messages whose rank is at
+    // least 3, ordered by join time." Use the specified `legend` to map message
+    // fields to table columns.
+    //
+    // Unlike the other operations, a query is not a fixed sequence of
+    // instructions. Instead, the description contains the SQL that selects the
+    // IDs of all of the messages, to which generated code appends "where,"
+    // "order by," and "limit" clauses. For each field that can be compared, the
+    // description contains the field's column and the parameter to compare it
+    // with. The messages having the selected IDs are then read as in
+    // "read-many."
+    function queryMessages({type, legend}) {
+        const {scalarFieldSources} = byMultiplicity(legend.fieldSources);
+        const keyColumnName = scalarFieldSources
+            .find(({fieldName}) => fieldName === type.idFieldName)
+            .columnName;
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+
+        return {
+            // e.g. select `id` from `boyscout`
+            sql: sqline(`select ${selector({
+                    columnName: keyColumnName,
+                    fieldType: fieldTypes[type.idFieldName]
+                })}
+                from ${quoteName(legend.tableName)}`),
+            key: quoteName(keyColumnName),
+            // Deleted messages are not selected.
+            ...(legend.deletedColumn === undefined ? {} : {
+                where: sqline(`${quoteName(legend.deletedColumn)} is null`)
+            }),
+            fields: Object.fromEntries(scalarFieldSources
+                .filter(source =>
+                    'fieldName' in source &&
+                    !('part' in source) &&
+                    isComparable(fieldTypes[source.fieldName]))
+                .map(({fieldName, columnName}) => [fieldName, {
+                    // The column is compared as is, rather than via `selector`,
+                    // so that the database can use an index on the column.
+                    column: quoteName(columnName),
+                    parameter: sqline(parameter(fieldTypes[fieldName]))
+                }]))
+        };
+    }
+
+    // Return a description of the "count" operation of the specified message
+    // `type`, which counts the messages that satisfy conditions built at
+    // runtime as in `queryMessages`. Use the specified `legend` to map message
+    // fields to table columns.
+    function countMessages({type, legend}) {
+        return {
+            // e.g. select count(*) from boyscout
+            sql: sqline(`select count(*) from ${quoteName(legend.tableName)}`),
+            // Deleted messages are not counted.
+            ...(legend.deletedColumn === undefined ? {} : {
+                where: sqline(`${quoteName(legend.deletedColumn)} is null`)
+            })
+        };
+    }
+
+    // Return a description of the history of the specified message `type` (see
+    // `crud.tisch.js`), or return `undefined` if `type` doesn't have a history
+    // table. Use the specified `legend` to find the history table.
+    //
+    // Rather than being an operation of its own, "record" is an instruction
+    // that generated code performs at the end of each operation that changes a
+    // message. It appends a record to the message's history table, numbered one
+    // more than the previous record of the message. "record many" does the
+    // same for many messages at once, e.g. at the end of "create-many." "as of"
+    // reads the operation and serialized message of the latest record of a
+    // message at a given time.
+    function historyMessages({type, legend}) {
+        if (legend.historyTableName === undefined) {
+            return;
+        }
+
+        const fieldTypes = Object.fromEntries(
+            type.fields.map(({name, type}) => [name, type]));
+        const idParameter = parameter(fieldTypes[type.idFieldName]);
+        const table = quoteName(legend.historyTableName);
+        const id = quoteName('id');
+        const ordinality = quoteName('ordinality');
+        const columns = ['id', 'ordinality', 'recorded_at', 'operation', 'actor',
+            'message'].map(quoteName).join(', ');
+
+        // Each record's ordinality is calculated from the table being inserted
+        // into, which dialects allow in different ways.
+        // e.g.
+        // (?, (select coalesce(max(ordinality), 0) + 1
+        //      from boyscout_history where id = ?),
+        //  current_timestamp(6), ?, ?, ?)
+        const tuple = sqline(`(${idParameter},
+            ${dialect.historyOrdinality({table, id, ordinality, idParameter})},
+            ${currentTimestamp()}, ?, ?, ?)`);
+        const parameters = [
+            {history: 'id'},
+            {history: 'id'},
+            {history: 'operation'},
+            {history: 'actor'},
+            {history: 'message'}
+        ];
+
+        return {
+            record: {
+                instruction: 'exec',
+                sql: sqline(`insert into ${table}(${columns})
+                    values ${tuple};`),
+                parameters
+            },
+
+            recordMany: {
+                instruction: 'exec-many-with-tuples',
+                sql: sqline(`insert into ${table}(${columns}) values`),
+                tuple,
+                parameters
+            },
+
+            // e.g.
+            // select operation, message from boyscout_history
+            // where id = ? and recorded_at <= ?
+            // order by ordinality desc
+            // limit 1;
+            asOf: {
+                instruction: 'query',
+                sql: sqline(`select ${quoteName('operation')}, ${quoteName('message')}
+                    from ${table}
+                    where ${id} = ${idParameter}
+                        and ${quoteName('recorded_at')} <= ${parameter({
+                            builtin: '.google.protobuf.Timestamp'
+                        })}
+                    order by ${ordinality} desc
+                    limit 1;`),
+                parameters: [
+                    {history: 'id'},
+                    {history: 'time'}
+                ]
+            }
+        };
+    }
+
+    // Return a description of how changes to the message type of the specified
+    // `legend` are inserted into the outbox (see `crud.tisch.js`), or return
+    // `undefined` if they aren't.
+    //
+    // As with "record" and "record many" in `historyMessages`, "publish" is
+    // performed at the end of each operation that changes a message, and
+    // "publish many" at the end of an operation that changes many.
+    function outboxMessages({legend}) {
+        if (legend.outboxTableName === undefined) {
+            return;
+        }
+
+        const table = quoteName(legend.outboxTableName);
+        const columns = ['type_name', 'message_id', 'operation', 'field_mask',
+            'message', 'recorded_at'].map(quoteName).join(', ');
+        const tuple = sqline(`(?, ?, ?, ?, ?, ${currentTimestamp()})`);
+        const parameters = [
+            {outbox: 'type'},
+            {outbox: 'id'},
+            {outbox: 'operation'},
+            {outbox: 'fieldMask'},
+            {outbox: 'message'}
+        ];
+
+        return {
+            // e.g.
+            // insert into okra_outbox(
+            //     type_name, message_id, operation, field_mask, message,
+            //     recorded_at)
+            // values (?, ?, ?, ?, ?, current_timestamp(6));
+            publish: {
+                instruction: 'exec',
+                sql: sqline(`insert into ${table}(${columns})
+                    values ${tuple};`),
+                parameters
+            },
+
+            publishMany: {
+                instruction: 'exec-many-with-tuples',
+                sql: sqline(`insert into ${table}(${columns}) values`),
+                tuple,
+                parameters
+            }
+        };
+    }
+
+    // Return a description of the statements with which generated code polls
+    // the outbox table having the specified `tableName` (see
+    // `outbox.tisch.js`).
+    function outboxPoller(tableName) {
+        const table = quoteName(tableName);
+        const sequence = quoteName('sequence');
+        const claim = quoteName('claim');
+        const claimedAt = quoteName('claimed_at');
+        const columns = ['sequence', 'type_name', 'message_id', 'operation',
+            'field_mask', 'message'].map(quoteName).join(', ');
+
+        return schemas.outbox.enforce({
+            // "claim" sets the claim and claim time of the events that are
+            // unclaimed or whose claim has expired, up to a limit, in order.
+            // Its parameters are the claim, the duration of a claim in
+            // microseconds, and the limit. Other pollers can claim later
+            // events while an earlier event is claimed, so events are
+            // delivered in order only if there is a single poller.
+            claim: sqline(
+                dialect.claimOutboxEvents({table, sequence, claim, claimedAt})),
+
+            // e.g.
+            // select sequence, type_name, message_id, operation, field_mask,
+            //     message
+            // from okra_outbox
+            // where claim = ?
+            // order by sequence;
+            read: sqline(`select ${columns}
+                from ${table}
+                where ${claim} = ?
+                order by ${sequence};`),
+
+            // e.g.
+            // delete from okra_outbox where sequence = ? and claim = ?;
+            acknowledge: sqline(`delete from ${table}
+                where ${sequence} = ? and ${claim} = ?;`)
+        });
+    }
+
+    // Return whether a column of the specified `fieldType` can be compared with
+    // a parameter in a query (see `queryMessages`). Columns that contain JSON
+    // cannot be.
+    function isComparable(fieldType) {
+        return !fieldType.json && ![
+            '.google.protobuf.Struct',
+            '.google.protobuf.Value',
+            '.google.protobuf.ListValue'
+        ].includes(fieldType.builtin);
+    }
+
+    //  __          ___           _   _       _   _           _                   _ _ ___  
+    //  \ \        / / |         | | ( )     | | | |         | |                  | | |__ \ 
+    //   \ \  /\  / /| |__   __ _| |_|/ ___  | |_| |__   __ _| |_   ___ _ __   ___| | |  ) |
+    //    \ \/  \/ / | '_ \ / _` | __| / __| | __| '_ \ / _` | __| / __| '_ \ / _ \ | | / / 
+    //     \  /\  /  | | | | (_| | |_  \__ \ | |_| | | | (_| | |_  \__ \ |_) |  __/ | ||_|  
+    //      \/  \/   |_| |_|\__,_|\__| |___/  \__|_| |_|\__,_|\__| |___/ .__/ \___|_|_|(_)  
+    //                                                                 | |                  
+    //                                                                 |_|    
+    //
+    //    _____ _____  _    _ _____    _   _   _ 
+    //   / ____|  __ \| |  | |  __ \  | | | | | |
+    //  | |    | |__) | |  | | |  | | | | | | | |
+    //  | |    |  _  /| |  | | |  | | | | | | | |
+    //  | |____| | \ \| |__| | |__| | |_| |_| |_|
+    //   \_____|_|  \_\\____/|_____/  (_) (_) (_)
+    //
+    // Return an object of create-read-update-delete (CRUD) operations for all
+    // of the specified `types`.
+    function types2crud(types) {
+        // Verify that `types` has the expected shape.
+        tisch.compileFunction(({Any, etc}) => ({
+            // Each property is the name of the type it describes.
+            [Any]: {
+                'type': schemas.type, // either a message or an enum
+                // present if `type` is a message having an ID. Message types
+                // without an ID appear only as the types of fields of other
+                // messages, and don't have their own CRUD operations.
+                'legend?': schemas.legend
+            },
+            ...etc
+        })).enforce(types);
+
+        // e.g. if we have two message types "foo" and "bar,"
+        //
+        //     {
+        //         foo: {
+        //             create: [...],
+        //             read: [...],
+        //             ...
+        //         },
+        //         bar: {
+        //             create: [...],
+        //             read: [...],
+        //             ...
+        //         }, 
+        //         ...
+        //     }
+        //
+        const result = Object.fromEntries(
+            Object.entries(types)
+                // CRUD operations are for message types having legends only.
+                .filter(([_, {type, legend}]) =>
+                    type.kind === 'message' && legend !== undefined)
+                // Each message type name is mapped to an object of arrays of
+                // CRUD instructions.
+                .map(([typeName, {type, legend}]) => {
+                    const operations = {
+                        create: instructionsCreateMessage({type, legend, types}),
+                        read: instructionsReadMessage({type, legend, types}),
+                        update: instructionsUpdateMessage({type, legend, types}),
+                        delete: legend.deletedColumn === undefined
+                            ? instructionsDeleteMessage({type, legend, types})
+                            : instructionsSoftDeleteMessage({type, legend}),
+                        upsert: instructionsUpsertMessage({type, legend, types}),
+                        list: instructionsListMessages({type, legend, types}),
+                        'read-many': instructionsReadManyMessages({type, legend, types}),
+                        'create-many': instructionsCreateManyMessages({type, legend, types}),
+                        exists: instructionsExistsMessage({type, legend}),
+                        count: countMessages({type, legend}),
+                        query: queryMessages({type, legend})
+                    };
+
+                    // A soft-deleted message can be undeleted, or purged, which
+                    // is what "delete" would otherwise be.
+                    if (legend.deletedColumn !== undefined) {
+                        operations.undelete =
+                            instructionsUndeleteMessage({type, legend});
+                        operations.purge =
+                            instructionsDeleteMessage({type, legend, types});
+                    }
+
+                    // Changes are recorded in the history table, if any.
+                    const history = historyMessages({type, legend});
+                    if (history !== undefined) {
+                        operations.history = history;
+                    }
+
+                    // Changes are inserted into the outbox, if any.
+                    const outbox = outboxMessages({legend});
+                    if (outbox !== undefined) {
+                        operations.outbox = outbox;
+                    }
+
+                    // Indexed fields can be looked up (see `lookupFields`).
+                    const fields = lookupFields({type, legend});
+                    if (fields.length !== 0) {
+                        operations.lookups = Object.fromEntries(fields.map(field =>
+                            [field.name,
+                             instructionsLookupMessages({type, legend, types, field})]));
+                    }
+
+                    return [typeName, operations];
+                }));
+
+        // Verify that the result has the expected shape.
+        return schemas.crud.enforce(result);
+    }
+
+    return {types2crud, outboxPoller};
+}
+
+return {sqlCrud};
+
+});
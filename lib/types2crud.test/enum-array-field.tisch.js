@@ -0,0 +1,320 @@
+// This is the expected output of running the `types2crud` function on
+// `enum-array-field.proto`, using the dialect in `test.js`.
+({
+    ".foobar.Grill": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into grill( id) values (?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "hotdog"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into grill_hotdog( id, ordinality, value) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "hotdog"
+                    },
+                    {
+                        field: "hotdog"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select id from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query",
+                condition: {
+                    included: "hotdog"
+                },
+                sql: "select value from grill_hotdog where id = ? order by ordinality;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-array",
+                destination: {
+                    field: "hotdog"
+                }
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from grill_hotdog where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ],
+                condition: {
+                    included: "hotdog"
+                }
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "hotdog"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into grill_hotdog( id, ordinality, value) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "hotdog"
+                    },
+                    {
+                        field: "hotdog"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from grill_hotdog where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into grill( id) values (?) on conflict (id) do nothing;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from grill_hotdog where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "hotdog"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into grill_hotdog( id, ordinality, value) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "hotdog"
+                    },
+                    {
+                        field: "hotdog"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select id from grill where ? or id > ? order by id limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query",
+                sql: "select id, value from grill_hotdog where (? or id > ?) and id <= ? order by id, ordinality;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "last"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-array",
+                destination: {
+                    field: "hotdog"
+                }
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select id from grill where id in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select id, value from grill_hotdog where id in (",
+                suffix: ") order by id, ordinality;",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-array",
+                destination: {
+                    field: "hotdog"
+                }
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?)",
+                sql: "insert into grill( id) values",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?)",
+                sql: "insert into grill_hotdog( id, ordinality, value) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "hotdog"
+                    },
+                    {
+                        field: "hotdog"
+                    }
+                ]
+            }
+        ],
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from grill where id = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from grill"
+        },
+        query: {
+            sql: "select id from grill",
+            key: "id",
+            fields: {
+                id: {
+                    column: "id",
+                    parameter: "?"
+                }
+            }
+        }
+    }
+})
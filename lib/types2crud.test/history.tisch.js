@@ -0,0 +1,541 @@
+// This is the expected output of running the `types2crud` function on
+// `history.proto` with the `types2tables` option `history`, using the dialect
+// in `test.js`.
+({
+    ".foobar.Grill": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into grill( id, name) values (?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select id, case when ? then name else null end from grill where (id = ?) and deleted_at is null;",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from grill where (id = ?) and deleted_at is null;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update grill set name = case when ? then ? else name end where id = ?;",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "update grill set deleted_at = current_timestamp where (id = ?) and deleted_at is null;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ],
+                onNoRows: "unchanged"
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into grill( id, name) values (?, ?) on conflict (id) do update set name = excluded.name, deleted_at = null;",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select id, name from grill where (? or id > ?) and deleted_at is null order by id limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select id, name from grill where id in (",
+                suffix: ") and deleted_at is null;",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?)",
+                sql: "insert into grill( id, name) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from grill where (id = ?) and deleted_at is null);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from grill",
+            where: "deleted_at is null"
+        },
+        query: {
+            sql: "select id from grill",
+            key: "id",
+            where: "deleted_at is null",
+            fields: {
+                id: {
+                    column: "id",
+                    parameter: "?"
+                },
+                name: {
+                    column: "name",
+                    parameter: "?"
+                }
+            }
+        },
+        undelete: [
+            {
+                instruction: "exec",
+                sql: "update grill set deleted_at = null where id = ? and deleted_at is not null;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ],
+                onNoRows: "unchanged"
+            }
+        ],
+        purge: [
+            {
+                instruction: "exec",
+                sql: "delete from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ],
+                onNoRows: "unchanged"
+            }
+        ],
+        history: {
+            record: {
+                instruction: "exec",
+                sql: "insert into grill_history(id, ordinality, recorded_at, operation, actor, message) values (?, (select coalesce(max(ordinality), 0) + 1 from grill_history where id = ?), current_timestamp, ?, ?, ?);",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            recordMany: {
+                instruction: "exec-many-with-tuples",
+                sql: "insert into grill_history(id, ordinality, recorded_at, operation, actor, message) values",
+                tuple: "(?, (select coalesce(max(ordinality), 0) + 1 from grill_history where id = ?), current_timestamp, ?, ?, ?)",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            asOf: {
+                instruction: "query",
+                sql: "select operation, message from grill_history where id = ? and recorded_at <= ? order by ordinality desc limit 1;",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "time"
+                    }
+                ]
+            }
+        }
+    },
+    ".foobar.Tent": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into tent( id, name) values (?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select id, case when ? then name else null end from tent where id = ?;",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from tent where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update tent set name = case when ? then ? else name end where id = ?;",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from tent where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ],
+                onNoRows: "unchanged"
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into tent( id, name) values (?, ?) on conflict (id) do update set name = excluded.name;",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select id, name from tent where ? or id > ? order by id limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select id, name from tent where id in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?)",
+                sql: "insert into tent( id, name) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from tent where id = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from tent"
+        },
+        query: {
+            sql: "select id from tent",
+            key: "id",
+            fields: {
+                id: {
+                    column: "id",
+                    parameter: "?"
+                },
+                name: {
+                    column: "name",
+                    parameter: "?"
+                }
+            }
+        },
+        history: {
+            record: {
+                instruction: "exec",
+                sql: "insert into tent_history(id, ordinality, recorded_at, operation, actor, message) values (?, (select coalesce(max(ordinality), 0) + 1 from tent_history where id = ?), current_timestamp, ?, ?, ?);",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            recordMany: {
+                instruction: "exec-many-with-tuples",
+                sql: "insert into tent_history(id, ordinality, recorded_at, operation, actor, message) values",
+                tuple: "(?, (select coalesce(max(ordinality), 0) + 1 from tent_history where id = ?), current_timestamp, ?, ?, ?)",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            asOf: {
+                instruction: "query",
+                sql: "select operation, message from tent_history where id = ? and recorded_at <= ? order by ordinality desc limit 1;",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "time"
+                    }
+                ]
+            }
+        }
+    }
+})
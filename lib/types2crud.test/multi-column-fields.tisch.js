@@ -0,0 +1,361 @@
+// This is the expected output of running the `types2crud` function on
+// `multi-column-fields.proto`, using the dialect in `test.js`.
+({
+    ".foobar.Stall": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into stall( id, opens, location_latitude, location_longitude, price_currency_code, price_amount) values (?, ?, ?, ?, ?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select id, case when ? then opens else null end, case when ? then location_latitude else null end, case when ? then location_longitude else null end, case when ? then price_currency_code else null end, case when ? then price_amount else null end from stall where id = ?;",
+                parameters: [
+                    {
+                        included: "opens"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from stall where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update stall set opens = case when ? then ? else opens end, location_latitude = case when ? then ? else location_latitude end, location_longitude = case when ? then ? else location_longitude end, price_currency_code = case when ? then ? else price_currency_code end, price_amount = case when ? then ? else price_amount end where id = ?;",
+                parameters: [
+                    {
+                        included: "opens"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from stall where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "query",
+                sql: "select null from (select count(*) as conflicts from stall where ((location_latitude = ? and location_longitude = ?)) and id <> ?) as counted where conflicts = 0;",
+                parameters: [
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ],
+                onNoRow: "uniqueViolation"
+            },
+            {
+                instruction: "exec",
+                sql: "insert into stall( id, opens, location_latitude, location_longitude, price_currency_code, price_amount) values (?, ?, ?, ?, ?, ?) on conflict (id) do update set opens = excluded.opens, location_latitude = excluded.location_latitude, location_longitude = excluded.location_longitude, price_currency_code = excluded.price_currency_code, price_amount = excluded.price_amount;",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select id, opens, location_latitude, location_longitude, price_currency_code, price_amount from stall where ? or id > ? order by id limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select id, opens, location_latitude, location_longitude, price_currency_code, price_amount from stall where id in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?, ?, ?, ?)",
+                sql: "insert into stall( id, opens, location_latitude, location_longitude, price_currency_code, price_amount) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from stall where id = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from stall"
+        },
+        query: {
+            sql: "select id from stall",
+            key: "id",
+            fields: {
+                id: {
+                    column: "id",
+                    parameter: "?"
+                },
+                opens: {
+                    column: "opens",
+                    parameter: "?"
+                }
+            }
+        }
+    }
+})
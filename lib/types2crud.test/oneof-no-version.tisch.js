@@ -0,0 +1,304 @@
+// This is the expected output of running the `types2crud` function on
+// `oneof-no-version.proto`, using the dialect in `test.js`.
+({
+    ".foobar.Grill": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into grill( id, name, email, phone, contact) values (?, ?, ?, ?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "email",
+                        oneof: "contact"
+                    },
+                    {
+                        field: "phone",
+                        oneof: "contact"
+                    },
+                    {
+                        oneof: "contact"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select id, case when ? then name else null end, case when ? then email else null end, case when ? then phone else null end, case when ? then contact else null end from grill where id = ?;",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        included: "contact"
+                    },
+                    {
+                        included: "contact"
+                    },
+                    {
+                        included: "contact"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "email",
+                        oneof: "contact"
+                    },
+                    {
+                        field: "phone",
+                        oneof: "contact"
+                    },
+                    {
+                        oneof: "contact"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update grill set name = case when ? then ? else name end, email = case when ? then ? else email end, phone = case when ? then ? else phone end, contact = case when ? then ? else contact end where id = ?;",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        included: "contact"
+                    },
+                    {
+                        field: "email",
+                        oneof: "contact"
+                    },
+                    {
+                        included: "contact"
+                    },
+                    {
+                        field: "phone",
+                        oneof: "contact"
+                    },
+                    {
+                        included: "contact"
+                    },
+                    {
+                        oneof: "contact"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into grill( id, name, email, phone, contact) values (?, ?, ?, ?, ?) on conflict (id) do update set name = excluded.name, email = excluded.email, phone = excluded.phone, contact = excluded.contact;",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "email",
+                        oneof: "contact"
+                    },
+                    {
+                        field: "phone",
+                        oneof: "contact"
+                    },
+                    {
+                        oneof: "contact"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select id, name, email, phone, contact from grill where ? or id > ? order by id limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "email",
+                        oneof: "contact"
+                    },
+                    {
+                        field: "phone",
+                        oneof: "contact"
+                    },
+                    {
+                        oneof: "contact"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select id, name, email, phone, contact from grill where id in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "email",
+                        oneof: "contact"
+                    },
+                    {
+                        field: "phone",
+                        oneof: "contact"
+                    },
+                    {
+                        oneof: "contact"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?, ?, ?)",
+                sql: "insert into grill( id, name, email, phone, contact) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "email",
+                        oneof: "contact"
+                    },
+                    {
+                        field: "phone",
+                        oneof: "contact"
+                    },
+                    {
+                        oneof: "contact"
+                    }
+                ]
+            }
+        ],
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from grill where id = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from grill"
+        },
+        query: {
+            sql: "select id from grill",
+            key: "id",
+            fields: {
+                id: {
+                    column: "id",
+                    parameter: "?"
+                },
+                name: {
+                    column: "name",
+                    parameter: "?"
+                },
+                email: {
+                    column: "email",
+                    parameter: "?"
+                },
+                phone: {
+                    column: "phone",
+                    parameter: "?"
+                }
+            }
+        }
+    }
+})
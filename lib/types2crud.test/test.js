@@ -0,0 +1,96 @@
+#!/usr/bin/env node
+
+'use strict';
+
+// Patch node's "require" system to allow for "define"-based modules.
+require('../../dependencies/node-amd-loader/amd-loader');
+
+const fs = require('fs');
+const path = require('path');
+const {glob, exists} = require('../filesystem');
+const {proto2types} = require('../proto2types');
+const {types2tables, outboxTableName} = require('../types2tables');
+const {sqlCrud} = require('../types2crud');
+const tisch = require('../../dependencies/tisch/tisch');
+
+// The CRUD instructions are the same in every SQL dialect, except for the
+// parts of the SQL that a dialect describes (see `sqlCrud`). This test uses a
+// dialect that describes no particular database, so that its expectations
+// are about the instructions rather than about the SQL of any one dialect.
+// The tests in `sql-dialects/*/types2crud.test/` cover the dialects.
+function onConflict({keyColumnName, assignments}) {
+    const action = assignments.length
+        ? 'do update set ' + assignments.join(', ')
+        : 'do nothing';
+    return `on conflict (${keyColumnName}) ${action}`;
+}
+
+function historyOrdinality({table, id, ordinality, idParameter}) {
+    return `(select coalesce(max(${ordinality}), 0) + 1
+        from ${table}
+        where ${id} = ${idParameter})`;
+}
+
+function claimOutboxEvents({table, sequence, claim, claimedAt}) {
+    return `update ${table}
+        set ${claim} = ?, ${claimedAt} = current_timestamp
+        where ${sequence} in (select ${sequence}
+            from ${table}
+            where ${claimedAt} is null or ${claimedAt} < current_timestamp - ?
+            order by ${sequence}
+            limit ?);`;
+}
+
+const {types2crud, outboxPoller} = sqlCrud({
+    quoteName: name => name,
+    numberedParameters: false,
+    selector: ({columnName}) => columnName,
+    parameter: () => '?',
+    currentTimestamp: () => 'current_timestamp',
+    integerParameter: '?',
+    insertedValue: column => `excluded.${column}`,
+    onConflict,
+    // so that the check that this implies is covered, too
+    upsertUpdatesOtherRows: true,
+    historyOrdinality,
+    claimOutboxEvents
+});
+
+// For each *.proto, calculate the CRUD operations JSON and compare it against
+// the expected schema *.tisch.js. If there's a corresponding *.options.json,
+// then it's the options to pass to `types2tables`, e.g. `{"history": true}`.
+const protos = glob(path.join(__dirname, '*.proto'));
+
+protos.forEach(protoPath => {
+    const {types} = proto2types({
+        protoFiles: [protoPath]
+    });
+
+    const stem = path.basename(protoPath, '.proto');
+    const optionsPath = path.join(__dirname, stem + '.options.json');
+    const options = exists(optionsPath)
+        ? JSON.parse(fs.readFileSync(optionsPath, {encoding: 'utf8'}))
+        : undefined;
+
+    const {legends} = types2tables(types, options);
+
+    const crud = types2crud(
+        Object.fromEntries(
+            types.map(type => [
+                type.name,
+                // the type, but also the legend if there is one
+                {type, ...(type.name in legends? {legend: legends[type.name]} : {})}
+            ])));
+
+    const schemaPath = path.join(__dirname, stem + '.tisch.js');
+
+    tisch.compileFile(schemaPath).enforce(crud);
+});
+
+// The outbox poller's statements don't depend on any types, so compare them
+// against the expected schema outbox-poller.tisch.js.
+tisch.compileFile(path.join(__dirname, 'outbox-poller.tisch.js'))
+    .enforce(outboxPoller(outboxTableName));
+
+// If we got here, then nothing above threw, so we succeeded.
+console.log(`All ${protos.length + 1} tests passed.`);
@@ -0,0 +1,232 @@
+// This is the expected output of running the `types2crud` function on
+// `timestamp-field.proto`, using the dialect in `test.js`.
+({
+    ".foobar.Reading": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into reading( id, when, celsius) values (?, ?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select id, case when ? then when else null end, case when ? then celsius else null end from reading where id = ?;",
+                parameters: [
+                    {
+                        included: "when"
+                    },
+                    {
+                        included: "celsius"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from reading where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update reading set when = case when ? then ? else when end, celsius = case when ? then ? else celsius end where id = ?;",
+                parameters: [
+                    {
+                        included: "when"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        included: "celsius"
+                    },
+                    {
+                        field: "celsius"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from reading where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into reading( id, when, celsius) values (?, ?, ?) on conflict (id) do update set when = excluded.when, celsius = excluded.celsius;",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select id, when, celsius from reading where ? or id > ? order by id limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select id, when, celsius from reading where id in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?)",
+                sql: "insert into reading( id, when, celsius) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from reading where id = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from reading"
+        },
+        query: {
+            sql: "select id from reading",
+            key: "id",
+            fields: {
+                id: {
+                    column: "id",
+                    parameter: "?"
+                },
+                when: {
+                    column: "when",
+                    parameter: "?"
+                },
+                celsius: {
+                    column: "celsius",
+                    parameter: "?"
+                }
+            }
+        }
+    }
+})
@@ -0,0 +1,306 @@
+// This is the expected output of running the `types2crud` function on
+// `unique-field.proto`, using the dialect in `test.js`.
+({
+    ".foobar.Grill": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into grill( id, name, serial_number) values (?, ?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "serial_number"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select id, case when ? then name else null end, case when ? then serial_number else null end from grill where id = ?;",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        included: "serial_number"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "serial_number"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update grill set name = case when ? then ? else name end, serial_number = case when ? then ? else serial_number end where id = ?;",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        included: "serial_number"
+                    },
+                    {
+                        field: "serial_number"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "query",
+                sql: "select null from (select count(*) as conflicts from grill where ((name = ?) or (serial_number = ?)) and id <> ?) as counted where conflicts = 0;",
+                parameters: [
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "serial_number"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ],
+                onNoRow: "uniqueViolation"
+            },
+            {
+                instruction: "exec",
+                sql: "insert into grill( id, name, serial_number) values (?, ?, ?) on conflict (id) do update set name = excluded.name, serial_number = excluded.serial_number;",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "serial_number"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select id, name, serial_number from grill where ? or id > ? order by id limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "serial_number"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select id, name, serial_number from grill where id in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "serial_number"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?)",
+                sql: "insert into grill( id, name, serial_number) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "serial_number"
+                    }
+                ]
+            }
+        ],
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from grill where id = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from grill"
+        },
+        query: {
+            sql: "select id from grill",
+            key: "id",
+            fields: {
+                id: {
+                    column: "id",
+                    parameter: "?"
+                },
+                name: {
+                    column: "name",
+                    parameter: "?"
+                },
+                serial_number: {
+                    column: "serial_number",
+                    parameter: "?"
+                }
+            }
+        },
+        lookups: {
+            name: [
+                {
+                    instruction: "query",
+                    sql: "select id, name, serial_number from grill where name = ? order by id;",
+                    parameters: [
+                        {
+                            lookup: "name"
+                        }
+                    ]
+                },
+                {
+                    instruction: "read-rows",
+                    destinations: [
+                        {
+                            field: "id"
+                        },
+                        {
+                            field: "name"
+                        },
+                        {
+                            field: "serial_number"
+                        }
+                    ]
+                }
+            ],
+            serial_number: [
+                {
+                    instruction: "query",
+                    sql: "select id, name, serial_number from grill where serial_number = ? order by id;",
+                    parameters: [
+                        {
+                            lookup: "serial_number"
+                        }
+                    ]
+                },
+                {
+                    instruction: "read-rows",
+                    destinations: [
+                        {
+                            field: "id"
+                        },
+                        {
+                            field: "name"
+                        },
+                        {
+                            field: "serial_number"
+                        }
+                    ]
+                }
+            ]
+        }
+    }
+})
@@ -0,0 +1,230 @@
+// This is the expected output of running the `types2crud` function on
+// `version-field.proto`, using the dialect in `test.js`.
+({
+    ".foobar.Grill": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into grill( id, name, revision) values (?, ?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "revision"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select id, case when ? then name else null end, case when ? then revision else null end from grill where id = ?;",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        included: "revision"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "revision"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update grill set name = case when ? then ? else name end, revision = coalesce(revision, 0) + 1 where id = ? and coalesce(revision, 0) = coalesce(?, 0);",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "revision"
+                    }
+                ],
+                onNoRows: "conflict"
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from grill where id = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into grill( id, name, revision) values (?, ?, ?) on conflict (id) do update set name = excluded.name, revision = coalesce(grill.revision, 0) + 1;",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "revision"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select id, name, revision from grill where ? or id > ? order by id limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "revision"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select id, name, revision from grill where id in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "revision"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?)",
+                sql: "insert into grill( id, name, revision) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "revision"
+                    }
+                ]
+            }
+        ],
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from grill where id = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from grill"
+        },
+        query: {
+            sql: "select id from grill",
+            key: "id",
+            fields: {
+                id: {
+                    column: "id",
+                    parameter: "?"
+                },
+                name: {
+                    column: "name",
+                    parameter: "?"
+                },
+                revision: {
+                    column: "revision",
+                    parameter: "?"
+                }
+            }
+        }
+    }
+})
@@ -19,18 +19,38 @@ function types2tables(types, options = {}) {
     types.forEach(schemas.type.enforce);
 
     options.namingStyle = options.namingStyle || 'snake_case';
+    // If `timestamps` is true, then each message table has two additional
+    // columns recording when each row was created and last updated (see
+    // `timestampColumns`).
+    options.timestamps = Boolean(options.timestamps);
+    // If `history` is true, then each message table has a history table, to
+    // which generated CRUD code appends a record of each change to a message
+    // (see `message2historyTable`).
+    options.history = Boolean(options.history);
+    // If `outbox` is true, then there is an outbox table, into which
+    // generated CRUD code inserts an event for each change to a message (see
+    // `outboxTable`).
+    options.outbox = Boolean(options.outbox);
 
     const tables = {};
     const legends = {};
+    const typesByName = Object.fromEntries(types.map(type => [type.name, type]));
 
     types.forEach(type => {
         if (type.kind === 'enum') {
             const table = enum2table(type, options);
             tables[table.name] = table;
         }
+        else if (type.kind === 'message' && type.idFieldName === undefined) {
+            // A message type without an ID is stored only as a field of other
+            // messages, in child tables (see `message2childTables`).
+            return;
+        }
         else if (type.kind === 'message') {
-            const {legend, table, arrayTables} = message2tables(type, options);
-            [table, ...arrayTables].forEach(table => tables[table.name] = table);
+            const {legend, table, arrayTables, childTables, historyTables} =
+                message2tables(type, typesByName, options);
+            [table, ...arrayTables, ...childTables, ...historyTables]
+                .forEach(table => tables[table.name] = table);
             legends[type.name] = legend;
         }
         else {
@@ -38,9 +58,174 @@ function types2tables(types, options = {}) {
         }
     });
 
+    if (options.outbox) {
+        if (outboxTableName in tables) {
+            throw Error(`The outbox table, ${outboxTableName}, would have ` +
+                'the same name as the table of a type or field.');
+        }
+        tables[outboxTableName] = outboxTable();
+    }
+
     return {tables, legends};
 }
 
+// `outboxTableName` is the name of the outbox table (see `outboxTable`). It
+// doesn't depend on the naming style, because the table belongs to okra
+// rather than to any message type.
+const outboxTableName = 'okra_outbox';
+
+// Return a table (satisfying the schema `table.tisch.js`) into which generated
+// CRUD code inserts an event for each change to a message of any type, in the
+// same transaction as the change. Other programs then claim the events in
+// order, act on them (e.g. publish them to a message bus), and acknowledge
+// them, which deletes them.
+//
+// An event says what the operation was (e.g. "update"), which fields it
+// changed (the "field mask," if not all of them), and holds the entire message
+// afterward, as a serialized protobuf message (null if the message was
+// deleted). Since events of all message types share the table, the ID of the
+// message is encoded as JSON text.
+function outboxTable() {
+    return schemas.table.enforce({
+        name: outboxTableName,
+        description: 'changes to messages, to be claimed and acknowledged in ' +
+            'order',
+        primaryKey: ['sequence'],
+        columns: [
+            {
+                name: 'sequence',
+                type: 'TYPE_INT64',
+                nullable: false,
+                autoIncrement: true,
+                description: 'position of the event in the outbox'
+            },
+            {
+                name: 'type_name',
+                type: 'name',
+                nullable: false,
+                description: 'fully qualified name of the message type, e.g. ' +
+                    '".scouts.BoyScout"'
+            },
+            {
+                name: 'message_id',
+                type: 'TYPE_STRING',
+                nullable: false,
+                description: 'ID of the message, encoded as JSON'
+            },
+            {
+                name: 'operation',
+                type: 'name',
+                nullable: false,
+                description: 'e.g. "create," "update," or "delete"'
+            },
+            {
+                name: 'field_mask',
+                type: 'TYPE_STRING',
+                nullable: true,
+                description: 'comma-separated names of the fields that the ' +
+                    'operation changed, or null if it changed the whole message'
+            },
+            {
+                name: 'message',
+                type: 'TYPE_BYTES',
+                nullable: true,
+                description: 'serialized message after the change, or null ' +
+                    'if it was deleted'
+            },
+            {
+                name: 'recorded_at',
+                type: '.google.protobuf.Timestamp',
+                nullable: false,
+                description: 'when the change was made'
+            },
+            {
+                name: 'claim',
+                type: 'name',
+                nullable: true,
+                description: 'token identifying who claimed the event, or ' +
+                    'null if it is unclaimed'
+            },
+            {
+                name: 'claimed_at',
+                type: '.google.protobuf.Timestamp',
+                nullable: true,
+                description: 'when the event was claimed, or null if it is ' +
+                    'unclaimed'
+            }
+        ],
+        indices: [{columns: ['claim']}]
+    });
+}
+
+// Return the name of the history table of the specified message `type` (see
+// `message2historyTable`), e.g. "boy_scout_history" for "boy_scout", using
+// the specified `namingStyle`.
+function historyTableName(type, namingStyle) {
+    return fieldName2columnName(
+        `${messageTableName(type, namingStyle)} history`, namingStyle);
+}
+
+// Return a table (satisfying the schema `table.tisch.js`) that holds the
+// history of instances of the specified message `type`. Use the specified
+// `namingStyle` for SQL table and column names.
+//
+// Each row is a record of one change to an instance: when it happened, what
+// the operation was (e.g. "update"), who did it (the "actor," if known), and
+// the entire instance afterward, as a serialized protobuf message (null if
+// the instance was deleted). Rows are only ever appended. The history table
+// has no foreign key to the message table, so that the history of a message
+// outlives the message.
+function message2historyTable(type, namingStyle) {
+    const idField = type.fields.find(field => field.name === type.idFieldName);
+
+    return schemas.table.enforce({
+        name: historyTableName(type, namingStyle),
+        // The records of an instance are numbered in the order in which they
+        // were appended, starting at one.
+        primaryKey: ['id', 'ordinality'],
+        columns: [
+            withMaxLength(type, idField, {
+                name: 'id',
+                type: primaryKeyColumnType(idField.type),
+                nullable: false,
+                description: `${type.idFieldName} of the relevant ${type.name}`
+            }),
+            {
+                name: 'ordinality',
+                type: 'TYPE_UINT32',
+                nullable: false,
+                description: 'one-based position within the history of the ' +
+                    'message'
+            },
+            {
+                name: 'recorded_at',
+                type: '.google.protobuf.Timestamp',
+                nullable: false,
+                description: 'when the change was made'
+            },
+            {
+                name: 'operation',
+                type: 'name',
+                nullable: false,
+                description: 'e.g. "create," "update," or "delete"'
+            },
+            {
+                name: 'actor',
+                type: 'TYPE_STRING',
+                nullable: true,
+                description: 'who made the change, if known'
+            },
+            {
+                name: 'message',
+                type: 'TYPE_BYTES',
+                nullable: true,
+                description: 'serialized message after the change, or null ' +
+                    'if it was deleted'
+            }
+        ]
+    });
+}
+
 // Return the column type corresponding to the specified `fieldType` for a column that is
 // also a primary key.
 // The reason that primary key columns are typed specially is that
@@ -60,99 +245,482 @@ function primaryKeyColumnType(fieldType) {
     }
 }
 
-// Return the name of the table for values of the specified array-valued field
-// having the specified `fieldName` in the protobuf message having the
-// specified `messageName`. Separate words in the output using the convention
-// indicated by the specified `namingStyle` (e.g. "snake_case").
-function arrayTableName(messageName, fieldName, namingStyle) {
+// Return the column type corresponding to the specified `builtin` type name.
+// A wrapper type, e.g. ".google.protobuf.StringValue", is stored in the same
+// kind of column as the type that it wraps, e.g. "TYPE_STRING". The column is
+// null when the wrapper is absent.
+function builtinColumnType(builtin) {
+    return {
+        '.google.protobuf.DoubleValue': 'TYPE_DOUBLE',
+        '.google.protobuf.FloatValue': 'TYPE_FLOAT',
+        '.google.protobuf.Int64Value': 'TYPE_INT64',
+        '.google.protobuf.UInt64Value': 'TYPE_UINT64',
+        '.google.protobuf.Int32Value': 'TYPE_INT32',
+        '.google.protobuf.UInt32Value': 'TYPE_UINT32',
+        '.google.protobuf.BoolValue': 'TYPE_BOOL',
+        '.google.protobuf.StringValue': 'TYPE_STRING',
+        '.google.protobuf.BytesValue': 'TYPE_BYTES'
+    }[builtin] || builtin;
+}
+
+// Return the name of the table for values of the specified array-valued (or
+// message-valued) `field` in the specified message `type`. Separate words in
+// the output using the convention indicated by the specified `namingStyle`
+// (e.g. "snake_case").
+function arrayTableName(type, field, namingStyle) {
     // The "chickens" array field of the "farm" message type will be a table
     // named "farm_chickens", or maybe "FarmChickens", depending on
-    // `namingStyle`.
+    // `namingStyle`. If the message has a `tableName` or the field has a
+    // `columnName`, then those are used instead of "farm" and "chickens".
 
-    // Since `typeName2tableName` will split the name apart in various ways, we can
-    // connect the two parts of the input name together using any whitespace or
-    // punctuation except a period. Here I use a space.
-    return typeName2tableName(`${messageName} ${fieldName}`, namingStyle);
+    // Since `fieldName2columnName` will split the name apart in various ways,
+    // we can connect the two parts of the input name together using any
+    // whitespace or punctuation. Here I use a space.
+    return fieldName2columnName(
+        `${messageTableName(type, namingStyle)} ` +
+        fieldColumnName(field, namingStyle), namingStyle);
 }
 
 // Return a legend object (satisfying the schema `legend.tisch.js`) that
 // describes the correspondence between the specified message `type` and any
-// SQL tables generated from it, such as the table of values of that type, and
-// tables of values for array (repeated) fields in the type. Use the specified
-// `namingStyle` for SQL table and column names.
-function message2legend(type, namingStyle) {
+// SQL tables generated from it, such as the table of values of that type,
+// tables of values for array (repeated) fields in the type, and child tables
+// for message fields in the type. Use the specified `typesByName` to look up
+// the types of message fields. Use the specified `namingStyle` for SQL table
+// and column names. If `timestamps` is true, then the message's table has
+// timestamp columns (see `timestampColumns`). If the type has the
+// `softDelete` option, then the table has a column marking deleted rows (see
+// `deletedColumn`). If `history` is true, then the message has a history
+// table (see `message2historyTable`). If `outbox` is true, then changes to
+// the message are inserted into the outbox table (see `outboxTable`).
+function message2legend(
+    type, typesByName, namingStyle, timestamps, history, outbox) {
     return schemas.legend.enforce({
         messageTypeName: type.name,
         // this has to be consistent with `message2table`
-        tableName: typeName2tableName(type.name, namingStyle),
-        fieldSources: type.fields.map(field => {
+        tableName: messageTableName(type, namingStyle),
+        // this has to be consistent with `timestampColumns`
+        ...(timestamps ? {timestamps: {
+            created: timestampColumnName('created', namingStyle),
+            updated: timestampColumnName('updated', namingStyle)
+        }} : {}),
+        // this has to be consistent with `deletedColumn`
+        ...(type.softDelete ? {
+            deletedColumn: timestampColumnName('deleted', namingStyle)
+        } : {}),
+        // this has to be consistent with `message2historyTable`
+        ...(history ? {
+            historyTableName: historyTableName(type, namingStyle)
+        } : {}),
+        // this has to be consistent with `outboxTable`
+        ...(outbox ? {outboxTableName} : {}),
+        fieldSources: type.fields.flatMap(field => {
             const source = {
                 fieldName: field.name
             };
 
-            if (isArrayLike(field.type)) {
-                source.tableName = arrayTableName(type.name, field.name, namingStyle);
+            const childTypeName = messageTypeNameOf(field.type);
+            if (childTypeName !== undefined) {
+                // this has to be consistent with `message2childTables`
+                source.tableName = arrayTableName(type, field, namingStyle);
+                source.messageTypeName = childTypeName;
+                source.fieldSources = typesByName[childTypeName].fields.map(
+                    childField => ({
+                        fieldName: childField.name,
+                        columnName: fieldColumnName(childField, namingStyle)
+                    }));
+            }
+            else if (isArrayLike(field.type)) {
+                source.tableName = arrayTableName(type, field, namingStyle);
                 // the column name is always "value"
             }
+            else if (field.type.builtin in multiColumnBuiltins) {
+                // this has to be consistent with `partColumns`
+                return multiColumnBuiltins[field.type.builtin].map(({part}) => ({
+                    fieldName: field.name,
+                    columnName: partColumnName(field, part, namingStyle),
+                    part
+                }));
+            }
             else {
-                source.columnName = fieldName2columnName(field.name, namingStyle);
+                source.columnName = scalarColumnName(field, namingStyle);
+                if (field.oneof !== undefined) {
+                    source.oneofName = field.oneof;
+                }
+                if (field.timestamp !== undefined) {
+                    source.timestamp = field.timestamp;
+                }
+            }
+
+            // If this is the last member of a oneof, then the oneof's
+            // discriminator column follows. This has to be consistent with
+            // `oneofColumns`.
+            const oneofName = lastOneofMember(type, field);
+            if (oneofName === undefined) {
+                return [source];
             }
 
-            return source;
+            return [source, {
+                oneofName,
+                columnName: fieldName2columnName(oneofName, namingStyle)
+            }];
         })
     });
 }
 
+// Return the name of the `oneof` of the specified message `type` whose last
+// member is the specified `field`, or return `undefined` if there is no such
+// oneof. The column that says which member of a oneof is set follows the
+// columns of the oneof's members.
+function lastOneofMember(type, field) {
+    if (field.oneof === undefined) {
+        return;
+    }
+
+    const members = type.fields.filter(({oneof}) => oneof === field.oneof);
+    if (members[members.length - 1] === field) {
+        return field.oneof;
+    }
+}
+
 // A message has a one-to-many relationship with each of its array-typed fields
-// (repeated fields), but also with the special builtin "FieldMask". This
-// function accounts for both cases.
+// (repeated fields) and map-typed fields, but also with the special builtin
+// "FieldMask". This function accounts for all three cases.
 function isArrayLike(type) {
-    return type.array || type.builtin === '.google.protobuf.FieldMask';
+    return type.array || type.map ||
+        type.builtin === '.google.protobuf.FieldMask';
+}
+
+// Return the name of the message type of a field having the specified `type`,
+// whether or not the field is repeated. Return `undefined` if the field is not
+// message-valued.
+function messageTypeNameOf(type) {
+    return (type.array || type).message;
 }
 
 // Return a table object (satisfying the schema `table.tisch.js`) that holds
 // instances of the specified message `type`. Use the specified `namingStyle`
 // for SQL table and column names. Note that other tables associated with the
 // type, such as those containing the values of its array-valued fields, are
-// not calculated by this function (see `message2arrayTables`).
-function message2table(type, namingStyle) {
-    const primaryKeyColumnName =
-        fieldName2columnName(type.idFieldName, namingStyle);
+// not calculated by this function (see `message2arrayTables`). If
+// `timestamps` is true, then the table ends with timestamp columns (see
+// `timestampColumns`). If the type has the `softDelete` option, then the
+// table ends with a column marking deleted rows (see `deletedColumn`).
+function message2table(type, namingStyle, timestamps) {
+    const primaryKeyColumnName = idColumnName(type, namingStyle);
 
-    return schemas.table.enforce(withDocs(type, {
-        name: typeName2tableName(type.name, namingStyle),
+    // Protobuf doesn't allow repeated fields or map fields in a oneof, but
+    // message fields are allowed. Those would have to be child tables,
+    // which have no column in which to be null.
+    type.fields
+        .filter(field => field.oneof !== undefined &&
+                         messageTypeNameOf(field.type) !== undefined)
+        .forEach(field => {
+            throw Error(`Field ${field.name} of message ${type.name} is ` +
+                `message-valued and is a member of the oneof ` +
+                `${field.oneof}. Message-valued members of a oneof must ` +
+                'be stored as JSON.');
+        });
+
+    // Similarly, some builtins have more than one column (see
+    // `partColumns`), which is too many for a member of a oneof.
+    type.fields
+        .filter(field => field.oneof !== undefined &&
+                         field.type.builtin in multiColumnBuiltins)
+        .forEach(field => {
+            throw Error(`Field ${field.name} of message ${type.name} is ` +
+                `a ${field.type.builtin} and is a member of the oneof ` +
+                `${field.oneof}, which is not supported because a ` +
+                `${field.type.builtin} has more than one column.`);
+        });
+
+    // Fields having the `indexed` or `unique` option are indexed, each by its
+    // own column(s). Only fields stored in the message's table can be indexed.
+    type.fields
+        .filter(field => isIndexed(field) &&
+                         (isArrayLike(field.type) ||
+                          messageTypeNameOf(field.type) !== undefined))
+        .forEach(field => {
+            throw Error(`Field ${field.name} of message ${type.name} is ` +
+                'indexed, but it is stored in a separate table. Only fields ' +
+                'stored in columns of the message\'s table can be indexed.');
+        });
+
+    // The version field, if any, is compared and incremented in its column by
+    // updates, so it must be an integer in a column of its own (not the ID,
+    // and not a member of a oneof).
+    type.fields
+        .filter(field => field.name === type.versionFieldName &&
+                         (!versionBuiltins.includes(field.type.builtin) ||
+                          field.name === type.idFieldName ||
+                          field.oneof !== undefined))
+        .forEach(field => {
+            throw Error(`Field ${field.name} of message ${type.name} is ` +
+                'its version, but it is not an integer field that is stored ' +
+                'in its own column. The version must be an integer field ' +
+                'other than the ID, and must not be a member of a oneof.');
+        });
+
+    // A field populated from one of the timestamp columns must be a
+    // `google.protobuf.Timestamp`, and there must be timestamp columns.
+    type.fields
+        .filter(field => field.timestamp !== undefined &&
+                         (!timestamps ||
+                          field.type.builtin !== '.google.protobuf.Timestamp' ||
+                          field.oneof !== undefined))
+        .forEach(field => {
+            throw Error(`Field ${field.name} of message ${type.name} is ` +
+                `populated from the ${field.timestamp} timestamp column, ` +
+                'but either the tables do not have timestamp columns, or ' +
+                'the field is not a google.protobuf.Timestamp that is ' +
+                'outside of any oneof.');
+        });
+
+    ['created', 'updated']
+        .filter(timestamp => type.fields
+            .filter(field => field.timestamp === timestamp).length > 1)
+        .forEach(timestamp => {
+            throw Error(`More than one field of message ${type.name} is ` +
+                `populated from the ${timestamp} timestamp column.`);
+        });
+
+    const table = withDocs(type, {
+        name: messageTableName(type, namingStyle),
         primaryKey: [primaryKeyColumnName],
         // Each non-array field is a column in the table. The array-valued
-        // fields are separate tables (dealt with later -- see
-        // `message2arrayTables`).
-        columns: type.fields.filter(field => !isArrayLike(field.type)).map(field => {
+        // and message-valued fields are separate tables (dealt with later --
+        // see `message2arrayTables` and `message2childTables`). Each oneof
+        // has an additional column -- see `oneofColumns`. A field populated
+        // from a timestamp column doesn't have a column of its own.
+        columns: type.fields.filter(field =>
+            !isArrayLike(field.type) &&
+            messageTypeNameOf(field.type) === undefined &&
+            field.timestamp === undefined).flatMap(field => {
             const column = withDocs(field, {
-                name: fieldName2columnName(field.name, namingStyle),
+                name: fieldColumnName(field, namingStyle),
                 nullable: field.name !== type.idFieldName
                 // `.type` and possibly `.foreignKey` are filled out below.
             });
 
-            if (field.type.enum) {
+            // A proto3 `optional` field has the same column as its
+            // non-optional counterpart. The column is nullable either way.
+            const fieldType = field.type.optional || field.type;
+
+            if (fieldType.enum) {
                 // Scalar (non-array) enum columns are int32 with a foreign
                 // key to the table of meanings for that enum.
                 column.type = 'TYPE_INT32';
                 column.foreignKey = {
-                    table: typeName2tableName(field.type.enum, namingStyle),
+                    table: typeName2tableName(fieldType.enum, namingStyle),
                     column: 'id' // enum tables are all keyed on an "id" column
                 };
             }
+            else if (fieldType.json) {
+                // The message is stored as JSON text.
+                column.type = 'json';
+            }
+            else if (fieldType.builtin in multiColumnBuiltins) {
+                return partColumns(type, field, namingStyle);
+            }
             // primary key column type is sometimes special
             else if (column.name === primaryKeyColumnName) {
-                column.type = primaryKeyColumnType(field.type);
+                column.type = primaryKeyColumnType(fieldType);
             }
             // otherwise it has to be builtin
             else {
-                column.type = field.type.builtin;
+                column.type = builtinColumnType(fieldType.builtin);
             }
 
-            return column;
+            return [
+                withMaxLength(type, field, column),
+                ...oneofColumns(type, field, namingStyle)
+            ];
         })
-    }));
+    });
+
+    // The timestamp columns, if any, and then the column that marks a
+    // soft-deleted row, if the message is soft-deleted, follow the columns
+    // of the fields.
+    const columns = [
+        ...(timestamps ? timestampColumns(namingStyle) : []),
+        ...(type.softDelete ? [deletedColumn(namingStyle)] : [])
+    ];
+    columns
+        .filter(({name}) => table.columns.some(column => column.name === name))
+        .forEach(({name}) => {
+            throw Error(`The table ${table.name} of message ${type.name} ` +
+                `has a column named ${name}, which is also the name of ` +
+                'one of its timestamp columns.');
+        });
+    table.columns.push(...columns);
+
+    const indices = type.fields
+        .filter(isIndexed)
+        .map(field => fieldIndex(field,
+            field.type.builtin in multiColumnBuiltins
+                ? multiColumnBuiltins[field.type.builtin].map(({part}) =>
+                    partColumnName(field, part, namingStyle))
+                : [scalarColumnName(field, namingStyle)]));
+    if (indices.length !== 0) {
+        table.indices = indices;
+    }
+
+    return schemas.table.enforce(table);
+}
+
+// These are the builtin types that a version field (see the `(okra.version)`
+// option) can have.
+const versionBuiltins = ['TYPE_INT32', 'TYPE_INT64', 'TYPE_UINT32', 'TYPE_UINT64'];
+
+// Return whether the specified `field` is to be indexed, i.e. whether it has
+// the `indexed` option or the `unique` option.
+function isIndexed(field) {
+    return Boolean(field.indexed || field.unique);
+}
+
+// Return an index (satisfying the "indices" of `table.tisch.js`) of the
+// specified `columns` of the specified `field`. The index is unique if the
+// field has the `unique` option.
+function fieldIndex(field, columns) {
+    return field.unique ? {columns, unique: true} : {columns};
+}
+
+// Return the specified `column` of the specified `field` of the specified
+// message `type`, after giving it the `maxLength` of `field`, if any. Only a
+// string column can have a maximum length.
+function withMaxLength(type, field, column) {
+    if (field.maxLength === undefined) {
+        return column;
+    }
+
+    if (!['TYPE_STRING', 'name'].includes(column.type)) {
+        throw Error(`Field ${field.name} of message ${type.name} has a ` +
+            `maximum length, but it is not stored as a string.`);
+    }
+
+    column.maxLength = field.maxLength;
+    return column;
+}
+
+// Return an array containing the "discriminator" column of the oneof whose
+// last member is the specified `field` of the specified message `type`, or
+// return an empty array if `field` is not the last member of a oneof. For
+// example,
+//
+//     message Payment {
+//         int64 id = 1;
+//         oneof method {
+//             string card_number = 2;
+//             string iban = 3;
+//         }
+//     }
+//
+// yields the columns "id", "card_number", "iban", and "method", where
+// "method" is either null or the name of the member field that is set, e.g.
+// "iban". Each member's column is null unless the member is set. Use the
+// specified `namingStyle` for the column name.
+function oneofColumns(type, field, namingStyle) {
+    const oneofName = lastOneofMember(type, field);
+    if (oneofName === undefined) {
+        return [];
+    }
+
+    // this has to be consistent with `message2legend`
+    const name = fieldName2columnName(oneofName, namingStyle);
+    if (type.fields.some(field =>
+            fieldColumnName(field, namingStyle) === name)) {
+        throw Error(`The oneof ${oneofName} of message ${type.name} ` +
+            'would have the same column name as one of the fields of ' +
+            'the message.');
+    }
+
+    return [{
+        name,
+        type: 'name',
+        nullable: true,
+        description: `name of the field set in the oneof ${oneofName}`
+    }];
+}
+
+// Some builtin types are stored in more than one column, one for each "part"
+// of the value. `multiColumnBuiltins` maps the name of each such builtin to
+// an array of its parts, in column order. Each part has a name (`part`), a
+// column type (`type`), and optionally a function that describes the part's
+// column given the field name (`describe`). A part without `describe` takes
+// the field's own documentation.
+const multiColumnBuiltins = {
+    '.google.protobuf.Any': [
+        {part: 'type_url', type: 'TYPE_STRING'},
+        {part: 'value',
+         type: 'TYPE_BYTES',
+         describe: name => `serialized value of the google.protobuf.Any ${name}`}
+    ],
+    '.google.type.LatLng': [
+        {part: 'latitude',
+         type: 'TYPE_DOUBLE',
+         describe: name => `latitude in degrees of ${name}`},
+        {part: 'longitude',
+         type: 'TYPE_DOUBLE',
+         describe: name => `longitude in degrees of ${name}`}
+    ],
+    '.google.type.Money': [
+        {part: 'currency_code',
+         type: 'currency',
+         describe: name => `currency code of ${name}`},
+        {part: 'amount', type: 'decimal', describe: name => `amount of ${name}`}
+    ]
+};
+
+// Return the name of the column of the specified `part` of the specified
+// `field`, e.g. "payload_type_url" for the "type_url" of "payload". Use the
+// specified `namingStyle` for the column name.
+function partColumnName(field, part, namingStyle) {
+    return fieldName2columnName(
+        `${fieldColumnName(field, namingStyle)} ${part}`, namingStyle);
+}
+
+// Return an array containing the columns of the specified `field` of the
+// specified message `type`, where the field's type is one of the
+// `multiColumnBuiltins`. For example,
+//
+//     message Event {
+//         int64 id = 1;
+//         google.protobuf.Any payload = 2;
+//         google.type.LatLng location = 3;
+//     }
+//
+// yields the columns "id", "payload_type_url", "payload_value",
+// "location_latitude", and "location_longitude", where "payload_type_url" is
+// the type URL of the `Any` (e.g. "type.googleapis.com/foo.Bar"),
+// "payload_value" is the serialized message, and so on. All of a field's
+// columns are null if the field is absent. Use the specified `namingStyle`
+// for the column names.
+function partColumns(type, field, namingStyle) {
+    // this has to be consistent with `message2legend`
+    const parts = multiColumnBuiltins[field.type.builtin];
+    const names = parts.map(({part}) =>
+        partColumnName(field, part, namingStyle));
+    if (type.fields.some(other =>
+            names.includes(fieldColumnName(other, namingStyle)))) {
+        throw Error(`The ${field.type.builtin} field ${field.name} of ` +
+            `message ${type.name} would have the same column name as one ` +
+            'of the other fields of the message.');
+    }
+
+    if (field.maxLength !== undefined) {
+        throw Error(`The ${field.type.builtin} field ${field.name} of ` +
+            `message ${type.name} has a maximum length, but it is not ` +
+            'stored as a string.');
+    }
+
+    return parts.map(({type: columnType, describe}, i) => {
+        const column = {name: names[i], type: columnType, nullable: true};
+        if (describe === undefined) {
+            return withDocs(field, column);
+        }
+        column.description = describe(field.name);
+        return column;
+    });
 }
 
 // Each array-valued field in a message has its own table of (id, value) pairs,
@@ -166,41 +734,59 @@ function message2table(type, namingStyle) {
 //     insert into painting_colors(id, ordinality, value)
 //     values (1337, 0, 'red'), (1337, 1, 'green'), (1337, 2, 'blue');
 //
+// Each map-valued field is similar, except that its table is keyed by the
+// map key instead of by position, e.g.
+//
+//     painting.id = 1337
+//     painting.labels = {"artist": "Bob Ross", "medium": "oil"}
+//
+// yields
+//
+//     insert into painting_labels(id, key, value)
+//     values (1337, 'artist', 'Bob Ross'), (1337, 'medium', 'oil');
+//
 function message2arrayTables(type, namingStyle) {
     // these have to be consistent with `message2table`
-    const messageTableName = typeName2tableName(type.name, namingStyle);
-    const messagePrimaryKey = fieldName2columnName(type.idFieldName, namingStyle);
+    const tableName = messageTableName(type, namingStyle);
+    const messagePrimaryKey = idColumnName(type, namingStyle);
 
     // The first column of each array table will have a foreign key to the ID
     // of `type`. Those columns have to have the same type.
-    const messageIdColumnType = primaryKeyColumnType(
-        type.fields.find(field => field.name === type.idFieldName).type);
+    const idField = type.fields.find(field => field.name === type.idFieldName);
+    const messageIdColumnType = primaryKeyColumnType(idField.type);
 
-    return type.fields.filter(field => isArrayLike(field.type)).map(field => {
+    return type.fields.filter(field =>
+        isArrayLike(field.type) &&
+        messageTypeNameOf(field.type) === undefined).map(field => {
         const arrayTable = withDocs(field, {
-            name: arrayTableName(type.name, field.name, namingStyle),
+            name: arrayTableName(type, field, namingStyle),
 
             // The primary key is the ID of the related message table, and
             // then the "ordinality" (array position, i.e. index, offset) of
-            // the value.
-            primaryKey: ['id', 'ordinality'],
+            // the value, or the key of the value if the field is a map.
+            primaryKey: ['id', field.type.map ? 'key' : 'ordinality'],
 
             // The array table has three columns. Here are the first two. The
             // third depends on the underlying type of the array, so that's
             // calculated separately.
             columns: [
-                {
+                withMaxLength(type, idField, {
                     name: 'id',
                     type: messageIdColumnType,
                     nullable: false,
                     foreignKey: {
-                        table: messageTableName,
+                        table: tableName,
                         column: messagePrimaryKey
                     },
                     // redundant, but possibly helpful
                     description: `${type.idFieldName} of the relevant ${type.name}`
-                },
-                {
+                }),
+                field.type.map ? {
+                    name: 'key',
+                    type: primaryKeyColumnType(field.type.map.key),
+                    nullable: false,
+                    description: 'key within the map'
+                } : {
                     name: 'ordinality',
                     // Do you really need more than four billion elements?
                     type: 'TYPE_UINT32',
@@ -210,34 +796,52 @@ function message2arrayTables(type, namingStyle) {
             ]
         });
 
-        // The second column depends on whether the array contains enums. If it
+        // The third column depends on whether the array contains enums. If it
         // contains enums then the values have a foreign key to the relevant
         // enum table. If they don't contain enums, then they just have
-        // whatever value they have.
+        // whatever value they have. A map is treated like an array of its
+        // values.
         // Also, the field might not be an array, it might be a FieldMask. In
         // that case, treat it as if it were an array of name strings.
-        if (field.type.array && field.type.array.enum) {
+        const elementType = field.type.array ||
+            (field.type.map && field.type.map.value);
+        if (elementType && elementType.enum) {
             arrayTable.columns.push({
                 name: 'value',
                 type: 'TYPE_INT32',
                 nullable: true,
                 foreignKey: {
-                    table: typeName2tableName(field.type.array.enum, namingStyle),
+                    table: typeName2tableName(elementType.enum, namingStyle),
                     column: 'id'
                 },
                 // redundant, but possibly helpful
                 description: `one of the ${field.name} in some ${type.name}`
             });
         }
-        else if (field.type.array) {
+        else if (elementType && elementType.json) {
             arrayTable.columns.push({
                 name: 'value',
-                type: field.type.array.builtin,
+                type: 'json',
                 nullable: true,
                 // redundant, but possibly helpful
                 description: `one of the ${field.name} in some ${type.name}`
             });
         }
+        else if (elementType && elementType.builtin in multiColumnBuiltins) {
+            throw Error(`Field ${field.name} of message ${type.name} is an ` +
+                `array or map of ${elementType.builtin}, which is not ` +
+                `supported because a ${elementType.builtin} has more than ` +
+                'one column.');
+        }
+        else if (elementType) {
+            arrayTable.columns.push(withMaxLength(type, field, {
+                name: 'value',
+                type: builtinColumnType(elementType.builtin),
+                nullable: true,
+                // redundant, but possibly helpful
+                description: `one of the ${field.name} in some ${type.name}`
+            }));
+        }
         else {
             // field.type.builtin === ".google.protobuf.FieldMask"
             arrayTable.columns.push({
@@ -253,32 +857,200 @@ function message2arrayTables(type, namingStyle) {
     });
 }
 
+// Each message-valued field in a message has its own "child" table whose rows
+// are instances of the field's message type, keyed by the parent's ID and, if
+// the field is repeated, by position, e.g.
+//
+//     order.id = 42
+//     order.items = [{sku: "A1", quantity: 2}, {sku: "B7", quantity: 1}]
+//
+// yields
+//
+//     insert into order_items(parent_id, ordinality, sku, quantity)
+//     values (42, 0, 'A1', 2), (42, 1, 'B7', 1);
+//
+// Only one level of nesting is supported, i.e. the child message type may not
+// itself have array-valued or message-valued fields. Use the specified
+// `typesByName` to look up the child message types.
+function message2childTables(type, typesByName, namingStyle) {
+    // these have to be consistent with `message2table`
+    const tableName = messageTableName(type, namingStyle);
+    const messagePrimaryKey = idColumnName(type, namingStyle);
+
+    // The "parent_id" column of each child table will have a foreign key to
+    // the ID of `type`. Those columns have to have the same type.
+    const idField = type.fields.find(field => field.name === type.idFieldName);
+    const messageIdColumnType = primaryKeyColumnType(idField.type);
+
+    return type.fields.filter(field =>
+        messageTypeNameOf(field.type) !== undefined).map(field => {
+        const childTypeName = messageTypeNameOf(field.type);
+        const childType = typesByName[childTypeName];
+        if (childType === undefined) {
+            throw Error(`Field ${field.name} of message ${type.name} has ` +
+                `the message type ${childTypeName}, but that type is not ` +
+                'among the types specified.');
+        }
+
+        // Tuples of child table rows are generated per field of the child
+        // message type, so there must be at least one field.
+        if (childType.fields.length === 0) {
+            throw Error(`Field ${field.name} of message ${type.name} has ` +
+                `the message type ${childTypeName}, which has no fields. ` +
+                'Message types without fields are not supported as the ' +
+                'types of fields.');
+        }
+
+        const repeated = field.type.array !== undefined;
+        const keyColumns = [
+            withMaxLength(type, idField, {
+                name: 'parent_id',
+                type: messageIdColumnType,
+                nullable: false,
+                foreignKey: {
+                    table: tableName,
+                    column: messagePrimaryKey
+                },
+                // redundant, but possibly helpful
+                description: `${type.idFieldName} of the relevant ${type.name}`
+            }),
+            ...(repeated ? [{
+                name: 'ordinality',
+                // Do you really need more than four billion elements?
+                type: 'TYPE_UINT32',
+                nullable: false,
+                description: 'zero-based position within the array'
+            }] : [])
+        ];
+
+        const childColumns = childType.fields.map(childField => {
+            if (isArrayLike(childField.type) ||
+                messageTypeNameOf(childField.type) !== undefined) {
+                throw Error(`Field ${childField.name} of message ` +
+                    `${childTypeName} is array-valued or message-valued, ` +
+                    `which is not supported because ${childTypeName} is ` +
+                    `itself the type of field ${field.name} of message ` +
+                    `${type.name}.`);
+            }
+
+            const column = withDocs(childField, {
+                name: fieldColumnName(childField, namingStyle),
+                nullable: true
+                // `.type` and possibly `.foreignKey` are filled out below.
+            });
+
+            if (childField.type.builtin in multiColumnBuiltins) {
+                throw Error(`Field ${childField.name} of message ` +
+                    `${childTypeName} is a ${childField.type.builtin}, which ` +
+                    `is not supported because ${childTypeName} is itself the ` +
+                    `type of field ${field.name} of message ${type.name}.`);
+            }
+
+            if (childField.oneof !== undefined) {
+                throw Error(`Field ${childField.name} of message ` +
+                    `${childTypeName} is a member of the oneof ` +
+                    `${childField.oneof}, which is not supported because ` +
+                    `${childTypeName} is itself the type of field ` +
+                    `${field.name} of message ${type.name}.`);
+            }
+
+            if (keyColumns.some(({name}) => name === column.name)) {
+                throw Error(`Field ${childField.name} of message ` +
+                    `${childTypeName} would have the same column name as ` +
+                    `one of the key columns of the child table for field ` +
+                    `${field.name} of message ${type.name}.`);
+            }
+
+            // See the analogous code in `message2table`.
+            const childFieldType = childField.type.optional || childField.type;
+            if (childFieldType.enum) {
+                column.type = 'TYPE_INT32';
+                column.foreignKey = {
+                    table: typeName2tableName(childFieldType.enum, namingStyle),
+                    column: 'id'
+                };
+            }
+            else if (childFieldType.json) {
+                column.type = 'json';
+            }
+            else {
+                column.type = builtinColumnType(childFieldType.builtin);
+            }
+
+            return withMaxLength(childType, childField, column);
+        });
+
+        const childTable = withDocs(field, {
+            name: arrayTableName(type, field, namingStyle),
+            primaryKey: keyColumns.map(({name}) => name),
+            columns: [...keyColumns, ...childColumns]
+        });
+
+        // An indexed field of the child message type is indexed in the
+        // child table, too.
+        const indices = childType.fields
+            .filter(isIndexed)
+            .map(childField => fieldIndex(childField,
+                [fieldColumnName(childField, namingStyle)]));
+        if (indices.length !== 0) {
+            childTable.indices = indices;
+        }
+
+        return schemas.table.enforce(childTable);
+    });
+}
+
 // Return a description of all SQL tables needed to store instances of the
 // specified `type`, together with a legend that correlates the fields of the
-// type with the columns of the tables. Customize the returned tables
+// type with the columns of the tables. Use the specified `typesByName` to
+// look up the types of message-valued fields. Customize the returned tables
 // according to the specified `options` object. See the comments in the
 // implementation for more information.
-function message2tables(type, options) {
+function message2tables(type, typesByName, options) {
     // `namingStyle` determines whether tables and columns will be
     // named_like_this, or namedLikeThis, or `named like this`, etc. As of this
     // writing, only "snake_case" is accepted, rendering SQL names_like_this.
-    const {namingStyle} = options;
+    const {namingStyle, timestamps, history, outbox} = options;
 
-    return {
+    const tables = {
         // the table whose rows are instances of the type.
         // satisfies the `table.tisch.js` schema.
-        table: message2table(type, namingStyle),
+        table: message2table(type, namingStyle, timestamps),
 
         // tables that contain values for array-valued fields (one table for
         // each such field).
         // an array whose elements each satisfy the `table.tisch.js` schema.
         arrayTables: message2arrayTables(type, namingStyle),
 
+        // tables that contain instances of the message types of
+        // message-valued fields (one table for each such field).
+        // an array whose elements each satisfy the `table.tisch.js` schema.
+        childTables: message2childTables(type, typesByName, namingStyle),
+
+        // the table of records of changes to instances of the type, if
+        // `history` is true (otherwise empty).
+        // an array whose elements each satisfy the `table.tisch.js` schema.
+        historyTables: history ? [message2historyTable(type, namingStyle)] : [],
+
         // an object that correlates the type and its fields with the generated
         // tables and their columns.
         // satisfies the `legend.tisch.js` schema.
-        legend: message2legend(type, namingStyle)
+        legend: message2legend(
+            type, typesByName, namingStyle, timestamps, history, outbox)
     };
+
+    // The name of the history table is derived like the name of an array
+    // table, so a field named "history" would have a table of the same name.
+    tables.historyTables
+        .filter(({name}) => [...tables.arrayTables, ...tables.childTables]
+            .some(table => table.name === name))
+        .forEach(({name}) => {
+            throw Error(`The history table of message ${type.name} would ` +
+                `be named ${name}, which is also the name of the table of ` +
+                'one of its fields.');
+        });
+
+    return tables;
 }
 
 // Return a table that describes the specified enum `type` and its values.
@@ -312,6 +1084,83 @@ function withDocs(sourceObject, destinationObject) {
     return destinationObject;
 }
 
+// Return the name of the table whose rows are instances of the specified
+// message `type`: its `tableName`, if any, or else a name derived from the
+// name of the type using the specified `namingStyle`.
+function messageTableName(type, namingStyle) {
+    return type.tableName || typeName2tableName(type.name, namingStyle);
+}
+
+// Return the name of the column in which the specified `field` is stored:
+// its `columnName`, if any, or else a name derived from the name of the
+// field using the specified `namingStyle`.
+function fieldColumnName(field, namingStyle) {
+    return field.columnName || fieldName2columnName(field.name, namingStyle);
+}
+
+// Return the name of the column in which the specified non-array `field` of a
+// message is stored. This is the field's own column, unless the field is
+// populated from a timestamp column (see `timestampColumns`). Use the
+// specified `namingStyle` if the column's name is derived from a name.
+function scalarColumnName(field, namingStyle) {
+    return field.timestamp === undefined
+        ? fieldColumnName(field, namingStyle)
+        : timestampColumnName(field.timestamp, namingStyle);
+}
+
+// Return the name of the column that records when a row was created, last
+// updated, or deleted, according to the specified `timestamp` ("created",
+// "updated", or "deleted").
+// Use the specified `namingStyle`, e.g. "created_at" for "snake_case".
+function timestampColumnName(timestamp, namingStyle) {
+    return fieldName2columnName(`${timestamp} at`, namingStyle);
+}
+
+// Return the columns (satisfying the column schema in `table.tisch.js`) that
+// are appended to the table of each message when the `timestamps` option is
+// specified. The generated CRUD code sets them when it creates and updates a
+// message. They're nullable so that they can be added to existing tables.
+// Use the specified `namingStyle` for the names of the columns.
+function timestampColumns(namingStyle) {
+    return [{
+        name: timestampColumnName('created', namingStyle),
+        type: '.google.protobuf.Timestamp',
+        nullable: true,
+        timestamp: 'created',
+        description: 'when the row was created'
+    }, {
+        name: timestampColumnName('updated', namingStyle),
+        type: '.google.protobuf.Timestamp',
+        nullable: true,
+        timestamp: 'updated',
+        description: 'when the row was last updated'
+    }];
+}
+
+// Return the column (satisfying the column schema in `table.tisch.js`) that
+// is appended to the table of a message having the `softDelete` option. The
+// generated CRUD code sets it when it deletes a message, and clears it when it
+// undeletes the message. A row is deleted if and only if the column is not
+// null. Use the specified `namingStyle` for the name of the column.
+function deletedColumn(namingStyle) {
+    return {
+        name: timestampColumnName('deleted', namingStyle),
+        type: '.google.protobuf.Timestamp',
+        nullable: true,
+        timestamp: 'deleted',
+        description: 'when the row was deleted, or null if it was not'
+    };
+}
+
+// Return the name of the primary key column of the table of the specified
+// message `type`, i.e. the column of the type's ID field. Use the specified
+// `namingStyle` if the column's name is derived from the field's name.
+function idColumnName(type, namingStyle) {
+    return fieldColumnName(
+        type.fields.find(field => field.name === type.idFieldName),
+        namingStyle);
+}
+
 function typeName2tableName(typeName, namingStyle) {
     const nameStem = typeName.split('.').pop();
     // Reuse the function for converting field names to column names (we'll
@@ -323,6 +1172,6 @@ function fieldName2columnName(fieldName, namingStyle) {
     return names.normalize(fieldName, namingStyle);
 }
 
-return {types2tables};
+return {types2tables, outboxTableName};
 
 });
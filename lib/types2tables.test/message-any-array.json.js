@@ -0,0 +1,14 @@
+// An array of google.protobuf.Any is not supported, because the value column
+// of an array table can't hold both the type URL and the serialized message.
+[
+    {
+        kind: 'message',
+        name: '.shop.Inbox',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_INT64'}},
+            {id: 2, name: 'messages',
+             type: {array: {builtin: '.google.protobuf.Any'}}}
+        ]
+    }
+]
@@ -0,0 +1,37 @@
+// a message type with a singular and a repeated field of another message
+// type, which itself has an enum field. The other message type has no ID,
+// because it's stored only in the child tables of the first.
+[
+    {
+        kind: 'enum',
+        name: '.shop.Color',
+        values: [
+            {id: 0, name: 'UNKNOWN'},
+            {id: 1, name: 'RED'},
+            {id: 2, name: 'BLUE'}
+        ]
+    },
+
+    {
+        kind: 'message',
+        name: '.shop.Item',
+        fields: [
+            {id: 1, name: 'sku', type: {builtin: 'TYPE_STRING'}},
+            {id: 2, name: 'quantity', type: {builtin: 'TYPE_UINT32'},
+             description: 'how many of them'},
+            {id: 3, name: 'color', type: {enum: '.shop.Color'}}
+        ]
+    },
+
+    {
+        kind: 'message',
+        name: '.shop.Order',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_INT64'}},
+            {id: 2, name: 'giftWrap', type: {message: '.shop.Item'}},
+            {id: 3, name: 'items', type: {array: {message: '.shop.Item'}},
+             description: 'what was ordered'}
+        ]
+    }
+]
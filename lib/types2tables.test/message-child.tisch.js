@@ -0,0 +1,97 @@
+({
+    tables: {
+        'color': {
+            name: 'color',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT32', nullable: false},
+                {name: 'name', type: 'name', nullable: false},
+                {name: 'description', type: 'TYPE_STRING', nullable: true}
+            ],
+            rows: [
+                [0, 'UNKNOWN', null],
+                [1, 'RED', null],
+                [2, 'BLUE', null]
+            ]
+        },
+        // There's no "item" table, because `Item` has no ID.
+        'order': {
+            name: 'order',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false}
+            ]
+        },
+        'order_gift_wrap': {
+            name: 'order_gift_wrap',
+            // singular, so there's no "ordinality"
+            primaryKey: ['parent_id'],
+            columns: [
+                {name: 'parent_id',
+                 type: 'TYPE_INT64',
+                 nullable: false,
+                 foreignKey: {table: 'order', column: 'id'},
+                 description: String},
+                {name: 'sku', type: 'TYPE_STRING', nullable: true},
+                {name: 'quantity',
+                 type: 'TYPE_UINT32',
+                 nullable: true,
+                 description: 'how many of them'},
+                {name: 'color',
+                 type: 'TYPE_INT32',
+                 nullable: true,
+                 foreignKey: {table: 'color', column: 'id'}}
+            ]
+        },
+        'order_items': {
+            name: 'order_items',
+            primaryKey: ['parent_id', 'ordinality'],
+            columns: [
+                {name: 'parent_id',
+                 type: 'TYPE_INT64',
+                 nullable: false,
+                 foreignKey: {table: 'order', column: 'id'},
+                 description: String},
+                {name: 'ordinality',
+                 type: 'TYPE_UINT32',
+                 nullable: false,
+                 description: String},
+                {name: 'sku', type: 'TYPE_STRING', nullable: true},
+                {name: 'quantity',
+                 type: 'TYPE_UINT32',
+                 nullable: true,
+                 description: 'how many of them'},
+                {name: 'color',
+                 type: 'TYPE_INT32',
+                 nullable: true,
+                 foreignKey: {table: 'color', column: 'id'}}
+            ],
+            description: 'what was ordered'
+        }
+    },
+    legends: {
+        '.shop.Order': {
+            messageTypeName: '.shop.Order',
+            tableName: 'order',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'giftWrap',
+                 tableName: 'order_gift_wrap',
+                 messageTypeName: '.shop.Item',
+                 fieldSources: [
+                     {fieldName: 'sku', columnName: 'sku'},
+                     {fieldName: 'quantity', columnName: 'quantity'},
+                     {fieldName: 'color', columnName: 'color'}
+                 ]},
+                {fieldName: 'items',
+                 tableName: 'order_items',
+                 messageTypeName: '.shop.Item',
+                 fieldSources: [
+                     {fieldName: 'sku', columnName: 'sku'},
+                     {fieldName: 'quantity', columnName: 'quantity'},
+                     {fieldName: 'color', columnName: 'color'}
+                 ]}
+            ]
+        }
+    }
+})
@@ -0,0 +1,31 @@
+// This will fail because only one level of message nesting is supported. The
+// `Lace` message type is the type of a field of `Shoe`, which itself is the
+// type of a field of `Outfit`.
+[
+    {
+        kind: 'message',
+        name: '.clothing.Lace',
+        fields: [
+            {id: 1, name: 'length', type: {builtin: 'TYPE_DOUBLE'}}
+        ]
+    },
+
+    {
+        kind: 'message',
+        name: '.clothing.Shoe',
+        fields: [
+            {id: 1, name: 'size', type: {builtin: 'TYPE_UINT32'}},
+            {id: 2, name: 'lace', type: {message: '.clothing.Lace'}}
+        ]
+    },
+
+    {
+        kind: 'message',
+        name: '.clothing.Outfit',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}},
+            {id: 2, name: 'shoes', type: {array: {message: '.clothing.Shoe'}}}
+        ]
+    }
+]
@@ -0,0 +1,17 @@
+// The "history" field's array table would have the same name as the history
+// table.
+({
+    options: {history: true},
+    types: [
+        {
+            kind: 'message',
+            name: '.scouts.BoyScout',
+            idFieldName: 'id',
+            fields: [
+                {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}},
+                {id: 2, name: 'history',
+                 type: {array: {builtin: 'TYPE_STRING'}}}
+            ]
+        }
+    ]
+})
@@ -0,0 +1,15 @@
+// a message type whose changes are recorded in a history table
+({
+    options: {history: true},
+    types: [
+        {
+            kind: 'message',
+            name: '.scouts.BoyScout',
+            idFieldName: 'id',
+            fields: [
+                {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}},
+                {id: 2, name: 'name', type: {builtin: 'TYPE_STRING'}}
+            ]
+        }
+    ]
+})
@@ -0,0 +1,42 @@
+({
+    tables: {
+        'boy_scout': {
+            name: 'boy_scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'name', nullable: false},
+                {name: 'name', type: 'TYPE_STRING', nullable: true}
+            ]
+        },
+        // The history table has no foreign key to the message table.
+        'boy_scout_history': {
+            name: 'boy_scout_history',
+            primaryKey: ['id', 'ordinality'],
+            columns: [
+                {name: 'id', type: 'name', nullable: false,
+                 description: String},
+                {name: 'ordinality', type: 'TYPE_UINT32', nullable: false,
+                 description: String},
+                {name: 'recorded_at', type: '.google.protobuf.Timestamp',
+                 nullable: false, description: String},
+                {name: 'operation', type: 'name', nullable: false,
+                 description: String},
+                {name: 'actor', type: 'TYPE_STRING', nullable: true,
+                 description: String},
+                {name: 'message', type: 'TYPE_BYTES', nullable: true,
+                 description: String}
+            ]
+        }
+    },
+    legends: {
+        '.scouts.BoyScout': {
+            messageTypeName: '.scouts.BoyScout',
+            tableName: 'boy_scout',
+            historyTableName: 'boy_scout_history',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'name', columnName: 'name'}
+            ]
+        }
+    }
+})
@@ -0,0 +1,25 @@
+// a message type that has a message field and an array of messages, both
+// stored as JSON rather than in child tables. The JSON message type has no ID,
+// and so has no table of its own.
+[
+    {
+        kind: 'message',
+        name: '.kitchen.Recipe',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_INT64'}},
+            {id: 2, name: 'main', type: {json: '.kitchen.Step'},
+             description: 'the step that matters'},
+            {id: 3, name: 'steps', type: {array: {json: '.kitchen.Step'}}}
+        ]
+    },
+
+    {
+        kind: 'message',
+        name: '.kitchen.Step',
+        fields: [
+            {id: 1, name: 'instruction', type: {builtin: 'TYPE_STRING'}},
+            {id: 2, name: 'minutes', type: {builtin: 'TYPE_UINT32'}}
+        ]
+    }
+]
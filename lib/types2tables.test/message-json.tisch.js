@@ -0,0 +1,45 @@
+({
+    tables: {
+        'recipe': {
+            name: 'recipe',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'main',
+                 type: 'json',
+                 nullable: true,
+                 description: 'the step that matters'}
+            ]
+        },
+        'recipe_steps': {
+            name: 'recipe_steps',
+            primaryKey: ['id', 'ordinality'],
+            columns: [
+                {name: 'id',
+                 type: 'TYPE_INT64',
+                 nullable: false,
+                 foreignKey: {table: 'recipe', column: 'id'},
+                 description: 'id of the relevant .kitchen.Recipe'},
+                {name: 'ordinality',
+                 type: 'TYPE_UINT32',
+                 nullable: false,
+                 description: 'zero-based position within the array'},
+                {name: 'value',
+                 type: 'json',
+                 nullable: true,
+                 description: 'one of the steps in some .kitchen.Recipe'}
+            ]
+        }
+    },
+    legends: {
+        '.kitchen.Recipe': {
+            messageTypeName: '.kitchen.Recipe',
+            tableName: 'recipe',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'main', columnName: 'main'},
+                {fieldName: 'steps', tableName: 'recipe_steps'}
+            ]
+        }
+    }
+})
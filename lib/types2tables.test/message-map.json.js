@@ -0,0 +1,30 @@
+// a message type that has map fields: one with scalar values, and one with
+// enum values. Thus we have two types: the enum, and the message that has the
+// maps.
+[
+    {
+        kind: 'enum',
+        name: '.zoo.Diet',
+        values: [
+            {id: 0, name: 'UNKNOWN'},
+            {id: 1, name: 'HERBIVORE'},
+            {id: 2, name: 'CARNIVORE'}
+        ]
+    },
+
+    {
+        kind: 'message',
+        name: '.zoo.Enclosure',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_INT64'}},
+            {id: 2, name: 'headcounts',
+             type: {map: {key: {builtin: 'TYPE_STRING'},
+                          value: {builtin: 'TYPE_UINT32'}}},
+             description: 'number of animals by species'},
+            {id: 3, name: 'diets',
+             type: {map: {key: {builtin: 'TYPE_INT32'},
+                          value: {enum: '.zoo.Diet'}}}}
+        ]
+    }
+]
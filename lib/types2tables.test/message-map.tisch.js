@@ -0,0 +1,76 @@
+({
+    tables: {
+        'diet': {
+            name: 'diet',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT32', nullable: false},
+                {name: 'name', type: 'name', nullable: false},
+                {name: 'description', type: 'TYPE_STRING', nullable: true}
+            ],
+            rows: [
+                [0, 'UNKNOWN', null],
+                [1, 'HERBIVORE', null],
+                [2, 'CARNIVORE', null]
+            ]
+        },
+        'enclosure': {
+            name: 'enclosure',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false}
+            ]
+        },
+        'enclosure_headcounts': {
+            name: 'enclosure_headcounts',
+            primaryKey: ['id', 'key'],
+            columns: [
+                {name: 'id',
+                 type: 'TYPE_INT64',
+                 nullable: false,
+                 foreignKey: {table: 'enclosure', column: 'id'},
+                 description: 'id of the relevant .zoo.Enclosure'},
+                {name: 'key',
+                 type: 'name', // fixed-size string (e.g. varchar(255))
+                 nullable: false,
+                 description: 'key within the map'},
+                {name: 'value',
+                 type: 'TYPE_UINT32',
+                 nullable: true,
+                 description: 'one of the headcounts in some .zoo.Enclosure'}
+            ],
+            description: 'number of animals by species'
+        },
+        'enclosure_diets': {
+            name: 'enclosure_diets',
+            primaryKey: ['id', 'key'],
+            columns: [
+                {name: 'id',
+                 type: 'TYPE_INT64',
+                 nullable: false,
+                 foreignKey: {table: 'enclosure', column: 'id'},
+                 description: 'id of the relevant .zoo.Enclosure'},
+                {name: 'key',
+                 type: 'TYPE_INT32',
+                 nullable: false,
+                 description: 'key within the map'},
+                {name: 'value',
+                 type: 'TYPE_INT32',
+                 nullable: true,
+                 foreignKey: {table: 'diet', column: 'id'},
+                 description: 'one of the diets in some .zoo.Enclosure'}
+            ]
+        }
+    },
+    legends: {
+        '.zoo.Enclosure': {
+            messageTypeName: '.zoo.Enclosure',
+            tableName: 'enclosure',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'headcounts', tableName: 'enclosure_headcounts'},
+                {fieldName: 'diets', tableName: 'enclosure_diets'}
+            ]
+        }
+    }
+})
@@ -0,0 +1,26 @@
+// a message type that has a oneof, whose members are each stored in their own
+// nullable column, followed by a column that says which member is set.
+[
+    {
+        kind: 'message',
+        name: '.shop.Payment',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_INT64'}},
+            {id: 2, name: 'card_number', type: {builtin: 'TYPE_STRING'},
+             oneof: 'method'},
+            {id: 3, name: 'wallet', type: {enum: '.shop.Wallet'},
+             oneof: 'method'},
+            {id: 4, name: 'amount', type: {builtin: 'TYPE_INT64'}}
+        ]
+    },
+
+    {
+        kind: 'enum',
+        name: '.shop.Wallet',
+        values: [
+            {id: 0, name: 'UNKNOWN'},
+            {id: 1, name: 'PHONE'}
+        ]
+    }
+]
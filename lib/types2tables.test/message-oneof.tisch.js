@@ -0,0 +1,49 @@
+({
+    tables: {
+        'payment': {
+            name: 'payment',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'card_number', type: 'TYPE_STRING', nullable: true},
+                {name: 'wallet',
+                 type: 'TYPE_INT32',
+                 nullable: true,
+                 foreignKey: {table: 'wallet', column: 'id'}},
+                {name: 'method',
+                 type: 'name',
+                 nullable: true,
+                 description: 'name of the field set in the oneof method'},
+                {name: 'amount', type: 'TYPE_INT64', nullable: true}
+            ]
+        },
+        'wallet': {
+            name: 'wallet',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT32', nullable: false},
+                {name: 'name', type: 'name', nullable: false},
+                {name: 'description', type: 'TYPE_STRING', nullable: true}
+            ],
+            rows: [
+                [0, 'UNKNOWN', null],
+                [1, 'PHONE', null]
+            ]
+        }
+    },
+    legends: {
+        '.shop.Payment': {
+            messageTypeName: '.shop.Payment',
+            tableName: 'payment',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'card_number',
+                 columnName: 'card_number',
+                 oneofName: 'method'},
+                {fieldName: 'wallet', columnName: 'wallet', oneofName: 'method'},
+                {oneofName: 'method', columnName: 'method'},
+                {fieldName: 'amount', columnName: 'amount'}
+            ]
+        }
+    }
+})
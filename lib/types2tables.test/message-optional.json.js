@@ -0,0 +1,28 @@
+// a message type that has proto3 `optional` fields and wrapper-typed fields,
+// each of which is stored in the same kind of column as its plain
+// counterpart.
+[
+    {
+        kind: 'message',
+        name: '.shop.Coupon',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_INT64'}},
+            {id: 2, name: 'code', type: {optional: {builtin: 'TYPE_STRING'}}},
+            {id: 3, name: 'tier', type: {optional: {enum: '.shop.Tier'}}},
+            {id: 4, name: 'discount',
+             type: {builtin: '.google.protobuf.DoubleValue'}},
+            {id: 5, name: 'stackable',
+             type: {builtin: '.google.protobuf.BoolValue'}}
+        ]
+    },
+
+    {
+        kind: 'enum',
+        name: '.shop.Tier',
+        values: [
+            {id: 0, name: 'UNKNOWN'},
+            {id: 1, name: 'GOLD'}
+        ]
+    }
+]
@@ -0,0 +1,44 @@
+({
+    tables: {
+        'coupon': {
+            name: 'coupon',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'code', type: 'TYPE_STRING', nullable: true},
+                {name: 'tier',
+                 type: 'TYPE_INT32',
+                 nullable: true,
+                 foreignKey: {table: 'tier', column: 'id'}},
+                {name: 'discount', type: 'TYPE_DOUBLE', nullable: true},
+                {name: 'stackable', type: 'TYPE_BOOL', nullable: true}
+            ]
+        },
+        'tier': {
+            name: 'tier',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT32', nullable: false},
+                {name: 'name', type: 'name', nullable: false},
+                {name: 'description', type: 'TYPE_STRING', nullable: true}
+            ],
+            rows: [
+                [0, 'UNKNOWN', null],
+                [1, 'GOLD', null]
+            ]
+        }
+    },
+    legends: {
+        '.shop.Coupon': {
+            messageTypeName: '.shop.Coupon',
+            tableName: 'coupon',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'code', columnName: 'code'},
+                {fieldName: 'tier', columnName: 'tier'},
+                {fieldName: 'discount', columnName: 'discount'},
+                {fieldName: 'stackable', columnName: 'stackable'}
+            ]
+        }
+    }
+})
@@ -0,0 +1,33 @@
+// a message type that uses the okra options (see `okra/options.proto`) to
+// name its table and columns, to limit the length of strings, and to index
+// columns.
+[
+    {
+        kind: 'message',
+        name: '.scouts.BoyScout',
+        tableName: 'scout',
+        idFieldName: 'uuid',
+        fields: [
+            {id: 1, name: 'uuid', type: {builtin: 'TYPE_STRING'},
+             maxLength: 36},
+            {id: 2, name: 'full_name', type: {builtin: 'TYPE_STRING'},
+             columnName: 'name', maxLength: 200},
+            {id: 3, name: 'country_code', type: {builtin: 'TYPE_STRING'},
+             indexed: true},
+            {id: 4, name: 'favorite_songs',
+             type: {array: {builtin: 'TYPE_STRING'}}, columnName: 'songs'},
+            {id: 5, name: 'den', type: {message: '.scouts.Den'}}
+        ]
+    },
+
+    {
+        kind: 'message',
+        name: '.scouts.Den',
+        fields: [
+            {id: 1, name: 'number', type: {builtin: 'TYPE_UINT32'},
+             indexed: true},
+            {id: 2, name: 'leader', type: {builtin: 'TYPE_STRING'},
+             columnName: 'leader_name'}
+        ]
+    }
+]
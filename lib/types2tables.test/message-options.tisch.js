@@ -0,0 +1,69 @@
+({
+    tables: {
+        'scout': {
+            name: 'scout',
+            primaryKey: ['uuid'],
+            columns: [
+                {name: 'uuid', type: 'name', maxLength: 36, nullable: false},
+                {name: 'name', type: 'TYPE_STRING', maxLength: 200,
+                 nullable: true},
+                {name: 'country_code', type: 'TYPE_STRING', nullable: true}
+            ],
+            indices: [
+                {columns: ['country_code']}
+            ]
+        },
+        'scout_songs': {
+            name: 'scout_songs',
+            primaryKey: ['id', 'ordinality'],
+            columns: [
+                {name: 'id',
+                 type: 'name',
+                 maxLength: 36,
+                 nullable: false,
+                 foreignKey: {table: 'scout', column: 'uuid'},
+                 description: String},
+                {name: 'ordinality', type: 'TYPE_UINT32', nullable: false,
+                 description: String},
+                {name: 'value', type: 'TYPE_STRING', nullable: true,
+                 description: String}
+            ]
+        },
+        'scout_den': {
+            name: 'scout_den',
+            primaryKey: ['parent_id'],
+            columns: [
+                {name: 'parent_id',
+                 type: 'name',
+                 maxLength: 36,
+                 nullable: false,
+                 foreignKey: {table: 'scout', column: 'uuid'},
+                 description: String},
+                {name: 'number', type: 'TYPE_UINT32', nullable: true},
+                {name: 'leader_name', type: 'TYPE_STRING', nullable: true}
+            ],
+            indices: [
+                {columns: ['number']}
+            ]
+        }
+    },
+    legends: {
+        '.scouts.BoyScout': {
+            messageTypeName: '.scouts.BoyScout',
+            tableName: 'scout',
+            fieldSources: [
+                {fieldName: 'uuid', columnName: 'uuid'},
+                {fieldName: 'full_name', columnName: 'name'},
+                {fieldName: 'country_code', columnName: 'country_code'},
+                {fieldName: 'favorite_songs', tableName: 'scout_songs'},
+                {fieldName: 'den',
+                 tableName: 'scout_den',
+                 messageTypeName: '.scouts.Den',
+                 fieldSources: [
+                     {fieldName: 'number', columnName: 'number'},
+                     {fieldName: 'leader', columnName: 'leader_name'}
+                 ]}
+            ]
+        }
+    }
+})
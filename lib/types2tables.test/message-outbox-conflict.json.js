@@ -0,0 +1,15 @@
+// The "OkraOutbox" message's table would have the same name as the outbox
+// table.
+({
+    options: {outbox: true},
+    types: [
+        {
+            kind: 'message',
+            name: '.scouts.OkraOutbox',
+            idFieldName: 'id',
+            fields: [
+                {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}}
+            ]
+        }
+    ]
+})
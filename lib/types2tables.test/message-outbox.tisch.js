@@ -0,0 +1,50 @@
+({
+    tables: {
+        'boy_scout': {
+            name: 'boy_scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'name', nullable: false},
+                {name: 'name', type: 'TYPE_STRING', nullable: true}
+            ]
+        },
+        // There is one outbox table, shared by all message types.
+        'okra_outbox': {
+            name: 'okra_outbox',
+            description: String,
+            primaryKey: ['sequence'],
+            columns: [
+                {name: 'sequence', type: 'TYPE_INT64', nullable: false,
+                 autoIncrement: true, description: String},
+                {name: 'type_name', type: 'name', nullable: false,
+                 description: String},
+                {name: 'message_id', type: 'TYPE_STRING', nullable: false,
+                 description: String},
+                {name: 'operation', type: 'name', nullable: false,
+                 description: String},
+                {name: 'field_mask', type: 'TYPE_STRING', nullable: true,
+                 description: String},
+                {name: 'message', type: 'TYPE_BYTES', nullable: true,
+                 description: String},
+                {name: 'recorded_at', type: '.google.protobuf.Timestamp',
+                 nullable: false, description: String},
+                {name: 'claim', type: 'name', nullable: true,
+                 description: String},
+                {name: 'claimed_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, description: String}
+            ],
+            indices: [{columns: ['claim']}]
+        }
+    },
+    legends: {
+        '.scouts.BoyScout': {
+            messageTypeName: '.scouts.BoyScout',
+            tableName: 'boy_scout',
+            outboxTableName: 'okra_outbox',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'name', columnName: 'name'}
+            ]
+        }
+    }
+})
@@ -0,0 +1,19 @@
+// a soft-deleted message type, whose table has a column marking deleted rows
+// after its timestamp columns
+({
+    options: {timestamps: true},
+    types: [
+        {
+            kind: 'message',
+            name: '.scouts.BoyScout',
+            idFieldName: 'id',
+            softDelete: true,
+            fields: [
+                {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}},
+                {id: 2, name: 'name', type: {builtin: 'TYPE_STRING'}},
+                {id: 3, name: 'badges',
+                 type: {array: {builtin: 'TYPE_STRING'}}}
+            ]
+        }
+    ]
+})
@@ -0,0 +1,46 @@
+({
+    tables: {
+        'boy_scout': {
+            name: 'boy_scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'name', nullable: false},
+                {name: 'name', type: 'TYPE_STRING', nullable: true},
+                {name: 'created_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, timestamp: 'created', description: String},
+                {name: 'updated_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, timestamp: 'updated', description: String},
+                {name: 'deleted_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, timestamp: 'deleted', description: String}
+            ]
+        },
+        // The array table doesn't have a deleted column. Its rows are kept
+        // when the message is deleted.
+        'boy_scout_badges': {
+            name: 'boy_scout_badges',
+            primaryKey: ['id', 'ordinality'],
+            columns: [
+                {name: 'id', type: 'name', nullable: false,
+                 foreignKey: {table: 'boy_scout', column: 'id'},
+                 description: String},
+                {name: 'ordinality', type: 'TYPE_UINT32', nullable: false,
+                 description: String},
+                {name: 'value', type: 'TYPE_STRING', nullable: true,
+                 description: String}
+            ]
+        }
+    },
+    legends: {
+        '.scouts.BoyScout': {
+            messageTypeName: '.scouts.BoyScout',
+            tableName: 'boy_scout',
+            timestamps: {created: 'created_at', updated: 'updated_at'},
+            deletedColumn: 'deleted_at',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'name', columnName: 'name'},
+                {fieldName: 'badges', tableName: 'boy_scout_badges'}
+            ]
+        }
+    }
+})
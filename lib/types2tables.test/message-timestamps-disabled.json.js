@@ -0,0 +1,15 @@
+// A field can be populated from a timestamp column only if the tables have
+// timestamp columns.
+[
+    {
+        kind: 'message',
+        name: '.scouts.BoyScout',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}},
+            {id: 2, name: 'join_time',
+             type: {builtin: '.google.protobuf.Timestamp'},
+             timestamp: 'created'}
+        ]
+    }
+]
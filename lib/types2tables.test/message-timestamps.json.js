@@ -0,0 +1,21 @@
+// a message type whose table has timestamp columns, one of which populates
+// a field of the message
+({
+    options: {timestamps: true},
+    types: [
+        {
+            kind: 'message',
+            name: '.scouts.BoyScout',
+            idFieldName: 'id',
+            fields: [
+                {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}},
+                {id: 2, name: 'name', type: {builtin: 'TYPE_STRING'}},
+                {id: 3, name: 'join_time',
+                 type: {builtin: '.google.protobuf.Timestamp'},
+                 timestamp: 'created', indexed: true},
+                {id: 4, name: 'badges',
+                 type: {array: {builtin: 'TYPE_STRING'}}}
+            ]
+        }
+    ]
+})
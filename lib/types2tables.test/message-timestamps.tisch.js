@@ -0,0 +1,47 @@
+({
+    tables: {
+        'boy_scout': {
+            name: 'boy_scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'name', nullable: false},
+                {name: 'name', type: 'TYPE_STRING', nullable: true},
+                {name: 'created_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, timestamp: 'created', description: String},
+                {name: 'updated_at', type: '.google.protobuf.Timestamp',
+                 nullable: true, timestamp: 'updated', description: String}
+            ],
+            indices: [
+                {columns: ['created_at']}
+            ]
+        },
+        // The array table doesn't have timestamp columns.
+        'boy_scout_badges': {
+            name: 'boy_scout_badges',
+            primaryKey: ['id', 'ordinality'],
+            columns: [
+                {name: 'id', type: 'name', nullable: false,
+                 foreignKey: {table: 'boy_scout', column: 'id'},
+                 description: String},
+                {name: 'ordinality', type: 'TYPE_UINT32', nullable: false,
+                 description: String},
+                {name: 'value', type: 'TYPE_STRING', nullable: true,
+                 description: String}
+            ]
+        }
+    },
+    legends: {
+        '.scouts.BoyScout': {
+            messageTypeName: '.scouts.BoyScout',
+            tableName: 'boy_scout',
+            timestamps: {created: 'created_at', updated: 'updated_at'},
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'name', columnName: 'name'},
+                {fieldName: 'join_time', columnName: 'created_at',
+                 timestamp: 'created'},
+                {fieldName: 'badges', tableName: 'boy_scout_badges'}
+            ]
+        }
+    }
+})
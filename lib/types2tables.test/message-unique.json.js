@@ -0,0 +1,26 @@
+// a message type having a unique field, an indexed field, and a child
+// message whose field is unique
+[
+    {
+        kind: 'message',
+        name: '.scouts.BoyScout',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}},
+            {id: 2, name: 'email', type: {builtin: 'TYPE_STRING'},
+             unique: true},
+            {id: 3, name: 'country_code', type: {builtin: 'TYPE_STRING'},
+             indexed: true},
+            {id: 4, name: 'badge', type: {message: '.scouts.Badge'}}
+        ]
+    },
+
+    {
+        kind: 'message',
+        name: '.scouts.Badge',
+        fields: [
+            {id: 1, name: 'serial', type: {builtin: 'TYPE_UINT64'},
+             unique: true, indexed: true}
+        ]
+    }
+]
@@ -0,0 +1,49 @@
+({
+    tables: {
+        'boy_scout': {
+            name: 'boy_scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'name', nullable: false},
+                {name: 'email', type: 'TYPE_STRING', nullable: true},
+                {name: 'country_code', type: 'TYPE_STRING', nullable: true}
+            ],
+            indices: [
+                {columns: ['email'], unique: true},
+                {columns: ['country_code']}
+            ]
+        },
+        'boy_scout_badge': {
+            name: 'boy_scout_badge',
+            primaryKey: ['parent_id'],
+            columns: [
+                {name: 'parent_id',
+                 type: 'name',
+                 nullable: false,
+                 foreignKey: {table: 'boy_scout', column: 'id'},
+                 description: String},
+                {name: 'serial', type: 'TYPE_UINT64', nullable: true}
+            ],
+            indices: [
+                {columns: ['serial'], unique: true}
+            ]
+        }
+    },
+    legends: {
+        '.scouts.BoyScout': {
+            messageTypeName: '.scouts.BoyScout',
+            tableName: 'boy_scout',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'email', columnName: 'email'},
+                {fieldName: 'country_code', columnName: 'country_code'},
+                {fieldName: 'badge',
+                 tableName: 'boy_scout_badge',
+                 messageTypeName: '.scouts.Badge',
+                 fieldSources: [
+                     {fieldName: 'serial', columnName: 'serial'}
+                 ]}
+            ]
+        }
+    }
+})
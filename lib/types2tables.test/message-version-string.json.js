@@ -0,0 +1,13 @@
+// A version field must be an integer, because updates increment it.
+[
+    {
+        kind: 'message',
+        name: '.scouts.BoyScout',
+        idFieldName: 'id',
+        versionFieldName: 'revision',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}},
+            {id: 2, name: 'revision', type: {builtin: 'TYPE_STRING'}}
+        ]
+    }
+]
@@ -0,0 +1,15 @@
+// a message type having a version field (see the `(okra.version)` option),
+// which is stored in an ordinary column
+[
+    {
+        kind: 'message',
+        name: '.scouts.BoyScout',
+        idFieldName: 'id',
+        versionFieldName: 'revision',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_STRING'}},
+            {id: 2, name: 'name', type: {builtin: 'TYPE_STRING'}},
+            {id: 3, name: 'revision', type: {builtin: 'TYPE_INT64'}}
+        ]
+    }
+]
@@ -0,0 +1,24 @@
+({
+    tables: {
+        'boy_scout': {
+            name: 'boy_scout',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'name', nullable: false},
+                {name: 'name', type: 'TYPE_STRING', nullable: true},
+                {name: 'revision', type: 'TYPE_INT64', nullable: true}
+            ]
+        }
+    },
+    legends: {
+        '.scouts.BoyScout': {
+            messageTypeName: '.scouts.BoyScout',
+            tableName: 'boy_scout',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'name', columnName: 'name'},
+                {fieldName: 'revision', columnName: 'revision'}
+            ]
+        }
+    }
+})
@@ -0,0 +1,24 @@
+// a message type that has fields of some of the "well-known" types: a
+// Duration is stored as a number of microseconds, a Struct as JSON, and a
+// TimeOfDay as a time. An Any is stored in two columns, one for the type URL
+// and one for the serialized message. Similarly, a LatLng has a column for
+// each coordinate, and a Money has a column for the currency code and a
+// column for the (decimal) amount.
+[
+    {
+        kind: 'message',
+        name: '.shop.Job',
+        idFieldName: 'id',
+        fields: [
+            {id: 1, name: 'id', type: {builtin: 'TYPE_INT64'}},
+            {id: 2, name: 'timeout',
+             type: {builtin: '.google.protobuf.Duration'}},
+            {id: 3, name: 'settings',
+             type: {builtin: '.google.protobuf.Struct'}},
+            {id: 4, name: 'payload', type: {builtin: '.google.protobuf.Any'}},
+            {id: 5, name: 'location', type: {builtin: '.google.type.LatLng'}},
+            {id: 6, name: 'price', type: {builtin: '.google.type.Money'}},
+            {id: 7, name: 'start', type: {builtin: '.google.type.TimeOfDay'}}
+        ]
+    }
+]
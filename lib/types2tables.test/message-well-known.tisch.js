@@ -0,0 +1,71 @@
+({
+    tables: {
+        'job': {
+            name: 'job',
+            primaryKey: ['id'],
+            columns: [
+                {name: 'id', type: 'TYPE_INT64', nullable: false},
+                {name: 'timeout',
+                 type: '.google.protobuf.Duration',
+                 nullable: true},
+                {name: 'settings',
+                 type: '.google.protobuf.Struct',
+                 nullable: true},
+                {name: 'payload_type_url', type: 'TYPE_STRING', nullable: true},
+                {name: 'payload_value',
+                 type: 'TYPE_BYTES',
+                 nullable: true,
+                 description: 'serialized value of the google.protobuf.Any payload'},
+                {name: 'location_latitude',
+                 type: 'TYPE_DOUBLE',
+                 nullable: true,
+                 description: 'latitude in degrees of location'},
+                {name: 'location_longitude',
+                 type: 'TYPE_DOUBLE',
+                 nullable: true,
+                 description: 'longitude in degrees of location'},
+                {name: 'price_currency_code',
+                 type: 'currency',
+                 nullable: true,
+                 description: 'currency code of price'},
+                {name: 'price_amount',
+                 type: 'decimal',
+                 nullable: true,
+                 description: 'amount of price'},
+                {name: 'start',
+                 type: '.google.type.TimeOfDay',
+                 nullable: true}
+            ]
+        }
+    },
+    legends: {
+        '.shop.Job': {
+            messageTypeName: '.shop.Job',
+            tableName: 'job',
+            fieldSources: [
+                {fieldName: 'id', columnName: 'id'},
+                {fieldName: 'timeout', columnName: 'timeout'},
+                {fieldName: 'settings', columnName: 'settings'},
+                {fieldName: 'payload',
+                 columnName: 'payload_type_url',
+                 part: 'type_url'},
+                {fieldName: 'payload',
+                 columnName: 'payload_value',
+                 part: 'value'},
+                {fieldName: 'location',
+                 columnName: 'location_latitude',
+                 part: 'latitude'},
+                {fieldName: 'location',
+                 columnName: 'location_longitude',
+                 part: 'longitude'},
+                {fieldName: 'price',
+                 columnName: 'price_currency_code',
+                 part: 'currency_code'},
+                {fieldName: 'price',
+                 columnName: 'price_amount',
+                 part: 'amount'},
+                {fieldName: 'start', columnName: 'start'}
+            ]
+        }
+    }
+})
@@ -14,7 +14,9 @@ const tisch = require('../../dependencies/tisch/tisch');
 const {glob, exists} = require('../filesystem');
 
 // Here's how this test driver works:
-// - Each *.json.js file is an input (an array of type definitions).
+// - Each *.json.js file is an input (an array of type definitions, or an
+//   object `{types, options}` of type definitions and options to pass to
+//   `types2tables`).
 // - If there's a corresponding *.tisch.js file, then the input is expected to
 //   be valid, and the output is expected to match the schema.
 // - If there's no corresponding *.tisch.js file, then the input is expected
@@ -28,14 +30,15 @@ const typeArrayFiles = glob(path.join(__dirname, '*.json.js')); // test inputs
 typeArrayFiles.forEach(inputPath => {
     const stem = path.basename(inputPath, '.json.js');
     const schemaPath = path.join(__dirname, stem + '.tisch.js');
-    const types = vm.runInNewContext(
+    const input = vm.runInNewContext(
         fs.readFileSync(inputPath, {encoding: 'utf8'}));
+    const {types, options} = Array.isArray(input) ? {types: input} : input;
 
     if (exists(schemaPath)) {
-        assertResult(inputPath, types, schemaPath);
+        assertResult(inputPath, types, options, schemaPath);
     }
     else {
-        assertFailure(inputPath, types);
+        assertFailure(inputPath, types, options);
     }
 })
 
@@ -43,10 +46,10 @@ typeArrayFiles.forEach(inputPath => {
 // test failed.
 console.log(`All ${typeArrayFiles.length} tests passed.`);
 
-function assertFailure(typesPath, types) {
+function assertFailure(typesPath, types, options) {
     let result;
     try {
-        result = types2tables(types);
+        result = types2tables(types, options);
     }
     catch (error) {
         if (verbose) {
@@ -63,11 +66,11 @@ function assertFailure(typesPath, types) {
                 `succeeded with the result: ${pretty(result)}`);
 }
 
-function assertResult(typesPath, types, schemaPath) {
+function assertResult(typesPath, types, options, schemaPath) {
     const validate = tisch.compileFile(schemaPath);
     let result;
     try {
-        result = types2tables(types);
+        result = types2tables(types, options);
     }
     catch (error) {
         console.error(`Test case ${typesPath} failed. An exception was thrown.`);
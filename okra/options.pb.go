@@ -0,0 +1,277 @@
+// Custom options for describing how a protobuf message is stored in the
+// database, right there in the .proto file. For example:
+//
+//     import "okra/options.proto";
+//
+//     message BoyScout {
+//         option (okra.table_name) = "scout";
+//         option (okra.soft_delete) = true;
+//
+//         string uuid = 1 [(okra.id) = true];
+//         string full_name = 2 [(okra.column_name) = "name",
+//                               (okra.max_length) = 200];
+//         string country_code = 3 [(okra.index) = true];
+//         string nickname = 4 [(okra.ignore) = true];
+//         int64 revision = 5 [(okra.version) = true];
+//     }
+//
+// okra adds the root of its repository to the protobuf include path, so
+// `import "okra/options.proto";` works without any additional `--proto_path`.
+//
+// Go code generated from a file that imports this one imports the Go package
+// generated from this file, `options.pb.go` in this directory.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: okra/options.proto
+
+package okra
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_okra_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         52100,
+		Name:          "okra.table_name",
+		Tag:           "bytes,52100,opt,name=table_name",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         52110,
+		Name:          "okra.soft_delete",
+		Tag:           "varint,52110,opt,name=soft_delete",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         52101,
+		Name:          "okra.id",
+		Tag:           "varint,52101,opt,name=id",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         52102,
+		Name:          "okra.column_name",
+		Tag:           "bytes,52102,opt,name=column_name",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         52103,
+		Name:          "okra.ignore",
+		Tag:           "varint,52103,opt,name=ignore",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*uint32)(nil),
+		Field:         52104,
+		Name:          "okra.max_length",
+		Tag:           "varint,52104,opt,name=max_length",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         52105,
+		Name:          "okra.index",
+		Tag:           "varint,52105,opt,name=index",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         52106,
+		Name:          "okra.unique",
+		Tag:           "varint,52106,opt,name=unique",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         52107,
+		Name:          "okra.version",
+		Tag:           "varint,52107,opt,name=version",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         52108,
+		Name:          "okra.created_at",
+		Tag:           "varint,52108,opt,name=created_at",
+		Filename:      "okra/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         52109,
+		Name:          "okra.updated_at",
+		Tag:           "varint,52109,opt,name=updated_at",
+		Filename:      "okra/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// name of the table in which the message is stored, instead of one
+	// derived from the name of the message type. Tables for the message's
+	// repeated, map, and message-valued fields are named after this name.
+	//
+	// optional string table_name = 52100;
+	E_TableName = &file_okra_options_proto_extTypes[0]
+	// whether deleting a message only marks it as deleted, by setting the
+	// `deleted_at` column of its table, rather than removing its rows. Reads
+	// exclude deleted messages. Generated CRUD code can then restore a
+	// deleted message, or remove it permanently ("purge").
+	//
+	// optional bool soft_delete = 52110;
+	E_SoftDelete = &file_okra_options_proto_extTypes[1]
+)
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// whether the field identifies the message, i.e. is the primary key of
+	// its table. This is an alternative to naming the field "id" or to
+	// specifying `--id_fields` on the command line (which takes precedence).
+	//
+	// optional bool id = 52101;
+	E_Id = &file_okra_options_proto_extTypes[2]
+	// name of the column in which the field is stored, instead of one
+	// derived from the name of the field. If the field is stored in its own
+	// table (e.g. it's repeated), then the name of that table is derived
+	// from this name.
+	//
+	// optional string column_name = 52102;
+	E_ColumnName = &file_okra_options_proto_extTypes[3]
+	// whether to leave the field out of the database entirely. An ignored
+	// field is neither written nor read.
+	//
+	// optional bool ignore = 52103;
+	E_Ignore = &file_okra_options_proto_extTypes[4]
+	// maximum length of a string field, e.g. to make the column a
+	// `varchar(max_length)` rather than a type that's larger or that
+	// can't be indexed.
+	//
+	// optional uint32 max_length = 52104;
+	E_MaxLength = &file_okra_options_proto_extTypes[5]
+	// whether to create an index on the field's column(s). Generated CRUD
+	// code can then look up messages by the field's value.
+	//
+	// optional bool index = 52105;
+	E_Index = &file_okra_options_proto_extTypes[6]
+	// whether to create a unique index on the field's column(s), so that no
+	// two messages have the same value. This implies `index`.
+	//
+	// optional bool unique = 52106;
+	E_Unique = &file_okra_options_proto_extTypes[7]
+	// whether the field is the message's version, for optimistic concurrency
+	// control. An update succeeds only if the version in the database is the
+	// field's value, and increments the version. Otherwise, the update fails
+	// with a conflict. The field must be an integer.
+	//
+	// optional bool version = 52107;
+	E_Version = &file_okra_options_proto_extTypes[8]
+	// whether the field is populated from the column that records when the
+	// message was created, or last updated, respectively. Those columns are
+	// added when okra is run with `--timestamps`. The field must be a
+	// google.protobuf.Timestamp, and it is read but never written.
+	//
+	// optional bool created_at = 52108;
+	E_CreatedAt = &file_okra_options_proto_extTypes[9]
+	// optional bool updated_at = 52109;
+	E_UpdatedAt = &file_okra_options_proto_extTypes[10]
+)
+
+var File_okra_options_proto protoreflect.FileDescriptor
+
+const file_okra_options_proto_rawDesc = "" +
+	"\n" +
+	"\x12okra/options.proto\x12\x04okra\x1a google/protobuf/descriptor.proto:@\n" +
+	"\n" +
+	"table_name\x12\x1f.google.protobuf.MessageOptions\x18\x84\x97\x03 \x01(\tR\ttableName:B\n" +
+	"\vsoft_delete\x12\x1f.google.protobuf.MessageOptions\x18\x8e\x97\x03 \x01(\bR\n" +
+	"softDelete:/\n" +
+	"\x02id\x12\x1d.google.protobuf.FieldOptions\x18\x85\x97\x03 \x01(\bR\x02id:@\n" +
+	"\vcolumn_name\x12\x1d.google.protobuf.FieldOptions\x18\x86\x97\x03 \x01(\tR\n" +
+	"columnName:7\n" +
+	"\x06ignore\x12\x1d.google.protobuf.FieldOptions\x18\x87\x97\x03 \x01(\bR\x06ignore:>\n" +
+	"\n" +
+	"max_length\x12\x1d.google.protobuf.FieldOptions\x18\x88\x97\x03 \x01(\rR\tmaxLength:5\n" +
+	"\x05index\x12\x1d.google.protobuf.FieldOptions\x18\x89\x97\x03 \x01(\bR\x05index:7\n" +
+	"\x06unique\x12\x1d.google.protobuf.FieldOptions\x18\x8a\x97\x03 \x01(\bR\x06unique:9\n" +
+	"\aversion\x12\x1d.google.protobuf.FieldOptions\x18\x8b\x97\x03 \x01(\bR\aversion:>\n" +
+	"\n" +
+	"created_at\x12\x1d.google.protobuf.FieldOptions\x18\x8c\x97\x03 \x01(\bR\tcreatedAt:>\n" +
+	"\n" +
+	"updated_at\x12\x1d.google.protobuf.FieldOptions\x18\x8d\x97\x03 \x01(\bR\tupdatedAtB Z\x1egithub.com/dgoffredo/okra/okrab\x06proto3"
+
+var file_okra_options_proto_goTypes = []any{
+	(*descriptorpb.MessageOptions)(nil), // 0: google.protobuf.MessageOptions
+	(*descriptorpb.FieldOptions)(nil),   // 1: google.protobuf.FieldOptions
+}
+var file_okra_options_proto_depIdxs = []int32{
+	0,  // 0: okra.table_name:extendee -> google.protobuf.MessageOptions
+	0,  // 1: okra.soft_delete:extendee -> google.protobuf.MessageOptions
+	1,  // 2: okra.id:extendee -> google.protobuf.FieldOptions
+	1,  // 3: okra.column_name:extendee -> google.protobuf.FieldOptions
+	1,  // 4: okra.ignore:extendee -> google.protobuf.FieldOptions
+	1,  // 5: okra.max_length:extendee -> google.protobuf.FieldOptions
+	1,  // 6: okra.index:extendee -> google.protobuf.FieldOptions
+	1,  // 7: okra.unique:extendee -> google.protobuf.FieldOptions
+	1,  // 8: okra.version:extendee -> google.protobuf.FieldOptions
+	1,  // 9: okra.created_at:extendee -> google.protobuf.FieldOptions
+	1,  // 10: okra.updated_at:extendee -> google.protobuf.FieldOptions
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	0,  // [0:11] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_okra_options_proto_init() }
+func file_okra_options_proto_init() {
+	if File_okra_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_okra_options_proto_rawDesc), len(file_okra_options_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 11,
+			NumServices:   0,
+		},
+		GoTypes:           file_okra_options_proto_goTypes,
+		DependencyIndexes: file_okra_options_proto_depIdxs,
+		ExtensionInfos:    file_okra_options_proto_extTypes,
+	}.Build()
+	File_okra_options_proto = out.File
+	file_okra_options_proto_goTypes = nil
+	file_okra_options_proto_depIdxs = nil
+}
@@ -13,6 +13,7 @@ define(['./builtin.tisch.js'], function (builtin) {
             // (i.e. there's no way to say "change just the nullability).
             'name': String,
             'type': builtin,
+            'maxLength?': Number,
             'nullable': Boolean,
             'description': String // e.g. COMMENT section in MySQL
         },
@@ -24,6 +25,7 @@ define(['./builtin.tisch.js'], function (builtin) {
             // for the "name" column in enum tables).
             'name': String,
             'type': builtin,
+            'maxLength?': Number,
             'foreignKey?': {
                 'table': String, // name of the foreign table
                 'column': String  // name of the column in the foreign table
@@ -34,5 +36,11 @@ define(['./builtin.tisch.js'], function (builtin) {
             'kind': 'alterDescription', // of the table
             // empty string means that it was removed
             'description': String
+        },
+        {
+            'kind': 'addIndex',
+            // as in the "indices" of `table.tisch.js`
+            'columns': [String, ...etc], // name of indexed column(s)
+            'unique?': Boolean
         });
 })
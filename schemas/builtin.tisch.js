@@ -27,7 +27,33 @@ or('TYPE_DOUBLE',
    // Keep these "well-known" built-ins up to date with
    // `function builtinMessage`, defined in `proto2types.js`.
    '.google.protobuf.Timestamp',
+   '.google.protobuf.Duration',
    '.google.type.Date',
+   '.google.type.TimeOfDay',
+   // `LatLng` and `Money` are each stored in two columns: latitude and
+   // longitude, and currency code and amount, respectively.
+   '.google.type.LatLng',
+   '.google.type.Money',
+   // `Struct`, `Value`, and `ListValue` are arbitrary JSON, and are stored as
+   // such.
+   '.google.protobuf.Struct',
+   '.google.protobuf.Value',
+   '.google.protobuf.ListValue',
+   // `Any` is a type URL together with a serialized message. It's stored in
+   // two columns, one for each.
+   '.google.protobuf.Any',
+   // The wrapper types are messages containing one field, `value`. Unlike a
+   // scalar field, a wrapper-valued field can be absent, and so it can tell
+   // "unset" (null) apart from zero.
+   '.google.protobuf.DoubleValue',
+   '.google.protobuf.FloatValue',
+   '.google.protobuf.Int64Value',
+   '.google.protobuf.UInt64Value',
+   '.google.protobuf.Int32Value',
+   '.google.protobuf.UInt32Value',
+   '.google.protobuf.BoolValue',
+   '.google.protobuf.StringValue',
+   '.google.protobuf.BytesValue',
    // `FieldMask` is special because it's the only "builtin" type that behaves
    // like an array. In proto, a `FieldMask` is a message that contains one
    // field: `repeated string paths`. So, you could accomplish the same thing
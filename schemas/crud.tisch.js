@@ -46,45 +46,202 @@
     // If "age" is excluded from the "read" operation, then the generated code
     // has to know not the write a value into the "age" property, even though
     // "read-row" says to do so.
+    // Some builtin fields are stored in more than one column, one for each
+    // "part" of the value: the type URL and serialized message of a
+    // `google.protobuf.Any`, the latitude and longitude of a
+    // `google.type.LatLng`, and the currency code and amount of a
+    // `google.type.Money`.
+    const part = or('type_url', 'value', 'latitude', 'longitude',
+                    'currency_code', 'amount');
+
     const inputParameter = or(
-        // protobuf field name as is appears in the `.proto` file
-        {'field': String},
+        // protobuf field name as is appears in the `.proto` file. If the field
+        // is a member of a oneof, then `oneof` is the name of the oneof, and
+        // the parameter is null unless the field is the member that is set.
+        {'field': String, 'oneof?': String},
+
+        // one of the parts of a field stored in more than one column
+        {'field': String, 'part': part},
+
+        // the name of the member field that is set in the named oneof, or
+        // null if none is set
+        {'oneof': String},
 
         // whether the field with the specified name is part of the operation.
         // A "read" operation might specify only a subset of fields to return,
         // and an "update" operation might specify only a subset of fields to
         // modify.
-        {'included': String}
+        {'included': String},
+
+        // A "list" operation reads a page of messages in order of their IDs.
+        // Its statements refer to the bounds of the page:
+        // - "first" is whether this is the first page (i.e. there is no
+        //   "after"),
+        // - "after" is the ID after which the page begins,
+        // - "last" is the ID of the last message in the page, and
+        // - "size" is the maximum number of messages in the page.
+        {'page': or('first', 'after', 'last', 'size')},
+
+        // A "read-many" operation reads the messages having any of a set of
+        // IDs. Its statements refer to each ID in the set. See the
+        // "query-with-tuples" instruction.
+        {'batch': 'id'},
+
+        // A lookup operation reads the messages whose indexed field, named
+        // here, has a particular value. Its statements refer to that value.
+        {'lookup': String},
+
+        // The statements of a message type's history (see "history" below)
+        // refer to:
+        // - "id", the ID of the message,
+        // - "operation", the name of the operation that changed the message,
+        //   e.g. "update",
+        // - "actor", who performed the operation, or null if unknown,
+        // - "message", the serialized message after the change, or null if
+        //   the message was deleted, and
+        // - "time", the time as of which to read the message.
+        {'history': or('id', 'operation', 'actor', 'message', 'time')},
+
+        // The statement that inserts a change to a message into the outbox
+        // (see "outbox" below) refers to:
+        // - "type", the name of the message type, e.g. ".scouts.BoyScout",
+        // - "id", the ID of the message, encoded as JSON,
+        // - "operation", the name of the operation that changed the message,
+        // - "fieldMask", the comma-separated names of the fields that the
+        //   operation changed, or null if it changed the whole message, and
+        // - "message", the serialized message after the change, or null if
+        //   the message was deleted.
+        {'outbox': or('type', 'id', 'operation', 'fieldMask', 'message')}
     );
 
+    // A field of the message-valued field named by `child`, e.g. `{child:
+    // "items", field: "sku"}` is the "sku" of one of the "items". Such a
+    // parameter appears only in the tuples of "exec-with-tuples" and
+    // "exec-many-with-tuples" instructions, where there's one tuple per
+    // element of the child field (or one tuple if the child field is not
+    // repeated and is present, and none if it is absent).
+    const childParameter = {'child': String, 'field': String};
+
     // The field name of the destination field, or just ignore it.
     // Ignoring an output parameter can be useful when the point of the query
     // is just to see whether there is a row in the result set.
-    const outputParameter = or({'field': String}, 'ignore');
+    // A member of a oneof additionally names its `oneof`, and the oneof
+    // itself is a destination for the name of the member that is set. The
+    // member is set only if it's the one named. A field stored in more than
+    // one column is the destination of each, and each names its `part`.
+    const outputParameter = or(
+        {'field': String, 'oneof?': String},
+        {'field': String, 'part': part},
+        {'oneof': String},
+        'ignore');
 
     const instruction = or(
         // Read-only SQL query.
         {
             'instruction': 'query',
+            // Some queries are to be executed conditionally based on the
+            // inclusion of a message field. For example, a "read" that
+            // excludes an array field must not query the corresponding array
+            // table.
+            'condition?': {'included': String},
             'sql': String,
             'parameters': [inputParameter, ...etc]
         },
     
+        // Read-only SQL query whose parameters are repeated once for each ID
+        // in the set of IDs read by a "read-many" operation. Such a SQL
+        // statement will look something like:
+        //
+        //     select id, name from boyscout where id in (?, ?, ?, ?, ...);
+        //
+        // where there's one "?" per ID. In a `query-with-tuples` instruction
+        // for the example above, the `tuple` is "?", the `sql` is everything
+        // before the first "?", and the `suffix` is everything after the last
+        // "?". The `parameters` are those of one `tuple`.
+        //
+        // If the set of IDs is empty, then do not execute the SQL.
+        {
+            'instruction': 'query-with-tuples',
+            'tuple': String,
+            'sql': String,
+            'suffix': String,
+            'parameters': [inputParameter, ...etc]
+        },
+
+        // Extract the only column of the current result row into the result
+        // of the operation, e.g. whether a message exists. If there is no
+        // current result row, then the result is the zero value.
+        {
+            'instruction': 'read-result',
+            'destination': or('exists', 'count')
+        },
+
         // Extract column values from the current result row, and advance to the
         // next row. If an excluded field is among the `destinations`, ignore
         // that field.
         {
             'instruction': 'read-row',
-            'destinations': [outputParameter, ...etc]
+            'destinations': [outputParameter, ...etc],
+            // If there is no current row, then fail the operation with a
+            // unique violation instead of "not found." This is how a MySQL
+            // upsert detects that it updated a different row, one that
+            // conflicted in a unique index other than the primary key.
+            'onNoRow?': 'uniqueViolation'
         },
     
         // Extract the first column of all remaining rows, and append each value
         // to the array-valued `destination`. If the field `destination` is not
-        // selected, then ignore this instruction.
+        // selected, then ignore this instruction. If `destination` is a map,
+        // then each row instead has two columns, a key and a value, and the
+        // map is replaced by the entries read.
         {
             'instruction': 'read-array',
             'destination': outputParameter
         },
+
+        // Extract column values from each remaining row into a new message,
+        // and append the message to the result of the operation. This is how
+        // an operation that reads many messages (e.g. "list" or "read-many")
+        // reads the rows of the message table.
+        {
+            'instruction': 'read-rows',
+            'destinations': [outputParameter, ...etc]
+        },
+
+        // Like "read-array", except that each remaining row has two columns:
+        // the ID of one of the messages previously read by "read-rows", and a
+        // value to append to the array-valued `destination` of that message.
+        // This way, the values of an array field for many messages can be
+        // read using one query. As with "read-array", if `destination` is a
+        // map, then the ID is followed by a key and a value.
+        {
+            'instruction': 'read-keyed-array',
+            'destination': outputParameter
+        },
+
+        // Extract column values from all remaining rows into new instances of
+        // the message type of the message-valued `destination` field. The
+        // `destinations` are fields of that message type. If `destination` is
+        // repeated, then append each instance to it. Otherwise, set it to the
+        // instance from the first row, or clear it if there are no rows. If
+        // the field `destination` is not selected, then ignore this
+        // instruction.
+        {
+            'instruction': 'read-child-rows',
+            'destination': {'field': String},
+            'destinations': [outputParameter, ...etc]
+        },
+
+        // Like "read-child-rows", except that the first column of each row is
+        // the ID of one of the messages previously read by "read-rows", and
+        // the remaining columns go to the instance of the child message type
+        // belonging to that message. This is to "read-child-rows" as
+        // "read-keyed-array" is to "read-array".
+        {
+            'instruction': 'read-keyed-child-rows',
+            'destination': {'field': String},
+            'destinations': [outputParameter, ...etc]
+        },
     
         // Read/write SQL query. Not expected to produce any rows.
         {
@@ -97,7 +254,15 @@
             // executed.
             'condition?': {'included': String},
             'sql': String,
-            'parameters': [inputParameter, ...etc]
+            'parameters': [inputParameter, ...etc],
+            // If the statement affects no rows, then fail the operation with
+            // a conflict. This is how an "update" of a message having a
+            // version field detects that the message was modified since its
+            // version was read. If instead the value is "unchanged," then
+            // the operation succeeds, but it changed nothing, e.g. it deleted
+            // a message that was already deleted, and so the change is not
+            // recorded in the message's history or inserted into the outbox.
+            'onNoRows?': or('conflict', 'unchanged')
         },
     
         // Read/write SQL query. Not expected to produce any rows.
@@ -122,7 +287,16 @@
         // Additionally, a parameter may be of the form `{index: String}`, in
         // which case the expected value is the zero-based index of the named
         // array-like field. This will be used for the "ordinality" column in
-        // array tables.
+        // array tables. Similarly, if the field is a map, then a parameter
+        // of the form `{key: String}` refers to the key of each entry, while
+        // `{field: String}` refers to its value. The key is used for the "key"
+        // column in map tables.
+        //
+        // Instead of an array-valued field, the tuples might correspond to
+        // the elements of a message-valued field, in which case there are
+        // `{child: String, field: String}` parameters instead (see
+        // `childParameter`), and `{index: String}` names the message-valued
+        // field if it is repeated.
         //
         // If the array-valued field is empty, then do not execute the SQL.
         {
@@ -138,7 +312,50 @@
             'sql': String,
             // I imagine that `parameters` will never contain `{included:
             // ...}` parameters, but it is still allowed here.
-            'parameters': [or(inputParameter, {'index': String}), ...etc]
+            'parameters': [
+                or(inputParameter,
+                   {'index': String},
+                   {'key': String},
+                   childParameter), ...etc]
+        },
+
+        // Read/write SQL query. Not expected to produce any rows.
+        //
+        // This instruction is like "exec-with-tuples," except that it is used
+        // by operations on many messages at once (e.g. "create-many"). There
+        // is one copy of `tuple` for each message, unless one of the
+        // `parameters` is array-valued or a FieldMask, in which case there is
+        // one copy of `tuple` for each element of that field in each message.
+        // The same goes for message-valued fields referred to by `{child:
+        // String, field: String}` parameters.
+        // For example, inserting the rows of many messages into their table
+        // looks something like:
+        //
+        //     insert into boyscout(id, name)
+        //     values (?, ?), (?, ?), (?, ?), (?, ?), ...
+        //
+        // where each "(?, ?)" is a different message. Likewise, the records
+        // of many messages are appended to their history using `{history:
+        // ...}` parameters, and their events are inserted into the outbox
+        // using `{outbox: ...}` parameters (see "history" and "outbox" below).
+        //
+        // Databases limit the size of a statement, so the tuples might have to
+        // be divided among several statements. If there are no tuples, then
+        // do not execute the SQL.
+        {
+            'instruction': 'exec-many-with-tuples',
+            'tuple': String,
+            'sql': String,
+            'parameters': [
+                or({'field': String, 'oneof?': String},
+                   {'field': String, 'part': part},
+                   {'oneof': String},
+                   {'index': String},
+                   {'key': String},
+                   childParameter,
+                   {'history': or('id', 'operation', 'actor', 'message')},
+                   {'outbox': or('type', 'id', 'operation', 'fieldMask', 'message')}),
+                ...etc]
         });
 
     return {
@@ -148,7 +365,80 @@
             'create': [instruction, ...etc],
             'read': [instruction, ...etc],
             'update': [instruction, ...etc],
-            'delete': [instruction, ...etc]
+            'delete': [instruction, ...etc],
+            // If the message type is soft-deleted, then "delete" marks a
+            // message as deleted, "undelete" clears the mark, and "purge"
+            // removes the message's rows, whether or not it is marked.
+            'undelete?': [instruction, ...etc],
+            'purge?': [instruction, ...etc],
+            'upsert': [instruction, ...etc],
+            'list': [instruction, ...etc],
+            'read-many': [instruction, ...etc],
+            'create-many': [instruction, ...etc],
+            'exists': [instruction, ...etc],
+            // Like a query (see below), "count" is described by SQL to which
+            // generated code appends a "where" clause built at runtime, e.g.
+            //
+            //     select count(*) from boyscout
+            //
+            // and, if the message type is soft-deleted, a condition that
+            // every counted message satisfies, e.g.
+            //
+            //     deleted_at is null
+            'count': {'sql': String, 'where?': String},
+            // Rather than instructions, a query is described by the SQL that
+            // selects the IDs of all of the messages, e.g.
+            //
+            //     select id from boyscout
+            //
+            // to which generated code appends "where," "order by," and
+            // "limit" clauses built at runtime, and then reads the messages
+            // having the selected IDs as in "read-many."
+            'query': {
+                'sql': String,
+                // a condition that every selected message satisfies, e.g.
+                // "deleted_at is null" if the message type is soft-deleted,
+                // in addition to those built at runtime
+                'where?': String,
+                // the ID column, e.g. "id", by which the messages are
+                // ordered after any other ordering
+                'key': String,
+                // Each property is the name of a field that a query can
+                // compare and order by. `column` is the field's column, and
+                // `parameter` is the SQL with which to compare the column
+                // with a value, e.g. "?" or "from_unixtime(?)".
+                'fields': {
+                    [Any]: {'column': String, 'parameter': String},
+                    ...etc
+                }
+            },
+            // If the message type has a history table, then "record" appends
+            // a record of a change to the history, and is performed at the
+            // end of each operation that changes a message. "recordMany"
+            // appends a record for each of many messages, and is performed
+            // at the end of each operation that changes many. "asOf" selects
+            // the operation and serialized message of the latest record of a
+            // message at a given time, if any.
+            'history?': {
+                'record': instruction,
+                'recordMany': instruction,
+                'asOf': instruction
+            },
+            // If changes to the message type are inserted into the outbox,
+            // then "publish" inserts an event describing a change, and is
+            // performed at the end of each operation that changes a message.
+            // Likewise, "publishMany" inserts an event for each of many
+            // messages.
+            // The statements that poll the outbox are not specific to any
+            // message type (see `outbox.tisch.js`).
+            'outbox?': {'publish': instruction, 'publishMany': instruction},
+            // Each property is the name of an indexed field of the message
+            // type, and its value is a lookup operation that reads the
+            // messages having a particular value of the field.
+            'lookups?': {
+                [Any]: [instruction, ...etc],
+                ...etc
+            }
         },
         ...etc
     };
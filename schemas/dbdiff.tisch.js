@@ -4,6 +4,7 @@
 // - adding tables (e.g. a new type or a new array-valued field)
 // - adding columns to existing tables (e.g. new field in a message)
 // - modifying existing columns (e.g. expanding an int type or changing a comment)
+// - adding indices to existing tables (e.g. a newly indexed field)
 // - adding rows to existing tables (e.g. new enum values)
 // - modifying existing rows in tables (e.g. changing the description of an enum value)
 //
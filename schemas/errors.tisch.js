@@ -0,0 +1,23 @@
+// Each SQL dialect classifies some of the errors that its database can report,
+// so that generated CRUD code can report them in a database-independent way.
+//
+// An error is identified by its code, which is either a vendor-specific error
+// number (e.g. MySQL's 1062 for a duplicate key) or a five-character SQLSTATE
+// string (e.g. PostgreSQL's "23505" for a unique violation).
+(function () {
+    const codes = [or(Number, String), ...etc];
+
+    return {
+        // e.g. inserting a row whose primary key is already in the table
+        'already-exists': codes,
+
+        // e.g. referring to an enum value that is not in the enum's table
+        'foreign-key': codes,
+
+        // e.g. a deadlock or lock wait timeout among concurrent transactions
+        'conflict': codes,
+
+        // e.g. a value that is out of range or too long for its column
+        'invalid-argument': codes
+    };
+}())
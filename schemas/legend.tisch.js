@@ -2,26 +2,85 @@
 // corresponding database tables. Each message type is associated with a table
 // whose rows are instances of that message type. Each enum type is associated
 // with a table whose rows are the possible values enumerated. Each
-// array-valued (repeated) message field is associated with a table that maps
-// the message instance's ID to values for that field.
+// array-valued (repeated) or map-valued message field is associated with a
+// table that maps the message instance's ID to values for that field. Each message-valued
+// field, repeated or not, is associated with a "child" table whose rows are
+// instances of the field's message type, keyed by the parent's ID, unless the
+// field is stored as JSON, in which case it's like any other field.
 //
 // This schema describes a legend.
 ({
     messageTypeName: String, // fully qualified, e.g. ".foo.bar.Shoe"
     tableName: String, // e.g. "shoe"
+    // If the message's table has timestamp columns (see the `timestamps`
+    // option of `types2tables`), then these are their names. Generated CRUD
+    // code sets them when it creates and updates a message.
+    'timestamps?': {
+        created: String, // e.g. "created_at"
+        updated: String // e.g. "updated_at"
+    },
+    // If the message type is soft-deleted (see the `softDelete` option of
+    // the type), then this is the name of the column that records when a row
+    // was deleted. Generated CRUD code reads and counts only the rows in
+    // which it is null.
+    'deletedColumn?': String, // e.g. "deleted_at"
+    // If the message has a history table (see the `history` option of
+    // `types2tables`), then this is its name. Generated CRUD code appends a
+    // record to it whenever it changes a message.
+    'historyTableName?': String, // e.g. "shoe_history"
+    // If changes to messages are inserted into an outbox (see the `outbox`
+    // option of `types2tables`), then this is the name of the outbox table.
+    'outboxTableName?': String, // e.g. "okra_outbox"
     // The `fieldSources` will come in the same order as the fields in the
-    // protobuf type. They also correspond by name (`.fieldName`).
+    // protobuf type. They also correspond by name (`.fieldName`). The
+    // exceptions are the source of a oneof (see below), which follows the
+    // source of the oneof's last member, and the sources of the parts of a
+    // field that is stored in more than one column.
     fieldSources: [or({
         // Each non-array field has a column in the message's table.
         fieldName: String, // e.g. "color"
-        columnName: String // e.g. "color"
+        columnName: String, // e.g. "color"
+        // If the field is a member of a oneof, then this is the name of the
+        // oneof. The column is null unless the field is set.
+        'oneofName?': String, // e.g. "fit"
+        // Some builtins are stored in more than one column, and so have more
+        // than one source, one for each "part." A `google.protobuf.Any` has
+        // a type URL and a serialized value, a `google.type.LatLng` has a
+        // latitude and a longitude, and a `google.type.Money` has a currency
+        // code and an amount.
+        'part?': or('type_url', 'value', 'latitude', 'longitude',
+                    'currency_code', 'amount'),
+        // If the field is populated from one of the timestamp columns, then
+        // this says which one. Such a field is read, but never written.
+        'timestamp?': or('created', 'updated')
+    }, {
+        // Each oneof has a column in the message's table that contains the
+        // name of the member field that is set, or null if none is set.
+        oneofName: String, // e.g. "fit"
+        columnName: String // e.g. "fit"
     }, {
         // Each array field has a table that maps the message type table's ID
         // to one of the values of the field, e.g. "Shoe.parts" will have a
         // "shoe_parts" table with two columns: "id" and "value". "id" refers
         // to the primary key of the message table, and "value" is the value,
         // which may have a foreign key to an enum table it it's an enum.
+        // Each map field likewise has a table, but with a "key" column
+        // between "id" and "value".
         fieldName: String, // name of the array-valued field, e.g. "parts"
         tableName: String // the mapping table, e.g. "shoe_parts"
+    }, {
+        // Each message-valued field has a child table keyed by the message
+        // table's ID (column "parent_id") and, if the field is repeated, by
+        // position ("ordinality"). The remaining columns correspond to the
+        // fields of the child message type, e.g. "Shoe.laces" will have a
+        // "shoe_laces" table with columns "parent_id", "ordinality",
+        // "color", and "length".
+        fieldName: String, // name of the message-valued field, e.g. "laces"
+        tableName: String, // the child table, e.g. "shoe_laces"
+        messageTypeName: String, // fully qualified, e.g. ".foo.bar.Lace"
+        fieldSources: [{
+            fieldName: String, // e.g. "color"
+            columnName: String // e.g. "color"
+        }, ...etc]
     }), ...etc]
 })
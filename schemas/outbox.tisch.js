@@ -0,0 +1,26 @@
+// When changes to messages are inserted into an outbox table (see the
+// `outbox` option of `types2tables`), other programs poll the outbox for the
+// changes using generated code. Each SQL dialect describes the statements of
+// that code, each of which takes parameters in the order listed here.
+(function () {
+    return {
+        // Claim up to a number of the events in the outbox that are not
+        // claimed, or whose claim has expired, in order of their sequence.
+        // The parameters are:
+        // 1. the token identifying the claim,
+        // 2. how long a claim lasts, in microseconds, and
+        // 3. the maximum number of events to claim.
+        'claim': String,
+
+        // Select the sequence, type name, message ID, operation, field mask,
+        // and serialized message of each event having a claim, in order of
+        // their sequence. The only parameter is the token identifying the
+        // claim.
+        'read': String,
+
+        // Delete an event that has been dealt with. The parameters are:
+        // 1. the sequence of the event, and
+        // 2. the token identifying the claim on the event.
+        'acknowledge': String
+    };
+}())
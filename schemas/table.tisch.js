@@ -16,17 +16,36 @@ define(['./builtin.tisch.js'], builtin => ({
         // capacity. This is unnecessary for the name of an enum value, which
         // is likely fewer than a few hundred characters. Thus, "name" is an
         // additional type, separate from what can be expressed in a proto
-        // file.
-        'type': or(builtin, 'name'),
+        // file. Similarly, "json" is the type of a message stored as JSON
+        // text (see the `jsonFields` option of `proto2types`). "currency" and
+        // "decimal" are the types of the columns of a `google.type.Money`:
+        // a three-letter ISO 4217 currency code, and an exact decimal number
+        // with nine digits after the decimal point.
+        'type': or(builtin, 'name', 'json', 'currency', 'decimal'),
         'nullable': Boolean,
+        // maximum length of a "TYPE_STRING" or "name" column, if it's to be
+        // limited (see the `(okra.max_length)` option)
+        'maxLength?': Number,
         'foreignKey?': {
             'table': String, // name of the foreign table
             'column': String  // name of the column in the foreign table
         },
+        // whether the column records when the row was created or last
+        // updated (see the `timestamps` option of `types2tables`), or when
+        // the row was deleted (see the `softDelete` option of a message
+        // type). Timestamp columns come after the table's other columns,
+        // even those added later (see `dbdiff`).
+        'timestamp?': or('created', 'updated', 'deleted'),
+        // whether the database assigns the column's value when a row is
+        // inserted, each value greater than those before it. Only a
+        // "TYPE_INT64" column that is the entire primary key can be
+        // auto-incremented.
+        'autoIncrement?': Boolean,
         'description?': String // e.g. COMMENT section in MySQL
     }, ...etc],
     'rows?': [[or(Number, String, null), ...etc], ...etc],
     'indices?': [{
-        'columns': [String, ...etc] // name of indexed column(s)
+        'columns': [String, ...etc], // name of indexed column(s)
+        'unique?': Boolean // whether no two rows may have the same values
     }, ...etc]
 }))
@@ -22,8 +22,21 @@ define(['builtin.tisch.js'], builtin =>
             'file?': String, // path to .proto file where this message is defined
             'name': String,
             'description?': String,
-            // name of the field that identifies this object (e.g. "id")
-            'idFieldName': String,
+            // name of the message's table, if not derived from `name` (see
+            // the `(okra.table_name)` option in `okra/options.proto`)
+            'tableName?': String,
+            // name of the field that identifies this object (e.g. "id").
+            // Messages that appear only as fields of other messages need not
+            // have an ID, since they're stored in child tables keyed by the
+            // ID of the parent.
+            'idFieldName?': String,
+            // name of the field that is the message's version (see the
+            // `(okra.version)` option in `okra/options.proto`), if any.
+            // Updates compare and increment the version.
+            'versionFieldName?': String,
+            // whether deleting a message only marks it as deleted (see the
+            // `(okra.soft_delete)` option in `okra/options.proto`)
+            'softDelete?': Boolean,
             'fields': [{
                 // Protobuf message fields have integer IDs. I think that
                 // they're mostly for efficient encoding (minimal field tags).
@@ -34,8 +47,41 @@ define(['builtin.tisch.js'], builtin =>
                 'type': or(
                     {'builtin': builtin},
                     {'enum': String},
+                    {'message': String},
+                    // a message stored as JSON rather than in a child table
+                    {'json': String},
+                    // a proto3 `optional` scalar or enum field, which can
+                    // tell "unset" apart from zero
+                    {'optional': {'builtin': builtin}},
+                    {'optional': {'enum': String}},
                     {'array': {'builtin': builtin}},
-                    {'array': {'enum': String}}),
+                    {'array': {'enum': String}},
+                    {'array': {'message': String}},
+                    {'array': {'json': String}},
+                    // a protobuf `map<key, value>`, whose keys are integers,
+                    // booleans, or strings
+                    {'map': {
+                        'key': {'builtin': builtin},
+                        'value': or(
+                            {'builtin': builtin},
+                            {'enum': String},
+                            {'json': String})
+                    }}),
+                // name of the protobuf `oneof` that this field is a member
+                // of, if any. At most one member of a oneof is set.
+                'oneof?': String,
+                // The following correspond to options in `okra/options.proto`.
+                // name of the field's column, if not derived from `name`
+                'columnName?': String,
+                // maximum length of a string field
+                'maxLength?': Number,
+                // whether the field's column(s) are indexed
+                'indexed?': Boolean,
+                // whether the field's column(s) have a unique index
+                'unique?': Boolean,
+                // which timestamp column, if any, populates the field (see
+                // the `timestamps` option of `types2tables`)
+                'timestamp?': or('created', 'updated'),
                 'description?': String
             }, ...etc]
         }));
@@ -88,7 +88,8 @@ function dbdiff2sql(dbdiff) {
     }
 
     const alterations = justThe('alterations')
-        .map(([tableName, alterations]) => alterTable(tableName, alterations));
+        .map(([tableName, alterations]) =>
+            alterTable(dbdiff.allTables[tableName], alterations));
 
     const updates = justThe('updates')
         .map(([tableName, updates]) =>
@@ -116,8 +117,10 @@ function dbdiff2sql(dbdiff) {
         .join('\n');
 }
 
-// Return a string containing a MySQL 5.6 `ALTER TABLE` statement
-function alterTable(name, alterations) {
+// Return a string containing a MySQL 5.6 `ALTER TABLE` statement that makes the
+// specified `alterations` to the specified `table`, where `table` is as it is
+// after the alterations.
+function alterTable(table, alterations) {
     // Loop through `alterations` a bunch of times, collecting a different part
     // of the `ALTER TABLE` statement each time.
 
@@ -143,11 +146,16 @@ function alterTable(name, alterations) {
         .map(({kind, ...column}) =>
             'add ' + column2foreignKeyTableClause(column));
 
+    const addIndices = alterations
+        .filter(alt => alt.kind === 'addIndex')
+        .map(({kind, ...index}) => 'add ' + index2tableClause(index, table));
+
     const clauses = [
-        ...commentChanges, ...modifyColumns, ...addColumns, ...foreignKeys
+        ...commentChanges, ...modifyColumns, ...addColumns, ...foreignKeys,
+        ...addIndices
     ];
 
-    return `alter table ${quoteName(name)}
+    return `alter table ${quoteName(table.name)}
 ${clauses.join(',\n')}`;
 }
 
@@ -188,7 +196,8 @@ function createTable(table) {
         .filter(column => 'foreignKey' in column)
         .map(column2foreignKeyTableClause));
 
-    const indexClauses = (table.indices || []).map(index2tableClause);
+    const indexClauses = (table.indices || [])
+        .map(index => index2tableClause(index, table));
 
     const tableClauses = [...columnClauses, ...keyClauses, ...indexClauses];
 
@@ -223,10 +232,14 @@ function column2foreignKeyTableClause(column) {
 function column2tableClause(column) {
     const parts = [
         quoteName(column.name),
-        type2sql(column.type),
+        type2sql(column.type, column.maxLength),
         column.nullable ? 'null' : 'not null'
     ];
 
+    if (column.autoIncrement) {
+        parts.push('auto_increment');
+    }
+
     if ('description' in column) {
         parts.push('comment', quoteString(column.description));
     }
@@ -234,8 +247,13 @@ function column2tableClause(column) {
     return parts.join(' ');
 }
 
-function type2sql(type) {
+function type2sql(type, maxLength) {
     // See column type in `table.tisch.js` and builtin in `builtin.tisch.js`.
+    // A string column having a maximum length is a `varchar` of that length.
+    if (maxLength !== undefined) {
+        return `varchar(${maxLength})`;
+    }
+
     return {
         'TYPE_DOUBLE': 'double',
         'TYPE_FLOAT': 'float',
@@ -248,13 +266,51 @@ function type2sql(type) {
         'TYPE_BYTES': 'longblob',
         '.google.protobuf.Timestamp': 'timestamp(6)',
         '.google.type.Date': 'date',
-        'name': 'varchar(255)'
+        '.google.type.TimeOfDay': 'time(6)',
+        '.google.protobuf.Duration': 'bigint', // microseconds
+        '.google.protobuf.Struct': 'longtext',
+        '.google.protobuf.Value': 'longtext',
+        '.google.protobuf.ListValue': 'longtext',
+        'name': 'varchar(255)',
+        'currency': 'char(3)',
+        'decimal': 'decimal(38, 9)',
+        'json': 'longtext' // MySQL 5.6 has no `json` type (5.7 does)
     }[type];
 }
 
-// e.g. "index (parent_id, child_id)"
-function index2tableClause(index) {
-   return `index (${index.columns.map(quoteName).join(', ')})`;
+// An InnoDB index key is at most 767 bytes, and a character in the `utf8mb4`
+// character set is at most 4 bytes, so an indexed string column can be at most
+// 191 characters long.
+const maxIndexedStringLength = 191;
+
+// Throw an `Error` if the specified `column` of the specified `table` cannot be
+// part of an index, i.e. if it's too long a string, or if it's a text or blob.
+function checkIndexable(column, table) {
+    const sqlType = type2sql(column.type, column.maxLength);
+    const [_, length] = sqlType.match(/^varchar\((\d+)\)$/) || [];
+
+    if (length === undefined) {
+        if (['longtext', 'longblob'].includes(sqlType)) {
+            throw Error(`Column ${column.name} of table ${table.name} is ` +
+                `indexed, but it is a ${sqlType}, which MySQL can index ` +
+                'only by a prefix.');
+        }
+    }
+    else if (Number(length) > maxIndexedStringLength) {
+        throw Error(`Column ${column.name} of table ${table.name} is ` +
+            `indexed, but it is a ${sqlType}, which is too long for an ` +
+            'InnoDB index key in the utf8mb4 character set. Give the field ' +
+            `an (okra.max_length) of at most ${maxIndexedStringLength}.`);
+    }
+}
+
+// e.g. "index (parent_id, child_id)" or "unique index (email)"
+function index2tableClause(index, table) {
+   index.columns.forEach(name =>
+       checkIndexable(table.columns.find(column => column.name === name), table));
+
+   const unique = index.unique ? 'unique ' : '';
+   return `${unique}index (${index.columns.map(quoteName).join(', ')})`;
 }
 
 return {dbdiff2sql};
@@ -0,0 +1,4 @@
+alter table `grill`
+add column `serial_number` varchar(191) null,
+add index (`brand`),
+add unique index (`serial_number`);
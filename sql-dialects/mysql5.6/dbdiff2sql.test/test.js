@@ -6,7 +6,7 @@
 require('../../../dependencies/node-amd-loader/amd-loader');
 
 const path = require('path');
-const {glob, diff} = require('../../../lib/filesystem');
+const {glob, diff, exists} = require('../../../lib/filesystem');
 const {proto2types} = require('../../../lib/proto2types');
 const {types2tables} = require('../../../lib/types2tables');
 const {dbdiff} = require('../../../lib/dbdiff');
@@ -14,6 +14,7 @@ const {dbdiff2sql} = require('../dbdiff2sql');
 
 // For each (*.before.proto, *.after.proto) pair, get the SQL for the resulting
 // dbdiff, and compare it with *.sql, which is the expected output of dbdiff2sql.
+// If there is no *.sql, then dbdiff2sql is expected to fail.
 
 // TODO: To test "from scratch" SQL generation, search first for *.sql, and
 // then if there's no corresponding *.{before,after}.proto files, consider it
@@ -36,6 +37,18 @@ befores.forEach(beforePath => {
     }).types;
     const newTables = types2tables(newTypes).tables;
 
+    if (!exists(sqlPath)) {
+        let sql;
+        try {
+            sql = dbdiff2sql(dbdiff(tables, newTables));
+        }
+        catch (error) {
+            return; // failure is expected
+        }
+        throw Error(`Expected ${afterPath} to fail, but it produced the ` +
+            `SQL:\n${sql}`);
+    }
+
     const sql = dbdiff2sql(dbdiff(tables, newTables));
     const diffResult = diff({path: sqlPath}, {string: sql});
     if (diffResult.length !== 0) {
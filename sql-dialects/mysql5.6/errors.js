@@ -0,0 +1,38 @@
+// This module exports an object, `errors`, that classifies the errors that
+// MySQL 5.6 can report during CRUD operations. See `schemas/errors.tisch.js`.
+
+define(['../../schemas/schemas'], function (schemas) {
+'use strict';
+
+// The numbers are MySQL server error codes, as documented in
+// <https://dev.mysql.com/doc/refman/5.6/en/server-error-reference.html>.
+const errors = {
+    'already-exists': [
+        1022, // ER_DUP_KEY
+        1062, // ER_DUP_ENTRY
+        1586  // ER_DUP_ENTRY_WITH_KEY_NAME
+    ],
+    'foreign-key': [
+        1216, // ER_NO_REFERENCED_ROW
+        1217, // ER_ROW_IS_REFERENCED
+        1451, // ER_ROW_IS_REFERENCED_2
+        1452  // ER_NO_REFERENCED_ROW_2
+    ],
+    'conflict': [
+        1205, // ER_LOCK_WAIT_TIMEOUT
+        1213  // ER_LOCK_DEADLOCK
+    ],
+    'invalid-argument': [
+        1048, // ER_BAD_NULL_ERROR
+        1264, // ER_WARN_DATA_OUT_OF_RANGE
+        1292, // ER_TRUNCATED_WRONG_VALUE
+        1366, // ER_TRUNCATED_WRONG_VALUE_FOR_FIELD
+        1406  // ER_DATA_TOO_LONG
+    ]
+};
+
+schemas.errors.enforce(errors);
+
+return {errors};
+
+});
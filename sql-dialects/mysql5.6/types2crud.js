@@ -2,40 +2,17 @@
 // create-read-update-delete (CRUD) operations for a given set of types and
 // their legends. The SQL statements used in the CRUD instructions are
 // compatible with MySQL 5.6.
+// It also exports a function, `outboxPoller`, that describes the statements
+// with which generated code polls the outbox table (see `outbox.tisch.js`).
+//
+// The instructions are built by `lib/types2crud.js`. This module describes
+// how MySQL 5.6 differs from the other dialects (see `sqlCrud` there).
 
-define(['../../schemas/schemas', '../../dependencies/tisch/tisch', './quote'],
-function (schemas, tisch, quote) {
+define(['../../lib/types2crud', './quote'], function (crud, quote) {
 'use strict';
 
 const {quoteName} = quote;
 
-// Return a `RegExp` object compiled from a pattern that is the disjunction of
-// the patterns of the specified `regexes`. The "global" flag ("g") will be
-// used.
-function combinePatterns(...regexes) {
-    const pattern = regexes.map(regex => `(${regex.source})`).join('|');
-    const flags = 'g';
-    return new RegExp(pattern, flags);
-}
-
-// Return a modified version of the specified `sqlText` that has had
-// unnecessary whitespace removed. This allows SQL template strings to be
-// formatted however is convenient in this code, and then reduced to something
-// consistent for output.
-const sqline = (function () {
-    const regex = combinePatterns(
-        /\s+/, // whitespace
-        /'[^']*'/, // 'single-quoted string'
-        /`[^`]*`/, // `backtick-quoted string`
-        /"[^"]*"/, // "double-quoted string"
-        /--[^\n]*\n/, // -- line comment
-        /\/\*(\*[^/]|[^*])*\*\//); // /* block comment */
-
-    const dedupeWhitespace = token => token.match(/^\s+$/) ? ' ' : token;
-
-    return sqlText => sqlText.replace(regex, dedupeWhitespace).trim();
-}());
-
 // In a simpler world, selecting the value from a column would always look like:
 //
 //     select `foo` from ...
@@ -73,6 +50,10 @@ function selector({columnName, fieldType}) {
         // but this is the default for MySQL 5.6
         return quoteName(columnName);
     }
+    else if (fieldType.builtin === '.google.type.TimeOfDay') {
+        // time(6) → "HH:MM:SS.ffffff"
+        return `time_format(${quoteName(columnName)}, '%H:%i:%s.%f')`;
+    }
     else {
         return quoteName(columnName);
     }
@@ -92,515 +73,86 @@ function parameter(fieldType) {
         // but this is the default for MySQL 5.6
         return '?';
     }
+    else if (fieldType.builtin === '.google.type.TimeOfDay') {
+        // "HH:MM:SS.ffffff" → time(6)
+        // MySQL does this by default, too.
+        return '?';
+    }
     else {
         return '?';
     }
 }
 
-// Return a CRUD instruction for adding values into the specified
-// `arrayTableName` from the specified `arrayField` of the message type having
-// the specified `messageIdField`, where the `messageIdField` has the
-// specified `messageIdFieldType` and the elements of the array field have the
-// specified `arrayFieldType`. The returned instruction will require that
-// `arrayField` is included in the operation (if `arrayField` is not included,
-// a code generator will not perform the instruction).
-function instructionInsertArray({
-    arrayTableName,
-    messageIdField,
-    messageIdFieldType,
-    arrayField,
-    arrayFieldType
-}) {
-    return {
-        instruction: 'exec-with-tuples',
-        condition: {included: arrayField},
-        // tuple is, e.g. "(?, ?, ?)" or "(?, ?, from_unixtime(...))"
-        tuple: '(' + [
-            messageIdFieldType,
-            {builtin: 'TYPE_UINT32'},
-            arrayFieldType
-        ].map(parameter).join(', ') + ')',
-        sql: sqline(`insert into
-            ${quoteName(arrayTableName)}(
-                ${quoteName('id')},
-                ${quoteName('ordinality')},
-                ${quoteName('value')})
-            values `),
-        parameters: [
-            {field: messageIdField},
-            {index: arrayField},
-            {field: arrayField}
-        ]
-    };
-}
-
-// Return a CRUD instruction for selecting rows from the specified
-// `arrayTableName` representing an array of the specified `arrayType` in the
-// message type having the specified `messageIdField` with the specified
-// `messageIdFieldType`.
-function instructionSelectArray({
-    arrayTableName,
-    arrayType, // type of the array itself, e.g. `{array: ...}`
-    messageIdField,
-    messageIdFieldType
-}) {
-    // If `arrayType` refers to an actual array, then the type of its elements
-    // is `arrayType.array`. However, if `arrayType` is a FieldMask, then
-    // the type of its elements is string.
-    const elementType = arrayType.array || {builtin: 'TYPE_STRING'};
-
-    return {
-        instruction: 'query',
-
-        // It's important that we select only the `value` column, so that the
-        // generated code then knows that the result set has only one column,
-        // and to put its value into the array field.
-        sql: sqline(`select ${selector({columnName: 'value', fieldType: elementType})}
-                from ${quoteName(arrayTableName)}
-                where ${quoteName('id')} = ${parameter(messageIdFieldType)}
-                order by ${quoteName('ordinality')};`),
-        parameters: [
-            {field: messageIdField}
-        ]
-    };
-}
-
-// Return a CRUD instruction that deletes all rows from the specified
-// `arrayTableName` whose message ID column ("id") of the specified type
-// `messageIdFieldType` has the same value as the specified `messageIdField`
-// (`messageIdField` is the _name_ of the field whose value we're interested
-// in). Optionally specify a `conditionField`, which makes the returned
-// instruction applicable only if that field is included in the relevant
-// operation (e.g. deleting values before replacing them in an "update," but
-// only if we're updating that field).
-function instructionDeleteArray({
-    arrayTableName,
-    messageIdField,
-    messageIdFieldType,
-    conditionField
-}) {
-    return {
-        instruction: 'exec',
-        sql: sqline(`delete from ${quoteName(arrayTableName)}
-                where ${quoteName('id')} = ${parameter(messageIdFieldType)};`),
-        parameters: [
-            {field: messageIdField}
-        ],
-        // "condition" is optional. Let it appear only if it has a value.
-        ...(conditionField ? {condition: {included: conditionField}} : {})
-    };
-}
-
-// Return a CRUD instruction that selects the scalar fields of an instance of
-// the specified `type` from the database. Use the specified `legend` to map
-// message fields to table columns.
-function instructionSelectMessage({type, legend}) {
-    const {scalarFieldSources} = byMultiplicity(legend.fieldSources);
-    const keyColumnName = scalarFieldSources
-        .find(({fieldName}) => fieldName === type.idFieldName)
-        .columnName;
-    const fieldTypes = Object.fromEntries(
-        type.fields.map(({name, type}) => [name, type]));
-    const selectors = scalarFieldSources
-        .map(({columnName, fieldName}) =>
-            selector({columnName, fieldType: fieldTypes[fieldName]}));
-    const idFieldType = fieldTypes[type.idFieldName];
-
-    return {
-        instruction: 'query',
-        sql: sqline(`select ${selectors.join(', ')}
-            from ${quoteName(legend.tableName)}
-            where ${quoteName(keyColumnName)} = ${parameter(idFieldType)};`),
-        parameters: [
-            {field: type.idFieldName}
-        ]
-    };
-}
-
-// Return an array of CRUD instructions that check whether there is a
-// particular instance of the specified `type` in the database, and produces
-// an error (by failing to read a result row) if there isn't. This mechanism
-// is used by updates to verify that there is anything to update.
-function instructionsMessageExists({type, legend}) {
-    const keyColumnName = legend.fieldSources
-        .find(({fieldName}) => fieldName === type.idFieldName)
-        .columnName;
-    const fieldTypes = Object.fromEntries(
-        type.fields.map(({name, type}) => [name, type]));
-    const idFieldType = fieldTypes[type.idFieldName];
-
-    return [
-        // Select something (null) for each matching row. The idea is that if
-        // there are no matching rows, then the following read-row will fail.
-        // There's guaranteed to be no more than one row, because we're
-        // querying on a primary key column.
-        {
-            instruction: 'query',
-            sql: sqline(`select null
-                from ${quoteName(legend.tableName)}
-                where ${quoteName(keyColumnName)} = ${parameter(idFieldType)};`),
-            parameters: [
-                {field: type.idFieldName}
-            ]
-        },
-        // This read-row will succeed if the above query produced any rows.
-        // It will fail if there are no rows. Ignore the column value.
-        {
-            instruction: 'read-row',
-            destinations: ['ignore']
-        }
-    ];
-}
-
-// Return a snippet of SQL that sets the value at the specified `columnName`
-// to either a parameterized value or to itself (a no-op) depending on a
-// parameterized boolean. The boolean says whether to update the column. The
-// SQL snipped returned by this function is intended to be used as one of the
-// "..." in "update foo set ..., ..., ...".
-function sqlClauseUpdateColumn({columnName, fieldType}) {
-    const name = quoteName(columnName);
-
-    // The first parameter will be bound to a predicate answering the question
-    // of whether `columnName` should be updated. The second parameter will be
-    // bound to the new value for `columnName`, or to anything (e.g. null) if
-    // the column is not to be updated.
-    const boolParameter = parameter({builtin: 'TYPE_BOOL'});
-    const valueParameter = parameter(fieldType);
-    return `${name} = case
-        when ${boolParameter} then ${valueParameter}
-        else ${name}
-      end`;
-}
-
-// Return a CRUD instruction that updates the scalar fields of an instance of
-// the specified `type` in the database, or return `undefined` if `type` does
-// not have any updatable fields. Use the specified `legend` to map message
-// fields to table columns.
-function instructionUpdateMessage({type, legend}) {
-    const {scalarFieldSources} = byMultiplicity(legend.fieldSources);
-    const keyColumnName = scalarFieldSources
-        .find(({fieldName}) => fieldName === type.idFieldName)
-        .columnName;
-    const fieldTypes = Object.fromEntries(
-        type.fields.map(({name, type}) => [name, type]));
-    const idFieldType = fieldTypes[type.idFieldName];
-
-    // Exclude the ID field from those that we might update (we're never going
-    // to change the primary key of a row), and also add the type of each field
-    // (for use by `sqlClauseUpdateColumn`).
-    const scalarFieldInfos = scalarFieldSources
-        .filter(({fieldName}) => fieldName !== type.idFieldName)
-        .map(entry => ({...entry, fieldType: fieldTypes[entry.fieldName]}));
-
-    if (scalarFieldSources.length === 1) {
-        // If there's only one scalar field, then that's just the ID. In that
-        // case, there's nothing to update, so return `undefined` to indicate
-        // this to the caller.
-        return;
+// Return SQL for the current time, in the representation of a
+// `.google.protobuf.Timestamp` column, i.e. timestamp(6). This is the value
+// of the timestamp columns that a statement sets (see
+// `timestampColumnNames`).
+function currentTimestamp() {
+    return 'current_timestamp(6)';
+}
+
+// Return the "on duplicate key update" clause of an upsert that performs the
+// specified `assignments`. If there's nothing to update, then "on duplicate
+// key update" still requires an assignment, so assign the specified
+// `keyColumnName` to itself.
+// Note that "on duplicate key update" applies to a conflict in any unique
+// index, not only the primary key, so the updated row might belong to a
+// different message (see `upsertUpdatesOtherRows`).
+function onConflict({keyColumnName, assignments}) {
+    if (assignments.length === 0) {
+        assignments = [`${keyColumnName} = ${keyColumnName}`];
     }
-
-    return {
-        instruction: 'exec',
-        sql: sqline(`update ${quoteName(legend.tableName)}
-            set ${scalarFieldInfos.map(sqlClauseUpdateColumn).join(', ')}
-            where ${quoteName(keyColumnName)} = ${parameter(idFieldType)};`),
-        parameters: [
-            // Each of the possibly-updated fields has two parameters: one that's a
-            // boolean saying whether to update it, and another that's the new
-            // value if it's to be updated.
-            ...scalarFieldInfos.map(({fieldName}) => [
-                {included: fieldName},
-                {field: fieldName}
-            ]).flat(),
-
-            // The last parameter is the primary key of the message, for the
-            // `where` clause in the `update` statement.
-            {field: type.idFieldName}
-        ]
-    };
+    return `on duplicate key update ${assignments.join(', ')}`;
 }
 
-// Deal the specified `fieldSources` array into two arrays: one for scalar
-// fields, and the other for array-like fields.
-// An array-like field is either an array or a FieldMask.
-function byMultiplicity(fieldSources) {
-    // We can distinguish scalar fields (e.g. int32, string) from array fields
-    // (e.g. repeated int32) by the presence of a "tableName" property in the
-    // corresponding element of the type's legend's `.fieldSources`. Values of
-    // array fields are stored in dedicated tables, so they're associated with
-    // a "tableName", while scalar fields are not (they're stored in the
-    // message type's table).
-    return {
-        scalarFieldSources: fieldSources.filter(source => !('tableName' in source)),
-        arrayFieldSources: fieldSources.filter(source => 'tableName' in source)
-    };
-}
-
-//    _____                _       
-//   / ____|              | |      
-//  | |     _ __ ___  __ _| |_ ___ 
-//  | |    | '__/ _ \/ _` | __/ _ \
-//  | |____| | |  __/ (_| | ||  __/
-//   \_____|_|  \___|\__,_|\__\___|
-// 
-// Return an array of CRUD instructions that add a new instance of the specified
-// `type` to the database. Use the specified `legend` to map message fields to
-// table columns.
-function instructionsCreateMessage({type, legend}) {
-    const {
-        scalarFieldSources,
-        arrayFieldSources
-    } = byMultiplicity(legend.fieldSources);
-
-    const fieldTypes = Object.fromEntries(
-        type.fields.map(({name, type}) => [name, type]));
-    const scalarFieldInfos = scalarFieldSources
-        .map(entry => ({...entry, fieldType: fieldTypes[entry.fieldName]}));
-
-    return [
-        // Insert a new row into the table of the message type, specifying
-        // all non-array fields.
-        {
-            instruction: 'exec',
-            sql: sqline(`insert into ${quoteName(legend.tableName)}(
-                ${scalarFieldSources.map(({columnName}) => quoteName(columnName)).join(', ')})
-                values (${scalarFieldInfos.map(({fieldType}) => parameter(fieldType)).join(', ')});`),
-            parameters: scalarFieldSources.map(({fieldName}) => ({field: fieldName}))
-        },
-
-        // For each array field, add rows to the corresponding table.
-        ...arrayFieldSources.map(({fieldName, tableName}) =>
-            instructionInsertArray({
-                arrayTableName: tableName,
-                messageIdField: type.idFieldName,
-                messageIdFieldType: fieldTypes[type.idFieldName],
-                arrayField: fieldName,
-                arrayFieldType: fieldTypes[fieldName]
-            }))
-    ];
-}
-
-//   _____                _ 
-//  |  __ \              | |
-//  | |__) |___  __ _  __| |
-//  |  _  // _ \/ _` |/ _` |
-//  | | \ \  __/ (_| | (_| |
-//  |_|  \_\___|\__,_|\__,_|
+// Return the ordinality of the next record in a message's history, e.g.
 //
-// Return an array of CRUD instructions that read an instance of the specified
-// message `type` from the database. Use the specified `legend` to map
-// message fields to table columns.
-function instructionsReadMessage({type, legend}) {
-    const {
-        scalarFieldSources,
-        arrayFieldSources
-    } = byMultiplicity(legend.fieldSources);
-
-    const fieldTypes = Object.fromEntries(
-        type.fields.map(({name, type}) => [name, type]));
-
-    return [
-        // Query the message table.
-        instructionSelectMessage({type, legend}),
-
-        // Read the resulting row.
-        {
-            instruction: 'read-row',
-            destinations: scalarFieldSources.map(
-                ({fieldName}) => ({field: fieldName}))
-        },
-
-        // For each array field:
-        // - query array table
-        // - read results into array field
-        ...arrayFieldSources.map(({fieldName, tableName}) => [
-            // e.g.
-            // select value from boyscout_badges where id = ?;
-            instructionSelectArray({
-                arrayTableName: tableName,
-                arrayType: fieldTypes[fieldName],
-                messageIdField: type.idFieldName,
-                messageIdFieldType: fieldTypes[type.idFieldName]
-            }),
-
-            // e.g.
-            // for row in result:
-            //     row.scan(&boyscout.badges.push_back())
-            {
-                instruction: 'read-array',
-                destination: {field: fieldName}
-        }
-        ]).flat()
-    ];
-}
-
-//   _    _           _       _       
-//  | |  | |         | |     | |      
-//  | |  | |_ __   __| | __ _| |_ ___ 
-//  | |  | | '_ \ / _` |/ _` | __/ _ \
-//  | |__| | |_) | (_| | (_| | ||  __/
-//   \____/| .__/ \__,_|\__,_|\__\___|
-//         | |                        
-//         |_|
+//     (select previous + 1
+//      from (select coalesce(max(ordinality), 0) as previous
+//            from boyscout_history where id = ?) as prior)
 //
-// Return an array of CRUD instructions that update an instance of the specified
-// message `type` in the database. Use the specified `legend` to map message
-// fields to table columns.
-function instructionsUpdateMessage({type, legend}) {
-    // "Update" is interesting because it takes field inclusion into account
-    // (i.e. when somebody does an update, they can specify some subset of
-    // message fields to be updated, rather than all of them).
-    const {arrayFieldSources} = byMultiplicity(legend.fieldSources);
-    const fieldTypes = Object.fromEntries(
-        type.fields.map(({name, type}) => [name, type]));
-
-    return [
-        // Check that there is a matching message to be updated.
-        ...instructionsMessageExists({type, legend}),
-
-        // Update the message table.
-        // `instructionUpdateMessage` will return `undefined` if no instruction is
-        // needed, so we "filter out" that case here.
-        ...[instructionUpdateMessage({type, legend})].filter(op => op),
-
-        // For each array field:
-        // - remove old (all) values from array table (only if the array is
-        //   included)
-        // - insert new values into array table (only if the array is included)
-        ...arrayFieldSources.map(({fieldName, tableName}) => [
-            // e.g.
-            // delete from boyscout_badges where id = ?;
-            instructionDeleteArray({
-                arrayTableName: tableName,
-                messageIdField: type.idFieldName,
-                messageIdFieldType: fieldTypes[type.idFieldName],
-                conditionField: fieldName
-            }),
-
-            // e.g.
-            // insert into boyscout_badges values (?, ?, ?), (?, ?, ?) ...
-            instructionInsertArray({
-                arrayTableName: tableName,
-                messageIdField: type.idFieldName,
-                messageIdFieldType: fieldTypes[type.idFieldName],
-                arrayField: fieldName,
-                arrayFieldType: fieldTypes[fieldName]
-            })
-        ]).flat()
-    ];
-}
-
-//   _____       _      _       
-//  |  __ \     | |    | |      
-//  | |  | | ___| | ___| |_ ___ 
-//  | |  | |/ _ \ |/ _ \ __/ _ \
-//  | |__| |  __/ |  __/ ||  __/
-//  |_____/ \___|_|\___|\__\___|
-//
-// Return an array of CRUD instructions that delete an instance of the specified
-// message `type` from the database. Use the specified `legend` to map message
-// fields to table columns.
-function instructionsDeleteMessage({type, legend}) {
-    const {
-        scalarFieldSources,
-        arrayFieldSources
-    } = byMultiplicity(legend.fieldSources);
-
-    const keyColumnName = scalarFieldSources
-        .find(({fieldName}) => fieldName === type.idFieldName)
-        .columnName;
-    const fieldTypes = Object.fromEntries(
-        type.fields.map(({name, type}) => [name, type]));
-    const idFieldType = fieldTypes[type.idFieldName];
-
-    return [
-        // Rows in array tables need to be deleted first, since they have
-        // foreign keys referencing the row in the message table.
-        ...arrayFieldSources.map(({tableName}) =>
-            instructionDeleteArray({
-                arrayTableName: tableName,
-                messageIdField: type.idFieldName,
-                messageIdFieldType: idFieldType
-            })),
-
-        // Once we've deleted everything that references the instance's row in
-        // the message table, we can delete that row.
-        {
-            instruction: 'exec',
-            sql: sqline(`delete from ${quoteName(legend.tableName)}
-                where ${quoteName(keyColumnName)} = ${parameter(idFieldType)};`),
-            parameters: [
-                {field: type.idFieldName}
-            ]
-        }
-    ];
+// for use in a tuple of an "insert" into the history table. The table can't
+// be the subject of a subquery in the "values" clause of an "insert" into the
+// same table, unless the subquery selects from a derived table that is
+// materialized, as one having an aggregate function always is.
+function historyOrdinality({table, id, ordinality, idParameter}) {
+    return `(select previous + 1
+        from (select coalesce(max(${ordinality}), 0) as previous
+              from ${table} where ${id} = ${idParameter}) as prior)`;
 }
 
-//  __          ___           _   _       _   _           _                    _ _ ___  
-//  \ \        / / |         | | ( )     | | | |         | |                  | | |__ \ 
-//   \ \  /\  / /| |__   __ _| |_|/ ___  | |_| |__   __ _| |_   ___ _ __   ___| | |  ) |
-//    \ \/  \/ / | '_ \ / _` | __| / __| | __| '_ \ / _` | __| / __| '_ \ / _ \ | | / / 
-//     \  /\  /  | | | | (_| | |_  \__ \ | |_| | | | (_| | |_  \__ \ |_) |  __/ | ||_|  
-//      \/  \/   |_| |_|\__,_|\__| |___/  \__|_| |_|\__,_|\__| |___/ .__/ \___|_|_|(_)  
-//                                                                 | |                  
-//                                                                 |_|    
+// Return the "claim" statement of the outbox poller, e.g.
 //
-//    _____ _____  _    _ _____    _   _   _ 
-//   / ____|  __ \| |  | |  __ \  | | | | | |
-//  | |    | |__) | |  | | |  | | | | | | | |
-//  | |    |  _  /| |  | | |  | | | | | | | |
-//  | |____| | \ \| |__| | |__| | |_| |_| |_|
-//   \_____|_|  \_\\____/|_____/  (_) (_) (_)
+//     update okra_outbox
+//     set claim = ?, claimed_at = current_timestamp(6)
+//     where claimed_at is null
+//         or claimed_at < current_timestamp(6) - interval ? microsecond
+//     order by sequence
+//     limit ?;
 //
-// Return an object of create-read-update-delete (CRUD) operations for all of
-// the specified `types`.
-function types2crud(types) {
-    // Verify that `types` has the expected shape.
-    tisch.compileFunction(({Any, etc}) => ({
-        // Each property is the name of the type it describes.
-        [Any]: {
-            'type': schemas.type, // either a message or an enum
-            'legend?': schemas.legend // present if `type` is a message
-        },
-        ...etc
-    })).enforce(types);
-
-    // e.g. if we have two message types "foo" and "bar,"
-    //
-    //     {
-    //         foo: {
-    //             create: [...],
-    //             read: [...],
-    //             ...
-    //         },
-    //         bar: {
-    //             create: [...],
-    //             read: [...],
-    //             ...
-    //         }, 
-    //         ...
-    //     }
-    //
-    const result = Object.fromEntries(
-        Object.entries(types)
-            // CRUD operations are for message types only.
-            .filter(([_, {type}]) => type.kind === 'message')
-            // Each message type name is mapped to an object of arrays of CRUD
-            // instructions.
-            .map(([typeName, {type, legend}]) => [
-                typeName,
-                {
-                    create: instructionsCreateMessage({type, legend}),
-                    read: instructionsReadMessage({type, legend}),
-                    update: instructionsUpdateMessage({type, legend}),
-                    delete: instructionsDeleteMessage({type, legend})
-                }
-            ]));
-
-    // Verify that the result has the expected shape.
-    return schemas.crud.enforce(result);
-}
-
-return {types2crud};
+// MySQL can't select from the table being updated in a subquery, but it can
+// order and limit the rows of an "update" instead.
+function claimOutboxEvents({table, sequence, claim, claimedAt}) {
+    return `update ${table}
+        set ${claim} = ?, ${claimedAt} = ${currentTimestamp()}
+        where ${claimedAt} is null
+            or ${claimedAt} < ${currentTimestamp()} - interval ? microsecond
+        order by ${sequence}
+        limit ?;`;
+}
+
+return crud.sqlCrud({
+    quoteName,
+    numberedParameters: false,
+    selector,
+    parameter,
+    currentTimestamp,
+    integerParameter: '?',
+    insertedValue: column => `values(${column})`,
+    onConflict,
+    upsertUpdatesOtherRows: true,
+    historyOrdinality,
+    claimOutboxEvents
+});
 
 });
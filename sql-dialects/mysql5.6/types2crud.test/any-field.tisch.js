@@ -0,0 +1,238 @@
+// This is the expected output of running the `types2crud` function on
+// `any-field.proto`.
+({
+    ".foobar.Smoker": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into `smoker`( `id`, `wood_type_url`, `wood_value`) values (?, ?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "wood",
+                        part: "type_url"
+                    },
+                    {
+                        field: "wood",
+                        part: "value"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select `id`, case when ? then `wood_type_url` else null end, case when ? then `wood_value` else null end from `smoker` where `id` = ?;",
+                parameters: [
+                    {
+                        included: "wood"
+                    },
+                    {
+                        included: "wood"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "wood",
+                        part: "type_url"
+                    },
+                    {
+                        field: "wood",
+                        part: "value"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from `smoker` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update `smoker` set `wood_type_url` = case when ? then ? else `wood_type_url` end, `wood_value` = case when ? then ? else `wood_value` end where `id` = ?;",
+                parameters: [
+                    {
+                        included: "wood"
+                    },
+                    {
+                        field: "wood",
+                        part: "type_url"
+                    },
+                    {
+                        included: "wood"
+                    },
+                    {
+                        field: "wood",
+                        part: "value"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from `smoker` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into `smoker`( `id`, `wood_type_url`, `wood_value`) values (?, ?, ?) on duplicate key update `wood_type_url` = values(`wood_type_url`), `wood_value` = values(`wood_value`);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "wood",
+                        part: "type_url"
+                    },
+                    {
+                        field: "wood",
+                        part: "value"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select `id`, `wood_type_url`, `wood_value` from `smoker` where ? or `id` > ? order by `id` limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "wood",
+                        part: "type_url"
+                    },
+                    {
+                        field: "wood",
+                        part: "value"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select `id`, `wood_type_url`, `wood_value` from `smoker` where `id` in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "wood",
+                        part: "type_url"
+                    },
+                    {
+                        field: "wood",
+                        part: "value"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?)",
+                sql: "insert into `smoker`( `id`, `wood_type_url`, `wood_value`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "wood",
+                        part: "type_url"
+                    },
+                    {
+                        field: "wood",
+                        part: "value"
+                    }
+                ]
+            }
+        ],
+        query: {
+            sql: "select `id` from `smoker`",
+            key: "`id`",
+            fields: {
+                id: {
+                    column: "`id`",
+                    parameter: "?"
+                }
+            }
+        },
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from `smoker` where `id` = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from `smoker`"
+        }
+    }
+})
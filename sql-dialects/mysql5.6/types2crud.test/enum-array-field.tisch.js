@@ -52,6 +52,9 @@
             },
             {
                 instruction: "query",
+                condition: {
+                    included: "hotdog"
+                },
                 sql: "select `value` from `grill_hotdog` where `id` = ? order by `ordinality`;",
                 parameters: [
                     {
@@ -126,6 +129,185 @@
                     }
                 ]
             }
-        ]
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select `id` from `grill` where ? or `id` > ? order by `id` limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query",
+                sql: "select `id`, `value` from `grill_hotdog` where (? or `id` > ?) and `id` <= ? order by `id`, `ordinality`;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "last"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-array",
+                destination: {
+                    field: "hotdog"
+                }
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select `id` from `grill` where `id` in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select `id`, `value` from `grill_hotdog` where `id` in (",
+                suffix: ") order by `id`, `ordinality`;",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-array",
+                destination: {
+                    field: "hotdog"
+                }
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?)",
+                sql: "insert into `grill`( `id`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_hotdog`( `id`, `ordinality`, `value`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "hotdog"
+                    },
+                    {
+                        field: "hotdog"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into `grill`( `id`) values (?) on duplicate key update `id` = `id`;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill_hotdog` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "hotdog"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_hotdog`( `id`, `ordinality`, `value`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "hotdog"
+                    },
+                    {
+                        field: "hotdog"
+                    }
+                ]
+            }
+        ],
+        query: {
+            sql: "select `id` from `grill`",
+            key: "`id`",
+            fields: {
+                id: {
+                    column: "`id`",
+                    parameter: "?"
+                }
+            }
+        },
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from `grill` where `id` = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from `grill`"
+        }
     }
 })
@@ -53,6 +53,9 @@
       },
       {
         instruction: "query",
+        condition: {
+          included: "stuff"
+        },
         sql: "select `value` from `update_item_stuff` where `id` = ? order by `ordinality`;",
         parameters: [
           {
@@ -125,6 +128,185 @@
           }
         ]
       }
-    ]
+    ],
+    list: [
+      {
+        instruction: "query",
+        sql: "select `id` from `update_item` where ? or `id` > ? order by `id` limit ?;",
+        parameters: [
+          {
+            page: "first"
+          },
+          {
+            page: "after"
+          },
+          {
+            page: "size"
+          }
+        ]
+      },
+      {
+        instruction: "read-rows",
+        destinations: [
+          {
+            field: "id"
+          }
+        ]
+      },
+      {
+        instruction: "query",
+        sql: "select `id`, `value` from `update_item_stuff` where (? or `id` > ?) and `id` <= ? order by `id`, `ordinality`;",
+        parameters: [
+          {
+            page: "first"
+          },
+          {
+            page: "after"
+          },
+          {
+            page: "last"
+          }
+        ]
+      },
+      {
+        instruction: "read-keyed-array",
+        destination: {
+          field: "stuff"
+        }
+      }
+    ],
+    "read-many": [
+      {
+        instruction: "query-with-tuples",
+        tuple: "?",
+        sql: "select `id` from `update_item` where `id` in (",
+        suffix: ");",
+        parameters: [
+          {
+            batch: "id"
+          }
+        ]
+      },
+      {
+        instruction: "read-rows",
+        destinations: [
+          {
+            field: "id"
+          }
+        ]
+      },
+      {
+        instruction: "query-with-tuples",
+        tuple: "?",
+        sql: "select `id`, `value` from `update_item_stuff` where `id` in (",
+        suffix: ") order by `id`, `ordinality`;",
+        parameters: [
+          {
+            batch: "id"
+          }
+        ]
+      },
+      {
+        instruction: "read-keyed-array",
+        destination: {
+          field: "stuff"
+        }
+      }
+    ],
+    "create-many": [
+      {
+        instruction: "exec-many-with-tuples",
+        tuple: "(?)",
+        sql: "insert into `update_item`( `id`) values",
+        parameters: [
+          {
+            field: "id"
+          }
+        ]
+      },
+      {
+        instruction: "exec-many-with-tuples",
+        tuple: "(?, ?, ?)",
+        sql: "insert into `update_item_stuff`( `id`, `ordinality`, `value`) values",
+        parameters: [
+          {
+            field: "id"
+          },
+          {
+            index: "stuff"
+          },
+          {
+            field: "stuff"
+          }
+        ]
+      }
+    ],
+    upsert: [
+      {
+        instruction: "exec",
+        sql: "insert into `update_item`( `id`) values (?) on duplicate key update `id` = `id`;",
+        parameters: [
+          {
+            field: "id"
+          }
+        ]
+      },
+      {
+        instruction: "exec",
+        sql: "delete from `update_item_stuff` where `id` = ?;",
+        parameters: [
+          {
+            field: "id"
+          }
+        ]
+      },
+      {
+        instruction: "exec-with-tuples",
+        condition: {
+          included: "stuff"
+        },
+        tuple: "(?, ?, ?)",
+        sql: "insert into `update_item_stuff`( `id`, `ordinality`, `value`) values",
+        parameters: [
+          {
+            field: "id"
+          },
+          {
+            index: "stuff"
+          },
+          {
+            field: "stuff"
+          }
+        ]
+      }
+    ],
+    query: {
+      sql: "select `id` from `update_item`",
+      key: "`id`",
+      fields: {
+        id: {
+          column: "`id`",
+          parameter: "?"
+        }
+      }
+    },
+    exists: [
+      {
+        instruction: "query",
+        sql: "select exists (select null from `update_item` where `id` = ?);",
+        parameters: [
+          {
+            field: "id"
+          }
+        ]
+      },
+      {
+        instruction: "read-result",
+        destination: "exists"
+      }
+    ],
+    count: {
+      sql: "select count(*) from `update_item`"
+    }
   }
 })
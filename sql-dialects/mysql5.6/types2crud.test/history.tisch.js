@@ -0,0 +1,125 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `history.proto` with the `types2tables` option `history`: the history
+// instructions, whose SQL the dialect writes.
+({
+    ".foobar.Grill": {
+        history: {
+            record: {
+                instruction: "exec",
+                sql: "insert into `grill_history`(`id`, `ordinality`, `recorded_at`, `operation`, `actor`, `message`) values (?, (select previous + 1 from (select coalesce(max(`ordinality`), 0) as previous from `grill_history` where `id` = ?) as prior), current_timestamp(6), ?, ?, ?);",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            recordMany: {
+                instruction: "exec-many-with-tuples",
+                sql: "insert into `grill_history`(`id`, `ordinality`, `recorded_at`, `operation`, `actor`, `message`) values",
+                tuple: "(?, (select previous + 1 from (select coalesce(max(`ordinality`), 0) as previous from `grill_history` where `id` = ?) as prior), current_timestamp(6), ?, ?, ?)",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            asOf: {
+                instruction: "query",
+                sql: "select `operation`, `message` from `grill_history` where `id` = ? and `recorded_at` <= from_unixtime(cast(? / 1000000.0 as decimal(20, 6))) order by `ordinality` desc limit 1;",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "time"
+                    }
+                ]
+            }
+        },
+        ...etc
+    },
+    ".foobar.Tent": {
+        history: {
+            record: {
+                instruction: "exec",
+                sql: "insert into `tent_history`(`id`, `ordinality`, `recorded_at`, `operation`, `actor`, `message`) values (?, (select previous + 1 from (select coalesce(max(`ordinality`), 0) as previous from `tent_history` where `id` = ?) as prior), current_timestamp(6), ?, ?, ?);",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            recordMany: {
+                instruction: "exec-many-with-tuples",
+                sql: "insert into `tent_history`(`id`, `ordinality`, `recorded_at`, `operation`, `actor`, `message`) values",
+                tuple: "(?, (select previous + 1 from (select coalesce(max(`ordinality`), 0) as previous from `tent_history` where `id` = ?) as prior), current_timestamp(6), ?, ?, ?)",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            asOf: {
+                instruction: "query",
+                sql: "select `operation`, `message` from `tent_history` where `id` = ? and `recorded_at` <= from_unixtime(cast(? / 1000000.0 as decimal(20, 6))) order by `ordinality` desc limit 1;",
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "time"
+                    }
+                ]
+            }
+        },
+        ...etc
+    }
+})
@@ -0,0 +1,320 @@
+// This is the expected output of running the `types2crud` function on
+// `map-field.proto`.
+({
+    ".foobar.Grill": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into `grill`( `id`) values (?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "temperatures"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_temperatures`( `id`, `key`, `value`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        key: "temperatures"
+                    },
+                    {
+                        field: "temperatures"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select `id` from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query",
+                condition: {
+                    included: "temperatures"
+                },
+                sql: "select `key`, `value` from `grill_temperatures` where `id` = ? order by `key`;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-array",
+                destination: {
+                    field: "temperatures"
+                }
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill_temperatures` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ],
+                condition: {
+                    included: "temperatures"
+                }
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "temperatures"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_temperatures`( `id`, `key`, `value`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        key: "temperatures"
+                    },
+                    {
+                        field: "temperatures"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from `grill_temperatures` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into `grill`( `id`) values (?) on duplicate key update `id` = `id`;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill_temperatures` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "temperatures"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_temperatures`( `id`, `key`, `value`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        key: "temperatures"
+                    },
+                    {
+                        field: "temperatures"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select `id` from `grill` where ? or `id` > ? order by `id` limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query",
+                sql: "select `id`, `key`, `value` from `grill_temperatures` where (? or `id` > ?) and `id` <= ? order by `id`, `key`;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "last"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-array",
+                destination: {
+                    field: "temperatures"
+                }
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select `id` from `grill` where `id` in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select `id`, `key`, `value` from `grill_temperatures` where `id` in (",
+                suffix: ") order by `id`, `key`;",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-array",
+                destination: {
+                    field: "temperatures"
+                }
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?)",
+                sql: "insert into `grill`( `id`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_temperatures`( `id`, `key`, `value`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        key: "temperatures"
+                    },
+                    {
+                        field: "temperatures"
+                    }
+                ]
+            }
+        ],
+        query: {
+            sql: "select `id` from `grill`",
+            key: "`id`",
+            fields: {
+                id: {
+                    column: "`id`",
+                    parameter: "?"
+                }
+            }
+        },
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from `grill` where `id` = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from `grill`"
+        }
+    }
+})
@@ -0,0 +1,505 @@
+// This is the expected output of running the `types2crud` function on
+// `message-field.proto`.
+({
+    ".foobar.Grill": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into `grill`( `id`) values (?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "main_burner"
+                },
+                tuple: "(?, ?)",
+                sql: "insert into `grill_main_burner`(`parent_id`, `btu`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        child: "main_burner",
+                        field: "btu"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "side_burners"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_side_burners`(`parent_id`, `ordinality`, `btu`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "side_burners"
+                    },
+                    {
+                        child: "side_burners",
+                        field: "btu"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select `id` from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query",
+                condition: {
+                    included: "main_burner"
+                },
+                sql: "select `btu` from `grill_main_burner` where `parent_id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-child-rows",
+                destination: {
+                    field: "main_burner"
+                },
+                destinations: [
+                    {
+                        field: "btu"
+                    }
+                ]
+            },
+            {
+                instruction: "query",
+                condition: {
+                    included: "side_burners"
+                },
+                sql: "select `btu` from `grill_side_burners` where `parent_id` = ? order by `ordinality`;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-child-rows",
+                destination: {
+                    field: "side_burners"
+                },
+                destinations: [
+                    {
+                        field: "btu"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill_main_burner` where `parent_id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ],
+                condition: {
+                    included: "main_burner"
+                }
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "main_burner"
+                },
+                tuple: "(?, ?)",
+                sql: "insert into `grill_main_burner`(`parent_id`, `btu`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        child: "main_burner",
+                        field: "btu"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill_side_burners` where `parent_id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ],
+                condition: {
+                    included: "side_burners"
+                }
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "side_burners"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_side_burners`(`parent_id`, `ordinality`, `btu`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "side_burners"
+                    },
+                    {
+                        child: "side_burners",
+                        field: "btu"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from `grill_main_burner` where `parent_id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill_side_burners` where `parent_id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into `grill`( `id`) values (?) on duplicate key update `id` = `id`;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill_main_burner` where `parent_id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "main_burner"
+                },
+                tuple: "(?, ?)",
+                sql: "insert into `grill_main_burner`(`parent_id`, `btu`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        child: "main_burner",
+                        field: "btu"
+                    }
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "delete from `grill_side_burners` where `parent_id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-with-tuples",
+                condition: {
+                    included: "side_burners"
+                },
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_side_burners`(`parent_id`, `ordinality`, `btu`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "side_burners"
+                    },
+                    {
+                        child: "side_burners",
+                        field: "btu"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select `id` from `grill` where ? or `id` > ? order by `id` limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query",
+                sql: "select `parent_id`, `btu` from `grill_main_burner` where (? or `parent_id` > ?) and `parent_id` <= ? order by `parent_id`;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "last"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-child-rows",
+                destination: {
+                    field: "main_burner"
+                },
+                destinations: [
+                    {
+                        field: "btu"
+                    }
+                ]
+            },
+            {
+                instruction: "query",
+                sql: "select `parent_id`, `btu` from `grill_side_burners` where (? or `parent_id` > ?) and `parent_id` <= ? order by `parent_id`, `ordinality`;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "last"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-child-rows",
+                destination: {
+                    field: "side_burners"
+                },
+                destinations: [
+                    {
+                        field: "btu"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select `id` from `grill` where `id` in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select `parent_id`, `btu` from `grill_main_burner` where `parent_id` in (",
+                suffix: ") order by `parent_id`;",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-child-rows",
+                destination: {
+                    field: "main_burner"
+                },
+                destinations: [
+                    {
+                        field: "btu"
+                    }
+                ]
+            },
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select `parent_id`, `btu` from `grill_side_burners` where `parent_id` in (",
+                suffix: ") order by `parent_id`, `ordinality`;",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-keyed-child-rows",
+                destination: {
+                    field: "side_burners"
+                },
+                destinations: [
+                    {
+                        field: "btu"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?)",
+                sql: "insert into `grill`( `id`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?)",
+                sql: "insert into `grill_main_burner`(`parent_id`, `btu`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        child: "main_burner",
+                        field: "btu"
+                    }
+                ]
+            },
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?)",
+                sql: "insert into `grill_side_burners`(`parent_id`, `ordinality`, `btu`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        index: "side_burners"
+                    },
+                    {
+                        child: "side_burners",
+                        field: "btu"
+                    }
+                ]
+            }
+        ],
+        query: {
+            sql: "select `id` from `grill`",
+            key: "`id`",
+            fields: {
+                id: {
+                    column: "`id`",
+                    parameter: "?"
+                }
+            }
+        },
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from `grill` where `id` = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from `grill`"
+        }
+    }
+})
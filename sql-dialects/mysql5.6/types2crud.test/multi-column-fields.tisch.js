@@ -0,0 +1,250 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `multi-column-fields.proto`: the operations that write, read, and query
+// the fields that are stored specially.
+({
+    ".foobar.Stall": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into `stall`( `id`, `opens`, `location_latitude`, `location_longitude`, `price_currency_code`, `price_amount`) values (?, ?, ?, ?, ?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select `id`, case when ? then time_format(`opens`, '%H:%i:%s.%f') else null end, case when ? then `location_latitude` else null end, case when ? then `location_longitude` else null end, case when ? then `price_currency_code` else null end, case when ? then `price_amount` else null end from `stall` where `id` = ?;",
+                parameters: [
+                    {
+                        included: "opens"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from `stall` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update `stall` set `opens` = case when ? then ? else `opens` end, `location_latitude` = case when ? then ? else `location_latitude` end, `location_longitude` = case when ? then ? else `location_longitude` end, `price_currency_code` = case when ? then ? else `price_currency_code` end, `price_amount` = case when ? then ? else `price_amount` end where `id` = ?;",
+                parameters: [
+                    {
+                        included: "opens"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "query",
+                sql: "select null from (select count(*) as conflicts from `stall` where ((`location_latitude` = ? and `location_longitude` = ?)) and `id` <> ?) as counted where conflicts = 0;",
+                parameters: [
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ],
+                onNoRow: "uniqueViolation"
+            },
+            {
+                instruction: "exec",
+                sql: "insert into `stall`( `id`, `opens`, `location_latitude`, `location_longitude`, `price_currency_code`, `price_amount`) values (?, ?, ?, ?, ?, ?) on duplicate key update `opens` = values(`opens`), `location_latitude` = values(`location_latitude`), `location_longitude` = values(`location_longitude`), `price_currency_code` = values(`price_currency_code`), `price_amount` = values(`price_amount`);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?, ?, ?, ?)",
+                sql: "insert into `stall`( `id`, `opens`, `location_latitude`, `location_longitude`, `price_currency_code`, `price_amount`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        query: {
+            sql: "select `id` from `stall`",
+            key: "`id`",
+            fields: {
+                id: {
+                    column: "`id`",
+                    parameter: "?"
+                },
+                opens: {
+                    column: "`opens`",
+                    parameter: "?"
+                }
+            }
+        },
+        ...etc
+    }
+})
@@ -0,0 +1,273 @@
+// This is the expected output of running the `types2crud` function on
+// `oneof-field.proto`.
+({
+    ".foobar.Grill": {
+        create: [
+            {
+                instruction: "exec",
+                sql: "insert into `grill`( `id`, `charcoal_brand`, `propane_psi`, `fuel`) values (?, ?, ?, ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "charcoal_brand",
+                        oneof: "fuel"
+                    },
+                    {
+                        field: "propane_psi",
+                        oneof: "fuel"
+                    },
+                    {
+                        oneof: "fuel"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: "select `id`, case when ? then `charcoal_brand` else null end, case when ? then `propane_psi` else null end, case when ? then `fuel` else null end from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        included: "fuel"
+                    },
+                    {
+                        included: "fuel"
+                    },
+                    {
+                        included: "fuel"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "charcoal_brand",
+                        oneof: "fuel"
+                    },
+                    {
+                        field: "propane_psi",
+                        oneof: "fuel"
+                    },
+                    {
+                        oneof: "fuel"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update `grill` set `charcoal_brand` = case when ? then ? else `charcoal_brand` end, `propane_psi` = case when ? then ? else `propane_psi` end, `fuel` = case when ? then ? else `fuel` end where `id` = ?;",
+                parameters: [
+                    {
+                        included: "fuel"
+                    },
+                    {
+                        field: "charcoal_brand",
+                        oneof: "fuel"
+                    },
+                    {
+                        included: "fuel"
+                    },
+                    {
+                        field: "propane_psi",
+                        oneof: "fuel"
+                    },
+                    {
+                        included: "fuel"
+                    },
+                    {
+                        oneof: "fuel"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        delete: [
+            {
+                instruction: "exec",
+                sql: "delete from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into `grill`( `id`, `charcoal_brand`, `propane_psi`, `fuel`) values (?, ?, ?, ?) on duplicate key update `charcoal_brand` = values(`charcoal_brand`), `propane_psi` = values(`propane_psi`), `fuel` = values(`fuel`);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "charcoal_brand",
+                        oneof: "fuel"
+                    },
+                    {
+                        field: "propane_psi",
+                        oneof: "fuel"
+                    },
+                    {
+                        oneof: "fuel"
+                    }
+                ]
+            }
+        ],
+        list: [
+            {
+                instruction: "query",
+                sql: "select `id`, `charcoal_brand`, `propane_psi`, `fuel` from `grill` where ? or `id` > ? order by `id` limit ?;",
+                parameters: [
+                    {
+                        page: "first"
+                    },
+                    {
+                        page: "after"
+                    },
+                    {
+                        page: "size"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "charcoal_brand",
+                        oneof: "fuel"
+                    },
+                    {
+                        field: "propane_psi",
+                        oneof: "fuel"
+                    },
+                    {
+                        oneof: "fuel"
+                    }
+                ]
+            }
+        ],
+        "read-many": [
+            {
+                instruction: "query-with-tuples",
+                tuple: "?",
+                sql: "select `id`, `charcoal_brand`, `propane_psi`, `fuel` from `grill` where `id` in (",
+                suffix: ");",
+                parameters: [
+                    {
+                        batch: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-rows",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "charcoal_brand",
+                        oneof: "fuel"
+                    },
+                    {
+                        field: "propane_psi",
+                        oneof: "fuel"
+                    },
+                    {
+                        oneof: "fuel"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "(?, ?, ?, ?)",
+                sql: "insert into `grill`( `id`, `charcoal_brand`, `propane_psi`, `fuel`) values",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "charcoal_brand",
+                        oneof: "fuel"
+                    },
+                    {
+                        field: "propane_psi",
+                        oneof: "fuel"
+                    },
+                    {
+                        oneof: "fuel"
+                    }
+                ]
+            }
+        ],
+        query: {
+            sql: "select `id` from `grill`",
+            key: "`id`",
+            fields: {
+                id: {
+                    column: "`id`",
+                    parameter: "?"
+                },
+                charcoal_brand: {
+                    column: "`charcoal_brand`",
+                    parameter: "?"
+                },
+                propane_psi: {
+                    column: "`propane_psi`",
+                    parameter: "?"
+                }
+            }
+        },
+        exists: [
+            {
+                instruction: "query",
+                sql: "select exists (select null from `grill` where `id` = ?);",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-result",
+                destination: "exists"
+            }
+        ],
+        count: {
+            sql: "select count(*) from `grill`"
+        }
+    }
+})
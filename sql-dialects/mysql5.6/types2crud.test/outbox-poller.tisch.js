@@ -0,0 +1,7 @@
+// This is the expected output of running the `outboxPoller` function on the
+// name of the outbox table, `okra_outbox`.
+({
+    claim: "update `okra_outbox` set `claim` = ?, `claimed_at` = current_timestamp(6) where `claimed_at` is null or `claimed_at` < current_timestamp(6) - interval ? microsecond order by `sequence` limit ?;",
+    read: "select `sequence`, `type_name`, `message_id`, `operation`, `field_mask`, `message` from `okra_outbox` where `claim` = ? order by `sequence`;",
+    acknowledge: "delete from `okra_outbox` where `sequence` = ? and `claim` = ?;"
+})
@@ -0,0 +1,41 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `unique-field.proto`: the upsert, which in some dialects checks whether
+// the message would conflict with another in a unique index.
+({
+    ".foobar.Grill": {
+        upsert: [
+            {
+                instruction: "query",
+                sql: "select null from (select count(*) as conflicts from `grill` where ((`name` = ?)) and `id` <> ?) as counted where conflicts = 0;",
+                parameters: [
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ],
+                onNoRow: "uniqueViolation"
+            },
+            {
+                instruction: "exec",
+                sql: "insert into `grill`( `id`, `name`) values (?, ?) on duplicate key update `name` = values(`name`);",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        ...etc
+    }
+})
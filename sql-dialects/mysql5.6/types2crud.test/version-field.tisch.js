@@ -0,0 +1,62 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `version-field.proto`: the operations that compare and increment the
+// version, which differ between dialects in how they reference the version
+// parameter and the version in the conflicting row.
+({
+    ".foobar.Grill": {
+        update: [
+            {
+                instruction: "query",
+                sql: "select null from `grill` where `id` = ?;",
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: "update `grill` set `name` = case when ? then ? else `name` end, `revision` = coalesce(`revision`, 0) + 1 where `id` = ? and coalesce(`revision`, 0) = coalesce(?, 0);",
+                parameters: [
+                    {
+                        included: "name"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "revision"
+                    }
+                ],
+                onNoRows: "conflict"
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: "insert into `grill`( `id`, `name`, `revision`) values (?, ?, ?) on duplicate key update `name` = values(`name`), `revision` = coalesce(`grill`.`revision`, 0) + 1;",
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    },
+                    {
+                        field: "revision"
+                    }
+                ]
+            }
+        ],
+        ...etc
+    }
+})
@@ -0,0 +1,323 @@
+// This module provides a function `dbdiff2sql`, which takes a description of
+// the difference between two database schemas and returns SQL statements that
+// migrate the "from" database schema to the "to" database schema, in
+// PostgreSQL.
+// The output of `dbdiff2sql` is what a database administrator would run to
+// reflect in the PostgreSQL database changes made to protobuf type
+// definitions.
+define(['../../schemas/schemas', './quote'],
+function (schemas, {quoteName, quoteString}) {
+
+// `{<table name>: table}` → `[table, ...]` such that a table comes before any
+// tables that reference it in a foreign key, i.e. you can execute `CREATE
+// TABLE` statements in that order.
+function topologicallySortedTables(tables) {
+    const result = [];
+    const visited = {}; // {<table name>: Boolean}
+    
+    // Use a post-order depth-first traversal.
+    function visit(table) {
+        if (visited[table.name]) {
+            return; // already seen it
+        }
+
+        visited[table.name] = true;
+
+        table.columns.forEach(column => {
+            if (column.foreignKey) {
+                // The referred-to table might not be among `tables`. In that
+                // case, skip it, it's an existing table that we don't need to
+                // visit. Otherwise, visit the referred-to table.
+                const foreignTable = tables[column.foreignKey.table];
+                if (foreignTable !== undefined) {
+                    visit(foreignTable);
+                }
+            }
+        });
+
+        result.push(table);
+    }
+
+    Object.values(tables).forEach(visit);
+    return result;
+}
+
+// Return a SQL literal from the specified `value`.
+function value2sql(value) {
+    if (Array.isArray(value)) {
+        return `(${value.map(value2sql).join(', ')})`;
+    }
+    if (typeof value === 'string') {
+        return quoteString(value);
+    }
+    if (typeof value === 'number') {
+        return value.toString();
+    }
+    if (value === null) {
+        return 'null';
+    }
+
+    throw Error(`cannot convert value ${value} of type ${typeof value} to a SQL literal`);
+}
+
+// Return a string containing PostgreSQL statements that migrate a database in
+// the manner described by the specified `dbdiff`. `dbdiff` satisfies the
+// `dbdiff.tisch.js` schema.
+function dbdiff2sql(dbdiff) {
+    // keep 'em honest
+    schemas.dbdiff.enforce(dbdiff);
+
+    // Order of statements returned:
+    // - create new tables
+    // - alter existing tables
+    // - update existing rows
+    // - insert new rows
+    //
+    // I use this order, rather than everything-per-table, so that the DDL
+    // statements are together at the top, and the DML statements are together
+    // at the bottom.
+    //
+    // Unlike in MySQL, comments and indices are not part of `CREATE TABLE` or
+    // `ALTER TABLE` in PostgreSQL, so creating or altering a table can
+    // produce more than one statement.
+
+    const creates = topologicallySortedTables(dbdiff.newTables)
+        .map(createTable)
+        .flat();
+
+    // Return an array of just the part of `dbdiff.modifications` indicated,
+    // one element for each table. Exclude tables where `what` is empty.
+    function justThe(what) {
+        return Object.entries(dbdiff.modifications)
+            .map(([tableName, modifications]) => [tableName, modifications[what]])
+            .filter(([_, whats]) => whats.length);
+    }
+
+    const alterations = justThe('alterations')
+        .map(([tableName, alterations]) => alterTable(tableName, alterations))
+        .flat();
+
+    const updates = justThe('updates')
+        .map(([tableName, updates]) =>
+            updates.map(update =>
+                updateRow(dbdiff.allTables[tableName], update)))
+        .flat(); // Each row updated gets its own statement.
+
+    // `INSERT` comes from two places: "insertions" and "newTables" with
+    // nonempty ".rows". Here is the latter, which is combined with the former
+    // below.
+    const newTablesWithRows = Object.entries(dbdiff.newTables)
+        .filter(([name, table]) => (table.rows || []).length)
+        .map(([name, table]) => [name, table.rows]);
+
+    // See the analogous comment in the MySQL 5.6 version of this module.
+    const inserts = [...justThe('insertions'), ...newTablesWithRows]
+        .map(([tableName, rows]) =>
+            insertRows(dbdiff.allTables[tableName], rows));
+
+    return [...creates, ...alterations, ...updates, ...inserts]
+        .map(statement => statement + ';\n')
+        .join('\n');
+}
+
+// Return an array of strings, each containing a PostgreSQL statement, that
+// together alter the table having the specified `name` as described by the
+// specified `alterations`. The first statement is an `ALTER TABLE` statement,
+// unless the only alteration is to the table's description.
+function alterTable(name, alterations) {
+    // Loop through `alterations` a bunch of times, collecting a different part
+    // of the `ALTER TABLE` statement each time.
+
+    // PostgreSQL can change a column's type and nullability, but not
+    // "rewrite" the whole column the way that MySQL's `MODIFY COLUMN` does.
+    const alterColumns = alterations
+        .filter(alt => alt.kind === 'alterColumn')
+        .map(({name, type, maxLength, nullable}) => [
+            `alter column ${quoteName(name)} type ${type2sql(type, maxLength)}`,
+            `alter column ${quoteName(name)} ${nullable ? 'drop' : 'set'} not null`
+        ])
+        .flat();
+
+    const addColumns = alterations
+        .filter(alt => alt.kind === 'appendColumn')
+        .map(({kind, ...column}) => 
+            'add column ' + column2tableClause({nullable: true, ...column}));
+
+    // Foreign keys happen as part of "appendColumn," but require a separate
+    // SQL clause, so we do them separately here.
+    const foreignKeys = alterations
+        .filter(alt => alt.kind === 'appendColumn' && alt.foreignKey)
+        .map(({kind, ...column}) =>
+            'add ' + column2foreignKeyTableClause(column));
+
+    const clauses = [...alterColumns, ...addColumns, ...foreignKeys];
+    const statements = [];
+    if (clauses.length) {
+        statements.push(`alter table ${quoteName(name)}
+${clauses.join(',\n')}`);
+    }
+
+    // Comments on the table and on its columns are separate statements. An
+    // empty description removes the comment.
+    alterations.forEach(alt => {
+        if (alt.kind === 'alterDescription') {
+            statements.push(commentOnTable(name, alt.description));
+        }
+        else if ('description' in alt) {
+            statements.push(commentOnColumn(name, alt.name, alt.description));
+        }
+    });
+
+    // Indices are separate statements, too.
+    statements.push(...alterations
+        .filter(alt => alt.kind === 'addIndex')
+        .map(({kind, ...index}) => createIndex(name, index)));
+
+    return statements;
+}
+
+function updateRow(table, update) {
+    const tableName = quoteName(table.name);
+    // Tables whose rows we update will have a primary key containing a single
+    // column.
+    const keyColumnName = quoteName(table.primaryKey[0]);
+    const keyValue = value2sql(update.primaryKeyValue);
+    const edits = Object.entries(update.columnValues).map(
+        ([column, value]) => `${quoteName(column)} = ${value2sql(value)}`);
+
+    return `update ${tableName}
+set ${edits.join(', ')}
+where ${keyColumnName} = ${keyValue}`;
+}
+
+function insertRows(table, rows) {
+    const tableName = quoteName(table.name);
+    const columns = table.columns.map(column => quoteName(column.name));
+    const values = rows.map(value2sql);
+
+    return `insert into ${tableName} (${columns.join(', ')}) values
+${values.join(',\n')}`;
+}
+
+// Return an array of strings, each containing a PostgreSQL statement, that
+// together create the specified `table`, where `table` satisfies the
+// `table.tisch.js` schema. The first statement is `CREATE TABLE`, and the
+// rest are `COMMENT ON` and `CREATE INDEX` statements.
+function createTable(table) {
+    const columnClauses = table.columns.map(column2tableClause);
+
+    const keyClauses = [];
+    if ('primaryKey' in table) {
+        keyClauses.push(`primary key (${table.primaryKey.map(quoteName).join(', ')})`);
+    }
+
+    keyClauses.push(...table.columns
+        .filter(column => 'foreignKey' in column)
+        .map(column2foreignKeyTableClause));
+
+    const tableClauses = [...columnClauses, ...keyClauses];
+
+    const comments = [];
+    if ('description' in table) {
+        comments.push(commentOnTable(table.name, table.description));
+    }
+    comments.push(...table.columns
+        .filter(column => 'description' in column)
+        .map(column =>
+            commentOnColumn(table.name, column.name, column.description)));
+
+    const indices = (table.indices || [])
+        .map(index => createIndex(table.name, index));
+
+    return [
+        `create table ${quoteName(table.name)}(
+    ${tableClauses.join(",\n    ")})`,
+        ...comments,
+        ...indices
+    ];
+}
+
+// e.g. "comment on table grill is 'where we put the food'"
+function commentOnTable(tableName, description) {
+    const comment = description === '' ? 'null' : quoteString(description);
+    return `comment on table ${quoteName(tableName)} is ${comment}`;
+}
+
+// e.g. "comment on column grill.id is 'account number of owner'"
+function commentOnColumn(tableName, columnName, description) {
+    const column = `${quoteName(tableName)}.${quoteName(columnName)}`;
+    const comment = description === '' ? 'null' : quoteString(description);
+    return `comment on column ${column} is ${comment}`;
+}
+
+// e.g. "foreign key (faith) references religion(id)"
+function column2foreignKeyTableClause(column) {
+    if (!('foreignKey' in column)) {
+        throw Error(`column passed to column2foreignKeyTableClause must ` +
+            `have a foreignKey property`);
+    }
+
+    const keyColumn = quoteName(column.name);
+    const foreignTable = quoteName(column.foreignKey.table);
+    const foreignColumn = quoteName(column.foreignKey.column);
+
+    return `foreign key (${keyColumn}) references ${foreignTable}(${foreignColumn})`;
+}
+
+// e.g. "foo text not null"
+// Column descriptions are not part of the clause. See `commentOnColumn`.
+function column2tableClause(column) {
+    const parts = [
+        quoteName(column.name),
+        type2sql(column.type, column.maxLength),
+        column.nullable ? 'null' : 'not null'
+    ];
+
+    if (column.autoIncrement) {
+        parts.push('generated by default as identity');
+    }
+
+    return parts.join(' ');
+}
+
+function type2sql(type, maxLength) {
+    // See column type in `table.tisch.js` and builtin in `builtin.tisch.js`.
+    // A string column having a maximum length is a `varchar` of that length.
+    if (maxLength !== undefined) {
+        return `varchar(${maxLength})`;
+    }
+
+    // PostgreSQL has no unsigned integer types, so unsigned types use the
+    // next larger signed type (or `numeric`, for 64 bits).
+    return {
+        'TYPE_DOUBLE': 'double precision',
+        'TYPE_FLOAT': 'real',
+        'TYPE_INT64': 'bigint',
+        'TYPE_UINT64': 'numeric(20)',
+        'TYPE_INT32': 'integer',
+        'TYPE_UINT32': 'bigint',
+        'TYPE_BOOL': 'boolean',
+        'TYPE_STRING': 'text',
+        'TYPE_BYTES': 'bytea',
+        '.google.protobuf.Timestamp': 'timestamptz',
+        '.google.type.Date': 'date',
+        '.google.type.TimeOfDay': 'time',
+        '.google.protobuf.Duration': 'bigint', // microseconds
+        '.google.protobuf.Struct': 'jsonb',
+        '.google.protobuf.Value': 'jsonb',
+        '.google.protobuf.ListValue': 'jsonb',
+        'name': 'varchar(255)',
+        'currency': 'char(3)',
+        'decimal': 'numeric(38, 9)',
+        'json': 'jsonb'
+    }[type];
+}
+
+// e.g. "create index on grill_hotdogs (id)"
+function createIndex(tableName, index) {
+    const unique = index.unique ? 'unique ' : '';
+    return `create ${unique}index on ${quoteName(tableName)} (${index.columns.map(quoteName).join(', ')})`;
+}
+
+return {dbdiff2sql};
+});
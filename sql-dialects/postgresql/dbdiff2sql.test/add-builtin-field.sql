@@ -0,0 +1,5 @@
+alter table "grill"
+add column "is_on" boolean null;
+
+insert into "hotdog" ("id", "name", "description") values
+(4, 'CARROT', 'for the vegans');
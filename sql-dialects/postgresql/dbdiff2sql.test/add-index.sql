@@ -0,0 +1,6 @@
+alter table "grill"
+add column "serial_number" varchar(191) null;
+
+create index on "grill" ("brand");
+
+create unique index on "grill" ("serial_number");
@@ -0,0 +1,35 @@
+// This module exports an object, `errors`, that classifies the errors that
+// PostgreSQL can report during CRUD operations. See `schemas/errors.tisch.js`.
+
+define(['../../schemas/schemas'], function (schemas) {
+'use strict';
+
+// The strings are SQLSTATE codes, as documented in
+// <https://www.postgresql.org/docs/current/errcodes-appendix.html>.
+const errors = {
+    'already-exists': [
+        '23505' // unique_violation
+    ],
+    'foreign-key': [
+        '23503' // foreign_key_violation
+    ],
+    'conflict': [
+        '40001', // serialization_failure
+        '40P01', // deadlock_detected
+        '55P03'  // lock_not_available
+    ],
+    'invalid-argument': [
+        '22001', // string_data_right_truncation
+        '22003', // numeric_value_out_of_range
+        '22007', // invalid_datetime_format
+        '22008', // datetime_field_overflow
+        '22P02', // invalid_text_representation
+        '23502'  // not_null_violation
+    ]
+};
+
+schemas.errors.enforce(errors);
+
+return {errors};
+
+});
@@ -0,0 +1,165 @@
+// This module exports a function, `types2crud`, that produces a description of
+// create-read-update-delete (CRUD) operations for a given set of types and
+// their legends. The SQL statements used in the CRUD instructions are
+// compatible with PostgreSQL.
+// It also exports a function, `outboxPoller`, that describes the statements
+// with which generated code polls the outbox table (see `outbox.tisch.js`).
+//
+// The instructions are built by `lib/types2crud.js`. This module describes
+// how PostgreSQL differs from the other dialects (see `sqlCrud` there):
+// identifiers are quoted with double quotes, parameters are numbered (e.g.
+// "$1"), timestamps are converted using `to_timestamp` and
+// `extract(epoch ...)`, and upserts use "insert ... on conflict".
+
+define(['../../lib/types2crud', './quote'], function (crud, quote) {
+'use strict';
+
+const {quoteName} = quote;
+
+// In a simpler world, selecting the value from a column would always look like:
+//
+//     select `foo` from ...
+//
+// and the SQL driver would marshal the value into the corresponding type in
+// the generated Go/Python/C++/etc. code. However, SQL drivers (and the
+// libraries that wrap them) differ in how they handle certain types. For
+// example, in one Go MySQL driver, a "parseTime" parameter must be specified
+// in the database connection string in order for datetime values to be
+// handled in terms of Go's `time.Time` type. Otherwise they're handled as
+// strings. The PostgreSQL drivers, on the other hand, always produce a
+// `time.Time` for date columns, so dates are selected using `to_char`.
+//
+// Also in an ideal world, references to parameters would always look like:
+//
+//     insert into foo(bar, baz) values(?, ?);
+//
+// For the same reason, though, the form of the parameter might differ between
+// drivers, e.g. it might have to be:
+//
+//     insert into foo(bar, baz) values(?, to_timestamp(0) + ? * interval '1 microsecond');
+//
+// Because of this, we customize how columns are selected ("selector") and how
+// parameters are referenced ("parameter") based on the type of the relevant
+// protobuf field. Okra picks a convention for the representation of certain
+// types, so that Go/Python/C++/etc. code generators can target that
+// representation regardless of which particular database driver library they
+// use.
+function selector({columnName, fieldType}) {
+    if (fieldType.builtin === '.google.protobuf.Timestamp') {
+        // timestamptz → unix timestamp in microseconds
+        // `round` rather than `floor`, because before PostgreSQL 14,
+        // `extract` returns a `double precision` that might be a hair below
+        // the exact number of microseconds.
+        return `cast(round(extract(epoch from ${quoteName(columnName)}) * 1000000) as bigint)`;
+    }
+    else if (fieldType.builtin === '.google.type.Date') {
+        // date → "YYYY-MM-DD"
+        return `to_char(${quoteName(columnName)}, 'YYYY-MM-DD')`;
+    }
+    else if (fieldType.builtin === '.google.type.TimeOfDay') {
+        // time → "HH:MM:SS.ffffff"
+        return `to_char(${quoteName(columnName)}, 'HH24:MI:SS.US')`;
+    }
+    else {
+        return quoteName(columnName);
+    }
+}
+
+// `fieldType` has the shape of the "type" of a "field" in a message type. See
+// `type.tisch.js`.
+function parameter(fieldType) {
+    if (fieldType.builtin === '.google.protobuf.Timestamp') {
+        // unix timestamp in microseconds → timestamptz
+        // Adding an interval to the epoch, rather than passing seconds to
+        // `to_timestamp`, avoids rounding through `double precision`.
+        return "to_timestamp(0) + cast(? as bigint) * interval '1 microsecond'";
+    }
+    else if (fieldType.builtin === '.google.type.Date') {
+        // "YYYY-MM-DD" → date
+        return 'cast(? as date)';
+    }
+    else if (fieldType.builtin === '.google.type.TimeOfDay') {
+        // "HH:MM:SS.ffffff" → time
+        return 'cast(? as time)';
+    }
+    else {
+        return '?';
+    }
+}
+
+// Return SQL for the current time, in the representation of a
+// `.google.protobuf.Timestamp` column, i.e. timestamptz. This is the value of
+// the timestamp columns that a statement sets (see `timestampColumnNames`).
+// It's the time at which the transaction began.
+function currentTimestamp() {
+    return 'current_timestamp';
+}
+
+// Return the "on conflict" clause of an upsert into a table having the
+// specified `keyColumnName` that performs the specified `assignments`, or
+// does nothing if there are none.
+function onConflict({keyColumnName, assignments}) {
+    const action = assignments.length
+        ? 'do update set ' + assignments.join(', ')
+        : 'do nothing';
+    return `on conflict (${keyColumnName}) ${action}`;
+}
+
+// Return the ordinality of the next record in a message's history, e.g.
+//
+//     (select coalesce(max(ordinality), 0) + 1
+//      from boyscout_history where id = $2)
+//
+// for use in a tuple of an "insert" into the history table. The record's
+// other values are parameters in the same tuple, rather than in the select
+// list of an "insert ... select," so that their types are those of the
+// columns.
+function historyOrdinality({table, id, ordinality, idParameter}) {
+    return `(select coalesce(max(${ordinality}), 0) + 1
+        from ${table} where ${id} = ${idParameter})`;
+}
+
+// Return the "claim" statement of the outbox poller, e.g.
+//
+//     update okra_outbox
+//     set claim = $1, claimed_at = current_timestamp
+//     where sequence in (select sequence
+//         from okra_outbox
+//         where claimed_at is null
+//             or claimed_at < current_timestamp
+//                 - cast($2 as bigint) * interval '1 microsecond'
+//         order by sequence
+//         limit $3
+//         for update skip locked);
+//
+// "skip locked" lets concurrent pollers claim different events, rather than
+// wait for each other.
+function claimOutboxEvents({table, sequence, claim, claimedAt}) {
+    return `update ${table}
+        set ${claim} = ?, ${claimedAt} = ${currentTimestamp()}
+        where ${sequence} in (select ${sequence}
+            from ${table}
+            where ${claimedAt} is null
+                or ${claimedAt} < ${currentTimestamp()}
+                    - cast(? as bigint) * interval '1 microsecond'
+            order by ${sequence}
+            limit ?
+            for update skip locked);`;
+}
+
+return crud.sqlCrud({
+    quoteName,
+    numberedParameters: true,
+    selector,
+    parameter,
+    currentTimestamp,
+    // The parameter is cast so that it has a type even when it's null.
+    integerParameter: 'cast(? as bigint)',
+    insertedValue: column => `excluded.${column}`,
+    onConflict,
+    upsertUpdatesOtherRows: false,
+    historyOrdinality,
+    claimOutboxEvents
+});
+
+});
@@ -0,0 +1,125 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `history.proto` with the `types2tables` option `history`: the history
+// instructions, whose SQL the dialect writes.
+({
+    ".foobar.Grill": {
+        history: {
+            record: {
+                instruction: "exec",
+                sql: 'insert into "grill_history"("id", "ordinality", "recorded_at", "operation", "actor", "message") values ($1, (select coalesce(max("ordinality"), 0) + 1 from "grill_history" where "id" = $2), current_timestamp, $3, $4, $5);',
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            recordMany: {
+                instruction: "exec-many-with-tuples",
+                sql: 'insert into "grill_history"("id", "ordinality", "recorded_at", "operation", "actor", "message") values',
+                tuple: '($1, (select coalesce(max("ordinality"), 0) + 1 from "grill_history" where "id" = $2), current_timestamp, $3, $4, $5)',
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            asOf: {
+                instruction: "query",
+                sql: 'select "operation", "message" from "grill_history" where "id" = $1 and "recorded_at" <= to_timestamp(0) + cast($2 as bigint) * interval \'1 microsecond\' order by "ordinality" desc limit 1;',
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "time"
+                    }
+                ]
+            }
+        },
+        ...etc
+    },
+    ".foobar.Tent": {
+        history: {
+            record: {
+                instruction: "exec",
+                sql: 'insert into "tent_history"("id", "ordinality", "recorded_at", "operation", "actor", "message") values ($1, (select coalesce(max("ordinality"), 0) + 1 from "tent_history" where "id" = $2), current_timestamp, $3, $4, $5);',
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            recordMany: {
+                instruction: "exec-many-with-tuples",
+                sql: 'insert into "tent_history"("id", "ordinality", "recorded_at", "operation", "actor", "message") values',
+                tuple: '($1, (select coalesce(max("ordinality"), 0) + 1 from "tent_history" where "id" = $2), current_timestamp, $3, $4, $5)',
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "operation"
+                    },
+                    {
+                        history: "actor"
+                    },
+                    {
+                        history: "message"
+                    }
+                ]
+            },
+            asOf: {
+                instruction: "query",
+                sql: 'select "operation", "message" from "tent_history" where "id" = $1 and "recorded_at" <= to_timestamp(0) + cast($2 as bigint) * interval \'1 microsecond\' order by "ordinality" desc limit 1;',
+                parameters: [
+                    {
+                        history: "id"
+                    },
+                    {
+                        history: "time"
+                    }
+                ]
+            }
+        },
+        ...etc
+    }
+})
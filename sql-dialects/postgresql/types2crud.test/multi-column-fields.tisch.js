@@ -0,0 +1,226 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `multi-column-fields.proto`: the operations that write, read, and query
+// the fields that are stored specially.
+({
+    ".foobar.Stall": {
+        create: [
+            {
+                instruction: "exec",
+                sql: 'insert into "stall"( "id", "opens", "location_latitude", "location_longitude", "price_currency_code", "price_amount") values ($1, cast($2 as time), $3, $4, $5, $6);',
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: 'select "id", case when $1 then to_char("opens", \'HH24:MI:SS.US\') else null end, case when $2 then "location_latitude" else null end, case when $3 then "location_longitude" else null end, case when $4 then "price_currency_code" else null end, case when $5 then "price_amount" else null end from "stall" where "id" = $6;',
+                parameters: [
+                    {
+                        included: "opens"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        update: [
+            {
+                instruction: "query",
+                sql: 'select null from "stall" where "id" = $1;',
+                parameters: [
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    "ignore"
+                ]
+            },
+            {
+                instruction: "exec",
+                sql: 'update "stall" set "opens" = case when $1 then cast($2 as time) else "opens" end, "location_latitude" = case when $3 then $4 else "location_latitude" end, "location_longitude" = case when $5 then $6 else "location_longitude" end, "price_currency_code" = case when $7 then $8 else "price_currency_code" end, "price_amount" = case when $9 then $10 else "price_amount" end where "id" = $11;',
+                parameters: [
+                    {
+                        included: "opens"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        included: "location"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        included: "price"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            }
+        ],
+        upsert: [
+            {
+                instruction: "exec",
+                sql: 'insert into "stall"( "id", "opens", "location_latitude", "location_longitude", "price_currency_code", "price_amount") values ($1, cast($2 as time), $3, $4, $5, $6) on conflict ("id") do update set "opens" = excluded."opens", "location_latitude" = excluded."location_latitude", "location_longitude" = excluded."location_longitude", "price_currency_code" = excluded."price_currency_code", "price_amount" = excluded."price_amount";',
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        "create-many": [
+            {
+                instruction: "exec-many-with-tuples",
+                tuple: "($1, cast($2 as time), $3, $4, $5, $6)",
+                sql: 'insert into "stall"( "id", "opens", "location_latitude", "location_longitude", "price_currency_code", "price_amount") values',
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "opens"
+                    },
+                    {
+                        field: "location",
+                        part: "latitude"
+                    },
+                    {
+                        field: "location",
+                        part: "longitude"
+                    },
+                    {
+                        field: "price",
+                        part: "currency_code"
+                    },
+                    {
+                        field: "price",
+                        part: "amount"
+                    }
+                ]
+            }
+        ],
+        query: {
+            sql: 'select "id" from "stall"',
+            key: '"id"',
+            fields: {
+                id: {
+                    column: '"id"',
+                    parameter: "$1"
+                },
+                opens: {
+                    column: '"opens"',
+                    parameter: "cast($1 as time)"
+                }
+            }
+        },
+        ...etc
+    }
+})
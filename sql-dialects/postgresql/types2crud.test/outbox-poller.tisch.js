@@ -0,0 +1,7 @@
+// This is the expected output of running the `outboxPoller` function on the
+// name of the outbox table, `okra_outbox`.
+({
+    claim: 'update "okra_outbox" set "claim" = $1, "claimed_at" = current_timestamp where "sequence" in (select "sequence" from "okra_outbox" where "claimed_at" is null or "claimed_at" < current_timestamp - cast($2 as bigint) * interval \'1 microsecond\' order by "sequence" limit $3 for update skip locked);',
+    read: 'select "sequence", "type_name", "message_id", "operation", "field_mask", "message" from "okra_outbox" where "claim" = $1 order by "sequence";',
+    acknowledge: 'delete from "okra_outbox" where "sequence" = $1 and "claim" = $2;'
+})
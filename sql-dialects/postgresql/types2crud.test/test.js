@@ -0,0 +1,55 @@
+#!/usr/bin/env node
+
+'use strict';
+
+// Patch node's "require" system to allow for "define"-based modules.
+require('../../../dependencies/node-amd-loader/amd-loader');
+
+const fs = require('fs');
+const path = require('path');
+const {glob, exists} = require('../../../lib/filesystem');
+const {proto2types} = require('../../../lib/proto2types');
+const {types2tables, outboxTableName} = require('../../../lib/types2tables');
+const {types2crud, outboxPoller} = require('../types2crud');
+const tisch = require('../../../dependencies/tisch/tisch');
+
+// For each *.proto, calculate the CRUD operations JSON and compare it against
+// the expected schema *.tisch.js. If there's a corresponding *.options.json,
+// then it's the options to pass to `types2tables`, e.g. `{"history": true}`.
+const protos = glob(path.join(__dirname, '*.proto'));
+
+protos.forEach(protoPath => {
+    const {types} = proto2types({
+        protoFiles: [protoPath]
+    });
+
+    const stem = path.basename(protoPath, '.proto');
+    const optionsPath = path.join(__dirname, stem + '.options.json');
+    const options = exists(optionsPath)
+        ? JSON.parse(fs.readFileSync(optionsPath, {encoding: 'utf8'}))
+        : undefined;
+
+    const {legends} = types2tables(types, options);
+
+    const crud = types2crud(
+        Object.fromEntries(
+            types.map(type => [
+                type.name,
+                // the type, but also the legend if there is one
+                {type, ...(type.name in legends? {legend: legends[type.name]} : {})}
+            ])));
+
+    // `crud` is what we were calculating. Now compile the schema describing
+    // the expected value, and compare `crud` against the expectation.
+    const schemaPath = path.join(__dirname, stem + '.tisch.js');
+
+    tisch.compileFile(schemaPath).enforce(crud);
+});
+
+// The outbox poller's statements don't depend on any types, so compare them
+// against the expected schema outbox-poller.tisch.js.
+tisch.compileFile(path.join(__dirname, 'outbox-poller.tisch.js'))
+    .enforce(outboxPoller(outboxTableName));
+
+// If we got here, then nothing above threw, so we succeeded.
+console.log(`All ${protos.length + 1} tests passed.`);
@@ -0,0 +1,57 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `timestamp-field.proto`: the operations that write and read the timestamp
+// field, which is converted to and from microseconds since the unix epoch in
+// SQL.
+({
+    ".foobar.Reading": {
+        create: [
+            {
+                instruction: "exec",
+                sql: 'insert into "reading"( "id", "when", "celsius") values ($1, to_timestamp(0) + cast($2 as bigint) * interval \'1 microsecond\', $3);',
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: 'select "id", case when $1 then cast(round(extract(epoch from "when") * 1000000) as bigint) else null end, case when $2 then "celsius" else null end from "reading" where "id" = $3;',
+                parameters: [
+                    {
+                        included: "when"
+                    },
+                    {
+                        included: "celsius"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        ...etc
+    }
+})
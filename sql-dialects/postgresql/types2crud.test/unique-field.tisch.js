@@ -0,0 +1,22 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `unique-field.proto`: the upsert, which in some dialects checks whether
+// the message conflicts with another in a unique index.
+({
+    ".foobar.Grill": {
+        upsert: [
+            {
+                instruction: "exec",
+                sql: 'insert into "grill"( "id", "name") values ($1, $2) on conflict ("id") do update set "name" = excluded."name";',
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "name"
+                    }
+                ]
+            }
+        ],
+        ...etc
+    }
+})
@@ -0,0 +1,328 @@
+// This module provides a function `dbdiff2sql`, which takes a description of
+// the difference between two database schemas and returns SQL statements that
+// migrate the "from" database schema to the "to" database schema, in SQLite.
+// The output of `dbdiff2sql` is what a database administrator (or a unit test)
+// would run to reflect in the SQLite database changes made to protobuf type
+// definitions.
+define(['../../schemas/schemas', './quote'],
+function (schemas, {quoteName, quoteString}) {
+
+// `{<table name>: table}` → `[table, ...]` such that a table comes before any
+// tables that reference it in a foreign key, i.e. you can execute `CREATE
+// TABLE` statements in that order.
+function topologicallySortedTables(tables) {
+    const result = [];
+    const visited = {}; // {<table name>: Boolean}
+    
+    // Use a post-order depth-first traversal.
+    function visit(table) {
+        if (visited[table.name]) {
+            return; // already seen it
+        }
+
+        visited[table.name] = true;
+
+        table.columns.forEach(column => {
+            if (column.foreignKey) {
+                // The referred-to table might not be among `tables`. In that
+                // case, skip it, it's an existing table that we don't need to
+                // visit. Otherwise, visit the referred-to table.
+                const foreignTable = tables[column.foreignKey.table];
+                if (foreignTable !== undefined) {
+                    visit(foreignTable);
+                }
+            }
+        });
+
+        result.push(table);
+    }
+
+    Object.values(tables).forEach(visit);
+    return result;
+}
+
+// Return a SQL literal from the specified `value`.
+function value2sql(value) {
+    if (Array.isArray(value)) {
+        return `(${value.map(value2sql).join(', ')})`;
+    }
+    if (typeof value === 'string') {
+        return quoteString(value);
+    }
+    if (typeof value === 'number') {
+        return value.toString();
+    }
+    if (value === null) {
+        return 'null';
+    }
+
+    throw Error(`cannot convert value ${value} of type ${typeof value} to a SQL literal`);
+}
+
+// Return a string containing SQLite statements that migrate a database in the
+// manner described by the specified `dbdiff`. `dbdiff` satisfies the
+// `dbdiff.tisch.js` schema.
+function dbdiff2sql(dbdiff) {
+    // keep 'em honest
+    schemas.dbdiff.enforce(dbdiff);
+
+    // Order of statements returned:
+    // - enable foreign keys
+    // - create new tables
+    // - alter existing tables
+    // - update existing rows
+    // - insert new rows
+    //
+    // I use this order, rather than everything-per-table, so that the DDL
+    // statements are together at the top, and the DML statements are together
+    // at the bottom.
+    //
+    // Indices are not part of `CREATE TABLE` in SQLite, and `ALTER TABLE` can
+    // add only one column at a time, so creating or altering a table can
+    // produce more than one statement.
+
+    const creates = topologicallySortedTables(dbdiff.newTables)
+        .map(createTable)
+        .flat();
+
+    // Return an array of just the part of `dbdiff.modifications` indicated,
+    // one element for each table. Exclude tables where `what` is empty.
+    function justThe(what) {
+        return Object.entries(dbdiff.modifications)
+            .map(([tableName, modifications]) => [tableName, modifications[what]])
+            .filter(([_, whats]) => whats.length);
+    }
+
+    const alterations = justThe('alterations')
+        .map(([tableName, alterations]) => alterTable(tableName, alterations))
+        .flat();
+
+    const updates = justThe('updates')
+        .map(([tableName, updates]) =>
+            updates.map(update =>
+                updateRow(dbdiff.allTables[tableName], update)))
+        .flat(); // Each row updated gets its own statement.
+
+    // `INSERT` comes from two places: "insertions" and "newTables" with
+    // nonempty ".rows". Here is the latter, which is combined with the former
+    // below.
+    const newTablesWithRows = Object.entries(dbdiff.newTables)
+        .filter(([name, table]) => (table.rows || []).length)
+        .map(([name, table]) => [name, table.rows]);
+
+    // See the analogous comment in the MySQL 5.6 version of this module.
+    const inserts = [...justThe('insertions'), ...newTablesWithRows]
+        .map(([tableName, rows]) =>
+            insertRows(dbdiff.allTables[tableName], rows));
+
+    // SQLite checks foreign keys only if they're enabled, and only for the
+    // current connection.
+    const pragmas = ['pragma foreign_keys = on'];
+
+    return [...pragmas, ...creates, ...alterations, ...updates, ...inserts]
+        .map(statement => statement + ';\n')
+        .join('\n');
+}
+
+// Return an array of strings, each containing a SQLite `ALTER TABLE` or
+// `CREATE INDEX` statement, that together alter the table having the
+// specified `name` as described by the specified `alterations`.
+function alterTable(name, alterations) {
+    // SQLite can add a column, but can't otherwise alter a table. Column
+    // types don't matter much to SQLite, and descriptions exist only as
+    // comments in `CREATE TABLE` statements, so "alterColumn" and
+    // "alterDescription" are ignored.
+    //
+    // Foreign keys happen as part of "appendColumn," and in SQLite they are
+    // part of the column definition.
+    const addColumns = alterations
+        .filter(alt => alt.kind === 'appendColumn')
+        .map(({kind, ...column}) => `alter table ${quoteName(name)}
+add column ${column2tableClause({nullable: true, ...column})}${column2references(column)}`);
+
+    const addIndices = alterations
+        .filter(alt => alt.kind === 'addIndex')
+        .map(({kind, ...index}) => createIndex(name, index));
+
+    return [...addColumns, ...addIndices];
+}
+
+function updateRow(table, update) {
+    const tableName = quoteName(table.name);
+    // Tables whose rows we update will have a primary key containing a single
+    // column.
+    const keyColumnName = quoteName(table.primaryKey[0]);
+    const keyValue = value2sql(update.primaryKeyValue);
+    const edits = Object.entries(update.columnValues).map(
+        ([column, value]) => `${quoteName(column)} = ${value2sql(value)}`);
+
+    return `update ${tableName}
+set ${edits.join(', ')}
+where ${keyColumnName} = ${keyValue}`;
+}
+
+function insertRows(table, rows) {
+    const tableName = quoteName(table.name);
+    const columns = table.columns.map(column => quoteName(column.name));
+    const values = rows.map(value2sql);
+
+    return `insert into ${tableName} (${columns.join(', ')}) values
+${values.join(',\n')}`;
+}
+
+// Return an array of strings, each containing a SQLite statement, that
+// together create the specified `table`, where `table` satisfies the
+// `table.tisch.js` schema. The first statement is `CREATE TABLE`, and the
+// rest are `CREATE INDEX` statements.
+function createTable(table) {
+    const columnClauses = table.columns.map(column2tableClause);
+
+    // An auto-incremented column is declared the primary key in its column
+    // clause (see `column2tableClause`), instead of in a clause of its own.
+    const keyClauses = [];
+    if ('primaryKey' in table &&
+        !table.columns.some(column => column.autoIncrement)) {
+        keyClauses.push(`primary key (${table.primaryKey.map(quoteName).join(', ')})`);
+    }
+
+    keyClauses.push(...table.columns
+        .filter(column => 'foreignKey' in column)
+        .map(column2foreignKeyTableClause));
+
+    // The descriptions of the columns are comments following the column
+    // clauses. Each clause but the last is followed by a comma, and the
+    // comment has to follow the comma. The last clause is followed by the
+    // closing parenthesis, so its comment must be a block comment.
+    const tableClauses = [
+        ...columnClauses.map((clause, i) => ({
+            clause,
+            description: table.columns[i].description
+        })),
+        ...keyClauses.map(clause => ({clause}))
+    ].map(({clause, description}, i, clauses) => {
+        const isLast = i === clauses.length - 1;
+        if (description === undefined) {
+            return clause + (isLast ? '' : ',');
+        }
+        if (isLast) {
+            return `${clause} ${blockComment(description)}`;
+        }
+        return `${clause}, ${comment(description)}`;
+    });
+
+    const tableComment = 'description' in table ?
+        ' ' + comment(table.description) : '';
+
+    const indices = (table.indices || [])
+        .map(index => createIndex(table.name, index));
+
+    return [
+        `create table ${quoteName(table.name)}(${tableComment}
+    ${tableClauses.join("\n    ")})`,
+        ...indices
+    ];
+}
+
+// Return a SQL comment containing the specified `description`, e.g.
+// "-- account number of owner". A description having multiple lines becomes
+// a block comment.
+function comment(description) {
+    if (!description.includes('\n')) {
+        return `-- ${description}`;
+    }
+
+    return blockComment(description);
+}
+
+// Return a SQL block comment containing the specified `description`, e.g.
+// "/* account number of owner */".
+function blockComment(description) {
+    return `/* ${description.replace(/\*\//g, '* /')} */`;
+}
+
+// e.g. "foreign key (faith) references religion(id)"
+function column2foreignKeyTableClause(column) {
+    if (!('foreignKey' in column)) {
+        throw Error(`column passed to column2foreignKeyTableClause must ` +
+            `have a foreignKey property`);
+    }
+
+    const keyColumn = quoteName(column.name);
+    const foreignTable = quoteName(column.foreignKey.table);
+    const foreignColumn = quoteName(column.foreignKey.column);
+
+    return `foreign key (${keyColumn}) references ${foreignTable}(${foreignColumn})`;
+}
+
+// e.g. " references religion(id)", or "" if the specified `column` does not
+// have a foreign key
+function column2references(column) {
+    if (!('foreignKey' in column)) {
+        return '';
+    }
+
+    const foreignTable = quoteName(column.foreignKey.table);
+    const foreignColumn = quoteName(column.foreignKey.column);
+
+    return ` references ${foreignTable}(${foreignColumn})`;
+}
+
+// e.g. "foo text not null"
+// Column descriptions are not part of the clause. See `createTable`.
+function column2tableClause(column) {
+    const parts = [
+        quoteName(column.name),
+        type2sql(column.type),
+        column.nullable ? 'null' : 'not null'
+    ];
+
+    // SQLite auto-increments only an "integer primary key" column, and only
+    // with "autoincrement" are values never reused after rows are deleted.
+    if (column.autoIncrement) {
+        parts.push('primary key autoincrement');
+    }
+
+    return parts.join(' ');
+}
+
+function type2sql(type) {
+    // See column type in `table.tisch.js` and builtin in `builtin.tisch.js`.
+    // SQLite has only a few storage classes, and column types merely express a
+    // preference among them (an "affinity"). Integers of all sizes are stored
+    // the same way, as are booleans and timestamps (as microseconds since the
+    // unix epoch). Dates are stored as "YYYY-MM-DD" text. SQLite doesn't
+    // enforce the length of text, so a column's `maxLength` is ignored.
+    return {
+        'TYPE_DOUBLE': 'real',
+        'TYPE_FLOAT': 'real',
+        'TYPE_INT64': 'integer',
+        'TYPE_UINT64': 'integer',
+        'TYPE_INT32': 'integer',
+        'TYPE_UINT32': 'integer',
+        'TYPE_BOOL': 'integer',
+        'TYPE_STRING': 'text',
+        'TYPE_BYTES': 'blob',
+        '.google.protobuf.Timestamp': 'integer',
+        '.google.type.Date': 'text',
+        '.google.type.TimeOfDay': 'text',
+        '.google.protobuf.Duration': 'integer', // microseconds
+        '.google.protobuf.Struct': 'text',
+        '.google.protobuf.Value': 'text',
+        '.google.protobuf.ListValue': 'text',
+        'name': 'text',
+        'currency': 'text',
+        'decimal': 'text', // no decimal type; text keeps every digit
+        'json': 'text'
+    }[type];
+}
+
+// e.g. "create index grill_hotdogs_id on grill_hotdogs (id)"
+// SQLite requires that an index have a name.
+function createIndex(tableName, index) {
+    const indexName = [tableName, ...index.columns].join('_');
+    const unique = index.unique ? 'unique ' : '';
+    return `create ${unique}index ${quoteName(indexName)} on ${quoteName(tableName)} (${index.columns.map(quoteName).join(', ')})`;
+}
+
+return {dbdiff2sql};
+});
@@ -0,0 +1,7 @@
+pragma foreign_keys = on;
+
+alter table "grill"
+add column "is_on" integer null;
+
+insert into "hotdog" ("id", "name", "description") values
+(4, 'CARROT', 'for the vegans');
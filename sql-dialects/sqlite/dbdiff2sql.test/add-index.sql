@@ -0,0 +1,8 @@
+pragma foreign_keys = on;
+
+alter table "grill"
+add column "serial_number" text null;
+
+create index "grill_brand" on "grill" ("brand");
+
+create unique index "grill_serial_number" on "grill" ("serial_number");
@@ -0,0 +1,36 @@
+// This module exports an object, `errors`, that classifies the errors that
+// SQLite can report during CRUD operations. See `schemas/errors.tisch.js`.
+
+define(['../../schemas/schemas'], function (schemas) {
+'use strict';
+
+// The numbers are SQLite extended result codes, as documented in
+// <https://www.sqlite.org/rescode.html>. The drivers report an extended
+// result code whenever there is one, so e.g. a constraint violation is never
+// reported as plain SQLITE_CONSTRAINT (19).
+const errors = {
+    'already-exists': [
+        1555, // SQLITE_CONSTRAINT_PRIMARYKEY
+        2067  // SQLITE_CONSTRAINT_UNIQUE
+    ],
+    'foreign-key': [
+        787 // SQLITE_CONSTRAINT_FOREIGNKEY
+    ],
+    'conflict': [
+        5,   // SQLITE_BUSY
+        6,   // SQLITE_LOCKED
+        517  // SQLITE_BUSY_SNAPSHOT
+    ],
+    'invalid-argument': [
+        18,   // SQLITE_TOOBIG
+        20,   // SQLITE_MISMATCH
+        275,  // SQLITE_CONSTRAINT_CHECK
+        1299  // SQLITE_CONSTRAINT_NOTNULL
+    ]
+};
+
+schemas.errors.enforce(errors);
+
+return {errors};
+
+});
@@ -0,0 +1,18 @@
+// Quote identifiers (e.g. table names) and strings for SQLite statements.
+define([], function () {
+'use strict';
+
+function quoteName(text) {
+    return '"' + text.replace(/"/g, '""') + '"';
+}
+
+function quoteString(text) {
+    return "'" + text.replace(/'/g, "''") + "'";
+}
+
+return {
+    quoteName,
+    quoteString
+};
+
+});
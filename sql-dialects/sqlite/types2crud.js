@@ -0,0 +1,107 @@
+// This module exports a function, `types2crud`, that produces a description of
+// create-read-update-delete (CRUD) operations for a given set of types and
+// their legends. The SQL statements used in the CRUD instructions are
+// compatible with SQLite.
+// It also exports a function, `outboxPoller`, that describes the statements
+// with which generated code polls the outbox table (see `outbox.tisch.js`).
+//
+// The instructions are built by `lib/types2crud.js`. This module describes
+// how SQLite differs from the other dialects (see `sqlCrud` there):
+// identifiers are quoted with double quotes, timestamps and dates need no
+// conversion, and upserts use "insert ... on conflict".
+
+define(['../../lib/types2crud', './quote'], function (crud, quote) {
+'use strict';
+
+const {quoteName} = quote;
+
+// In other SQL dialects, how columns are selected ("selector") and how
+// parameters are referenced ("parameter") depend on the type of the relevant
+// protobuf field, so that values are converted to and from the
+// representations that Okra uses for certain types (see the MySQL 5.6 version
+// of this module). In SQLite, those representations are how the values are
+// stored:
+//
+// - a `.google.protobuf.Timestamp` is an integer number of microseconds since
+//   the unix epoch, and
+// - a `.google.type.Date` is a "YYYY-MM-DD" string.
+//
+// So, no conversions are necessary.
+function selector({columnName, fieldType}) {
+    return quoteName(columnName);
+}
+
+// `fieldType` has the shape of the "type" of a "field" in a message type. See
+// `type.tisch.js`.
+function parameter(fieldType) {
+    return '?';
+}
+
+// Return SQL for the current time, in the representation of a
+// `.google.protobuf.Timestamp` column, i.e. an integer number of microseconds
+// since the unix epoch. This is the value of the timestamp columns that a
+// statement sets (see `timestampColumnNames`). 2440587.5 is the Julian day of
+// the unix epoch.
+function currentTimestamp() {
+    return "cast((julianday('now') - 2440587.5) * 86400000000 as integer)";
+}
+
+// Return the "on conflict" clause of an upsert into a table having the
+// specified `keyColumnName` that performs the specified `assignments`, or
+// does nothing if there are none.
+function onConflict({keyColumnName, assignments}) {
+    const action = assignments.length
+        ? 'do update set ' + assignments.join(', ')
+        : 'do nothing';
+    return `on conflict (${keyColumnName}) ${action}`;
+}
+
+// Return the ordinality of the next record in a message's history, e.g.
+//
+//     (select coalesce(max(ordinality), 0) + 1
+//      from boyscout_history where id = ?)
+//
+// for use in a tuple of an "insert" into the history table.
+function historyOrdinality({table, id, ordinality, idParameter}) {
+    return `(select coalesce(max(${ordinality}), 0) + 1
+        from ${table} where ${id} = ${idParameter})`;
+}
+
+// Return the "claim" statement of the outbox poller, e.g.
+//
+//     update okra_outbox
+//     set claim = ?, claimed_at = now
+//     where sequence in (select sequence
+//         from okra_outbox
+//         where claimed_at is null or claimed_at < now - ?
+//         order by sequence
+//         limit ?);
+//
+// Timestamps are microseconds since the unix epoch, so the duration of a claim
+// can be subtracted from them as is.
+function claimOutboxEvents({table, sequence, claim, claimedAt}) {
+    return `update ${table}
+        set ${claim} = ?, ${claimedAt} = ${currentTimestamp()}
+        where ${sequence} in (select ${sequence}
+            from ${table}
+            where ${claimedAt} is null
+                or ${claimedAt} < ${currentTimestamp()} - ?
+            order by ${sequence}
+            limit ?);`;
+}
+
+return crud.sqlCrud({
+    quoteName,
+    numberedParameters: false,
+    selector,
+    parameter,
+    currentTimestamp,
+    integerParameter: '?',
+    insertedValue: column => `excluded.${column}`,
+    onConflict,
+    upsertUpdatesOtherRows: false,
+    historyOrdinality,
+    claimOutboxEvents
+});
+
+});
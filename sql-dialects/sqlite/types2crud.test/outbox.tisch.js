@@ -0,0 +1,101 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `outbox.proto` with the `types2tables` option `outbox`: the outbox
+// instructions, whose SQL depends on how the dialect writes the current time.
+({
+    ".foobar.Grill": {
+        outbox: {
+            publish: {
+                instruction: "exec",
+                sql: 'insert into "okra_outbox"("type_name", "message_id", "operation", "field_mask", "message", "recorded_at") values (?, ?, ?, ?, ?, cast((julianday(\'now\') - 2440587.5) * 86400000000 as integer));',
+                parameters: [
+                    {
+                        outbox: "type"
+                    },
+                    {
+                        outbox: "id"
+                    },
+                    {
+                        outbox: "operation"
+                    },
+                    {
+                        outbox: "fieldMask"
+                    },
+                    {
+                        outbox: "message"
+                    }
+                ]
+            },
+            publishMany: {
+                instruction: "exec-many-with-tuples",
+                sql: 'insert into "okra_outbox"("type_name", "message_id", "operation", "field_mask", "message", "recorded_at") values',
+                tuple: "(?, ?, ?, ?, ?, cast((julianday('now') - 2440587.5) * 86400000000 as integer))",
+                parameters: [
+                    {
+                        outbox: "type"
+                    },
+                    {
+                        outbox: "id"
+                    },
+                    {
+                        outbox: "operation"
+                    },
+                    {
+                        outbox: "fieldMask"
+                    },
+                    {
+                        outbox: "message"
+                    }
+                ]
+            }
+        },
+        ...etc
+    },
+    ".foobar.Tent": {
+        outbox: {
+            publish: {
+                instruction: "exec",
+                sql: 'insert into "okra_outbox"("type_name", "message_id", "operation", "field_mask", "message", "recorded_at") values (?, ?, ?, ?, ?, cast((julianday(\'now\') - 2440587.5) * 86400000000 as integer));',
+                parameters: [
+                    {
+                        outbox: "type"
+                    },
+                    {
+                        outbox: "id"
+                    },
+                    {
+                        outbox: "operation"
+                    },
+                    {
+                        outbox: "fieldMask"
+                    },
+                    {
+                        outbox: "message"
+                    }
+                ]
+            },
+            publishMany: {
+                instruction: "exec-many-with-tuples",
+                sql: 'insert into "okra_outbox"("type_name", "message_id", "operation", "field_mask", "message", "recorded_at") values',
+                tuple: "(?, ?, ?, ?, ?, cast((julianday('now') - 2440587.5) * 86400000000 as integer))",
+                parameters: [
+                    {
+                        outbox: "type"
+                    },
+                    {
+                        outbox: "id"
+                    },
+                    {
+                        outbox: "operation"
+                    },
+                    {
+                        outbox: "fieldMask"
+                    },
+                    {
+                        outbox: "message"
+                    }
+                ]
+            }
+        },
+        ...etc
+    }
+})
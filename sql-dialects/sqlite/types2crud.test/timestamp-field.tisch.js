@@ -0,0 +1,57 @@
+// This is the expected part of the output of running the `types2crud` function
+// on `timestamp-field.proto`: the operations that write and read the timestamp
+// field, which is converted to and from microseconds since the unix epoch in
+// SQL.
+({
+    ".foobar.Reading": {
+        create: [
+            {
+                instruction: "exec",
+                sql: 'insert into "reading"( "id", "when", "celsius") values (?, ?, ?);',
+                parameters: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        read: [
+            {
+                instruction: "query",
+                sql: 'select "id", case when ? then "when" else null end, case when ? then "celsius" else null end from "reading" where "id" = ?;',
+                parameters: [
+                    {
+                        included: "when"
+                    },
+                    {
+                        included: "celsius"
+                    },
+                    {
+                        field: "id"
+                    }
+                ]
+            },
+            {
+                instruction: "read-row",
+                destinations: [
+                    {
+                        field: "id"
+                    },
+                    {
+                        field: "when"
+                    },
+                    {
+                        field: "celsius"
+                    }
+                ]
+            }
+        ],
+        ...etc
+    }
+})